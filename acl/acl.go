@@ -890,7 +890,8 @@ func (a *ACL) AllowPluginRead() bool {
 	case a.client == PolicyRead,
 		a.client == PolicyWrite:
 		return true
-	case a.plugin == PolicyRead:
+	case a.plugin == PolicyRead,
+		a.plugin == PolicyWrite:
 		return true
 	default:
 		return false
@@ -909,7 +910,22 @@ func (a *ACL) AllowPluginList() bool {
 		return true
 	case a.plugin == PolicyList:
 		return true
-	case a.plugin == PolicyRead:
+	case a.plugin == PolicyRead,
+		a.plugin == PolicyWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllowPluginWrite checks if write operations are allowed for all plugins
+func (a *ACL) AllowPluginWrite() bool {
+	switch {
+	case a == nil:
+		return false
+	case a.aclsDisabled, a.management:
+		return true
+	case a.plugin == PolicyWrite:
 		return true
 	default:
 		return false
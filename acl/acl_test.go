@@ -99,6 +99,7 @@ func TestACLManagement(t *testing.T) {
 	must.True(t, acl.AllowOperatorWrite())
 	must.True(t, acl.AllowQuotaRead())
 	must.True(t, acl.AllowQuotaWrite())
+	must.True(t, acl.AllowPluginWrite())
 	must.True(t, acl.AllowServerOp())
 	must.True(t, acl.AllowClientOp())
 }
@@ -1095,3 +1096,56 @@ func TestAgentDebug(t *testing.T) {
 		})
 	}
 }
+
+func TestPlugin(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		policy      string
+		expectList  bool
+		expectRead  bool
+		expectWrite bool
+	}{
+		{
+			name:       "plugin list",
+			policy:     `plugin { policy = "list" }`,
+			expectList: true,
+		},
+		{
+			name:       "plugin read",
+			policy:     `plugin { policy = "read" }`,
+			expectList: true,
+			expectRead: true,
+		},
+		{
+			name:        "plugin write",
+			policy:      `plugin { policy = "write" }`,
+			expectList:  true,
+			expectRead:  true,
+			expectWrite: true,
+		},
+		{
+			name:   "plugin deny",
+			policy: `plugin { policy = "deny" }`,
+		},
+		{
+			name:   "no plugin rule",
+			policy: `agent { policy = "read" }`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := Parse(tc.policy)
+			must.NoError(t, err)
+
+			acl, err := NewACL(false, []*Policy{policy})
+			must.NoError(t, err)
+
+			must.Eq(t, tc.expectList, acl.AllowPluginList())
+			must.Eq(t, tc.expectRead, acl.AllowPluginRead())
+			must.Eq(t, tc.expectWrite, acl.AllowPluginWrite())
+		})
+	}
+}
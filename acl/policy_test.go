@@ -852,6 +852,19 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+			plugin {
+				policy = "write"
+			}
+			`,
+			"",
+			&Policy{
+				Plugin: &PluginPolicy{
+					Policy: PolicyWrite,
+				},
+			},
+		},
 		{
 			`
 			plugin {
@@ -292,6 +292,7 @@ type AllocationMetric struct {
 	NodesExhausted     int
 	ClassExhausted     map[string]int
 	DimensionExhausted map[string]int
+	SkewFiltered       map[string]int
 	QuotaExhausted     []string
 	ResourcesExhausted map[string]*Resources
 	// Deprecated, replaced with ScoreMetaData
@@ -402,10 +403,11 @@ type AllocationListStub struct {
 // deployment. This can include things like if the allocation has been marked as
 // healthy.
 type AllocDeploymentStatus struct {
-	Healthy     *bool
-	Timestamp   time.Time
-	Canary      bool
-	ModifyIndex uint64
+	Healthy        *bool
+	Timestamp      time.Time
+	Canary         bool
+	IngressPending bool
+	ModifyIndex    uint64
 }
 
 // AllocNetworkStatus captures the status of an allocation's network during runtime.
@@ -21,6 +21,7 @@ const (
 	TopicNode       Topic = "Node"
 	TopicNodePool   Topic = "NodePool"
 	TopicService    Topic = "Service"
+	TopicIngress    Topic = "Ingress"
 	TopicAll        Topic = "*"
 )
 
@@ -120,6 +121,16 @@ func (e *Event) Service() (*ServiceRegistration, error) {
 	return out.Service, nil
 }
 
+// IngressRoute returns an IngressRoute struct from a given event payload. If
+// the Event Topic is Ingress this will return a valid IngressRoute.
+func (e *Event) IngressRoute() (*IngressRoute, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Route, nil
+}
+
 type eventPayload struct {
 	Allocation *Allocation          `mapstructure:"Allocation"`
 	Deployment *Deployment          `mapstructure:"Deployment"`
@@ -128,6 +139,7 @@ type eventPayload struct {
 	Node       *Node                `mapstructure:"Node"`
 	NodePool   *NodePool            `mapstructure:"NodePool"`
 	Service    *ServiceRegistration `mapstructure:"Service"`
+	Route      *IngressRoute        `mapstructure:"Route"`
 }
 
 func (e *Event) decodePayload() (*eventPayload, error) {
@@ -1 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
 package api
+
+import (
+	"net/url"
+	"sort"
+	"time"
+)
+
+// IngressPlugins is used to query the ingress plugin endpoints.
+type IngressPlugins struct {
+	client *Client
+}
+
+// IngressPlugins returns a handle on the IngressPlugins endpoint.
+func (c *Client) IngressPlugins() *IngressPlugins {
+	return &IngressPlugins{client: c}
+}
+
+// List returns all ingress plugins. The Prefix query option filters plugins
+// by ID.
+func (p *IngressPlugins) List(q *QueryOptions) ([]*IngressPluginListStub, *QueryMeta, error) {
+	var resp []*IngressPluginListStub
+	qm, err := p.client.query("/v1/plugins/ingress", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Sort(IngressPluginIndexSort(resp))
+	return resp, qm, nil
+}
+
+// Info is used to retrieve a single ingress plugin.
+func (p *IngressPlugins) Info(id string, q *QueryOptions) (*IngressPlugin, *QueryMeta, error) {
+	var resp *IngressPlugin
+	qm, err := p.client.query("/v1/plugins/ingress/specific?id="+url.QueryEscape(id), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Delete is used to delete an ingress plugin which has no controllers left.
+func (p *IngressPlugins) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	return p.client.delete("/v1/plugins/ingress/specific?id="+url.QueryEscape(id), nil, nil, w)
+}
+
+// IngressPlugin is an ingress controller registered by the clients running
+// its tasks.
+type IngressPlugin struct {
+	ID       string
+	Provider string
+	Version  string
+
+	// Map Node.ID to IngressInfo fingerprint results
+	Controllers         map[string]*IngressInfo
+	Allocations         []*AllocationListStub
+	ControllersHealthy  int
+	ControllersExpected int
+	CreateIndex         uint64
+	ModifyIndex         uint64
+}
+
+// IngressInfo is the fingerprint of an ingress controller on a single node.
+type IngressInfo struct {
+	PluginID             string
+	AllocID              string
+	Healthy              bool
+	HealthDescription    string
+	UpdateTime           time.Time
+	NodeStatusUpdateTime time.Time
+	Provider             string
+	ProviderVersion      string
+}
+
+type IngressPluginListStub struct {
+	ID                  string
+	Provider            string
+	ControllersHealthy  int
+	ControllersExpected int
+	CreateIndex         uint64
+	ModifyIndex         uint64
+}
+
+// IngressPluginIndexSort is a helper used for sorting plugin stubs by creation
+// time.
+type IngressPluginIndexSort []*IngressPluginListStub
+
+func (v IngressPluginIndexSort) Len() int {
+	return len(v)
+}
+
+func (v IngressPluginIndexSort) Less(i, j int) bool {
+	return v[i].CreateIndex > v[j].CreateIndex
+}
+
+func (v IngressPluginIndexSort) Swap(i, j int) {
+	v[i], v[j] = v[j], v[i]
+}
+
+// IngressRoutes is used to query the ingress route endpoints.
+type IngressRoutes struct {
+	client *Client
+}
+
+// IngressRoutes returns a handle on the IngressRoutes endpoint.
+func (c *Client) IngressRoutes() *IngressRoutes {
+	return &IngressRoutes{client: c}
+}
+
+// List returns the ingress routes of the namespace. The Prefix query option
+// filters routes by job ID.
+func (r *IngressRoutes) List(q *QueryOptions) ([]*IngressRoute, *QueryMeta, error) {
+	var resp []*IngressRoute
+	qm, err := r.client.query("/v1/ingress/routes", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].ID < resp[j].ID })
+	return resp, qm, nil
+}
+
+// Ack acknowledges that ingress routes were programmed in a load balancer.
+// The healthy allocations of a deployment only count towards its health once
+// the routes they are a backend of are acknowledged.
+func (r *IngressRoutes) Ack(acks []*IngressRouteAck, w *WriteOptions) (*WriteMeta, error) {
+	req := &ingressRouteAckRequest{Acks: acks}
+	return r.client.put("/v1/ingress/routes/ack", req, nil, w)
+}
+
+// IngressRoute is a route programmed by ingress plugins. It is built by the
+// servers from the ingress block of a service and the addresses of the
+// healthy allocations running the service.
+type IngressRoute struct {
+	ID        string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	Service   string
+	Ingress   *ServiceIngress
+	Backends  []*IngressBackend
+
+	// ProgrammedIndex is the index of the route acknowledged by the ingress
+	// plugins programming it.
+	ProgrammedIndex uint64
+	CreateIndex     uint64
+	ModifyIndex     uint64
+}
+
+// IngressBackend is the address of a single allocation behind an ingress
+// route.
+type IngressBackend struct {
+	AllocID string
+	NodeID  string
+	Address string
+	Port    int
+
+	// Weight is the percentage of the traffic of the route sent to the
+	// backend.
+	Weight int
+
+	// Canary is set when the allocation is an unpromoted canary.
+	Canary      bool
+	CreateIndex uint64
+}
+
+// IngressRouteAck acknowledges that an ingress plugin programmed a route up
+// to an index.
+type IngressRouteAck struct {
+	Namespace string
+	RouteID   string
+	Index     uint64
+}
+
+type ingressRouteAckRequest struct {
+	Acks []*IngressRouteAck
+}
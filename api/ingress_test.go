@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestIngressPlugins_List(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	plugs, _, err := c.IngressPlugins().List(nil)
+	must.NoError(t, err)
+	must.SliceEmpty(t, plugs)
+
+	_, _, err = c.IngressPlugins().Info("unknown", nil)
+	must.ErrorContains(t, err, "plugin not found")
+}
+
+func TestIngressRoutes_List(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	job := testJob()
+	job.TaskGroups[0].Networks = []*NetworkResource{{
+		DynamicPorts: []Port{{Label: "http"}},
+	}}
+	job.TaskGroups[0].Services = []*Service{{
+		Name:      "web",
+		PortLabel: "http",
+		Ingress: &ServiceIngress{
+			Hosts: []string{"example.com"},
+		},
+	}}
+	_, _, err := c.Jobs().Register(job, nil)
+	must.NoError(t, err)
+
+	routes, _, err := c.IngressRoutes().List(nil)
+	must.NoError(t, err)
+	must.Len(t, 1, routes)
+	must.Eq(t, *job.ID, routes[0].JobID)
+	must.Eq(t, "web", routes[0].Service)
+	must.Eq(t, []string{"example.com"}, routes[0].Ingress.Hosts)
+	must.Eq(t, 100, *routes[0].Ingress.Weight)
+}
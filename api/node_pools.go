@@ -119,10 +119,24 @@ type NodePool struct {
 	Description            string                          `hcl:"description,optional"`
 	Meta                   map[string]string               `hcl:"meta,block"`
 	SchedulerConfiguration *NodePoolSchedulerConfiguration `hcl:"scheduler_config,block"`
+	MaintenanceWindows     []*MaintenanceWindow            `hcl:"maintenance_window,block"`
 	CreateIndex            uint64
 	ModifyIndex            uint64
 }
 
+// MaintenanceWindow is a recurring period during which the nodes of a node
+// pool, or a single node, are drained. Duration and Lead are duration strings
+// such as "4h".
+type MaintenanceWindow struct {
+	Name     string `hcl:"name,label"`
+	Cron     string `hcl:"cron"`
+	TimeZone string `hcl:"time_zone,optional"`
+	Duration string `hcl:"duration"`
+	Lead     string `hcl:"lead,optional"`
+
+	MaxParallel int `hcl:"max_parallel,optional"`
+}
+
 // NodePoolSchedulerConfiguration is used to serialize the scheduler
 // configuration of a node pool.
 type NodePoolSchedulerConfiguration struct {
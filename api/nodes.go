@@ -554,6 +554,7 @@ type Node struct {
 	Meta                  map[string]string
 	NodeClass             string
 	NodePool              string
+	MaintenanceWindows    []*MaintenanceWindow
 	CgroupParent          string
 	Drain                 bool
 	DrainStrategy         *DrainStrategy
@@ -567,6 +568,7 @@ type Node struct {
 	HostNetworks          map[string]*HostNetworkInfo
 	CSIControllerPlugins  map[string]*CSIInfo
 	CSINodePlugins        map[string]*CSIInfo
+	IngressPlugins        map[string]*IngressInfo
 	LastDrain             *DrainMetadata
 	CreateIndex           uint64
 	ModifyIndex           uint64
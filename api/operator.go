@@ -180,6 +180,18 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// RebalanceConfig controls the rebalancer, which periodically migrates
+	// allocations to rebalance the nodes of each node pool.
+	RebalanceConfig RebalanceConfig
+
+	// FairShareConfig controls how the evaluation broker shares the
+	// schedulers between namespaces.
+	FairShareConfig FairShareConfig
+
+	// ResourceWeights are the weights of the resources of nodes when scoring
+	// with the weighted scheduler algorithm.
+	ResourceWeights ResourceWeights
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -209,8 +221,9 @@ type SchedulerSetConfigurationResponse struct {
 type SchedulerAlgorithm string
 
 const (
-	SchedulerAlgorithmBinpack SchedulerAlgorithm = "binpack"
-	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmBinpack  SchedulerAlgorithm = "binpack"
+	SchedulerAlgorithmSpread   SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmWeighted SchedulerAlgorithm = "weighted"
 )
 
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
@@ -221,6 +234,66 @@ type PreemptionConfig struct {
 	ServiceSchedulerEnabled  bool
 }
 
+// RebalanceConfig specifies whether and how much the rebalancer migrates
+// allocations.
+type RebalanceConfig struct {
+	// Enabled specifies if the rebalancer runs.
+	Enabled bool
+
+	// MaxMoves is the maximum number of allocations migrated by each run of
+	// the rebalancer. Zero means no limit other than the migrate blocks of
+	// the task groups.
+	MaxMoves int
+}
+
+// FairShareConfig specifies whether and how the evaluation broker shares the
+// schedulers between namespaces.
+type FairShareConfig struct {
+	// Enabled specifies if evaluations of equal priority are dequeued by
+	// weighted fair queueing across namespaces.
+	Enabled bool
+
+	// NamespaceWeights are the relative shares of the schedulers given to
+	// each namespace. Namespaces without a weight have a weight of 1.
+	NamespaceWeights map[string]int
+}
+
+// ResourceWeights are the relative weights of the resources of nodes when
+// scoring with the weighted scheduler algorithm. A resource with a weight of zero
+// is ignored, unless all the weights are zero, in which case every resource
+// has a weight of 1.
+type ResourceWeights struct {
+	CPU     int
+	Memory  int
+	Disk    int
+	Network int
+	Cores   int
+	Devices int
+}
+
+// RebalanceMove is an allocation the rebalancer migrates off its node.
+type RebalanceMove struct {
+	AllocID   string
+	AllocName string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+	NodePool  string
+
+	// Reason describes why the allocation is moved.
+	Reason string
+}
+
+// SchedulerRebalancePlanResponse is the response object previewing the
+// allocations the rebalancer would migrate.
+type SchedulerRebalancePlanResponse struct {
+	// Moves are the allocations the rebalancer would migrate.
+	Moves []*RebalanceMove
+
+	QueryMeta
+}
+
 // SchedulerGetConfiguration is used to query the current Scheduler configuration.
 func (op *Operator) SchedulerGetConfiguration(q *QueryOptions) (*SchedulerConfigurationResponse, *QueryMeta, error) {
 	var resp SchedulerConfigurationResponse
@@ -231,6 +304,17 @@ func (op *Operator) SchedulerGetConfiguration(q *QueryOptions) (*SchedulerConfig
 	return &resp, qm, nil
 }
 
+// SchedulerRebalancePlan is used to preview the allocations the rebalancer
+// would migrate, whether it is enabled or not.
+func (op *Operator) SchedulerRebalancePlan(q *QueryOptions) (*SchedulerRebalancePlanResponse, *QueryMeta, error) {
+	var resp SchedulerRebalancePlanResponse
+	qm, err := op.c.query("/v1/operator/scheduler/rebalance", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // SchedulerSetConfiguration is used to set the current Scheduler configuration.
 func (op *Operator) SchedulerSetConfiguration(conf *SchedulerConfiguration, q *WriteOptions) (*SchedulerSetConfigurationResponse, *WriteMeta, error) {
 	var out SchedulerSetConfigurationResponse
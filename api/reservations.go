@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// Reservations is used to access the reservations endpoints.
+type Reservations struct {
+	client *Client
+}
+
+// Reservations returns a handle on the reservations endpoints.
+func (c *Client) Reservations() *Reservations {
+	return &Reservations{client: c}
+}
+
+// List is used to list the reservations of a namespace.
+func (r *Reservations) List(q *QueryOptions) ([]*Reservation, *QueryMeta, error) {
+	var resp []*Reservation
+	qm, err := r.client.query("/v1/reservations", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// PrefixList is used to list the reservations with an ID matching a given
+// prefix.
+func (r *Reservations) PrefixList(prefix string, q *QueryOptions) ([]*Reservation, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	q.Prefix = prefix
+	return r.List(q)
+}
+
+// Info is used to fetch the details of a reservation.
+func (r *Reservations) Info(id string, q *QueryOptions) (*Reservation, *QueryMeta, error) {
+	if id == "" {
+		return nil, nil, errors.New("missing reservation ID")
+	}
+
+	var resp Reservation
+	qm, err := r.client.query("/v1/reservation/"+url.PathEscape(id), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Upsert is used to create a reservation, or to update it when its ID is
+// set. The reservation is returned as written, including its generated ID.
+func (r *Reservations) Upsert(reservation *Reservation, w *WriteOptions) (*Reservation, *WriteMeta, error) {
+	if reservation == nil {
+		return nil, nil, errors.New("missing reservation")
+	}
+
+	endpoint := "/v1/reservations"
+	if reservation.ID != "" {
+		endpoint = "/v1/reservation/" + url.PathEscape(reservation.ID)
+	}
+
+	var resp Reservation
+	wm, err := r.client.put(endpoint, reservation, &resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Delete is used to delete a reservation.
+func (r *Reservations) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	if id == "" {
+		return nil, errors.New("missing reservation ID")
+	}
+
+	wm, err := r.client.delete("/v1/reservation/"+url.PathEscape(id), nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Reservation holds capacity on the nodes of a node pool for a namespace.
+// The resources are reserved on every node of the node pool matching the
+// constraints. Allocations of other namespaces can't use the reserved
+// resources the namespace doesn't use.
+type Reservation struct {
+	ID             string
+	Namespace      string
+	NodePool       string
+	Description    string
+	Resources      *ReservationResources
+	Constraints    []*Constraint
+	ExpirationTime *time.Time
+	ExpirationTTL  time.Duration
+	CreateTime     time.Time
+	CreateIndex    uint64
+	ModifyIndex    uint64
+}
+
+// ReservationResources are the resources held by a reservation on each node.
+type ReservationResources struct {
+	CPU      int
+	MemoryMB int
+	DiskMB   int
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"errors"
+	"net/url"
+)
+
+const (
+	// ServiceIntentionActionAllow is the action of intentions authorizing the
+	// traffic from their source to their destination service.
+	ServiceIntentionActionAllow = "allow"
+
+	// ServiceIntentionActionDeny is the action of intentions denying the
+	// traffic from their source to their destination service.
+	ServiceIntentionActionDeny = "deny"
+)
+
+// ServiceMeshIntentions is used to access the service intentions endpoints
+// of the Nomad service mesh.
+type ServiceMeshIntentions struct {
+	client *Client
+}
+
+// ServiceMeshIntentions returns a handle on the service intentions
+// endpoints.
+func (c *Client) ServiceMeshIntentions() *ServiceMeshIntentions {
+	return &ServiceMeshIntentions{client: c}
+}
+
+// List is used to list the service intentions of a namespace.
+func (i *ServiceMeshIntentions) List(q *QueryOptions) ([]*ServiceIntention, *QueryMeta, error) {
+	var resp []*ServiceIntention
+	qm, err := i.client.query("/v1/service-mesh/intentions", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Upsert is used to create a service intention, or to replace the intention
+// between the same source and destination services.
+func (i *ServiceMeshIntentions) Upsert(intention *ServiceIntention, w *WriteOptions) (*WriteMeta, error) {
+	if intention == nil {
+		return nil, errors.New("missing intention")
+	}
+
+	wm, err := i.client.put("/v1/service-mesh/intention", intention, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Delete is used to delete the service intention between the source and
+// destination services.
+func (i *ServiceMeshIntentions) Delete(source, destination string, w *WriteOptions) (*WriteMeta, error) {
+	if source == "" || destination == "" {
+		return nil, errors.New("missing source or destination service")
+	}
+
+	endpoint := "/v1/service-mesh/intention/" + url.PathEscape(source) + "/" + url.PathEscape(destination)
+	wm, err := i.client.delete(endpoint, nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// ServiceIntention authorizes or denies the traffic of the Nomad service mesh
+// from a source service to a destination service of the same namespace. The
+// "*" wildcard matches any service.
+type ServiceIntention struct {
+	Namespace          string
+	SourceService      string
+	DestinationService string
+	Action             string
+	Description        string
+	CreateIndex        uint64
+	ModifyIndex        uint64
+}
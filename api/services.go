@@ -59,6 +59,12 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// CheckStatus is the aggregate status of the checks of the service. It is
+	// "failure" if any check is failing, "pending" if any check has not yet
+	// passed, "success" once all checks are passing, and empty when the
+	// service has no checks.
+	CheckStatus string
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -224,6 +230,7 @@ type ServiceCheck struct {
 	FailuresBeforeCritical int                 `mapstructure:"failures_before_critical" hcl:"failures_before_critical,optional"`
 	Body                   string              `hcl:"body,optional"`
 	OnUpdate               string              `mapstructure:"on_update" hcl:"on_update,optional"`
+	Role                   string              `hcl:"role,optional"`
 }
 
 // Service represents a Nomad job-submitters view of a Consul or Nomad service.
@@ -251,6 +258,78 @@ type Service struct {
 
 	// Cluster is valid only for Nomad Enterprise with provider: consul
 	Cluster string `hcl:"cluster,optional`
+
+	// Ingress describes how traffic from outside the cluster is routed to
+	// the service by ingress plugins.
+	Ingress *ServiceIngress `hcl:"ingress,block"`
+
+	// Mesh adds the service to the Nomad service mesh. It is only valid for
+	// group services using the "nomad" provider.
+	Mesh *ServiceMesh `hcl:"mesh,block"`
+}
+
+// ServiceMesh is used to configure the proxy Nomad clients run for a service
+// of the Nomad service mesh.
+type ServiceMesh struct {
+	LocalServicePort int             `mapstructure:"local_service_port" hcl:"local_service_port,optional"`
+	Upstreams        []*MeshUpstream `hcl:"upstreams,block"`
+}
+
+// MeshUpstream is a service of the Nomad service mesh a service connects to
+// through a local port.
+type MeshUpstream struct {
+	DestinationName string `mapstructure:"destination_name" hcl:"destination_name,optional"`
+	LocalBindPort   int    `mapstructure:"local_bind_port" hcl:"local_bind_port,optional"`
+}
+
+// Canonicalize the ServiceMesh.
+func (m *ServiceMesh) Canonicalize() {
+	if m == nil {
+		return
+	}
+
+	if len(m.Upstreams) == 0 {
+		m.Upstreams = nil
+	}
+}
+
+// ServiceIngress is used to configure the routes ingress plugins program
+// for a service.
+type ServiceIngress struct {
+	Hosts     []string `hcl:"hosts,optional"`
+	Paths     []string `hcl:"paths,optional"`
+	TLSSecret string   `mapstructure:"tls_secret" hcl:"tls_secret,optional"`
+	Class     string   `hcl:"class,optional"`
+	Weight    *int     `hcl:"weight,optional"`
+
+	// CanaryWeight is the percentage of the traffic sent to the canaries of
+	// a deployment until they are promoted.
+	CanaryWeight int `mapstructure:"canary_weight" hcl:"canary_weight,optional"`
+}
+
+const (
+	// ServiceIngressDefaultWeight is the weight given to a service ingress
+	// when none is set.
+	ServiceIngressDefaultWeight = 100
+)
+
+// Canonicalize the ServiceIngress by defaulting its weight.
+func (i *ServiceIngress) Canonicalize() {
+	if i == nil {
+		return
+	}
+
+	if i.Weight == nil {
+		i.Weight = pointerOf(ServiceIngressDefaultWeight)
+	}
+
+	if len(i.Hosts) == 0 {
+		i.Hosts = nil
+	}
+
+	if len(i.Paths) == 0 {
+		i.Paths = nil
+	}
 }
 
 const (
@@ -306,6 +385,10 @@ func (s *Service) Canonicalize(t *Task, tg *TaskGroup, job *Job) {
 
 	s.Connect.Canonicalize()
 
+	s.Ingress.Canonicalize()
+
+	s.Mesh.Canonicalize()
+
 	// Canonicalize CheckRestart on Checks and merge Service.CheckRestart
 	// into each check.
 	for i, check := range s.Checks {
@@ -63,6 +63,27 @@ func TestServiceCheck_Canonicalize(t *testing.T) {
 	must.Eq(t, OnUpdateRequireHealthy, s.Checks[0].OnUpdate)
 }
 
+func TestServiceIngress_Canonicalize(t *testing.T) {
+	testutil.Parallel(t)
+
+	j := &Job{Name: pointerOf("job")}
+	tg := &TaskGroup{Name: pointerOf("group")}
+	s := &Service{
+		Ingress: &ServiceIngress{
+			Hosts: []string{"example.com"},
+			Paths: []string{},
+		},
+	}
+
+	s.Canonicalize(nil, tg, j)
+	must.Eq(t, ServiceIngressDefaultWeight, *s.Ingress.Weight)
+	must.Nil(t, s.Ingress.Paths)
+
+	s.Ingress.Weight = pointerOf(0)
+	s.Canonicalize(nil, tg, j)
+	must.Eq(t, 0, *s.Ingress.Weight)
+}
+
 func TestService_Check_PassFail(t *testing.T) {
 	testutil.Parallel(t)
 
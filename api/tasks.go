@@ -205,6 +205,23 @@ func (a *Affinity) Canonicalize() {
 	}
 }
 
+// JobAffinity is used to place allocations close to, or away from, the
+// allocations of other jobs.
+type JobAffinity struct {
+	Namespace string            `hcl:"namespace,optional"`
+	JobID     string            `mapstructure:"job_id" hcl:"job_id,optional"`
+	Meta      map[string]string `hcl:"meta,block"`
+	Attribute string            `hcl:"attribute,optional"`
+	Weight    *int8             `hcl:"weight,optional"` // Negative for anti-affinity
+	Required  bool              `hcl:"required,optional"`
+}
+
+func (a *JobAffinity) Canonicalize() {
+	if a.Weight == nil {
+		a.Weight = pointerOf(int8(50))
+	}
+}
+
 func NewDefaultReschedulePolicy(jobType string) *ReschedulePolicy {
 	var dp *ReschedulePolicy
 	switch jobType {
@@ -283,6 +300,7 @@ type Spread struct {
 	Attribute    string          `hcl:"attribute,optional"`
 	Weight       *int8           `hcl:"weight,optional"`
 	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	MaxSkew      int             `mapstructure:"max_skew" hcl:"max_skew,optional"`
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -443,6 +461,7 @@ type TaskGroup struct {
 	Count                     *int                      `hcl:"count,optional"`
 	Constraints               []*Constraint             `hcl:"constraint,block"`
 	Affinities                []*Affinity               `hcl:"affinity,block"`
+	JobAffinities             []*JobAffinity            `hcl:"job_affinity,block"`
 	Tasks                     []*Task                   `hcl:"task,block"`
 	Spreads                   []*Spread                 `hcl:"spread,block"`
 	Volumes                   map[string]*VolumeRequest `hcl:"volume,block"`
@@ -459,6 +478,7 @@ type TaskGroup struct {
 	MaxClientDisconnect       *time.Duration            `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
 	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
 	Consul                    *Consul                   `hcl:"consul,block"`
+	Gang                      *bool                     `hcl:"gang,optional"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -571,6 +591,9 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	for _, a := range g.Affinities {
 		a.Canonicalize()
 	}
+	for _, a := range g.JobAffinities {
+		a.Canonicalize()
+	}
 	for _, n := range g.Networks {
 		n.Canonicalize()
 	}
@@ -631,6 +654,12 @@ func (g *TaskGroup) AddAffinity(a *Affinity) *TaskGroup {
 	return g
 }
 
+// AddJobAffinity is used to add a new job affinity to a task group.
+func (g *TaskGroup) AddJobAffinity(a *JobAffinity) *TaskGroup {
+	g.JobAffinities = append(g.JobAffinities, a)
+	return g
+}
+
 // RequireDisk adds a ephemeral disk to the task group
 func (g *TaskGroup) RequireDisk(disk *EphemeralDisk) *TaskGroup {
 	g.EphemeralDisk = disk
@@ -777,6 +806,9 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	if t.CSIPluginConfig != nil {
 		t.CSIPluginConfig.Canonicalize()
 	}
+	if t.IngressPluginConfig != nil {
+		t.IngressPluginConfig.Canonicalize()
+	}
 	if t.RestartPolicy == nil {
 		t.RestartPolicy = tg.RestartPolicy
 	} else {
@@ -1200,32 +1232,55 @@ type TaskIngressPluginConfig struct {
 	// Nomad token to access Nomad API
 	NomadToken string                      `mapstructure:"nomad_token" hcl:"nomad_token"`
 	Class      IngressClass                `mapstructure:"class" hcl:"class"`
-	Internal   *InternalIngressClassConfig `mapstructure:"internal" hcl:"internal,optional"`
-	External   *ExternalIngressClassConfig `mapstructure:"external" hcl:"external,optional"`
+	Internal   *InternalIngressClassConfig `mapstructure:"internal" hcl:"internal,block"`
+	External   *ExternalIngressClassConfig `mapstructure:"external" hcl:"external,block"`
 }
 
 // InternalIngressClassConfig contains parameters for ingress controller
 // which manages load balancer inside nomad cluster
 type InternalIngressClassConfig struct {
-	LoadBalancerConfigurationPath string `mapstructure:"lb_conf_path" hcl:"lb_conf_path"`
+	LoadBalancerConfigurationPath string `mapstructure:"lb_conf_path" hcl:"lb_conf_path,optional"`
+	// Template is the built-in template Nomad renders the load balancer
+	// configuration with: "haproxy", "nginx" or "envoy"
+	Template string `mapstructure:"template" hcl:"template,optional"`
+	// PortLabel is the port the rendered load balancer listens on
+	PortLabel string `mapstructure:"port" hcl:"port,optional"`
+	// ReloadSignal is sent to the task when the configuration changes
+	ReloadSignal string `mapstructure:"reload_signal" hcl:"reload_signal,optional"`
 }
 
 // ExternalIngressClassConfig contains parameters for ingress controller
 // which manages load balancer outside nomad cluster
 type ExternalIngressClassConfig struct {
+	// TargetGroup is the identity of the target group or pool of the
+	// external load balancer
+	TargetGroup string `mapstructure:"target_group" hcl:"target_group"`
+	// DrainTimeout is how long in-flight connections to an allocation are
+	// served before it is removed from the target group
+	DrainTimeout *time.Duration `mapstructure:"drain_timeout" hcl:"drain_timeout,optional"`
 }
 
 func (t *TaskIngressPluginConfig) Canonicalize() {
-	if t.Internal == nil && t.External == nil {
-		t.Class = InternalIngressClass
-		t.Internal = &InternalIngressClassConfig{
-			LoadBalancerConfigurationPath: "/lb",
+	if t.Class == "" {
+		if t.External != nil {
+			t.Class = ExternalIngressClass
+		} else {
+			t.Class = InternalIngressClass
 		}
 	}
 
+	if t.Class == InternalIngressClass && t.Internal == nil {
+		t.Internal = &InternalIngressClassConfig{}
+	}
+
 	if t.Internal != nil {
 		if t.Internal.LoadBalancerConfigurationPath == "" {
 			t.Internal.LoadBalancerConfigurationPath = "/lb"
 		}
+		t.Internal.ReloadSignal = strings.ToUpper(t.Internal.ReloadSignal)
+	}
+
+	if t.External != nil && t.External.DrainTimeout == nil {
+		t.External.DrainTimeout = pointerOf(30 * time.Second)
 	}
 }
@@ -950,6 +950,18 @@ func TestTask_Canonicalize_IngressPluginConfig(t *testing.T) {
 		must.Eq(t, "/lb", conf.Internal.LoadBalancerConfigurationPath)
 	})
 
+	t.Run("reload signal", func(t *testing.T) {
+		conf := &TaskIngressPluginConfig{
+			Internal: &InternalIngressClassConfig{
+				Template:     "haproxy",
+				ReloadSignal: "sigusr2",
+			},
+		}
+		conf.Canonicalize()
+
+		must.Eq(t, "SIGUSR2", conf.Internal.ReloadSignal)
+	})
+
 	t.Run("not set internal, external", func(t *testing.T) {
 		conf := &TaskIngressPluginConfig{
 			NomadEndpoint: "my-server",
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-set/v2"
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -625,6 +626,9 @@ func (t *Tracker) watchNomadEvents() {
 	// latest set of nomad check results
 	var results map[structs.CheckID]*structs.CheckQueryResult
 
+	// startup checks which have passed, and are no longer considered
+	started := set.New[structs.CheckID](0)
+
 	for {
 		select {
 
@@ -649,8 +653,14 @@ func (t *Tracker) watchNomadEvents() {
 		// scan to see if any checks are failing
 		passing := true
 		for _, result := range results {
+			if started.Contains(result.ID) {
+				continue
+			}
 			switch result.Status {
 			case structs.CheckSuccess:
+				if result.Role == structs.CheckRoleStartup {
+					started.Insert(result.ID)
+				}
 				continue
 			case structs.CheckFailure:
 				if result.Mode == structs.Readiness {
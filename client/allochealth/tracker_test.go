@@ -408,6 +408,74 @@ func TestTracker_NomadChecks_Unhealthy(t *testing.T) {
 	}
 }
 
+func TestTracker_NomadChecks_Startup(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Migrate.MinHealthyTime = 1 // let's speed things up
+	alloc.Job.TaskGroups[0].Tasks[0].Services[0].Provider = "nomad"
+
+	logger := testlog.HCLogger(t)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Synthesize running alloc and tasks
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.TaskStates = map[string]*structs.TaskState{
+		alloc.Job.TaskGroups[0].Tasks[0].Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now(),
+		},
+	}
+
+	result := func(id structs.CheckID, role string, status structs.CheckStatus) *structs.CheckQueryResult {
+		return &structs.CheckQueryResult{
+			ID:        id,
+			Mode:      "healthiness",
+			Role:      role,
+			Status:    status,
+			Timestamp: time.Now().Unix(),
+			Group:     alloc.TaskGroup,
+			Task:      alloc.Job.TaskGroups[0].Tasks[0].Name,
+			Service:   alloc.Job.TaskGroups[0].Tasks[0].Services[0].Name,
+			Check:     alloc.Job.TaskGroups[0].Tasks[0].Services[0].Checks[0].Name,
+		}
+	}
+
+	// the startup check has passed, the readiness check is pending
+	checks := checkstore.NewStore(logger, state.NewMemDB(logger))
+	must.NoError(t, checks.Set(alloc.ID, result("abc123", structs.CheckRoleStartup, structs.CheckSuccess)))
+	must.NoError(t, checks.Set(alloc.ID, result("def456", structs.CheckRoleReadiness, structs.CheckPending)))
+
+	consul := regmock.NewServiceRegistrationHandler(logger)
+	checkInterval := 10 * time.Millisecond
+	taskEnvBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
+
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), taskEnvBuilder, consul, checks, time.Millisecond, true)
+	tracker.checkLookupInterval = checkInterval
+	tracker.Start()
+
+	go func() {
+		// wait a bit then fail the startup check, which no longer matters
+		time.Sleep(25 * time.Millisecond)
+		must.NoError(t, checks.Set(alloc.ID, result("abc123", structs.CheckRoleStartup, structs.CheckFailure)))
+
+		// wait a bit then update the readiness check to passing
+		time.Sleep(25 * time.Millisecond)
+		must.NoError(t, checks.Set(alloc.ID, result("def456", structs.CheckRoleReadiness, structs.CheckSuccess)))
+	}()
+
+	select {
+	case <-time.After(10 * checkInterval):
+		t.Fatalf("timed out while waiting for success")
+	case healthy := <-tracker.HealthyCh():
+		must.True(t, healthy)
+	}
+}
+
 func TestTracker_Checks_PendingPostStop_Healthy(t *testing.T) {
 	ci.Parallel(t)
 
@@ -302,6 +302,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			StateUpdater:        ar,
 			DynamicRegistry:     ar.dynamicRegistry,
 			ConsulServices:      ar.consulServicesHandler,
+			CheckStore:          ar.checkStore,
 			ConsulProxiesFunc:   ar.consulProxiesClientFunc,
 			ConsulSI:            ar.sidsClient,
 			VaultFunc:           ar.vaultClientFunc,
@@ -317,6 +318,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			Wranglers:           ar.wranglers,
 			AllocHookResources:  ar.hookResources,
 			WIDMgr:              ar.widmgr,
+			RPCClient:           ar.rpcClient,
 		}
 
 		// Create, but do not Run, the task runner
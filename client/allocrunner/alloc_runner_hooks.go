@@ -135,6 +135,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		newCPUPartsHook(hookLogger, ar.partitions, alloc),
 		newAllocHealthWatcherHook(hookLogger, alloc, newEnvBuilder, hs, ar.Listener(), ar.consulServicesHandler, ar.checkStore),
 		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, builtTaskEnv),
+		newMeshHook(hookLogger, alloc, ns, ar.rpcClient, ar.clientConfig.Node.SecretID),
 		newGroupServiceHook(groupServiceHookConfig{
 			alloc:             alloc,
 			providerNamespace: alloc.ServiceProviderNamespace(),
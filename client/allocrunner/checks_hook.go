@@ -187,12 +187,18 @@ func (h *checksHook) observe(alloc *structs.Allocation, services []*structs.Serv
 			}
 
 			// insert a pending result into state store for each check
-			result := checks.Stub(id, structs.GetCheckMode(check), now, alloc.Name, service.TaskName, service.Name, check.Name)
+			result := checks.Stub(id, structs.GetCheckMode(check), check.Role, now, alloc.Name, service.TaskName, service.Name, check.Name)
 			if err := h.shim.Set(h.allocID, result); err != nil {
 				h.logger.Error("failed to set initial check status", "id", h.allocID, "error", err)
 				continue
 			}
 
+			// script checks are executed by the script check hook of their
+			// task, which has access to the task driver
+			if check.Type == structs.ServiceCheckScript {
+				continue
+			}
+
 			// start the observer
 			go h.observers[id].start()
 		}
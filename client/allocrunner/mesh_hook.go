@@ -0,0 +1,341 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/mesh"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// meshRetryInterval is the time waited before retrying failed
+	// certificate renewals and intention queries.
+	meshRetryInterval = 10 * time.Second
+)
+
+// meshHook runs the proxies of the services of the Nomad service mesh of an
+// allocation. It gets the certificates of the services signed by the servers,
+// renews them before they expire, and keeps the proxies up to date with the
+// service intentions of the allocation namespace.
+//
+// The proxies listen in the network namespace of the allocation, like the
+// Connect sidecars, so only the tasks of the allocation can reach the
+// upstream listeners, and the service is only reachable through the inbound
+// listener mapped to the service port.
+//
+// It is a noop for allocs that have no mesh service.
+type meshHook struct {
+	alloc            *structs.Allocation
+	logger           hclog.Logger
+	networkIsolation networkIsolationGetter
+	rpcClient        config.RPCer
+	nodeSecret       string
+
+	// withNetNS runs a function in a network namespace, and is overridden
+	// by tests.
+	withNetNS func(path string, fn func() error) error
+
+	proxies     []*mesh.Proxy
+	proxiesLock sync.Mutex
+
+	shutdownCtx      context.Context
+	shutdownCancelFn context.CancelFunc
+}
+
+func newMeshHook(logger hclog.Logger, alloc *structs.Allocation, networkIsolation networkIsolationGetter,
+	rpcClient config.RPCer, nodeSecret string) *meshHook {
+	shutdownCtx, shutdownCancelFn := context.WithCancel(context.Background())
+	return &meshHook{
+		alloc:            alloc,
+		logger:           logger.Named("mesh_hook"),
+		networkIsolation: networkIsolation,
+		rpcClient:        rpcClient,
+		nodeSecret:       nodeSecret,
+		withNetNS:        withNetNS,
+		shutdownCtx:      shutdownCtx,
+		shutdownCancelFn: shutdownCancelFn,
+	}
+}
+
+func (h *meshHook) Name() string {
+	return "mesh"
+}
+
+func (h *meshHook) Prerun() error {
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+	if tg == nil {
+		return nil
+	}
+	var services []*structs.Service
+	for _, service := range tg.Services {
+		if service.Mesh != nil {
+			services = append(services, service)
+		}
+	}
+	if len(services) == 0 {
+		return nil
+	}
+
+	// Jobs registered before the network mode of mesh services was
+	// validated may still use other modes.
+	if len(tg.Networks) != 1 || tg.Networks[0].Mode != "bridge" {
+		return errors.New("mesh services require a bridge network")
+	}
+	var netns string
+	if h.networkIsolation != nil {
+		if spec := h.networkIsolation.NetworkIsolation(); spec != nil {
+			netns = spec.Path
+		}
+	}
+	if netns == "" {
+		return errors.New("mesh services require a network namespace")
+	}
+
+	h.proxiesLock.Lock()
+	defer h.proxiesLock.Unlock()
+
+	// Get the intentions before accepting connections, so authorized
+	// services are not denied while the proxies start.
+	intentions, index, err := h.listIntentions(0)
+	if err != nil {
+		return fmt.Errorf("failed to list service intentions: %w", err)
+	}
+
+	for _, service := range services {
+		proxy := mesh.NewProxy(h.logger, h.alloc.Namespace, service.Name, h)
+		h.proxies = append(h.proxies, proxy)
+		proxy.SetIntentions(intentions)
+
+		expiration, err := h.signCertificate(proxy, service.Name)
+		if err != nil {
+			return fmt.Errorf("failed to sign certificate of mesh service %q: %w", service.Name, err)
+		}
+		if err := h.serve(proxy, service, netns); err != nil {
+			return err
+		}
+		go h.renewCertificate(proxy, service.Name, expiration)
+	}
+
+	go h.watchIntentions(index)
+	return nil
+}
+
+// serve starts the listeners of the proxy of the service in the network
+// namespace of the allocation: the inbound listener on the port the service
+// port is mapped to, and a loopback listener for each upstream.
+func (h *meshHook) serve(proxy *mesh.Proxy, service *structs.Service, netns string) error {
+	var ports structs.AllocatedPorts
+	if h.alloc.AllocatedResources != nil {
+		ports = h.alloc.AllocatedResources.Shared.Ports
+	}
+	port, ok := ports.Get(service.PortLabel)
+	if !ok {
+		return fmt.Errorf("mesh service %q port %q is not a port of the group network", service.Name, service.PortLabel)
+	}
+	inboundPort := port.To
+	if inboundPort < 1 {
+		inboundPort = port.Value
+	}
+
+	ln, err := h.listen(netns, net.JoinHostPort("", strconv.Itoa(inboundPort)))
+	if err != nil {
+		return fmt.Errorf("failed to listen for mesh service %q: %w", service.Name, err)
+	}
+	target := net.JoinHostPort("127.0.0.1", strconv.Itoa(service.Mesh.LocalServicePort))
+	proxy.ServeInbound(ln, func(ctx context.Context) (net.Conn, error) {
+		var conn net.Conn
+		err := h.withNetNS(netns, func() error {
+			var dialer net.Dialer
+			var err error
+			conn, err = dialer.DialContext(ctx, "tcp", target)
+			return err
+		})
+		return conn, err
+	})
+
+	for _, upstream := range service.Mesh.Upstreams {
+		ln, err := h.listen(netns, net.JoinHostPort("127.0.0.1", strconv.Itoa(upstream.LocalBindPort)))
+		if err != nil {
+			return fmt.Errorf("failed to listen for upstream %q of mesh service %q: %w",
+				upstream.DestinationName, service.Name, err)
+		}
+		proxy.ServeUpstream(ln, upstream.DestinationName)
+	}
+	return nil
+}
+
+// listen returns a TCP listener on the address in the network namespace.
+func (h *meshHook) listen(netns, addr string) (net.Listener, error) {
+	var ln net.Listener
+	err := h.withNetNS(netns, func() error {
+		var err error
+		ln, err = net.Listen("tcp", addr)
+		return err
+	})
+	return ln, err
+}
+
+// signCertificate generates a new key for the proxy of the service, and sets
+// the proxy certificate once signed by the servers. It returns the expiration
+// time of the certificate.
+func (h *meshHook) signCertificate(proxy *mesh.Proxy, service string) (time.Time, error) {
+	key, csr, err := mesh.GenerateKey(service)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	req := &structs.MeshSignCertificateRequest{
+		AllocID: h.alloc.ID,
+		Service: service,
+		CSR:     csr,
+		WriteRequest: structs.WriteRequest{
+			Region:    h.alloc.Job.Region,
+			Namespace: h.alloc.Namespace,
+			AuthToken: h.nodeSecret,
+		},
+	}
+	var resp structs.MeshSignCertificateResponse
+	if err := h.rpcClient.RPC(structs.MeshSignCertificateRPCMethod, req, &resp); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := proxy.SetCertificate(resp.Certificate, key, resp.CACertificates); err != nil {
+		return time.Time{}, err
+	}
+	return resp.Expiration, nil
+}
+
+// renewCertificate renews the certificate of the proxy when two thirds of its
+// lifetime have elapsed, until the hook is shut down.
+func (h *meshHook) renewCertificate(proxy *mesh.Proxy, service string, expiration time.Time) {
+	wait := time.Until(expiration) * 2 / 3
+	timer, stop := helper.NewSafeTimer(wait)
+	defer stop()
+
+	for {
+		select {
+		case <-h.shutdownCtx.Done():
+			return
+		case <-timer.C:
+		}
+
+		newExpiration, err := h.signCertificate(proxy, service)
+		if err != nil {
+			h.logger.Error("failed to renew mesh certificate", "service", service, "error", err)
+			timer.Reset(meshRetryInterval)
+			continue
+		}
+		timer.Reset(time.Until(newExpiration) * 2 / 3)
+	}
+}
+
+// watchIntentions updates the intentions of the proxies when the intentions
+// of the allocation namespace change, until the hook is shut down.
+func (h *meshHook) watchIntentions(index uint64) {
+	for {
+		intentions, newIndex, err := h.listIntentions(index)
+		if h.shutdownCtx.Err() != nil {
+			return
+		}
+		if err != nil {
+			h.logger.Error("failed to list service intentions", "error", err)
+			select {
+			case <-h.shutdownCtx.Done():
+				return
+			case <-time.After(meshRetryInterval):
+			}
+			continue
+		}
+		if newIndex == index {
+			continue
+		}
+		index = newIndex
+
+		h.proxiesLock.Lock()
+		for _, proxy := range h.proxies {
+			proxy.SetIntentions(intentions)
+		}
+		h.proxiesLock.Unlock()
+	}
+}
+
+// listIntentions returns the service intentions of the allocation namespace,
+// blocking until their index is greater than the passed index if it isn't
+// zero.
+func (h *meshHook) listIntentions(index uint64) ([]*structs.ServiceIntention, uint64, error) {
+	req := &structs.MeshListIntentionsRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:        h.alloc.Job.Region,
+			Namespace:     h.alloc.Namespace,
+			AuthToken:     h.nodeSecret,
+			MinQueryIndex: index,
+			AllowStale:    true,
+		},
+	}
+	var resp structs.MeshListIntentionsResponse
+	if err := h.rpcClient.RPC(structs.MeshListIntentionsRPCMethod, req, &resp); err != nil {
+		return nil, 0, err
+	}
+	return resp.Intentions, resp.Index, nil
+}
+
+// Resolve implements mesh.Resolver by returning the addresses of the
+// healthy instances of the service.
+func (h *meshHook) Resolve(_ context.Context, namespace, service string) ([]string, error) {
+	req := &structs.ServiceRegistrationByNameRequest{
+		ServiceName: service,
+		Healthy:     true,
+		QueryOptions: structs.QueryOptions{
+			Region:     h.alloc.Job.Region,
+			Namespace:  namespace,
+			AuthToken:  h.nodeSecret,
+			AllowStale: true,
+		},
+	}
+	var resp structs.ServiceRegistrationByNameResponse
+	if err := h.rpcClient.RPC(structs.ServiceRegistrationGetServiceRPCMethod, req, &resp); err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0, len(resp.Services))
+	for _, registration := range resp.Services {
+		addrs = append(addrs, net.JoinHostPort(registration.Address, strconv.Itoa(registration.Port)))
+	}
+	return addrs, nil
+}
+
+// Postrun stops the proxies once the allocation is terminal.
+func (h *meshHook) Postrun() error {
+	h.stop()
+	return nil
+}
+
+// Destroy stops the proxies if the allocation is destroyed before it is
+// terminal.
+func (h *meshHook) Destroy() error {
+	h.stop()
+	return nil
+}
+
+func (h *meshHook) stop() {
+	h.shutdownCancelFn()
+
+	h.proxiesLock.Lock()
+	defer h.proxiesLock.Unlock()
+	for _, proxy := range h.proxies {
+		proxy.Close()
+	}
+	h.proxies = nil
+}
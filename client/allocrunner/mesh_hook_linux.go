@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"fmt"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+)
+
+// withNetNS runs fn in the network namespace at path, so the sockets fn
+// creates belong to the namespace.
+func withNetNS(path string, fn func() error) error {
+	netns, err := ns.GetNS(path)
+	if err != nil {
+		return fmt.Errorf("failed to open network namespace: %w", err)
+	}
+	defer netns.Close()
+	return netns.Do(func(ns.NetNS) error {
+		return fn()
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+// TestMeshHook_Prerun_NetNS asserts the proxies listen in the network
+// namespace of their allocation, so allocations on the same host may use the
+// same ports and the upstream listeners are not reachable from the host.
+func TestMeshHook_Prerun_NetNS(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	ports := ci.PortAllocator.Grab(2)
+	for i := 0; i < 2; i++ {
+		alloc := meshAlloc(ports[0], ports[1])
+		netns, err := nsutil.NewNS(alloc.ID)
+		must.NoError(t, err)
+		t.Cleanup(func() { _ = nsutil.UnmountNS(netns.Path()) })
+
+		spec := &drivers.NetworkIsolationSpec{Mode: drivers.NetIsolationModeGroup, Path: netns.Path()}
+		h := newMeshHook(testlog.HCLogger(t), alloc, staticNetworkIsolation{spec}, newMeshRPCer(t), "secret")
+		must.NoError(t, h.Prerun())
+		t.Cleanup(func() { _ = h.Destroy() })
+	}
+
+	for _, port := range ports {
+		_, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		must.Error(t, err)
+	}
+}
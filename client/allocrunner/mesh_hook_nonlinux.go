@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+// +build !linux
+
+package allocrunner
+
+import "errors"
+
+// withNetNS returns an error as network namespaces are only supported on
+// Linux.
+func withNetNS(string, func() error) error {
+	return errors.New("network namespaces are not supported on this platform")
+}
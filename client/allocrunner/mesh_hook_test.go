@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+var _ interfaces.RunnerPrerunHook = (*meshHook)(nil)
+var _ interfaces.RunnerPostrunHook = (*meshHook)(nil)
+var _ interfaces.RunnerDestroyHook = (*meshHook)(nil)
+
+// meshRPCer mocks the server RPCs used by the mesh hook, signing
+// certificates with a self-signed certificate authority.
+type meshRPCer struct {
+	caCert  *x509.Certificate
+	caKey   ed25519.PrivateKey
+	signErr error
+	done    chan struct{}
+}
+
+func newMeshRPCer(t *testing.T) *meshRPCer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	must.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test mesh CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	must.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	must.NoError(t, err)
+
+	r := &meshRPCer{caCert: cert, caKey: key, done: make(chan struct{})}
+	t.Cleanup(func() { close(r.done) })
+	return r
+}
+
+func (r *meshRPCer) RPC(method string, args any, reply any) error {
+	switch method {
+	case structs.MeshListIntentionsRPCMethod:
+		req := args.(*structs.MeshListIntentionsRequest)
+		if req.MinQueryIndex > 0 {
+			// Block like a query that never sees an update.
+			<-r.done
+		}
+		resp := reply.(*structs.MeshListIntentionsResponse)
+		resp.Index = 1
+		return nil
+
+	case structs.MeshSignCertificateRPCMethod:
+		if r.signErr != nil {
+			return r.signErr
+		}
+		req := args.(*structs.MeshSignCertificateRequest)
+		block, _ := pem.Decode([]byte(req.CSR))
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			return err
+		}
+		expiration := time.Now().Add(time.Hour)
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			URIs:         []*url.URL{structs.MeshServiceURI(req.Namespace, req.Service)},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     expiration,
+		}, r.caCert, csr.PublicKey, r.caKey)
+		if err != nil {
+			return err
+		}
+		resp := reply.(*structs.MeshSignCertificateResponse)
+		resp.Certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+		resp.CACertificates = []string{string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: r.caCert.Raw}))}
+		resp.Expiration = expiration
+		return nil
+
+	default:
+		return fmt.Errorf("unexpected RPC method %s", method)
+	}
+}
+
+// meshAlloc returns an allocation with a group mesh service on a port
+// mapped to the passed port in the network namespace.
+func meshAlloc(port, upstreamPort int) *structs.Allocation {
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = structs.Networks{{
+		Mode:         "bridge",
+		DynamicPorts: []structs.Port{{Label: "http", To: port}},
+	}}
+	alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 31000, To: port, HostIP: "127.0.0.1"},
+	}
+	alloc.Job.TaskGroups[0].Services = []*structs.Service{{
+		Name:      "web",
+		PortLabel: "http",
+		Provider:  structs.ServiceProviderNomad,
+		Mesh: &structs.ServiceMesh{
+			LocalServicePort: 8080,
+			Upstreams: []*structs.MeshUpstream{
+				{DestinationName: "api", LocalBindPort: upstreamPort},
+			},
+		},
+	}}
+	return alloc
+}
+
+// staticNetworkIsolation returns the same network isolation spec.
+type staticNetworkIsolation struct {
+	spec *drivers.NetworkIsolationSpec
+}
+
+func (s staticNetworkIsolation) NetworkIsolation() *drivers.NetworkIsolationSpec { return s.spec }
+
+// newTestMeshHook returns a mesh hook for the alloc which runs its proxies in
+// the network namespace of the test.
+func newTestMeshHook(t *testing.T, alloc *structs.Allocation, rpc config.RPCer) *meshHook {
+	spec := &drivers.NetworkIsolationSpec{Mode: drivers.NetIsolationModeGroup, Path: "/var/run/netns/test"}
+	h := newMeshHook(testlog.HCLogger(t), alloc, staticNetworkIsolation{spec}, rpc, "secret")
+	h.withNetNS = func(_ string, fn func() error) error { return fn() }
+	return h
+}
+
+func TestMeshHook_Noop(t *testing.T) {
+	ci.Parallel(t)
+
+	// A nil RPC client fails the test if the hook calls any RPC.
+	h := newMeshHook(testlog.HCLogger(t), mock.Alloc(), staticNetworkIsolation{}, nil, "secret")
+	must.NoError(t, h.Prerun())
+	must.NoError(t, h.Postrun())
+}
+
+func TestMeshHook_Prerun_HostNetwork(t *testing.T) {
+	ci.Parallel(t)
+
+	ports := ci.PortAllocator.Grab(2)
+	alloc := meshAlloc(ports[0], ports[1])
+	alloc.Job.TaskGroups[0].Networks[0].Mode = "host"
+	h := newTestMeshHook(t, alloc, nil)
+	must.ErrorContains(t, h.Prerun(), "mesh services require a bridge network")
+
+	// Bridge networks without a network namespace are rejected too.
+	h = newMeshHook(testlog.HCLogger(t), meshAlloc(ports[0], ports[1]), staticNetworkIsolation{}, nil, "secret")
+	must.ErrorContains(t, h.Prerun(), "mesh services require a network namespace")
+}
+
+func TestMeshHook_Prerun(t *testing.T) {
+	ci.Parallel(t)
+
+	ports := ci.PortAllocator.Grab(2)
+	h := newTestMeshHook(t, meshAlloc(ports[0], ports[1]), newMeshRPCer(t))
+	must.NoError(t, h.Prerun())
+	must.Len(t, 1, h.proxies)
+
+	// The proxy listens on the service port and the upstream port.
+	for _, port := range ports {
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		must.NoError(t, err)
+		_ = conn.Close()
+	}
+
+	must.NoError(t, h.Postrun())
+	must.Len(t, 0, h.proxies)
+	for _, port := range ports {
+		_, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		must.Error(t, err)
+	}
+}
+
+func TestMeshHook_Prerun_SignError(t *testing.T) {
+	ci.Parallel(t)
+
+	ports := ci.PortAllocator.Grab(2)
+	rpc := newMeshRPCer(t)
+	rpc.signErr = errors.New("permission denied")
+	h := newTestMeshHook(t, meshAlloc(ports[0], ports[1]), rpc)
+	t.Cleanup(func() { _ = h.Destroy() })
+
+	err := h.Prerun()
+	must.ErrorContains(t, err, `failed to sign certificate of mesh service "web"`)
+}
@@ -6,6 +6,7 @@ package allocrunner
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/taskenv"
@@ -32,19 +33,41 @@ type networkIsolationSetter interface {
 	SetNetworkIsolation(*drivers.NetworkIsolationSpec)
 }
 
+// networkIsolationGetter is implemented by the shim holding the network
+// isolation spec of the alloc, for the hooks running after the network hook.
+type networkIsolationGetter interface {
+	NetworkIsolation() *drivers.NetworkIsolationSpec
+}
+
 // allocNetworkIsolationSetter is a shim to allow the alloc network hook to
 // set the alloc network isolation configuration without full access
 // to the alloc runner
 type allocNetworkIsolationSetter struct {
 	ar *allocRunner
+
+	// spec is the last network isolation spec set, synchronized by specLock
+	spec     *drivers.NetworkIsolationSpec
+	specLock sync.Mutex
 }
 
 func (a *allocNetworkIsolationSetter) SetNetworkIsolation(n *drivers.NetworkIsolationSpec) {
+	a.specLock.Lock()
+	a.spec = n
+	a.specLock.Unlock()
+
 	for _, tr := range a.ar.tasks {
 		tr.SetNetworkIsolation(n)
 	}
 }
 
+// NetworkIsolation returns the network isolation spec of the alloc, or nil
+// if the alloc has no network namespace.
+func (a *allocNetworkIsolationSetter) NetworkIsolation() *drivers.NetworkIsolationSpec {
+	a.specLock.Lock()
+	defer a.specLock.Unlock()
+	return a.spec
+}
+
 type networkStatusSetter interface {
 	SetNetworkStatus(*structs.AllocNetworkStatus)
 }
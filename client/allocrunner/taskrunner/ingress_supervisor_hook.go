@@ -1,19 +1,39 @@
 package taskrunner
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-template/signals"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/client/lib/ingresslb"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/lib/file"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/ingress"
-	"os"
-	"path/filepath"
-	"sync"
-	"time"
+)
+
+const (
+	// ingressHookStateRoutes and ingressHookStateTargets are the keys of the
+	// hook state holding the JSON encoded IDs of the routes and the targets
+	// programmed by the plugin, so the ones no longer served can be removed
+	// after the task or the client restarted.
+	ingressHookStateRoutes  = "routes"
+	ingressHookStateTargets = "targets"
 )
 
 // ingressPluginSupervisorHook manages supervising plugins that are running as Nomad
@@ -31,6 +51,23 @@ type ingressPluginSupervisorHook struct {
 	eventEmitter ti.EventEmitter
 	lifecycle    ti.TaskLifecycle
 
+	// rpcClient is used by external class plugins to watch the ingress
+	// routes whose backends are registered in the target group, and to
+	// render the load balancer configuration of internal class plugins
+	rpcClient config.RPCer
+
+	// lbTemplate renders the load balancer configuration of internal class
+	// plugins with a template into lbConfDir, which is mounted in the task
+	// at lbConfPath. lbRendered is the last rendered content of each file.
+	lbTemplate *ingresslb.Template
+	lbConfDir  string
+	lbPort     int
+	lbRendered map[string][]byte
+	lbLock     sync.Mutex
+
+	// persisted is the hook state last persisted by the routes loop.
+	persisted map[string]string
+
 	shutdownCtx      context.Context
 	shutdownCancelFn context.CancelFunc
 	runOnce          sync.Once
@@ -45,6 +82,7 @@ type ingressPluginSupervisorHookConfig struct {
 	events             ti.EventEmitter
 	runner             *TaskRunner
 	lifecycle          ti.TaskLifecycle
+	rpcClient          config.RPCer
 	lbConfPath         string
 	logger             hclog.Logger
 }
@@ -62,6 +100,7 @@ func newIngressPluginSupervisorHook(c *ingressPluginSupervisorHookConfig) *ingre
 		runner:           c.runner,
 		socketMountPoint: socketMountPoint,
 		lifecycle:        c.lifecycle,
+		rpcClient:        c.rpcClient,
 		logger:           c.logger,
 		task:             task,
 		lbConfPath:       c.lbConfPath,
@@ -87,6 +126,13 @@ func (i *ingressPluginSupervisorHook) Prestart(
 	req *interfaces.TaskPrestartRequest,
 	resp *interfaces.TaskPrestartResponse) error {
 
+	if i.rendersLoadBalancerConfig() {
+		return i.prestartLoadBalancer(req)
+	}
+
+	// Keep the routes and targets persisted by the routes loop.
+	resp.State = req.PreviousState
+
 	if err := os.MkdirAll(i.socketMountPoint, 0700); err != nil && !os.IsExist(err) {
 		return fmt.Errorf("failed to create socket mount point: %v", err)
 	}
@@ -103,7 +149,7 @@ func (i *ingressPluginSupervisorHook) Prestart(
 
 	if _, ok := i.task.Env["INGRESS_ENDPOINT"]; !ok {
 		resp.Env = map[string]string{
-			"CSI_ENDPOINT": "unix://" + i.socketPath}
+			"INGRESS_ENDPOINT": "unix://" + i.socketPath}
 	}
 
 	mounts := ensureMountpointInserted(i.runner.hookResources.getMounts(), configMount)
@@ -137,6 +183,13 @@ func (i *ingressPluginSupervisorHook) Poststart(_ context.Context,
 
 	// If we're already running the supervisor routine, then we don't need to try
 	// and restart it here as it only terminates on `Stop` hooks.
+	if i.rendersLoadBalancerConfig() {
+		i.runOnce.Do(func() {
+			go i.ensureLoadBalancerConfigLoop(i.shutdownCtx)
+		})
+		return nil
+	}
+
 	i.runOnce.Do(func() {
 		i.setSocketHook()
 		go i.ensureSupervisorLoop(i.shutdownCtx)
@@ -185,15 +238,27 @@ WAITFORREADY:
 		}
 	}
 
-	// Step 2: Register the plugin with the catalog.
+	// Step 2: Send the plugin its configuration and register it with the
+	// catalog.
+	if err := i.setPluginConfig(client); err != nil {
+		i.kill(ctx, fmt.Errorf("Ingress plugin failed to accept configuration: %v", err))
+		return
+	}
+
 	deregisterPluginFn, err := i.registerPlugin(client, i.socketPath)
 	if err != nil {
-		i.kill(ctx, fmt.Errorf("CSI plugin failed to register: %v", err))
+		i.kill(ctx, fmt.Errorf("Ingress plugin failed to register: %v", err))
 		return
 	}
 	// De-register plugins on task shutdown
 	defer deregisterPluginFn()
 
+	// Plugins don't read the routes from the cluster, so keep the routes
+	// programmed by the plugin in sync with the routes it serves.
+	if i.rpcClient != nil {
+		go i.ensureRoutesLoop(ctx, client)
+	}
+
 	// Step 3: Start the lightweight supervisor loop. At this point,
 	// probe failures don't cause the task to restart
 	t.Reset(0)
@@ -234,6 +299,20 @@ WAITFORREADY:
 	}
 }
 
+// setPluginConfig sends the ingress_plugin block of the task to the plugin
+// as a MessagePack encoded configuration.
+func (i *ingressPluginSupervisorHook) setPluginConfig(client ingress.IngressPlugin) error {
+	var buf []byte
+	if err := base.MsgPackEncode(&buf, i.task.IngressPluginConfig); err != nil {
+		return fmt.Errorf("failed to encode plugin config: %v", err)
+	}
+
+	return client.SetConfig(&base.Config{
+		ApiVersion:   ingress.ApiVersion010,
+		PluginConfig: buf,
+	})
+}
+
 func (i *ingressPluginSupervisorHook) registerPlugin(client ingress.IngressPlugin, socketPath string) (func(), error) {
 	// At this point we know the plugin is ready and we can fingerprint it
 	// to get its vendor name and version
@@ -282,6 +361,503 @@ func (i *ingressPluginSupervisorHook) registerPlugin(client ingress.IngressPlugi
 	}, nil
 }
 
+// rendersLoadBalancerConfig returns true if Nomad renders the load balancer
+// configuration of the task itself, in which case the task doesn't implement
+// the plugin API.
+func (i *ingressPluginSupervisorHook) rendersLoadBalancerConfig() bool {
+	cfg := i.task.IngressPluginConfig
+	return cfg.Class == structs.InternalIngressClass &&
+		cfg.Internal != nil && cfg.Internal.Template != ""
+}
+
+// prestartLoadBalancer renders the initial load balancer configuration into
+// the shared allocation directory and mounts it in the task, so the load
+// balancer starts with the current routes.
+func (i *ingressPluginSupervisorHook) prestartLoadBalancer(req *interfaces.TaskPrestartRequest) error {
+	cfg := i.task.IngressPluginConfig.Internal
+
+	if i.lbTemplate == nil {
+		tmpl, err := ingresslb.Lookup(cfg.Template)
+		if err != nil {
+			return err
+		}
+		i.lbTemplate = tmpl
+	}
+
+	var port structs.AllocatedPortMapping
+	var ok bool
+	if i.alloc.AllocatedResources != nil {
+		port, ok = i.alloc.AllocatedResources.Shared.Ports.Get(cfg.PortLabel)
+	}
+	if !ok {
+		return fmt.Errorf("ingress load balancer port %q is not allocated", cfg.PortLabel)
+	}
+	i.lbPort = port.Value
+	if port.To > 0 {
+		i.lbPort = port.To
+	}
+
+	i.lbConfDir = filepath.Join(req.TaskDir.SharedAllocDir, "ingress", i.task.Name)
+
+	routes, _, err := i.listRoutes(0)
+	if err != nil {
+		return structs.NewRecoverableError(fmt.Errorf("failed to list ingress routes: %v", err), true)
+	}
+	if _, err := i.writeLoadBalancerConfig(routes); err != nil {
+		return err
+	}
+
+	mounts := ensureMountpointInserted(i.runner.hookResources.getMounts(), &drivers.MountConfig{
+		TaskPath: i.lbConfPath,
+		HostPath: i.lbConfDir,
+		Readonly: true,
+	})
+	i.runner.hookResources.setMounts(mounts)
+	return nil
+}
+
+// ensureLoadBalancerConfigLoop renders the load balancer configuration each
+// time the routes served by the task change and signals the task to reload
+// it.
+func (i *ingressPluginSupervisorHook) ensureLoadBalancerConfigLoop(ctx context.Context) {
+	signal := i.task.IngressPluginConfig.Internal.ReloadSignal
+	var sig os.Signal
+	if signal != "" {
+		var err error
+		if sig, err = signals.Parse(signal); err != nil {
+			i.logger.Error("invalid ingress load balancer reload signal", "signal", signal, "error", err)
+			signal = ""
+		}
+	}
+
+	i.watchRoutes(ctx, func(routes []*structs.IngressRoute) error {
+		changed, err := i.writeLoadBalancerConfig(routes)
+		if err != nil || !changed || signal == "" {
+			return err
+		}
+
+		event := structs.NewTaskEvent(structs.TaskSignaling).
+			SetTaskSignal(sig).
+			SetDisplayMessage("Ingress load balancer configuration re-rendered")
+		if err := i.lifecycle.Signal(event, signal); err != nil {
+			// The task reads the configuration when it starts again, so
+			// there is nothing to retry.
+			i.logger.Error("failed to signal ingress load balancer", "signal", signal, "error", err)
+		}
+		return nil
+	})
+}
+
+// writeLoadBalancerConfig renders the routes with the template of the task
+// and atomically replaces the files whose content changed. It returns true if
+// any file was written.
+func (i *ingressPluginSupervisorHook) writeLoadBalancerConfig(routes []*structs.IngressRoute) (bool, error) {
+	i.lbLock.Lock()
+	defer i.lbLock.Unlock()
+
+	files, err := i.lbTemplate.Render(i.lbPort, routes)
+	if err != nil {
+		return false, err
+	}
+
+	if i.lbRendered == nil {
+		i.lbRendered = make(map[string][]byte, len(files))
+	}
+
+	changed := false
+	for _, name := range i.lbTemplate.Files() {
+		contents := files[name]
+		if prev, ok := i.lbRendered[name]; ok && bytes.Equal(prev, contents) {
+			continue
+		}
+		path := filepath.Join(i.lbConfDir, name)
+		if err := file.WriteAtomicWithPerms(path, contents, 0755, 0644); err != nil {
+			return changed, fmt.Errorf("failed to write ingress load balancer configuration %q: %v", path, err)
+		}
+		i.lbRendered[name] = contents
+		changed = true
+	}
+	return changed, nil
+}
+
+// ensureRoutesLoop watches the ingress routes served by the plugin and
+// upserts or deletes them in the plugin as they change. External class
+// plugins also register or deregister allocation addresses in the target
+// group of the external load balancer as allocations become healthy or stop.
+func (i *ingressPluginSupervisorHook) ensureRoutesLoop(ctx context.Context, client ingress.IngressPlugin) {
+	programmed := newIngressRouteSet()
+
+	var targets *ingressTargetSet
+	if i.task.IngressPluginConfig.Class == structs.ExternalIngressClass {
+		targets = newIngressTargetSet(i.task.IngressPluginConfig)
+	}
+
+	i.restoreRoutes(programmed, targets)
+
+	i.watchRoutes(ctx, func(routes []*structs.IngressRoute) error {
+		defer i.persistRoutes(programmed, targets)
+
+		if err := programmed.sync(ctx, client, routes); err != nil {
+			return err
+		}
+		if targets == nil {
+			return nil
+		}
+		return targets.sync(ctx, client, ingressRouteTargets(routes))
+	})
+}
+
+// restoreRoutes loads the routes and targets programmed before the task or
+// the client restarted from the hook state. The restarted plugin lost its
+// routes, so they are restored as not programmed and are upserted again,
+// while the targets are still registered in the external load balancer.
+func (i *ingressPluginSupervisorHook) restoreRoutes(programmed *ingressRouteSet, targets *ingressTargetSet) {
+	hookState := i.runner.hookState(i.Name())
+	if hookState == nil {
+		return
+	}
+	i.persisted = hookState.Data
+
+	if raw := hookState.Data[ingressHookStateRoutes]; raw != "" {
+		var ids []string
+		if err := json.Unmarshal([]byte(raw), &ids); err != nil {
+			i.logger.Error("failed to restore ingress routes", "error", err)
+		}
+		for _, id := range ids {
+			programmed.programmed[id] = 0
+		}
+	}
+
+	if raw := hookState.Data[ingressHookStateTargets]; raw != "" && targets != nil {
+		var registered []*ingress.Backend
+		if err := json.Unmarshal([]byte(raw), &registered); err != nil {
+			i.logger.Error("failed to restore ingress targets", "error", err)
+		}
+		for _, target := range registered {
+			targets.registered[ingressTargetKey(target)] = target
+		}
+	}
+}
+
+// persistRoutes stores the routes and targets programmed by the plugin in the
+// hook state if they changed since they were last persisted.
+func (i *ingressPluginSupervisorHook) persistRoutes(programmed *ingressRouteSet, targets *ingressTargetSet) {
+	data := map[string]string{}
+
+	ids := make([]string, 0, len(programmed.programmed))
+	for id := range programmed.programmed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	buf, err := json.Marshal(ids)
+	if err != nil {
+		i.logger.Error("failed to encode ingress routes", "error", err)
+		return
+	}
+	data[ingressHookStateRoutes] = string(buf)
+
+	if targets != nil {
+		registered := make([]*ingress.Backend, 0, len(targets.registered))
+		for _, target := range targets.registered {
+			registered = append(registered, target)
+		}
+		sort.Slice(registered, func(a, b int) bool {
+			return ingressTargetKey(registered[a]) < ingressTargetKey(registered[b])
+		})
+		buf, err := json.Marshal(registered)
+		if err != nil {
+			i.logger.Error("failed to encode ingress targets", "error", err)
+			return
+		}
+		data[ingressHookStateTargets] = string(buf)
+	}
+
+	if maps.Equal(data, i.persisted) {
+		return
+	}
+	if err := i.runner.setHookData(i.Name(), data); err != nil {
+		i.logger.Error("failed to persist ingress routes", "error", err)
+		return
+	}
+	i.persisted = data
+}
+
+// watchRoutes calls fn with the ingress routes served by the plugin each time
+// they change, until the context is done, and acknowledges the routes once fn
+// programmed them. Failures are retried after a short delay.
+func (i *ingressPluginSupervisorHook) watchRoutes(ctx context.Context, fn func([]*structs.IngressRoute) error) {
+	var index uint64
+	for {
+		routes, next, err := i.listRoutes(index)
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if err == nil {
+			err = fn(routes)
+		}
+		if err == nil {
+			err = i.ackRoutes(routes)
+		}
+		if err == nil {
+			index = next
+			continue
+		}
+
+		i.logger.Error("failed to update ingress plugin from routes", "error", err)
+
+		timer, stop := helper.NewSafeTimer(5 * time.Second)
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-timer.C:
+		}
+		stop()
+	}
+}
+
+// ackRoutes acknowledges the routes programmed by the plugin which were not
+// acknowledged yet, so the deployments waiting on them can progress.
+func (i *ingressPluginSupervisorHook) ackRoutes(routes []*structs.IngressRoute) error {
+	acks := ingressRouteAcks(routes)
+	if len(acks) == 0 {
+		return nil
+	}
+
+	req := &structs.IngressRouteAckRequest{
+		Acks: acks,
+		WriteRequest: structs.WriteRequest{
+			Region:    i.runner.clientConfig.Region,
+			AuthToken: i.runner.clientConfig.Node.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	if err := i.rpcClient.RPC("IngressRoute.Ack", req, &resp); err != nil {
+		return fmt.Errorf("failed to acknowledge ingress routes: %v", err)
+	}
+	return nil
+}
+
+// ingressRouteAcks returns the acknowledgements of the routes whose latest
+// version was not acknowledged yet.
+func ingressRouteAcks(routes []*structs.IngressRoute) []*structs.IngressRouteAck {
+	var acks []*structs.IngressRouteAck
+	for _, route := range routes {
+		if route.ProgrammedIndex >= route.ModifyIndex {
+			continue
+		}
+		acks = append(acks, &structs.IngressRouteAck{
+			Namespace: route.Namespace,
+			RouteID:   route.ID,
+			Index:     route.ModifyIndex,
+		})
+	}
+	return acks
+}
+
+// listRoutes returns the ingress routes served by the plugin, blocking until
+// the routes change past index. Routes are served by the plugin when their
+// class is the plugin ID.
+func (i *ingressPluginSupervisorHook) listRoutes(index uint64) ([]*structs.IngressRoute, uint64, error) {
+	req := &structs.IngressRouteListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:        i.runner.clientConfig.Region,
+			Namespace:     structs.AllNamespacesSentinel,
+			MinQueryIndex: index,
+			MaxQueryTime:  5 * time.Minute,
+			AllowStale:    true,
+			AuthToken:     i.runner.clientConfig.Node.SecretID,
+		},
+	}
+	var resp structs.IngressRouteListResponse
+	if err := i.rpcClient.RPC("IngressRoute.List", req, &resp); err != nil {
+		return nil, 0, err
+	}
+	return ingressPluginRoutes(resp.Routes, i.task.IngressPluginConfig.ID), resp.Index, nil
+}
+
+// ingressPluginRoutes returns the routes served by the plugin with the given
+// ID.
+func ingressPluginRoutes(routes []*structs.IngressRoute, pluginID string) []*structs.IngressRoute {
+	out := make([]*structs.IngressRoute, 0, len(routes))
+	for _, route := range routes {
+		if route.Ingress == nil || route.Ingress.Class != pluginID {
+			continue
+		}
+		out = append(out, route)
+	}
+	return out
+}
+
+// ingressPluginRoute converts a route to the route programmed by a plugin.
+func ingressPluginRoute(route *structs.IngressRoute) *ingress.Route {
+	out := &ingress.Route{
+		ID:        route.ID,
+		Namespace: route.Namespace,
+		JobID:     route.JobID,
+		TaskGroup: route.TaskGroup,
+		Service:   route.Service,
+		Backends:  make([]*ingress.Backend, 0, len(route.Backends)),
+	}
+	if route.Ingress != nil {
+		out.Hosts = route.Ingress.Hosts
+		out.Paths = route.Ingress.Paths
+		out.TLSSecret = route.Ingress.TLSSecret
+		out.Weight = route.Ingress.Weight
+	}
+	for _, b := range route.Backends {
+		out.Backends = append(out.Backends, &ingress.Backend{
+			AllocID: b.AllocID,
+			Address: b.Address,
+			Port:    b.Port,
+		})
+	}
+	return out
+}
+
+// ingressRouteSet is the set of routes programmed by the plugin, with the
+// ModifyIndex of the version of each route the plugin last accepted.
+type ingressRouteSet struct {
+	programmed map[string]uint64
+}
+
+func newIngressRouteSet() *ingressRouteSet {
+	return &ingressRouteSet{programmed: map[string]uint64{}}
+}
+
+// sync upserts the routes which changed since they were last programmed and
+// deletes the routes which are no longer served by the plugin.
+func (s *ingressRouteSet) sync(ctx context.Context, client ingress.IngressPlugin, routes []*structs.IngressRoute) error {
+	desired := make(map[string]struct{}, len(routes))
+	var upsert []*structs.IngressRoute
+	for _, route := range routes {
+		desired[route.ID] = struct{}{}
+		if index, ok := s.programmed[route.ID]; ok && index == route.ModifyIndex {
+			continue
+		}
+		upsert = append(upsert, route)
+	}
+
+	var remove []string
+	for id := range s.programmed {
+		if _, ok := desired[id]; !ok {
+			remove = append(remove, id)
+		}
+	}
+	sort.Strings(remove)
+
+	if len(upsert) > 0 {
+		out := make([]*ingress.Route, 0, len(upsert))
+		for _, route := range upsert {
+			out = append(out, ingressPluginRoute(route))
+		}
+		if err := client.UpsertRoutes(ctx, out); err != nil {
+			return fmt.Errorf("failed to upsert routes: %v", err)
+		}
+		for _, route := range upsert {
+			s.programmed[route.ID] = route.ModifyIndex
+		}
+	}
+
+	if len(remove) > 0 {
+		if err := client.DeleteRoutes(ctx, remove); err != nil {
+			return fmt.Errorf("failed to delete routes: %v", err)
+		}
+		for _, id := range remove {
+			delete(s.programmed, id)
+		}
+	}
+
+	return nil
+}
+
+// ingressRouteTargets returns the backends of the routes, keyed by target.
+func ingressRouteTargets(routes []*structs.IngressRoute) map[string]*ingress.Backend {
+	out := map[string]*ingress.Backend{}
+	for _, route := range routes {
+		for _, b := range route.Backends {
+			target := &ingress.Backend{
+				AllocID: b.AllocID,
+				Address: b.Address,
+				Port:    b.Port,
+			}
+			out[ingressTargetKey(target)] = target
+		}
+	}
+	return out
+}
+
+func ingressTargetKey(b *ingress.Backend) string {
+	return fmt.Sprintf("%s/%s:%d", b.AllocID, b.Address, b.Port)
+}
+
+// ingressTargetSet is the set of targets registered by the plugin in the
+// target group of an external load balancer. It is persisted in the hook
+// state, so targets registered before the task or the client restarted are
+// deregistered once they are no longer desired.
+type ingressTargetSet struct {
+	group        string
+	drainTimeout time.Duration
+	registered   map[string]*ingress.Backend
+}
+
+func newIngressTargetSet(cfg *structs.TaskIngressPluginConfig) *ingressTargetSet {
+	s := &ingressTargetSet{registered: map[string]*ingress.Backend{}}
+	if cfg.External != nil {
+		s.group = cfg.External.TargetGroup
+		s.drainTimeout = cfg.External.DrainTimeout
+	}
+	return s
+}
+
+// sync registers the desired targets which aren't registered yet and
+// deregisters the targets which are no longer desired.
+func (s *ingressTargetSet) sync(ctx context.Context, client ingress.IngressPlugin, desired map[string]*ingress.Backend) error {
+	var add, remove []*ingress.Backend
+	for key, target := range desired {
+		if _, ok := s.registered[key]; !ok {
+			add = append(add, target)
+		}
+	}
+	for key, target := range s.registered {
+		if _, ok := desired[key]; !ok {
+			remove = append(remove, target)
+		}
+	}
+
+	sortTargets := func(targets []*ingress.Backend) {
+		sort.Slice(targets, func(i, j int) bool {
+			return ingressTargetKey(targets[i]) < ingressTargetKey(targets[j])
+		})
+	}
+	sortTargets(add)
+	sortTargets(remove)
+
+	if len(add) > 0 {
+		if err := client.RegisterTargets(ctx, s.group, add); err != nil {
+			return fmt.Errorf("failed to register targets: %v", err)
+		}
+		for _, target := range add {
+			s.registered[ingressTargetKey(target)] = target
+		}
+	}
+
+	if len(remove) > 0 {
+		if err := client.DeregisterTargets(ctx, s.group, remove, s.drainTimeout); err != nil {
+			return fmt.Errorf("failed to deregister targets: %v", err)
+		}
+		for _, target := range remove {
+			delete(s.registered, ingressTargetKey(target))
+		}
+	}
+
+	return nil
+}
+
 func (i *ingressPluginSupervisorHook) supervisorLoopOnce(ctx context.Context, client ingress.IngressPlugin) (bool, error) {
 	probeCtx, probeCancelFn := context.WithTimeout(ctx, 5*time.Second)
 	defer probeCancelFn()
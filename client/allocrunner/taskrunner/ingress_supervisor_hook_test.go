@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	"github.com/hashicorp/nomad/client/lib/ingresslb"
+	cstate "github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/ingress"
+	"github.com/hashicorp/nomad/plugins/ingress/fake"
+	"github.com/shoenig/test/must"
+)
+
+func TestIngressPluginSupervisorHook_RouteTargets(t *testing.T) {
+	ci.Parallel(t)
+
+	routes := []*structs.IngressRoute{
+		{
+			ID:      "default/web/group/web",
+			Ingress: &structs.ServiceIngress{Class: "aws-alb"},
+			Backends: []*structs.IngressBackend{
+				{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080},
+			},
+		},
+		{
+			ID:      "default/api/group/api",
+			Ingress: &structs.ServiceIngress{Class: "aws-alb"},
+			Backends: []*structs.IngressBackend{
+				{AllocID: "alloc2", Address: "10.0.0.2", Port: 9090},
+			},
+		},
+		{
+			ID:      "default/admin/group/admin",
+			Ingress: &structs.ServiceIngress{Class: "haproxy"},
+			Backends: []*structs.IngressBackend{
+				{AllocID: "alloc3", Address: "10.0.0.3", Port: 8080},
+			},
+		},
+	}
+
+	routes = ingressPluginRoutes(routes, "aws-alb")
+	must.Len(t, 2, routes)
+
+	targets := ingressRouteTargets(routes)
+	must.MapLen(t, 2, targets)
+	must.MapContainsKeys(t, targets, []string{
+		"alloc1/10.0.0.1:8080",
+		"alloc2/10.0.0.2:9090",
+	})
+}
+
+func TestIngressPluginSupervisorHook_RouteAcks(t *testing.T) {
+	ci.Parallel(t)
+
+	routes := []*structs.IngressRoute{
+		{ID: "default/web/group/web", Namespace: "default", ProgrammedIndex: 10, ModifyIndex: 10},
+		{ID: "default/api/group/api", Namespace: "default", ProgrammedIndex: 10, ModifyIndex: 20},
+		{ID: "default/admin/group/admin", Namespace: "default", ModifyIndex: 30},
+	}
+
+	// Only the routes changed since they were last acknowledged are
+	// acknowledged again.
+	must.Eq(t, []*structs.IngressRouteAck{
+		{Namespace: "default", RouteID: "default/api/group/api", Index: 20},
+		{Namespace: "default", RouteID: "default/admin/group/admin", Index: 30},
+	}, ingressRouteAcks(routes))
+
+	must.SliceEmpty(t, ingressRouteAcks(routes[:1]))
+}
+
+func TestIngressPluginSupervisorHook_RouteSetSync(t *testing.T) {
+	ci.Parallel(t)
+
+	client := &fake.Client{}
+	programmed := newIngressRouteSet()
+	ctx := context.Background()
+
+	web := &structs.IngressRoute{
+		ID:        "default/web/group/web",
+		Namespace: "default",
+		JobID:     "web",
+		TaskGroup: "group",
+		Service:   "web",
+		Ingress: &structs.ServiceIngress{
+			Hosts:  []string{"example.com"},
+			Paths:  []string{"/"},
+			Weight: 100,
+		},
+		Backends: []*structs.IngressBackend{
+			{AllocID: "alloc1", NodeID: "node1", Address: "10.0.0.1", Port: 8080},
+		},
+		ModifyIndex: 10,
+	}
+	api := &structs.IngressRoute{
+		ID:          "default/api/group/api",
+		Namespace:   "default",
+		Ingress:     &structs.ServiceIngress{},
+		ModifyIndex: 11,
+	}
+
+	// New routes are upserted.
+	must.NoError(t, programmed.sync(ctx, client, []*structs.IngressRoute{web, api}))
+	must.Eq(t, 1, client.UpsertRoutesCallCount)
+	must.Eq(t, []*ingress.Route{
+		{
+			ID:        "default/web/group/web",
+			Namespace: "default",
+			JobID:     "web",
+			TaskGroup: "group",
+			Service:   "web",
+			Hosts:     []string{"example.com"},
+			Paths:     []string{"/"},
+			Weight:    100,
+			Backends: []*ingress.Backend{
+				{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080},
+			},
+		},
+		{
+			ID:        "default/api/group/api",
+			Namespace: "default",
+			Backends:  []*ingress.Backend{},
+		},
+	}, client.PrevUpsertRoutes)
+
+	// Nothing changed, so the plugin isn't called.
+	must.NoError(t, programmed.sync(ctx, client, []*structs.IngressRoute{web, api}))
+	must.Eq(t, 1, client.UpsertRoutesCallCount)
+
+	// Only the routes which changed are upserted again.
+	web = web.Copy()
+	web.ModifyIndex = 12
+	must.NoError(t, programmed.sync(ctx, client, []*structs.IngressRoute{web, api}))
+	must.Eq(t, 2, client.UpsertRoutesCallCount)
+	must.Len(t, 1, client.PrevUpsertRoutes)
+	must.Eq(t, web.ID, client.PrevUpsertRoutes[0].ID)
+
+	// Failed deletions are retried on the next sync.
+	client.NextDeleteRoutesErr = errors.New("throttled")
+	must.ErrorContains(t, programmed.sync(ctx, client, []*structs.IngressRoute{web}), "throttled")
+
+	client.NextDeleteRoutesErr = nil
+	must.NoError(t, programmed.sync(ctx, client, []*structs.IngressRoute{web}))
+	must.Eq(t, 2, client.DeleteRoutesCallCount)
+	must.Eq(t, []string{api.ID}, client.PrevDeleteRouteIDs)
+	must.Eq(t, 2, client.UpsertRoutesCallCount)
+}
+
+func TestIngressPluginSupervisorHook_TargetSetSync(t *testing.T) {
+	ci.Parallel(t)
+
+	client := &fake.Client{}
+	targets := newIngressTargetSet(&structs.TaskIngressPluginConfig{
+		ID:    "aws-alb",
+		Class: structs.ExternalIngressClass,
+		External: &structs.ExternalIngressClassConfig{
+			TargetGroup:  "tg-web",
+			DrainTimeout: 10 * time.Second,
+		},
+	})
+
+	alloc1 := &ingress.Backend{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080}
+	alloc2 := &ingress.Backend{AllocID: "alloc2", Address: "10.0.0.2", Port: 8080}
+	ctx := context.Background()
+
+	// New allocations are registered in the target group.
+	must.NoError(t, targets.sync(ctx, client, map[string]*ingress.Backend{
+		ingressTargetKey(alloc1): alloc1,
+		ingressTargetKey(alloc2): alloc2,
+	}))
+	must.Eq(t, 1, client.RegisterTargetsCallCount)
+	must.Eq(t, "tg-web", client.PrevRegisterTargetGroup)
+	must.Eq(t, []*ingress.Backend{alloc1, alloc2}, client.PrevRegisterTargets)
+	must.Eq(t, 0, client.DeregisterTargetsCallCount)
+
+	// Nothing changed, so the plugin isn't called.
+	must.NoError(t, targets.sync(ctx, client, map[string]*ingress.Backend{
+		ingressTargetKey(alloc1): alloc1,
+		ingressTargetKey(alloc2): alloc2,
+	}))
+	must.Eq(t, 1, client.RegisterTargetsCallCount)
+
+	// Stopped allocations are drained.
+	must.NoError(t, targets.sync(ctx, client, map[string]*ingress.Backend{
+		ingressTargetKey(alloc2): alloc2,
+	}))
+	must.Eq(t, 1, client.DeregisterTargetsCallCount)
+	must.Eq(t, []*ingress.Backend{alloc1}, client.PrevDeregisterTargets)
+	must.Eq(t, 10*time.Second, client.PrevDrainTimeout)
+
+	// Failed deregistrations are retried on the next sync.
+	client.NextDeregisterTargetsErr = errors.New("throttled")
+	must.ErrorContains(t, targets.sync(ctx, client, map[string]*ingress.Backend{}), "throttled")
+
+	client.NextDeregisterTargetsErr = nil
+	must.NoError(t, targets.sync(ctx, client, map[string]*ingress.Backend{}))
+	must.Eq(t, 3, client.DeregisterTargetsCallCount)
+	must.Eq(t, []*ingress.Backend{alloc2}, client.PrevDeregisterTargets)
+}
+
+func TestIngressPluginSupervisorHook_PersistRoutes(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	db := cstate.NewMemDB(logger)
+	runner := &TaskRunner{
+		allocID:    "alloc",
+		taskName:   "alb",
+		localState: state.NewLocalState(),
+		stateDB:    db,
+	}
+	hook := &ingressPluginSupervisorHook{logger: logger, runner: runner}
+	cfg := &structs.TaskIngressPluginConfig{
+		ID:       "aws-alb",
+		Class:    structs.ExternalIngressClass,
+		External: &structs.ExternalIngressClassConfig{TargetGroup: "tg-web"},
+	}
+
+	client := &fake.Client{}
+	ctx := context.Background()
+	programmed := newIngressRouteSet()
+	targets := newIngressTargetSet(cfg)
+	routes := []*structs.IngressRoute{{
+		ID:      "default/web/group/web",
+		Ingress: &structs.ServiceIngress{},
+		Backends: []*structs.IngressBackend{
+			{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080},
+		},
+		ModifyIndex: 10,
+	}}
+	must.NoError(t, programmed.sync(ctx, client, routes))
+	must.NoError(t, targets.sync(ctx, client, ingressRouteTargets(routes)))
+	hook.persistRoutes(programmed, targets)
+
+	ls, _, err := db.GetTaskRunnerState("alloc", "alb")
+	must.NoError(t, err)
+	must.MapContainsKeys(t, ls.Hooks[hook.Name()].Data, []string{
+		ingressHookStateRoutes, ingressHookStateTargets,
+	})
+
+	// After a restart the routes are upserted again, and the route and
+	// target which are no longer served are removed from the plugin.
+	restored := &ingressPluginSupervisorHook{logger: logger, runner: runner}
+	programmed = newIngressRouteSet()
+	targets = newIngressTargetSet(cfg)
+	restored.restoreRoutes(programmed, targets)
+	must.MapLen(t, 1, targets.registered)
+
+	client = &fake.Client{}
+	must.NoError(t, programmed.sync(ctx, client, nil))
+	must.NoError(t, targets.sync(ctx, client, nil))
+	must.Eq(t, []string{"default/web/group/web"}, client.PrevDeleteRouteIDs)
+	must.Eq(t, []*ingress.Backend{
+		{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080},
+	}, client.PrevDeregisterTargets)
+}
+
+func TestIngressPluginSupervisorHook_WriteLoadBalancerConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	tmpl, err := ingresslb.Lookup(structs.IngressTemplateHAProxy)
+	must.NoError(t, err)
+
+	hook := &ingressPluginSupervisorHook{
+		lbTemplate: tmpl,
+		lbConfDir:  filepath.Join(t.TempDir(), "ingress", "haproxy"),
+		lbPort:     8000,
+	}
+	path := filepath.Join(hook.lbConfDir, "haproxy.cfg")
+
+	routes := []*structs.IngressRoute{{
+		ID:      "default/web/group/web",
+		Ingress: &structs.ServiceIngress{Hosts: []string{"example.com"}, Weight: 100},
+		Backends: []*structs.IngressBackend{
+			{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080, Weight: 100},
+		},
+	}}
+
+	changed, err := hook.writeLoadBalancerConfig(routes)
+	must.NoError(t, err)
+	must.True(t, changed)
+
+	contents, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.StrContains(t, string(contents), "server alloc1_8080 10.0.0.1:8080 weight 100")
+
+	// The same routes don't rewrite the configuration, so the load
+	// balancer isn't reloaded.
+	changed, err = hook.writeLoadBalancerConfig(routes)
+	must.NoError(t, err)
+	must.False(t, changed)
+
+	routes[0].Backends = nil
+	changed, err = hook.writeLoadBalancerConfig(routes)
+	must.NoError(t, err)
+	must.True(t, changed)
+
+	contents, err = os.ReadFile(path)
+	must.NoError(t, err)
+	must.StrNotContains(t, string(contents), "10.0.0.1")
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	tinterfaces "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/taskenv"
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -30,15 +31,19 @@ type scriptCheckHookConfig struct {
 	alloc        *structs.Allocation
 	task         *structs.Task
 	consul       serviceregistration.Handler
+	checkStore   checkstore.Shim
 	logger       log.Logger
 	shutdownWait time.Duration
 }
 
 // scriptCheckHook implements a task runner hook for running script
-// checks in the context of a task
+// checks in the context of a task. The results of checks of Consul services
+// are heartbeated to Consul, while the results of checks of Nomad services
+// are recorded in the client check store.
 type scriptCheckHook struct {
 	consul          serviceregistration.Handler
 	consulNamespace string
+	checkStore      checkstore.Shim
 	alloc           *structs.Allocation
 	task            *structs.Task
 	logger          log.Logger
@@ -65,6 +70,7 @@ func newScriptCheckHook(c scriptCheckHookConfig) *scriptCheckHook {
 	h := &scriptCheckHook{
 		consul:          c.consul,
 		consulNamespace: c.alloc.Job.LookupTaskGroup(c.alloc.TaskGroup).Consul.GetNamespace(),
+		checkStore:      c.checkStore,
 		alloc:           c.alloc,
 		task:            c.task,
 		scripts:         make(map[string]*scriptCheck),
@@ -185,19 +191,25 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 			if check.Type != structs.ServiceCheckScript {
 				continue
 			}
+			ttlUpdater, ok := h.ttlUpdater(service, check)
+			if !ok {
+				continue
+			}
 			serviceID := serviceregistration.MakeAllocServiceID(
 				h.alloc.ID, h.task.Name, service)
 			sc := newScriptCheck(&scriptCheckConfig{
 				consulNamespace: h.consulNamespace,
 				allocID:         h.alloc.ID,
+				group:           h.alloc.TaskGroup,
 				taskName:        h.task.Name,
 				check:           check,
 				serviceID:       serviceID,
-				ttlUpdater:      h.consul,
+				ttlUpdater:      ttlUpdater,
 				driverExec:      h.driverExec,
 				taskEnv:         h.taskEnv,
 				logger:          h.logger,
 				shutdownCh:      h.shutdownCh,
+				isNomad:         service.Provider == structs.ServiceProviderNomad,
 			})
 			if sc != nil {
 				scriptChecks[sc.id] = sc
@@ -224,21 +236,27 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 			if !h.associated(h.task.Name, service.TaskName, check.TaskName) {
 				continue
 			}
+			ttlUpdater, ok := h.ttlUpdater(service, check)
+			if !ok {
+				continue
+			}
 			groupTaskName := "group-" + tg.Name
 			serviceID := serviceregistration.MakeAllocServiceID(
 				h.alloc.ID, groupTaskName, service)
 			sc := newScriptCheck(&scriptCheckConfig{
 				consulNamespace: h.consulNamespace,
 				allocID:         h.alloc.ID,
+				group:           h.alloc.TaskGroup,
 				taskName:        groupTaskName,
 				check:           check,
 				serviceID:       serviceID,
-				ttlUpdater:      h.consul,
+				ttlUpdater:      ttlUpdater,
 				driverExec:      h.driverExec,
 				taskEnv:         h.taskEnv,
 				logger:          h.logger,
 				shutdownCh:      h.shutdownCh,
 				isGroup:         true,
+				isNomad:         service.Provider == structs.ServiceProviderNomad,
 			})
 			if sc != nil {
 				scriptChecks[sc.id] = sc
@@ -248,6 +266,27 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	return scriptChecks
 }
 
+// ttlUpdater returns the TTLUpdater receiving the results of the script check
+// of the service, and false if the check cannot be run.
+func (h *scriptCheckHook) ttlUpdater(service *structs.Service, check *structs.ServiceCheck) (TTLUpdater, bool) {
+	if service.Provider != structs.ServiceProviderNomad {
+		return h.consul, true
+	}
+	if h.checkStore == nil {
+		return nil, false
+	}
+	return &checkStoreUpdater{
+		store:   h.checkStore,
+		allocID: h.alloc.ID,
+		group:   h.alloc.Name,
+		task:    service.TaskName,
+		service: service.Name,
+		check:   check.Name,
+		mode:    structs.GetCheckMode(check),
+		role:    check.Role,
+	}, true
+}
+
 // associated returns true if the script check is associated with the task. This
 // would be the case if the check.task is the same as task, or if the service.task
 // is the same as the task _and_ check.task is not configured (i.e. the check
@@ -268,6 +307,39 @@ type TTLUpdater interface {
 	UpdateTTL(id, namespace, output, status string) error
 }
 
+// checkStoreUpdater is a TTLUpdater recording the results of a script check
+// of a Nomad service in the client check store. Nomad checks have no warning
+// state, so only a passing script results in a successful check.
+type checkStoreUpdater struct {
+	store   checkstore.Shim
+	allocID string
+	group   string
+	task    string
+	service string
+	check   string
+	mode    structs.CheckMode
+	role    string
+}
+
+func (u *checkStoreUpdater) UpdateTTL(id, _, output, status string) error {
+	result := &structs.CheckQueryResult{
+		ID:        structs.CheckID(id),
+		Mode:      u.mode,
+		Role:      u.role,
+		Status:    structs.CheckFailure,
+		Output:    output,
+		Timestamp: time.Now().UTC().Unix(),
+		Group:     u.group,
+		Task:      u.task,
+		Service:   u.service,
+		Check:     u.check,
+	}
+	if status == api.HealthPassing {
+		result.Status = structs.CheckSuccess
+	}
+	return u.store.Set(u.allocID, result)
+}
+
 // scriptCheck runs script checks via a interfaces.ScriptExecutor and updates the
 // appropriate check's TTL when the script succeeds.
 type scriptCheck struct {
@@ -282,6 +354,7 @@ type scriptCheck struct {
 // scriptCheckConfig is a parameter struct for newScriptCheck
 type scriptCheckConfig struct {
 	allocID         string
+	group           string
 	taskName        string
 	serviceID       string
 	consulNamespace string
@@ -292,6 +365,7 @@ type scriptCheckConfig struct {
 	logger          log.Logger
 	shutdownCh      chan struct{}
 	isGroup         bool
+	isNomad         bool
 }
 
 // newScriptCheck constructs a scriptCheck. we're only going to
@@ -325,7 +399,11 @@ func newScriptCheck(config *scriptCheckConfig) *scriptCheck {
 	sc.check.Command = sc.Command
 	sc.check.Args = sc.Args
 
-	if config.isGroup {
+	if config.isNomad {
+		// checks of nomad services are identified the same way by the
+		// checks hook, which tracks them in the check store.
+		sc.id = string(structs.NomadCheckID(config.allocID, config.group, orig))
+	} else if config.isGroup {
 		// group services don't have access to a task environment
 		// at creation, so their checks get registered before the
 		// check can be interpolated here. if we don't use the
@@ -15,8 +15,10 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	regMock "github.com/hashicorp/nomad/client/serviceregistration/mock"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
+	"github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
@@ -24,6 +26,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/require"
 )
 
@@ -331,3 +334,78 @@ func TestScript_associated(t *testing.T) {
 		require.False(t, new(scriptCheckHook).associated("task1", "task2", "task2"))
 	})
 }
+
+// TestScript_NomadService asserts the results of script checks of Nomad
+// services are recorded in the check store.
+func TestScript_NomadService(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	store := checkstore.NewStore(logger, state.NewMemDB(logger))
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Services = []*structs.Service{{
+		Name:     "web",
+		Provider: structs.ServiceProviderNomad,
+		TaskName: task.Name,
+		Checks: []*structs.ServiceCheck{{
+			Name:     "script",
+			Type:     structs.ServiceCheckScript,
+			Command:  "/bin/check",
+			Interval: time.Hour,
+			Timeout:  time.Second,
+			TaskName: task.Name,
+		}},
+	}}
+	checkID := structs.NomadCheckID(alloc.ID, alloc.TaskGroup, task.Services[0].Checks[0])
+
+	scHook := newScriptCheckHook(scriptCheckHookConfig{
+		alloc:      alloc,
+		task:       task,
+		consul:     regMock.NewServiceRegistrationHandler(logger),
+		checkStore: store,
+		logger:     logger,
+	})
+	scHook.taskEnv = taskenv.NewBuilder(mock.Node(), alloc, task, "global").Build()
+
+	testCases := []struct {
+		name   string
+		code   int
+		status structs.CheckStatus
+	}{
+		{name: "passing", code: 0, status: structs.CheckSuccess},
+		{name: "warning", code: 1, status: structs.CheckFailure},
+		{name: "critical", code: 2, status: structs.CheckFailure},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scHook.driverExec = newSimpleExec(tc.code, nil)
+
+			scripts := scHook.newScriptChecks()
+			must.MapLen(t, 1, scripts)
+			script, ok := scripts[string(checkID)]
+			must.True(t, ok)
+
+			handle := script.run()
+			defer handle.cancel()
+
+			must.Wait(t, wait.InitialSuccess(
+				wait.BoolFunc(func() bool {
+					result := store.List(alloc.ID)[checkID]
+					return result != nil && result.Status == tc.status
+				}),
+				wait.Timeout(3*time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+
+			result := store.List(alloc.ID)[checkID]
+			must.Eq(t, "web", result.Service)
+			must.Eq(t, "script", result.Check)
+			must.Eq(t, task.Name, result.Task)
+
+			// reset the result for the next case
+			must.NoError(t, store.Purge(alloc.ID))
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -185,6 +186,10 @@ type TaskRunner struct {
 	// registering services and checks
 	consulServiceClient serviceregistration.Handler
 
+	// checkStore is used by the script check hook to record the results of
+	// script checks of Nomad services
+	checkStore checkstore.Shim
+
 	// consulProxiesClientFunc gets a client used by the envoy version hook for
 	// asking consul what version of envoy nomad should inject into the connect
 	// sidecar or gateway task.
@@ -270,6 +275,9 @@ type TaskRunner struct {
 
 	// widmgr manages workload identities
 	widmgr widmgr.IdentityManager
+
+	// rpcClient is used by hooks to make RPC calls to the servers
+	rpcClient config.RPCer
 }
 
 type Config struct {
@@ -282,6 +290,10 @@ type Config struct {
 	// ConsulServices is used for managing Consul service registrations
 	ConsulServices serviceregistration.Handler
 
+	// CheckStore is used to store the results of script checks of Nomad
+	// services
+	CheckStore checkstore.Shim
+
 	// ConsulProxiesFunc gets a client to use for looking up supported envoy versions
 	// from Consul.
 	ConsulProxiesFunc consul.SupportedProxiesAPIFunc
@@ -345,6 +357,9 @@ type Config struct {
 
 	// WIDMgr manages workload identities
 	WIDMgr widmgr.IdentityManager
+
+	// RPCClient is used by hooks to make RPC calls to the servers
+	RPCClient config.RPCer
 }
 
 func NewTaskRunner(config *Config) (*TaskRunner, error) {
@@ -379,6 +394,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		envBuilder:              envBuilder,
 		dynamicRegistry:         config.DynamicRegistry,
 		consulServiceClient:     config.ConsulServices,
+		checkStore:              config.CheckStore,
 		consulProxiesClientFunc: config.ConsulProxiesFunc,
 		siClient:                config.ConsulSI,
 		vaultClientFunc:         config.VaultFunc,
@@ -407,6 +423,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		getter:                  config.Getter,
 		wranglers:               config.Wranglers,
 		widmgr:                  config.WIDMgr,
+		rpcClient:               config.RPCClient,
 	}
 
 	// Create the logger based on the allocation ID
@@ -1111,6 +1128,24 @@ func (tr *TaskRunner) persistLocalState() error {
 	return tr.stateDB.PutTaskRunnerLocalState(tr.allocID, tr.taskName, tr.localState)
 }
 
+// setHookData replaces the data of the named hook's state and persists the
+// local state. It is used by hooks whose state changes after Prestart.
+func (tr *TaskRunner) setHookData(name string, data map[string]string) error {
+	tr.stateLock.Lock()
+	hookState := tr.localState.Hooks[name].Copy()
+	if hookState == nil {
+		hookState = &state.HookState{}
+	}
+	hookState.Data = data
+	if tr.localState.Hooks == nil {
+		tr.localState.Hooks = make(map[string]*state.HookState)
+	}
+	tr.localState.Hooks[name] = hookState
+	tr.stateLock.Unlock()
+
+	return tr.persistLocalState()
+}
+
 // buildTaskConfig builds a drivers.TaskConfig with an unique ID for the task.
 // The ID is unique for every invocation, it is built from the alloc ID, task
 // name and 8 random characters.
@@ -89,13 +89,19 @@ func (tr *TaskRunner) initHooks() {
 	}
 
 	if task.IngressPluginConfig != nil {
+		var lbConfPath string
+		if task.IngressPluginConfig.Internal != nil {
+			lbConfPath = task.IngressPluginConfig.Internal.LoadBalancerConfigurationPath
+		}
 		tr.runnerHooks = append(tr.runnerHooks, newIngressPluginSupervisorHook(
 			&ingressPluginSupervisorHookConfig{
-				events:     tr,
-				runner:     tr,
-				lifecycle:  tr,
-				lbConfPath: "task.IngressPluginConfig.Internal.LoadBalancerConfigurationPath",
-				logger:     hookLogger,
+				clientStateDirPath: tr.clientConfig.StateDir,
+				events:             tr,
+				runner:             tr,
+				lifecycle:          tr,
+				rpcClient:          tr.rpcClient,
+				lbConfPath:         lbConfPath,
+				logger:             hookLogger,
 			}),
 		)
 	}
@@ -190,10 +196,11 @@ func (tr *TaskRunner) initHooks() {
 	// initial registration may be updated to include script checks, which must
 	// be handled with this hook.
 	tr.runnerHooks = append(tr.runnerHooks, newScriptCheckHook(scriptCheckHookConfig{
-		alloc:  tr.Alloc(),
-		task:   tr.Task(),
-		consul: tr.consulServiceClient,
-		logger: hookLogger,
+		alloc:      tr.Alloc(),
+		task:       tr.Task(),
+		consul:     tr.consulServiceClient,
+		checkStore: tr.checkStore,
+		logger:     hookLogger,
 	}))
 
 	// If this task driver has remote capabilities, add the remote task
@@ -357,6 +357,10 @@ type ClientTemplateConfig struct {
 	// the task directory.
 	DisableSandbox bool `hcl:"disable_file_sandbox"`
 
+	// NomadServiceHealthyOnly restricts the nomadService and nomadServices
+	// template functions to service registrations whose checks are passing.
+	NomadServiceHealthyOnly bool `hcl:"nomad_service_healthy_only"`
+
 	// This is the maximum interval to allow "stale" data. By default, only the
 	// Consul leader will respond to queries; any requests to a follower will
 	// forward to the leader. In large clusters with many requests, this is not as
@@ -462,6 +466,7 @@ func (c *ClientTemplateConfig) IsEmpty() bool {
 	}
 
 	return !c.DisableSandbox &&
+		!c.NomadServiceHealthyOnly &&
 		c.FunctionDenylist == nil &&
 		c.FunctionBlacklist == nil &&
 		c.BlockQueryWaitTime == nil &&
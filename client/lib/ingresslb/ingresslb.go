@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package ingresslb renders the configuration of load balancers serving the
+// ingress routes of an internal ingress class plugin, so that a load balancer
+// task can be used as ingress without writing a controller.
+package ingresslb
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// templateFiles maps the name of a built-in template to the files it
+// renders. Adding a load balancer only requires a new entry and its
+// templates.
+var templateFiles = map[string][]string{
+	structs.IngressTemplateEnvoy:   {"cds.yaml", "lds.yaml"},
+	structs.IngressTemplateHAProxy: {"haproxy.cfg"},
+	structs.IngressTemplateNginx:   {"nginx.conf"},
+}
+
+// Template renders the configuration files of a load balancer.
+type Template struct {
+	name  string
+	files map[string]*template.Template
+}
+
+// Lookup returns the built-in template with the given name.
+func Lookup(name string) (*Template, error) {
+	names, ok := templateFiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown ingress load balancer template %q", name)
+	}
+
+	t := &Template{
+		name:  name,
+		files: make(map[string]*template.Template, len(names)),
+	}
+	for _, file := range names {
+		src, err := templatesFS.ReadFile("templates/" + name + "/" + file + ".tmpl")
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := template.New(file).Funcs(template.FuncMap{
+			"hasPrefix":  strings.HasPrefix,
+			"trimPrefix": strings.TrimPrefix,
+		}).Parse(string(src))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template %s: %v", name, file, err)
+		}
+		t.files[file] = tmpl
+	}
+	return t, nil
+}
+
+// Files returns the names of the files rendered by the template, sorted.
+func (t *Template) Files() []string {
+	files := make([]string, 0, len(t.files))
+	for file := range t.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Render renders the configuration of a load balancer listening on port
+// and serving routes. It returns the contents of each file keyed by file
+// name.
+func (t *Template) Render(port int, routes []*structs.IngressRoute) (map[string][]byte, error) {
+	config := NewConfig(port, routes)
+
+	out := make(map[string][]byte, len(t.files))
+	for file, tmpl := range t.files {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, config); err != nil {
+			return nil, fmt.Errorf("failed to render %s template %s: %v", t.name, file, err)
+		}
+		out[file] = buf.Bytes()
+	}
+	return out, nil
+}
+
+// Config is the data the templates are rendered with.
+type Config struct {
+	// Port is the port the load balancer listens on.
+	Port int
+
+	// Hosts are the virtual hosts of the load balancer, sorted by name. The
+	// host without a name matches any host and is last.
+	Hosts []*Host
+
+	// Frontends are the frontends of all hosts.
+	Frontends []*Frontend
+}
+
+// Host is a virtual host of the load balancer.
+type Host struct {
+	// Name is the host name, possibly with a leading wildcard label.
+	Name string
+
+	// ID is a name for the host safe to use as identifier.
+	ID string
+
+	// Frontends are the frontends of the host, the longest path first.
+	// They include the frontends matching any host.
+	Frontends []*Frontend
+}
+
+// Frontend matches a host and a path prefix and sends traffic to the
+// backends of all the routes matching them.
+type Frontend struct {
+	// Name is a unique name for the frontend safe to use as identifier.
+	Name string
+	Host string
+	Path string
+
+	// Backends are the backends with a non-zero weight.
+	Backends []*Backend
+}
+
+// Backend is an allocation address a frontend sends traffic to.
+type Backend struct {
+	// Name is the name of the backend, unique within the frontend.
+	Name    string
+	Address string
+	Port    int
+
+	// Addr is the address and port of the backend, joined for the address
+	// family.
+	Addr string
+
+	// Weight is the weight of the route the backend belongs to, scaled by
+	// the share of traffic of the backend within the route.
+	Weight int
+}
+
+var invalidIdentChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+func ident(s string) string {
+	return strings.Trim(invalidIdentChars.ReplaceAllString(s, "_"), "_")
+}
+
+// NewConfig builds the data the templates are rendered with from the ingress
+// routes. Routes matching the same host and path are merged in a single
+// frontend, weighted by the weight of each route.
+func NewConfig(port int, routes []*structs.IngressRoute) *Config {
+	type key struct{ host, path string }
+	frontends := map[key]*Frontend{}
+
+	for _, route := range routes {
+		if route.Ingress == nil {
+			continue
+		}
+		hosts := route.Ingress.Hosts
+		if len(hosts) == 0 {
+			hosts = []string{""}
+		}
+		paths := route.Ingress.Paths
+		if len(paths) == 0 {
+			paths = []string{"/"}
+		}
+
+		for _, host := range hosts {
+			for _, path := range paths {
+				k := key{host, path}
+				f, ok := frontends[k]
+				if !ok {
+					f = &Frontend{Host: host, Path: path}
+					frontends[k] = f
+				}
+				if route.Ingress.Weight == 0 {
+					continue
+				}
+				for _, b := range route.Backends {
+					weight := backendWeight(route.Ingress.Weight, b.Weight)
+					if weight == 0 {
+						continue
+					}
+					f.Backends = append(f.Backends, &Backend{
+						Name:    fmt.Sprintf("%s_%d", shortID(b.AllocID), b.Port),
+						Address: b.Address,
+						Port:    b.Port,
+						Addr:    net.JoinHostPort(b.Address, strconv.Itoa(b.Port)),
+						Weight:  weight,
+					})
+				}
+			}
+		}
+	}
+
+	config := &Config{Port: port}
+	for _, f := range frontends {
+		sort.Slice(f.Backends, func(i, j int) bool { return f.Backends[i].Name < f.Backends[j].Name })
+		config.Frontends = append(config.Frontends, f)
+	}
+
+	// Sort by host with the frontends matching any host last, then the
+	// longest path first, so load balancers matching rules in order pick the
+	// most specific rule.
+	sort.Slice(config.Frontends, func(i, j int) bool {
+		a, b := config.Frontends[i], config.Frontends[j]
+		if a.Host != b.Host {
+			if a.Host == "" || b.Host == "" {
+				return b.Host == ""
+			}
+			return a.Host < b.Host
+		}
+		return pathLess(a, b)
+	})
+
+	names := map[string]int{}
+	for _, f := range config.Frontends {
+		host := f.Host
+		if host == "" {
+			host = "any"
+		}
+		name := ident(host + "_" + f.Path)
+		if n := names[name]; n > 0 {
+			f.Name = fmt.Sprintf("%s_%d", name, n)
+		} else {
+			f.Name = name
+		}
+		names[name]++
+
+		if len(config.Hosts) == 0 || config.Hosts[len(config.Hosts)-1].Name != f.Host {
+			id := ident(f.Host)
+			if id == "" {
+				id = "default"
+			}
+			config.Hosts = append(config.Hosts, &Host{Name: f.Host, ID: fmt.Sprintf("%s_%d", id, len(config.Hosts))})
+		}
+		h := config.Hosts[len(config.Hosts)-1]
+		h.Frontends = append(h.Frontends, f)
+	}
+
+	// Frontends matching any host also apply to the named hosts, unless the
+	// host has its own frontend for the path.
+	if n := len(config.Hosts); n > 0 && config.Hosts[n-1].Name == "" {
+		anyHost := config.Hosts[n-1]
+		for _, h := range config.Hosts[:n-1] {
+			paths := map[string]bool{}
+			for _, f := range h.Frontends {
+				paths[f.Path] = true
+			}
+			for _, f := range anyHost.Frontends {
+				if !paths[f.Path] {
+					h.Frontends = append(h.Frontends, f)
+				}
+			}
+			sort.SliceStable(h.Frontends, func(i, j int) bool {
+				return pathLess(h.Frontends[i], h.Frontends[j])
+			})
+		}
+	}
+
+	return config
+}
+
+// backendWeight scales the weight of a route by the weight of one of its
+// backends, a percentage of the traffic of the route. Backends with a weight
+// of 0 don't receive traffic, others always receive some.
+func backendWeight(routeWeight, weight int) int {
+	if routeWeight == 0 || weight == 0 {
+		return 0
+	}
+	if w := routeWeight * weight / 100; w > 0 {
+		return w
+	}
+	return 1
+}
+
+// pathLess orders frontends with the longest path first.
+func pathLess(a, b *Frontend) bool {
+	if len(a.Path) != len(b.Path) {
+		return len(a.Path) > len(b.Path)
+	}
+	return a.Path < b.Path
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
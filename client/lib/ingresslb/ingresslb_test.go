@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ingresslb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func testRoutes() []*structs.IngressRoute {
+	return []*structs.IngressRoute{
+		{
+			ID: "default/web/group/web",
+			Ingress: &structs.ServiceIngress{
+				Hosts:  []string{"example.com"},
+				Paths:  []string{"/", "/api"},
+				Weight: 90,
+			},
+			Backends: []*structs.IngressBackend{
+				{AllocID: "11111111-aaaa", Address: "10.0.0.1", Port: 8080, Weight: 100},
+			},
+		},
+		{
+			ID: "default/web-canary/group/web",
+			Ingress: &structs.ServiceIngress{
+				Hosts:  []string{"example.com"},
+				Paths:  []string{"/"},
+				Weight: 10,
+			},
+			Backends: []*structs.IngressBackend{
+				{AllocID: "22222222-bbbb", Address: "10.0.0.2", Port: 8080, Weight: 100},
+			},
+		},
+		{
+			ID: "default/admin/group/admin",
+			Ingress: &structs.ServiceIngress{
+				Paths:  []string{"/admin"},
+				Weight: 0,
+			},
+			Backends: []*structs.IngressBackend{
+				{AllocID: "33333333-cccc", Address: "10.0.0.3", Port: 9090, Weight: 100},
+			},
+		},
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	config := NewConfig(8000, testRoutes())
+	must.Eq(t, 8000, config.Port)
+
+	// Frontends are sorted by host with any host last, then the longest
+	// path first.
+	must.Len(t, 3, config.Frontends)
+	must.Eq(t, "example_com_api", config.Frontends[0].Name)
+	must.Eq(t, "example_com", config.Frontends[1].Name)
+	must.Eq(t, "any_admin", config.Frontends[2].Name)
+
+	// Routes matching the same host and path are merged.
+	must.Eq(t, []*Backend{
+		{Name: "11111111_8080", Address: "10.0.0.1", Port: 8080, Addr: "10.0.0.1:8080", Weight: 90},
+		{Name: "22222222_8080", Address: "10.0.0.2", Port: 8080, Addr: "10.0.0.2:8080", Weight: 10},
+	}, config.Frontends[1].Backends)
+
+	// Routes with a weight of 0 don't receive traffic.
+	must.SliceEmpty(t, config.Frontends[2].Backends)
+
+	must.Len(t, 2, config.Hosts)
+	must.Eq(t, "example.com", config.Hosts[0].Name)
+	must.Eq(t, "example_com_0", config.Hosts[0].ID)
+	must.Eq(t, "", config.Hosts[1].Name)
+	must.Eq(t, "default_1", config.Hosts[1].ID)
+
+	// Frontends matching any host apply to the named hosts.
+	must.Eq(t, []*Frontend{config.Frontends[2], config.Frontends[0], config.Frontends[1]},
+		config.Hosts[0].Frontends)
+}
+
+func TestNewConfig_BackendWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	routes := []*structs.IngressRoute{{
+		ID: "default/web/group/web",
+		Ingress: &structs.ServiceIngress{
+			Paths:  []string{"/"},
+			Weight: 50,
+		},
+		Backends: []*structs.IngressBackend{
+			{AllocID: "11111111-aaaa", Address: "10.0.0.1", Port: 8080, Weight: 80},
+			{AllocID: "22222222-bbbb", Address: "10.0.0.2", Port: 8080, Weight: 1, Canary: true},
+			{AllocID: "33333333-cccc", Address: "10.0.0.3", Port: 8080, Weight: 0},
+		},
+	}}
+
+	// Backend weights scale the weight of the route, backends with a weight
+	// of 0 are left out.
+	config := NewConfig(8000, routes)
+	must.Len(t, 1, config.Frontends)
+	must.Len(t, 2, config.Frontends[0].Backends)
+	must.Eq(t, 40, config.Frontends[0].Backends[0].Weight)
+	must.Eq(t, 1, config.Frontends[0].Backends[1].Weight)
+}
+
+func TestTemplate_Render(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		expected map[string][]string
+	}{
+		{
+			name: structs.IngressTemplateHAProxy,
+			expected: map[string][]string{
+				"haproxy.cfg": {
+					"bind *:8000",
+					"use_backend example_com_api if { req.hdr(host),field(1,:) -m str -i example.com } { path_beg /api }",
+					"use_backend any_admin if { path_beg /admin }",
+					"server 22222222_8080 10.0.0.2:8080 weight 10",
+				},
+			},
+		},
+		{
+			name: structs.IngressTemplateNginx,
+			expected: map[string][]string{
+				"nginx.conf": {
+					"listen 8000 default_server;",
+					"server_name example.com;",
+					"server 10.0.0.1:8080 weight=90;",
+					"proxy_pass http://example_com_api;",
+					"return 503;",
+				},
+			},
+		},
+		{
+			name: structs.IngressTemplateEnvoy,
+			expected: map[string][]string{
+				"cds.yaml": {
+					"name: example_com",
+					"load_balancing_weight: 10",
+				},
+				"lds.yaml": {
+					"port_value: 8000",
+					`domains: ["example.com"]`,
+					`domains: ["*"]`,
+					"cluster: any_admin",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := Lookup(tc.name)
+			must.NoError(t, err)
+
+			files, err := tmpl.Render(8000, testRoutes())
+			must.NoError(t, err)
+			must.MapLen(t, len(tc.expected), files)
+
+			for file, lines := range tc.expected {
+				must.MapContainsKey(t, files, file)
+				for _, line := range lines {
+					must.StrContains(t, string(files[file]), line)
+				}
+			}
+
+			// Rendering without any route must still produce a valid
+			// configuration.
+			files, err = tmpl.Render(8000, nil)
+			must.NoError(t, err)
+			for _, contents := range files {
+				must.False(t, strings.Contains(string(contents), "<no value>"))
+			}
+		})
+	}
+
+	_, err := Lookup("traefik")
+	must.ErrorContains(t, err, "unknown ingress load balancer template")
+}
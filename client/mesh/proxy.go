@@ -0,0 +1,397 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package mesh implements the proxy Nomad clients run for the services of
+// the Nomad service mesh. Each service of the mesh has its own proxy, which
+// holds the certificate identifying the service. The proxy accepts mutual TLS
+// connections from the other services of the mesh, authorizes them using the
+// service intentions of the namespace, and forwards them to the service. It
+// also accepts plaintext connections from the service on the local bind port
+// of each upstream, and forwards them to an instance of the upstream service
+// over mutual TLS.
+package mesh
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// handshakeTimeout is the time allowed to complete the TLS handshake of
+	// mesh connections.
+	handshakeTimeout = 10 * time.Second
+
+	// dialTimeout is the time allowed to connect to the service or to an
+	// instance of an upstream service.
+	dialTimeout = 5 * time.Second
+)
+
+// Resolver resolves the addresses of the instances of the services of the
+// mesh.
+type Resolver interface {
+	// Resolve returns the addresses of the instances of the service of the
+	// namespace, in host:port form.
+	Resolve(ctx context.Context, namespace, service string) ([]string, error)
+}
+
+// DialFunc connects to the service of a proxy.
+type DialFunc func(ctx context.Context) (net.Conn, error)
+
+// Proxy is the proxy of a service of the mesh.
+type Proxy struct {
+	logger    hclog.Logger
+	namespace string
+	service   string
+	resolver  Resolver
+
+	lock       sync.RWMutex
+	cert       *tls.Certificate
+	roots      *x509.CertPool
+	intentions []*structs.ServiceIntention
+	listeners  []net.Listener
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewProxy returns the proxy of the service of the namespace. The proxy does
+// not accept connections until a certificate is set and listeners are
+// served.
+func NewProxy(logger hclog.Logger, namespace, service string, resolver Resolver) *Proxy {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Proxy{
+		logger:    logger.Named("mesh_proxy").With("service", service),
+		namespace: namespace,
+		service:   service,
+		resolver:  resolver,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// GenerateKey returns a new private key for the certificate of a service, and
+// the PEM encoded certificate signing request to get signed by the servers.
+func GenerateKey(service string) (crypto.Signer, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: service},
+	}, key)
+	if err != nil {
+		return nil, "", err
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// SetCertificate sets the PEM encoded certificate identifying the service,
+// its private key, and the PEM encoded certificates of the mesh certificate
+// authorities used to verify the peers of the proxy.
+func (p *Proxy) SetCertificate(certPEM string, key crypto.Signer, caPEMs []string) error {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return errors.New("failed to decode certificate")
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	for _, caPEM := range caPEMs {
+		if !roots.AppendCertsFromPEM([]byte(caPEM)) {
+			return errors.New("failed to parse certificate authority")
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.cert = &tls.Certificate{
+		Certificate: [][]byte{block.Bytes},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	p.roots = roots
+	return nil
+}
+
+// SetIntentions sets the service intentions of the namespace of the proxy.
+func (p *Proxy) SetIntentions(intentions []*structs.ServiceIntention) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.intentions = intentions
+}
+
+// ServeInbound accepts the connections of the other services of the mesh on
+// the listener, and forwards the authorized ones to the service connected to
+// by dial. It returns immediately; the listener is closed when the proxy is
+// closed.
+func (p *Proxy) ServeInbound(ln net.Listener, dial DialFunc) {
+	p.serve(ln, func(conn net.Conn) {
+		p.handleInbound(conn, dial)
+	})
+}
+
+// ServeUpstream accepts the connections of the service on the listener, and
+// forwards them to an instance of the destination service. It returns
+// immediately; the listener is closed when the proxy is closed.
+func (p *Proxy) ServeUpstream(ln net.Listener, destination string) {
+	p.serve(ln, func(conn net.Conn) {
+		p.handleUpstream(conn, destination)
+	})
+}
+
+// Close stops accepting connections, and closes the connections in flight.
+func (p *Proxy) Close() {
+	p.cancel()
+
+	p.lock.Lock()
+	for _, ln := range p.listeners {
+		_ = ln.Close()
+	}
+	p.listeners = nil
+	p.lock.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *Proxy) serve(ln net.Listener, handle func(net.Conn)) {
+	p.lock.Lock()
+	if p.ctx.Err() != nil {
+		p.lock.Unlock()
+		_ = ln.Close()
+		return
+	}
+	p.listeners = append(p.listeners, ln)
+	p.lock.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				if p.ctx.Err() == nil {
+					p.logger.Error("failed to accept connection", "address", ln.Addr(), "error", err)
+				}
+				return
+			}
+			p.wg.Add(1)
+			go func() {
+				defer p.wg.Done()
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+}
+
+func (p *Proxy) handleInbound(raw net.Conn, dial DialFunc) {
+	conn := tls.Server(raw, &tls.Config{
+		MinVersion:            tls.VersionTLS12,
+		GetCertificate:        func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return p.certificate() },
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: p.verifyPeer(""),
+	})
+	if err := p.handshake(conn); err != nil {
+		p.logger.Debug("inbound handshake failed", "remote", raw.RemoteAddr(), "error", err)
+		return
+	}
+
+	source, err := p.authorize(conn.ConnectionState())
+	if err != nil {
+		p.logger.Warn("denied inbound connection", "remote", raw.RemoteAddr(), "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, dialTimeout)
+	local, err := dial(ctx)
+	cancel()
+	if err != nil {
+		p.logger.Error("failed to connect to service", "error", err)
+		return
+	}
+	p.logger.Trace("proxying inbound connection", "source", source, "remote", raw.RemoteAddr())
+	p.pipe(conn, local)
+}
+
+func (p *Proxy) handleUpstream(local net.Conn, destination string) {
+	ctx, cancel := context.WithTimeout(p.ctx, dialTimeout)
+	addrs, err := p.resolver.Resolve(ctx, p.namespace, destination)
+	cancel()
+	if err != nil {
+		p.logger.Error("failed to resolve upstream", "upstream", destination, "error", err)
+		return
+	}
+	if len(addrs) == 0 {
+		p.logger.Warn("no instance of upstream", "upstream", destination)
+		return
+	}
+
+	// Spread the connections over the instances of the upstream, moving on
+	// to the next instance when one cannot be reached.
+	mrand.Shuffle(len(addrs), func(i, j int) { addrs[i], addrs[j] = addrs[j], addrs[i] })
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	for _, addr := range addrs {
+		raw, err := dialer.DialContext(p.ctx, "tcp", addr)
+		if err != nil {
+			p.logger.Debug("failed to connect to upstream instance", "upstream", destination, "address", addr, "error", err)
+			continue
+		}
+		conn := tls.Client(raw, &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			// The certificate of the upstream is verified against the mesh
+			// certificate authorities and its identity, instead of its host
+			// name, by VerifyPeerCertificate.
+			InsecureSkipVerify:    true,
+			GetClientCertificate:  func(*tls.CertificateRequestInfo) (*tls.Certificate, error) { return p.certificate() },
+			VerifyPeerCertificate: p.verifyPeer(destination),
+		})
+		if err := p.handshake(conn); err != nil {
+			p.logger.Debug("upstream handshake failed", "upstream", destination, "address", addr, "error", err)
+			_ = raw.Close()
+			continue
+		}
+		p.pipe(local, conn)
+		return
+	}
+	p.logger.Error("failed to connect to upstream", "upstream", destination)
+}
+
+func (p *Proxy) handshake(conn *tls.Conn) error {
+	ctx, cancel := context.WithTimeout(p.ctx, handshakeTimeout)
+	defer cancel()
+	return conn.HandshakeContext(ctx)
+}
+
+func (p *Proxy) certificate() (*tls.Certificate, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.cert == nil {
+		return nil, errors.New("no certificate")
+	}
+	return p.cert, nil
+}
+
+// verifyPeer returns the function verifying that the certificate of a peer
+// was issued by a mesh certificate authority and, if the expected service is
+// set, that it identifies that service of the namespace of the proxy.
+func (p *Proxy) verifyPeer(expected string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("no peer certificate")
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("failed to parse peer certificate: %w", err)
+			}
+			certs[i] = cert
+		}
+
+		p.lock.RLock()
+		roots := p.roots
+		p.lock.RUnlock()
+		if roots == nil {
+			return errors.New("no certificate authority")
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		if _, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			return err
+		}
+
+		if expected == "" {
+			return nil
+		}
+		namespace, service, err := peerIdentity(certs[0])
+		if err != nil {
+			return err
+		}
+		if namespace != p.namespace || service != expected {
+			return fmt.Errorf("peer identity is service %q of namespace %q, expected %q of %q",
+				service, namespace, expected, p.namespace)
+		}
+		return nil
+	}
+}
+
+// authorize returns the name of the service of the verified peer of the
+// connection if the intentions allow it to connect to the service of the
+// proxy.
+func (p *Proxy) authorize(state tls.ConnectionState) (string, error) {
+	if len(state.PeerCertificates) == 0 {
+		return "", errors.New("no peer certificate")
+	}
+	namespace, source, err := peerIdentity(state.PeerCertificates[0])
+	if err != nil {
+		return "", err
+	}
+	if namespace != p.namespace {
+		return "", fmt.Errorf("service %q of namespace %q cannot connect across namespaces", source, namespace)
+	}
+
+	p.lock.RLock()
+	allowed := structs.ServiceIntentionAllows(p.intentions, source, p.service)
+	p.lock.RUnlock()
+	if !allowed {
+		return "", fmt.Errorf("intentions deny service %q", source)
+	}
+	return source, nil
+}
+
+func peerIdentity(cert *x509.Certificate) (string, string, error) {
+	if len(cert.URIs) != 1 {
+		return "", "", errors.New("peer certificate must have exactly one URI")
+	}
+	return structs.ParseMeshServiceURI(cert.URIs[0])
+}
+
+// pipe copies data between the connections until either is closed or the
+// proxy is closed.
+func (p *Proxy) pipe(a, b net.Conn) {
+	defer a.Close()
+	defer b.Close()
+
+	errCh := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(a, b)
+		errCh <- err
+	}()
+	go func() {
+		_, err := io.Copy(b, a)
+		errCh <- err
+	}()
+
+	select {
+	case <-errCh:
+	case <-p.ctx.Done():
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package mesh
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  ed25519.PrivateKey
+	pem  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	must.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test mesh CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	must.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	must.NoError(t, err)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
+
+// issue sets the certificate of the proxy, identifying the service of the
+// namespace and signed by the CA.
+func (ca *testCA) issue(t *testing.T, p *Proxy, namespace, service string) {
+	key, csrPEM, err := GenerateKey(service)
+	must.NoError(t, err)
+	block, _ := pem.Decode([]byte(csrPEM))
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	must.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		URIs:         []*url.URL{structs.MeshServiceURI(namespace, service)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, csr.PublicKey, ca.key)
+	must.NoError(t, err)
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	must.NoError(t, p.SetCertificate(certPEM, key, []string{ca.pem}))
+}
+
+type staticResolver map[string][]string
+
+func (r staticResolver) Resolve(_ context.Context, _, service string) ([]string, error) {
+	return r[service], nil
+}
+
+// echoServer starts a server writing back the lines it receives.
+func echoServer(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				line, err := bufio.NewReader(conn).ReadString('\n')
+				if err != nil {
+					return
+				}
+				_, _ = conn.Write([]byte(line))
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// dialAddr returns the function connecting to the address.
+func dialAddr(addr string) DialFunc {
+	return func(ctx context.Context) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+}
+
+func listen(t *testing.T) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	return ln
+}
+
+// roundTrip sends a line to the address and returns the line received back,
+// or an error if the connection is closed first.
+func roundTrip(t *testing.T, addr string) (string, error) {
+	conn, err := net.Dial("tcp", addr)
+	must.NoError(t, err)
+	defer conn.Close()
+	must.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Write([]byte("ping\n"))
+	must.NoError(t, err)
+	return bufio.NewReader(conn).ReadString('\n')
+}
+
+// setupMesh starts the proxy of the api service forwarding to an echo
+// server, and the proxy of the web service with api as upstream. It returns
+// the proxies and the address of the upstream listener of web.
+func setupMesh(t *testing.T, webNamespace string) (*Proxy, *Proxy, string) {
+	logger := testlog.HCLogger(t)
+	ca := newTestCA(t)
+
+	api := NewProxy(logger, "default", "api", staticResolver{})
+	ca.issue(t, api, "default", "api")
+	apiLn := listen(t)
+	api.ServeInbound(apiLn, dialAddr(echoServer(t)))
+	t.Cleanup(api.Close)
+
+	web := NewProxy(logger, webNamespace, "web", staticResolver{"api": {apiLn.Addr().String()}})
+	ca.issue(t, web, webNamespace, "web")
+	upstreamLn := listen(t)
+	web.ServeUpstream(upstreamLn, "api")
+	t.Cleanup(web.Close)
+
+	return api, web, upstreamLn.Addr().String()
+}
+
+func TestProxy_Intentions(t *testing.T) {
+	ci.Parallel(t)
+
+	api, _, upstream := setupMesh(t, "default")
+
+	// Connections not matched by any intention are denied.
+	_, err := roundTrip(t, upstream)
+	must.Error(t, err)
+
+	api.SetIntentions([]*structs.ServiceIntention{{
+		Namespace:          "default",
+		SourceService:      "web",
+		DestinationService: "api",
+		Action:             structs.ServiceIntentionActionAllow,
+	}})
+	line, err := roundTrip(t, upstream)
+	must.NoError(t, err)
+	must.Eq(t, "ping\n", line)
+
+	// A more specific deny intention takes precedence.
+	api.SetIntentions([]*structs.ServiceIntention{
+		{
+			Namespace:          "default",
+			SourceService:      structs.ServiceIntentionWildcard,
+			DestinationService: structs.ServiceIntentionWildcard,
+			Action:             structs.ServiceIntentionActionAllow,
+		},
+		{
+			Namespace:          "default",
+			SourceService:      "web",
+			DestinationService: "api",
+			Action:             structs.ServiceIntentionActionDeny,
+		},
+	})
+	_, err = roundTrip(t, upstream)
+	must.Error(t, err)
+}
+
+func TestProxy_CrossNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	api, _, upstream := setupMesh(t, "other")
+	api.SetIntentions([]*structs.ServiceIntention{{
+		Namespace:          "default",
+		SourceService:      structs.ServiceIntentionWildcard,
+		DestinationService: structs.ServiceIntentionWildcard,
+		Action:             structs.ServiceIntentionActionAllow,
+	}})
+
+	// The web proxy expects api in its own namespace, and the api proxy only
+	// accepts services of its namespace.
+	_, err := roundTrip(t, upstream)
+	must.Error(t, err)
+}
+
+func TestProxy_UntrustedPeer(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+
+	api := NewProxy(logger, "default", "api", staticResolver{})
+	newTestCA(t).issue(t, api, "default", "api")
+	api.SetIntentions([]*structs.ServiceIntention{{
+		Namespace:          "default",
+		SourceService:      structs.ServiceIntentionWildcard,
+		DestinationService: structs.ServiceIntentionWildcard,
+		Action:             structs.ServiceIntentionActionAllow,
+	}})
+	apiLn := listen(t)
+	api.ServeInbound(apiLn, dialAddr(echoServer(t)))
+	t.Cleanup(api.Close)
+
+	// The web proxy has a certificate from another CA.
+	web := NewProxy(logger, "default", "web", staticResolver{"api": {apiLn.Addr().String()}})
+	newTestCA(t).issue(t, web, "default", "web")
+	upstreamLn := listen(t)
+	web.ServeUpstream(upstreamLn, "api")
+	t.Cleanup(web.Close)
+
+	_, err := roundTrip(t, upstreamLn.Addr().String())
+	must.Error(t, err)
+}
@@ -67,8 +67,8 @@ SEND_BATCH:
 	var ingressChanged bool
 	c.batchNodeUpdates.batchIngressUpdates(func(name string, info *structs.IngressInfo) {
 		if c.updateNodeFromIngressControllerLocked(name, info, newConfig.Node) {
-			if newConfig.Node.CSIControllerPlugins[name].UpdateTime.IsZero() {
-				newConfig.Node.CSIControllerPlugins[name].UpdateTime = time.Now()
+			if newConfig.Node.IngressPlugins[name].UpdateTime.IsZero() {
+				newConfig.Node.IngressPlugins[name].UpdateTime = time.Now()
 			}
 			ingressChanged = true
 		}
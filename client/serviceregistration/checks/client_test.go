@@ -21,6 +21,10 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
 	"golang.org/x/exp/maps"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"oss.indeed.com/go/libtime/libtimetest"
 )
 
@@ -538,3 +542,113 @@ func tcpServer(t *testing.T, ctx context.Context, port int) {
 		}
 	}()
 }
+
+func TestChecker_Do_GRPC(t *testing.T) {
+	ci.Parallel(t)
+
+	// create a mock clock so we can assert time is set
+	now := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	clock := libtimetest.NewClockMock(t).NowMock.Return(now)
+
+	// borrow the self-signed certificate of an httptest server for the
+	// grpc server using tls
+	tlsSrv := httptest.NewUnstartedServer(nil)
+	tlsSrv.StartTLS()
+	t.Cleanup(tlsSrv.Close)
+	cert := tlsSrv.TLS.Certificates[0]
+
+	startServer := func(t *testing.T, useTLS bool) (string, int) {
+		var opts []grpc.ServerOption
+		if useTLS {
+			opts = append(opts, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+		}
+		srv := grpc.NewServer(opts...)
+		hs := health.NewServer()
+		hs.SetServingStatus("ok", grpc_health_v1.HealthCheckResponse_SERVING)
+		hs.SetServingStatus("broken", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		grpc_health_v1.RegisterHealthServer(srv, hs)
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		must.NoError(t, err)
+		go func() { _ = srv.Serve(ln) }()
+		t.Cleanup(srv.Stop)
+
+		return "127.0.0.1", ln.Addr().(*net.TCPAddr).Port
+	}
+
+	makeQueryContext := func(address string, port int) *QueryContext {
+		return &QueryContext{
+			ID:               "abc123",
+			CustomAddress:    address,
+			ServicePortLabel: fmt.Sprintf("%d", port),
+			NetworkStatus:    mock.NewNetworkStatus(address),
+			Group:            "group",
+			Task:             "task",
+			Service:          "service",
+			Check:            "check",
+		}
+	}
+
+	cases := []struct {
+		name      string
+		serverTLS bool
+		q         *Query
+		expStatus structs.CheckStatus
+		expOutput string
+	}{{
+		name:      "serving",
+		q:         &Query{GRPCService: "ok"},
+		expStatus: structs.CheckSuccess,
+		expOutput: "nomad: grpc ok",
+	}, {
+		name:      "all services",
+		q:         &Query{},
+		expStatus: structs.CheckSuccess,
+		expOutput: "nomad: grpc ok",
+	}, {
+		name:      "not serving",
+		q:         &Query{GRPCService: "broken"},
+		expStatus: structs.CheckFailure,
+		expOutput: "nomad: grpc serving status: NOT_SERVING",
+	}, {
+		name:      "unknown service",
+		q:         &Query{GRPCService: "unknown"},
+		expStatus: structs.CheckFailure,
+		expOutput: "nomad: rpc error: code = NotFound desc = unknown service",
+	}, {
+		name:      "tls",
+		serverTLS: true,
+		q:         &Query{GRPCService: "ok", GRPCUseTLS: true, TLSSkipVerify: true},
+		expStatus: structs.CheckSuccess,
+		expOutput: "nomad: grpc ok",
+	}, {
+		name:      "tls unverified",
+		serverTLS: true,
+		q:         &Query{GRPCService: "ok", GRPCUseTLS: true},
+		expStatus: structs.CheckFailure,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, port := startServer(t, tc.serverTLS)
+
+			c := New(testlog.HCLogger(t))
+			c.(*checker).clock = clock
+
+			q := tc.q
+			q.Mode = structs.Healthiness
+			q.Type = "grpc"
+			q.Timeout = time.Second
+			q.AddressMode = "auto"
+			q.PortLabel = fmt.Sprintf("%d", port)
+
+			result := c.Do(context.Background(), makeQueryContext(addr, port), q)
+			must.Eq(t, tc.expStatus, result.Status)
+			must.Eq(t, now.Unix(), result.Timestamp)
+			must.Eq(t, "abc123", result.ID)
+			if tc.expOutput != "" {
+				must.Eq(t, tc.expOutput, result.Output)
+			}
+		})
+	}
+}
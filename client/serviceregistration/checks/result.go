@@ -19,6 +19,7 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 	}
 	return &Query{
 		Mode:        structs.GetCheckMode(c),
+		Role:        c.Role,
 		Type:        c.Type,
 		Timeout:     c.Timeout,
 		AddressMode: c.AddressMode,
@@ -28,6 +29,11 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 		Method:      c.Method,
 		Headers:     maps.Clone(c.Header),
 		Body:        c.Body,
+
+		GRPCService:   c.GRPCService,
+		GRPCUseTLS:    c.GRPCUseTLS,
+		TLSServerName: c.TLSServerName,
+		TLSSkipVerify: c.TLSSkipVerify,
 	}
 }
 
@@ -35,7 +41,8 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 // amount of information needed to actually execute that check.
 type Query struct {
 	Mode structs.CheckMode // readiness or healthiness
-	Type string            // tcp or http
+	Role string            // startup, readiness, liveness, or empty
+	Type string            // tcp, http, or grpc
 
 	Timeout time.Duration // connection / request timeout
 
@@ -47,6 +54,11 @@ type Query struct {
 	Method   string      // http checks only
 	Headers  http.Header // http checks only
 	Body     string      // http checks only
+
+	GRPCService   string // grpc checks only
+	GRPCUseTLS    bool   // grpc checks only
+	TLSServerName string // grpc checks only
+	TLSSkipVerify bool   // grpc checks only
 }
 
 // A QueryContext contains allocation and service parameters necessary for
@@ -68,12 +80,13 @@ type QueryContext struct {
 // Stub creates a temporary QueryResult for the check of ID in the Pending state
 // so we can represent the status of not being checked yet.
 func Stub(
-	id structs.CheckID, kind structs.CheckMode, now int64,
+	id structs.CheckID, kind structs.CheckMode, role string, now int64,
 	group, task, service, check string,
 ) *structs.CheckQueryResult {
 	return &structs.CheckQueryResult{
 		ID:        id,
 		Mode:      kind,
+		Role:      role,
 		Status:    structs.CheckPending,
 		Output:    "nomad: waiting to run",
 		Timestamp: now,
@@ -93,14 +93,16 @@ func TestChecks_GetCheckQuery(t *testing.T) {
 func TestChecks_Stub(t *testing.T) {
 	now := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC).Unix()
 	result := Stub(
-		"abc123",            // check id
-		structs.Healthiness, // kind
-		now,                 // timestamp
+		"abc123",                 // check id
+		structs.Healthiness,      // kind
+		structs.CheckRoleStartup, // role
+		now,                      // timestamp
 		"group", "task", "service", "check",
 	)
 	must.Eq(t, &structs.CheckQueryResult{
 		ID:        "abc123",
 		Mode:      structs.Healthiness,
+		Role:      structs.CheckRoleStartup,
 		Status:    structs.CheckPending,
 		Output:    "nomad: waiting to run",
 		Timestamp: now,
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
@@ -34,8 +35,28 @@ type ServiceRegistrationHandler struct {
 	// shutDownCh coordinates shutting down the handler and any long-running
 	// processes, such as the RPC retry.
 	shutDownCh chan struct{}
+
+	// registrations tracks the registered services which have checks, keyed
+	// by their ID, so the status of their checks can be propagated to their
+	// registration. The lock is held while updating registrations, so that a
+	// removed service is never registered again by an in-flight update.
+	registrations     map[string]*checkedRegistration
+	registrationsLock sync.Mutex
+}
+
+// checkedRegistration is a service registration along with the IDs of the
+// checks of the service.
+type checkedRegistration struct {
+	registration *structs.ServiceRegistration
+	checkIDs     []string
 }
 
+var (
+	// checkStatusInterval is the interval at which the status of checks is
+	// compared with the status held by the service registrations.
+	checkStatusInterval = 1 * time.Second
+)
+
 // ServiceRegistrationHandlerCfg holds critical information used during the
 // normal process of the ServiceRegistrationHandler. It is used to keep the
 // NewServiceRegistrationHandler function signature small and easy to modify.
@@ -62,6 +83,11 @@ type ServiceRegistrationHandlerCfg struct {
 	// CheckWatcher watches checks of services in the Nomad service provider,
 	// and restarts associated tasks in accordance with their check_restart block.
 	CheckWatcher serviceregistration.CheckWatcher
+
+	// CheckStatusGetter returns the status of the checks of services in the
+	// Nomad service provider, which is propagated to their registrations. The
+	// registrations hold no check status if it is nil.
+	CheckStatusGetter serviceregistration.CheckStatusGetter
 }
 
 // NewServiceRegistrationHandler returns a ready to use
@@ -69,13 +95,18 @@ type ServiceRegistrationHandlerCfg struct {
 // interface.
 func NewServiceRegistrationHandler(log hclog.Logger, cfg *ServiceRegistrationHandlerCfg) serviceregistration.Handler {
 	go cfg.CheckWatcher.Run(context.TODO())
-	return &ServiceRegistrationHandler{
+	s := &ServiceRegistrationHandler{
 		cfg:                 cfg,
 		log:                 log.Named("service_registration.nomad"),
 		registrationEnabled: cfg.Enabled,
 		checkWatcher:        cfg.CheckWatcher,
 		shutDownCh:          make(chan struct{}),
+		registrations:       make(map[string]*checkedRegistration),
 	}
+	if cfg.CheckStatusGetter != nil {
+		go s.watchCheckStatuses()
+	}
+	return s
 }
 
 func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistration.WorkloadServices) error {
@@ -108,12 +139,44 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 		return err
 	}
 
+	// Set the current status of the checks of each service, so the
+	// registrations of services with failing checks are not briefly seen as
+	// passing.
+	checked := make([]*checkedRegistration, 0, len(registrations))
+	if s.cfg.CheckStatusGetter != nil {
+		statuses, err := s.cfg.CheckStatusGetter.Get()
+		if err != nil {
+			return fmt.Errorf("failed to get check statuses: %v", err)
+		}
+		for i, service := range workload.Services {
+			checkIDs := make([]string, 0, len(service.Checks))
+			for _, check := range service.Checks {
+				// startup and liveness checks do not affect the
+				// visibility of the service
+				switch check.Role {
+				case structs.CheckRoleStartup, structs.CheckRoleLiveness:
+					continue
+				}
+				checkIDs = append(checkIDs, string(structs.NomadCheckID(
+					workload.AllocInfo.AllocID, workload.AllocInfo.Group, check)))
+			}
+			if len(checkIDs) == 0 {
+				continue
+			}
+			registrations[i].CheckStatus = aggregateCheckStatus(statuses, checkIDs)
+			checked = append(checked, &checkedRegistration{
+				registration: registrations[i],
+				checkIDs:     checkIDs,
+			})
+		}
+	}
+
 	// Service registrations look ok; startup check watchers as specified. The
 	// astute observer may notice the services are not actually registered yet -
 	// this is the same as the Consul flow so hopefully things just work out.
 	for _, service := range workload.Services {
 		for _, check := range service.Checks {
-			if check.TriggersRestarts() {
+			if check.TriggersRestarts() || check.Role == structs.CheckRoleStartup {
 				checkID := string(structs.NomadCheckID(workload.AllocInfo.AllocID, workload.AllocInfo.Group, check))
 				s.checkWatcher.Watch(workload.AllocInfo.AllocID, workload.Name(), checkID, check, workload.Restarter)
 			}
@@ -130,7 +193,22 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 
 	var resp structs.ServiceRegistrationUpsertResponse
 
-	return s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp)
+	s.registrationsLock.Lock()
+	defer s.registrationsLock.Unlock()
+
+	if err := s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp); err != nil {
+		return err
+	}
+
+	// Replace the tracked registrations, as services may have been updated
+	// to remove their checks.
+	for _, registration := range registrations {
+		delete(s.registrations, registration.ID)
+	}
+	for _, c := range checked {
+		s.registrations[c.registration.ID] = c
+	}
+	return nil
 }
 
 // RemoveWorkload iterates the services and removes them from the service
@@ -172,6 +250,12 @@ func (s *ServiceRegistrationHandler) removeWorkload(
 	// Generate the consistent ID for this service, so we know what to remove.
 	id := serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID, workload.Name(), serviceSpec)
 
+	// Stop propagating the status of the service checks before removing the
+	// registration.
+	s.registrationsLock.Lock()
+	delete(s.registrations, id)
+	s.registrationsLock.Unlock()
+
 	deleteArgs := structs.ServiceRegistrationDeleteByIDRequest{
 		ID: id,
 		WriteRequest: structs.WriteRequest{
@@ -304,6 +388,89 @@ func (s *ServiceRegistrationHandler) UpdateTTL(_, _, _, _ string) error {
 // orphaned.
 func (s *ServiceRegistrationHandler) Shutdown() { close(s.shutDownCh) }
 
+// watchCheckStatuses periodically updates the service registrations whose
+// checks changed status until the handler is shut down.
+func (s *ServiceRegistrationHandler) watchCheckStatuses() {
+	ticker := time.NewTicker(checkStatusInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutDownCh:
+			return
+		case <-ticker.C:
+			s.updateCheckStatuses()
+		}
+	}
+}
+
+// updateCheckStatuses updates the service registrations whose aggregate
+// check status no longer matches the status of their checks.
+func (s *ServiceRegistrationHandler) updateCheckStatuses() {
+	statuses, err := s.cfg.CheckStatusGetter.Get()
+	if err != nil {
+		s.log.Error("failed to get check statuses", "error", err)
+		return
+	}
+
+	s.registrationsLock.Lock()
+	defer s.registrationsLock.Unlock()
+
+	var updated []*structs.ServiceRegistration
+	for _, c := range s.registrations {
+		status := aggregateCheckStatus(statuses, c.checkIDs)
+		if status == c.registration.CheckStatus {
+			continue
+		}
+		registration := c.registration.Copy()
+		registration.CheckStatus = status
+		updated = append(updated, registration)
+	}
+	if len(updated) == 0 {
+		return
+	}
+
+	args := structs.ServiceRegistrationUpsertRequest{
+		Services: updated,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.cfg.Region,
+			AuthToken: s.cfg.NodeSecret,
+		},
+	}
+	var resp structs.ServiceRegistrationUpsertResponse
+
+	// The registrations keep their previous status on failure, so the update
+	// is retried on the next interval.
+	if err := s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp); err != nil {
+		s.log.Error("failed to update service registration check status", "error", err)
+		return
+	}
+
+	for _, registration := range updated {
+		s.registrations[registration.ID].registration = registration
+	}
+}
+
+// aggregateCheckStatus returns the status of a service from the status of its
+// checks. Checks without a result yet are pending.
+func aggregateCheckStatus(statuses map[string]string, checkIDs []string) structs.CheckStatus {
+	if len(checkIDs) == 0 {
+		return ""
+	}
+
+	status := structs.CheckSuccess
+	for _, id := range checkIDs {
+		switch structs.CheckStatus(statuses[id]) {
+		case structs.CheckFailure:
+			return structs.CheckFailure
+		case structs.CheckSuccess:
+		default:
+			status = structs.CheckPending
+		}
+	}
+	return status
+}
+
 // generateNomadServiceRegistration is a helper to build the Nomad specific
 // registration object on a per-service basis.
 func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sync"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -591,6 +593,163 @@ func TestServiceRegistrationHandler_dedupUpdatedWorkload(t *testing.T) {
 	}
 }
 
+func TestServiceRegistrationHandler_CheckStatus(t *testing.T) {
+	workload := mockWorkload()
+	checkID := string(structs.NomadCheckID(
+		workload.AllocInfo.AllocID, workload.AllocInfo.Group, workload.Services[1].Checks[0]))
+
+	getter := &mockStatusGetter{statuses: map[string]string{}}
+
+	var l sync.Mutex
+	var upserted []*structs.ServiceRegistration
+	rpcFn := func(method string, args, _ interface{}) error {
+		l.Lock()
+		defer l.Unlock()
+		switch method {
+		case structs.ServiceRegistrationUpsertRPCMethod:
+			upserted = args.(*structs.ServiceRegistrationUpsertRequest).Services
+		}
+		return nil
+	}
+	lastUpserted := func() []*structs.ServiceRegistration {
+		l.Lock()
+		defer l.Unlock()
+		out := upserted
+		upserted = nil
+		return out
+	}
+
+	h := NewServiceRegistrationHandler(hclog.NewNullLogger(), &ServiceRegistrationHandlerCfg{
+		Enabled:           true,
+		CheckWatcher:      new(mockCheckWatcher),
+		CheckStatusGetter: getter,
+		RPCFn:             rpcFn,
+	}).(*ServiceRegistrationHandler)
+
+	// Stop the periodic updates so that they can be triggered by the test.
+	h.Shutdown()
+
+	// The service without checks has no check status, while the check of the
+	// other service has not run yet.
+	must.NoError(t, h.RegisterWorkload(workload))
+	registrations := lastUpserted()
+	must.Len(t, 2, registrations)
+	must.Eq(t, "", registrations[0].CheckStatus)
+	must.Eq(t, structs.CheckPending, registrations[1].CheckStatus)
+
+	// Registrations are only updated when the status of their checks changes.
+	h.updateCheckStatuses()
+	must.Nil(t, lastUpserted())
+
+	getter.set(checkID, string(structs.CheckFailure))
+	h.updateCheckStatuses()
+	registrations = lastUpserted()
+	must.Len(t, 1, registrations)
+	must.Eq(t, workload.Services[1].Name, registrations[0].ServiceName)
+	must.Eq(t, structs.CheckFailure, registrations[0].CheckStatus)
+
+	h.updateCheckStatuses()
+	must.Nil(t, lastUpserted())
+
+	getter.set(checkID, string(structs.CheckSuccess))
+	h.updateCheckStatuses()
+	registrations = lastUpserted()
+	must.Len(t, 1, registrations)
+	must.Eq(t, structs.CheckSuccess, registrations[0].CheckStatus)
+
+	// Removed services are no longer updated.
+	h.RemoveWorkload(workload)
+	getter.set(checkID, string(structs.CheckFailure))
+	h.updateCheckStatuses()
+	must.Nil(t, lastUpserted())
+}
+
+func TestServiceRegistrationHandler_CheckStatus_Roles(t *testing.T) {
+	workload := mockWorkload()
+
+	// The first service only has a liveness check, while the second one has
+	// a failing startup check and a passing readiness check.
+	workload.Services[0].Checks = []*structs.ServiceCheck{{
+		Name:     "liveness",
+		Type:     "tcp",
+		Interval: 5 * time.Second,
+		Timeout:  1 * time.Second,
+		Role:     structs.CheckRoleLiveness,
+	}}
+	readiness := workload.Services[1].Checks[0]
+	readiness.Role = structs.CheckRoleReadiness
+	readiness.CheckRestart = nil
+	startup := readiness.Copy()
+	startup.Name = "startup"
+	startup.Role = structs.CheckRoleStartup
+	workload.Services[1].Checks = append(workload.Services[1].Checks, startup)
+
+	checkID := func(check *structs.ServiceCheck) string {
+		return string(structs.NomadCheckID(workload.AllocInfo.AllocID, workload.AllocInfo.Group, check))
+	}
+	getter := &mockStatusGetter{statuses: map[string]string{
+		checkID(workload.Services[0].Checks[0]): string(structs.CheckFailure),
+		checkID(readiness):                      string(structs.CheckSuccess),
+		checkID(startup):                        string(structs.CheckFailure),
+	}}
+
+	var upserted []*structs.ServiceRegistration
+	rpcFn := func(method string, args, _ interface{}) error {
+		if method == structs.ServiceRegistrationUpsertRPCMethod {
+			upserted = args.(*structs.ServiceRegistrationUpsertRequest).Services
+		}
+		return nil
+	}
+
+	h := NewServiceRegistrationHandler(hclog.NewNullLogger(), &ServiceRegistrationHandlerCfg{
+		Enabled:           true,
+		CheckWatcher:      new(mockCheckWatcher),
+		CheckStatusGetter: getter,
+		RPCFn:             rpcFn,
+	}).(*ServiceRegistrationHandler)
+	h.Shutdown()
+
+	// Only readiness checks and checks without a role determine the check
+	// status of the registrations.
+	must.NoError(t, h.RegisterWorkload(workload))
+	must.Len(t, 2, upserted)
+	must.Eq(t, "", upserted[0].CheckStatus)
+	must.Eq(t, structs.CheckSuccess, upserted[1].CheckStatus)
+	must.MapLen(t, 1, h.registrations)
+}
+
+func Test_aggregateCheckStatus(t *testing.T) {
+	statuses := map[string]string{
+		"passing": string(structs.CheckSuccess),
+		"failing": string(structs.CheckFailure),
+		"pending": string(structs.CheckPending),
+	}
+
+	must.Eq(t, "", aggregateCheckStatus(statuses, nil))
+	must.Eq(t, structs.CheckSuccess, aggregateCheckStatus(statuses, []string{"passing"}))
+	must.Eq(t, structs.CheckPending, aggregateCheckStatus(statuses, []string{"passing", "pending"}))
+	must.Eq(t, structs.CheckPending, aggregateCheckStatus(statuses, []string{"passing", "unknown"}))
+	must.Eq(t, structs.CheckFailure, aggregateCheckStatus(statuses, []string{"pending", "failing", "passing"}))
+}
+
+// mockStatusGetter mocks the status of checks.
+type mockStatusGetter struct {
+	statuses map[string]string
+	l        sync.Mutex
+}
+
+func (g *mockStatusGetter) set(checkID, status string) {
+	g.l.Lock()
+	defer g.l.Unlock()
+	g.statuses[checkID] = status
+}
+
+func (g *mockStatusGetter) Get() (map[string]string, error) {
+	g.l.Lock()
+	defer g.l.Unlock()
+	return maps.Clone(g.statuses), nil
+}
+
 func mockWorkload() *serviceregistration.WorkloadServices {
 	return &serviceregistration.WorkloadServices{
 		AllocInfo: structs.AllocInfo{
@@ -31,6 +31,10 @@ type restarter struct {
 	timeLimit      time.Duration
 	ignoreWarnings bool
 
+	// startup is set if the check is a startup check, which never restarts
+	// its task but suppresses restarts of the task until it first passes.
+	startup bool
+
 	// unhealthyState is the time a check first went unhealthy. Set to the
 	// zero value if the check passes before timeLimit.
 	unhealthyState time.Time
@@ -141,7 +145,8 @@ type CheckWatcher interface {
 
 	// Watch the given check. If the check status enters a failing state, the
 	// task associated with the check will be restarted according to its check_restart
-	// policy via wr.
+	// policy via wr. Restarts of the task are suppressed until its watched startup
+	// checks have passed.
 	Watch(allocID, taskName, checkID string, check *structs.ServiceCheck, wr WorkloadRestarter)
 
 	// Unwatch will cause the CheckWatcher to no longer monitor the check of given checkID.
@@ -180,6 +185,11 @@ func NewCheckWatcher(logger hclog.Logger, getter CheckStatusGetter) *UniversalCh
 
 // Watch a check and restart its task if unhealthy.
 func (w *UniversalCheckWatcher) Watch(allocID, taskName, checkID string, check *structs.ServiceCheck, wr WorkloadRestarter) {
+	if check.Role == structs.CheckRoleStartup {
+		w.watchStartup(allocID, taskName, checkID, check)
+		return
+	}
+
 	if !check.TriggersRestarts() {
 		return // check_restart not set; no-op
 	}
@@ -208,6 +218,28 @@ func (w *UniversalCheckWatcher) Watch(allocID, taskName, checkID string, check *
 	}
 }
 
+// watchStartup watches a startup check, suppressing the restarts of its task
+// until the check passes.
+func (w *UniversalCheckWatcher) watchStartup(allocID, taskName, checkID string, check *structs.ServiceCheck) {
+	c := &restarter{
+		allocID:   allocID,
+		taskName:  taskName,
+		checkID:   checkID,
+		checkName: check.Name,
+		taskKey:   key(allocID + taskName),
+		startup:   true,
+		logger:    w.logger.With("alloc_id", allocID, "task", taskName, "check", check.Name),
+	}
+
+	select {
+	case w.checkUpdateCh <- checkWatchUpdate{
+		checkID: checkID,
+		restart: c,
+	}: // activate watch
+	case <-w.done: // exited; nothing to do
+	}
+}
+
 // Unwatch a check.
 func (w *UniversalCheckWatcher) Unwatch(checkID string) {
 	select {
@@ -289,6 +321,21 @@ func (w *UniversalCheckWatcher) interval(ctx context.Context, now time.Time, wat
 	// keep track of tasks restarted this interval
 	restarts := set.New[key](len(statuses))
 
+	// keep track of tasks with startup checks that have not passed yet; a
+	// startup check is no longer watched once it passes
+	starting := set.New[key](0)
+	for checkID, checkRestarter := range watched {
+		if !checkRestarter.startup {
+			continue
+		}
+		if statuses[checkID] == string(structs.CheckSuccess) {
+			checkRestarter.logger.Debug("startup check passed")
+			delete(watched, checkID)
+			continue
+		}
+		starting.Insert(checkRestarter.taskKey)
+	}
+
 	// iterate over status of all checks, and update the status of checks
 	// we care about watching
 	for checkID, checkRestarter := range watched {
@@ -302,6 +349,16 @@ func (w *UniversalCheckWatcher) interval(ctx context.Context, now time.Time, wat
 			continue
 		}
 
+		if checkRestarter.startup {
+			continue
+		}
+
+		if starting.Contains(checkRestarter.taskKey) {
+			// skip; task is still starting, and failures are not counted
+			checkRestarter.unhealthyState = time.Time{}
+			continue
+		}
+
 		status, exists := statuses[checkID]
 		if !exists {
 			// warn only if outside grace period; avoiding race with check registration
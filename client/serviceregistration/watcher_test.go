@@ -320,6 +320,53 @@ func TestCheckWatcher_Unwatch(t *testing.T) {
 	must.SliceEmpty(t, restarter1.restarts, must.Sprint("expected check 1 to not be restarted"))
 }
 
+// TestCheckWatcher_Startup asserts liveness checks do not restart tasks until
+// the startup checks of the task have passed.
+func TestCheckWatcher_Startup(t *testing.T) {
+	ci.Parallel(t)
+
+	now := before()
+	getter, cw := testWatcherSetup(t)
+
+	// Liveness checks have always been failing
+	getter.add("liveness1", string(structs.CheckFailure), now)
+	getter.add("liveness2", string(structs.CheckFailure), now)
+
+	// Startup check of task 1 never passes, startup check of task 2 passes
+	// after a while
+	getter.add("startup1", string(structs.CheckPending), now)
+	getter.add("startup2", string(structs.CheckPending), now)
+	startedAt := time.Now().Add(200 * time.Millisecond)
+	getter.add("startup2", string(structs.CheckSuccess), startedAt)
+
+	startup := testCheck()
+	startup.Role = structs.CheckRoleStartup
+	startup.CheckRestart = nil
+
+	liveness1 := testCheck()
+	liveness1.Role = structs.CheckRoleLiveness
+	restarter1 := newFakeWorkloadRestarter(cw, "testalloc1", "testtask1", "liveness1", liveness1)
+	cw.Watch("testalloc1", "testtask1", "startup1", startup, restarter1)
+	cw.Watch("testalloc1", "testtask1", "liveness1", liveness1, restarter1)
+
+	liveness2 := testCheck()
+	liveness2.Role = structs.CheckRoleLiveness
+	restarter2 := newFakeWorkloadRestarter(cw, "testalloc2", "testtask2", "liveness2", liveness2)
+	cw.Watch("testalloc2", "testtask2", "startup2", startup, restarter2)
+	cw.Watch("testalloc2", "testtask2", "liveness2", liveness2, restarter2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
+	defer cancel()
+	cw.Run(ctx)
+
+	// Ensure task 1 was never restarted while starting
+	must.SliceEmpty(t, restarter1.restarts, must.Sprint("expected task 1 to not be restarted"))
+
+	// Ensure task 2 was restarted once started
+	must.SliceNotEmpty(t, restarter2.restarts, must.Sprint("expected task 2 to be restarted"))
+	must.True(t, restarter2.restarts[0].timestamp.After(startedAt))
+}
+
 // TestCheckWatcher_MultipleChecks asserts that when there are multiple checks
 // for a single task, all checks should be removed when any of them restart the
 // task to avoid multiple restarts.
@@ -360,6 +360,26 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 
 	conf.OIDCIssuer = agentConfig.Server.OIDCIssuer
 
+	for _, plugin := range agentConfig.Server.PlacementPlugins {
+		policy := plugin.FailurePolicy
+		switch policy {
+		case "":
+			policy = nomad.PlacementPluginFailOpen
+		case nomad.PlacementPluginFailOpen, nomad.PlacementPluginFailClosed:
+		default:
+			return nil, fmt.Errorf("placement_plugin %q failure_policy must be %q or %q",
+				plugin.Name, nomad.PlacementPluginFailOpen, nomad.PlacementPluginFailClosed)
+		}
+		if plugin.Timeout < 0 {
+			return nil, fmt.Errorf("placement_plugin %q timeout cannot be negative", plugin.Name)
+		}
+		conf.PlacementPlugins = append(conf.PlacementPlugins, &nomad.PlacementPluginConfig{
+			Name:          plugin.Name,
+			Timeout:       plugin.Timeout,
+			FailurePolicy: policy,
+		})
+	}
+
 	// Set up the bind addresses
 	rpcAddr, err := net.ResolveTCPAddr("tcp", agentConfig.normalizedAddrs.RPC)
 	if err != nil {
@@ -465,6 +485,13 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.CSIPluginGCThreshold = dur
 	}
+	if gcThreshold := agentConfig.Server.IngressPluginGCThreshold; gcThreshold != "" {
+		dur, err := time.ParseDuration(gcThreshold)
+		if err != nil {
+			return nil, err
+		}
+		conf.IngressPluginGCThreshold = dur
+	}
 	if gcThreshold := agentConfig.Server.ACLTokenGCThreshold; gcThreshold != "" {
 		dur, err := time.ParseDuration(gcThreshold)
 		if err != nil {
@@ -635,6 +662,9 @@ func (a *Agent) finalizeServerConfig(c *nomad.Config) {
 	c.Logger = a.logger
 	c.LogOutput = a.logOutput
 	c.AgentShutdown = func() error { return a.Shutdown() }
+
+	// Setup the plugin loader for placement plugins
+	c.PluginLoader = a.pluginLoader
 }
 
 // clientConfig is used to generate a new client configuration struct for
@@ -779,6 +809,21 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Node.NodeClass = agentConfig.Client.NodeClass
 	conf.Node.NodePool = agentConfig.Client.NodePool
 
+	for _, w := range agentConfig.Client.MaintenanceWindows {
+		window := &structs.MaintenanceWindow{
+			Name:        w.Name,
+			Cron:        w.Cron,
+			TimeZone:    w.TimeZone,
+			Duration:    w.Duration,
+			Lead:        w.Lead,
+			MaxParallel: w.MaxParallel,
+		}
+		if err := window.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid maintenance_window %q: %v", w.Name, err)
+		}
+		conf.Node.MaintenanceWindows = append(conf.Node.MaintenanceWindows, window)
+	}
+
 	// Set up the HTTP advertise address
 	conf.Node.HTTPAddr = agentConfig.AdvertiseAddrs.HTTP
 
@@ -911,6 +956,14 @@ func (a *Agent) setupServer() error {
 		return nil
 	}
 
+	// Placement plugins are dispensed by the plugin loader, which must be
+	// setup before the call to serverConfig.
+	if len(a.config.Server.PlacementPlugins) > 0 {
+		if err := a.setupPlugins(); err != nil {
+			return err
+		}
+	}
+
 	// Setup the configuration
 	conf, err := a.serverConfig()
 	if err != nil {
@@ -1095,9 +1148,12 @@ func (a *Agent) setupClient() error {
 
 	// Plugin setup must happen before the call to clientConfig, because it
 	// copies the pointers to the plugin loaders from the Agent to the
-	// Client config.
-	if err := a.setupPlugins(); err != nil {
-		return err
+	// Client config. Servers with placement plugins have already setup the
+	// plugin loaders.
+	if a.pluginLoader == nil {
+		if err := a.setupPlugins(); err != nil {
+			return err
+		}
 	}
 
 	// Setup the configuration
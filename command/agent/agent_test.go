@@ -16,6 +16,7 @@ import (
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/testutil"
@@ -1715,3 +1716,30 @@ func TestAgent_ServerConfig_JobDefaultPriority_Bad(t *testing.T) {
 		})
 	}
 }
+
+func TestAgent_ServerConfig_PlacementPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DevConfig(nil)
+	must.NoError(t, conf.normalizeAddrs())
+
+	conf.Server.PlacementPlugins = []*PlacementPluginConfig{
+		{Name: "rack-inventory", Timeout: 250 * time.Millisecond, FailurePolicy: "closed"},
+		{Name: "cost"},
+	}
+
+	serverConf, err := convertServerConfig(conf)
+	must.NoError(t, err)
+	must.Eq(t, []*nomad.PlacementPluginConfig{
+		{Name: "rack-inventory", Timeout: 250 * time.Millisecond, FailurePolicy: nomad.PlacementPluginFailClosed},
+		{Name: "cost", FailurePolicy: nomad.PlacementPluginFailOpen},
+	}, serverConf.PlacementPlugins)
+
+	conf.Server.PlacementPlugins = []*PlacementPluginConfig{{Name: "cost", FailurePolicy: "ignore"}}
+	_, err = convertServerConfig(conf)
+	must.ErrorContains(t, err, `placement_plugin "cost" failure_policy must be`)
+
+	conf.Server.PlacementPlugins = []*PlacementPluginConfig{{Name: "cost", Timeout: -time.Second}}
+	_, err = convertServerConfig(conf)
+	must.ErrorContains(t, err, `placement_plugin "cost" timeout cannot be negative`)
+}
@@ -55,6 +55,7 @@ type Command struct {
 	args           []string
 	agent          *Agent
 	httpServers    []*HTTPServer
+	dnsServer      *DNSServer
 	logFilter      *logutils.LevelFilter
 	logOutput      io.Writer
 	retryJoinErrCh chan struct{}
@@ -611,6 +612,17 @@ func (c *Command) setupAgent(config *Config, logger hclog.InterceptLogger, logOu
 	}
 	c.httpServers = httpServers
 
+	// Setup the DNS server
+	if config.DNS != nil && config.DNS.Enabled {
+		dnsServer, err := NewDNSServer(agent, config)
+		if err != nil {
+			agent.Shutdown()
+			c.Ui.Error(fmt.Sprintf("Error starting dns server: %s", err))
+			return err
+		}
+		c.dnsServer = dnsServer
+	}
+
 	for _, vault := range config.Vaults {
 		if vault.Token != "" {
 			logger.Warn("Setting a Vault token in the agent configuration is deprecated and will be removed in Nomad 1.9. Migrate your Vault configuration to use workload identity.", "cluster", vault.Name)
@@ -821,6 +833,9 @@ func (c *Command) Run(args []string) int {
 				srv.Shutdown()
 			}
 		}
+		if c.dnsServer != nil {
+			c.dnsServer.Shutdown()
+		}
 	}()
 
 	// Join startup nodes if specified
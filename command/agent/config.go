@@ -112,6 +112,9 @@ type Config struct {
 	// ACL has our acl related settings
 	ACL *ACLConfig `hcl:"acl"`
 
+	// DNS has the settings of the DNS interface of the agent
+	DNS *DNSConfig `hcl:"dns"`
+
 	// Telemetry is used to configure sending telemetry
 	Telemetry *Telemetry `hcl:"telemetry"`
 
@@ -380,6 +383,10 @@ type ClientConfig struct {
 	// Drain specifies whether to drain the client on shutdown; ignored in dev mode.
 	Drain *config.DrainConfig `hcl:"drain_on_shutdown"`
 
+	// MaintenanceWindows are the maintenance windows of the node, in addition
+	// to the ones of its node pool.
+	MaintenanceWindows []*MaintenanceWindowConfig `hcl:"maintenance_window"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -403,10 +410,49 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.NomadServiceDiscovery = pointer.Copy(c.NomadServiceDiscovery)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
+	nc.MaintenanceWindows = helper.CopySlice(c.MaintenanceWindows)
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
 
+// MaintenanceWindowConfig configures a maintenance window of a client.
+type MaintenanceWindowConfig struct {
+	// Name identifies the window among the windows of the node.
+	Name string `hcl:",key"`
+
+	// Cron is the cron expression of the times the window opens.
+	Cron string `hcl:"cron"`
+
+	// TimeZone is the time zone the cron expression is evaluated in.
+	TimeZone string `hcl:"time_zone"`
+
+	// Duration is how long the window stays open.
+	Duration    time.Duration `hcl:"-"`
+	DurationHCL string        `hcl:"duration" json:"-"`
+
+	// Lead is how long before the window opens the scheduler stops placing
+	// new allocations of service jobs on the node.
+	Lead    time.Duration `hcl:"-"`
+	LeadHCL string        `hcl:"lead" json:"-"`
+
+	// MaxParallel is the maximum number of nodes of the node pool drained
+	// for maintenance windows at once.
+	MaxParallel int `hcl:"max_parallel"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (w *MaintenanceWindowConfig) Copy() *MaintenanceWindowConfig {
+	if w == nil {
+		return nil
+	}
+
+	nw := *w
+	nw.ExtraKeysHCL = slices.Clone(w.ExtraKeysHCL)
+	return &nw
+}
+
 // ACLConfig is configuration specific to the ACL system
 type ACLConfig struct {
 	// Enabled controls if we are enforce and manage ACLs
@@ -463,6 +509,62 @@ func (a *ACLConfig) Copy() *ACLConfig {
 	return &na
 }
 
+// DNSConfig is configuration specific to the DNS interface of the agent,
+// which answers queries for services registered with the Nomad provider.
+type DNSConfig struct {
+	// Enabled controls if the agent answers DNS queries
+	Enabled bool `hcl:"enabled"`
+
+	// Domain is the domain the agent answers queries for. Defaults to
+	// "nomad".
+	Domain string `hcl:"domain"`
+
+	// TTL is the time to live of the records in DNS responses. Defaults to
+	// "0s" so that resolvers do not cache records of unhealthy services.
+	TTL    time.Duration `hcl:"-"`
+	TTLHCL string        `hcl:"ttl" json:"-"`
+
+	// Token is the ACL token used to read service registrations. If empty,
+	// the anonymous policy applies.
+	Token string `hcl:"token"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (d *DNSConfig) Copy() *DNSConfig {
+	if d == nil {
+		return nil
+	}
+
+	nd := *d
+	nd.ExtraKeysHCL = slices.Clone(d.ExtraKeysHCL)
+	return &nd
+}
+
+// Merge is used to merge two DNS configs together. The settings from the
+// input always take precedence.
+func (d *DNSConfig) Merge(b *DNSConfig) *DNSConfig {
+	result := *d
+
+	if b.Enabled {
+		result.Enabled = true
+	}
+	if b.Domain != "" {
+		result.Domain = b.Domain
+	}
+	if b.TTL != 0 {
+		result.TTL = b.TTL
+	}
+	if b.TTLHCL != "" {
+		result.TTLHCL = b.TTLHCL
+	}
+	if b.Token != "" {
+		result.Token = b.Token
+	}
+	return &result
+}
+
 // ServerConfig is configuration specific to the server mode
 type ServerConfig struct {
 	// Enabled controls if we are a server
@@ -544,6 +646,11 @@ type ServerConfig struct {
 	// GCed but the threshold can be used to filter by age.
 	CSIPluginGCThreshold string `hcl:"csi_plugin_gc_threshold"`
 
+	// IngressPluginGCThreshold controls how "old" an ingress plugin must be
+	// to be collected by GC. Age is not the only requirement for a plugin to
+	// be GCed but the threshold can be used to filter by age.
+	IngressPluginGCThreshold string `hcl:"ingress_plugin_gc_threshold"`
+
 	// ACLTokenGCThreshold controls how "old" an expired ACL token must be to
 	// be collected by GC.
 	ACLTokenGCThreshold string `hcl:"acl_token_gc_threshold"`
@@ -707,6 +814,10 @@ type ServerConfig struct {
 	// issuer. Third parties such as AWS IAM OIDC Provider expect the issuer to
 	// be a publically accessible HTTPS URL signed by a trusted well-known CA.
 	OIDCIssuer string `hcl:"oidc_issuer"`
+
+	// PlacementPlugins are the placement plugins the schedulers consult to
+	// filter and score nodes, in the order they are consulted.
+	PlacementPlugins []*PlacementPluginConfig `hcl:"placement_plugin"`
 }
 
 func (s *ServerConfig) Copy() *ServerConfig {
@@ -736,9 +847,54 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.JobDefaultPriority = pointer.Copy(s.JobDefaultPriority)
 	ns.JobMaxPriority = pointer.Copy(s.JobMaxPriority)
 	ns.JobTrackedVersions = pointer.Copy(s.JobTrackedVersions)
+	ns.PlacementPlugins = helper.CopySlice(s.PlacementPlugins)
 	return &ns
 }
 
+// PlacementPluginConfig configures a placement plugin on servers.
+type PlacementPluginConfig struct {
+	// Name is the name of the plugin in the plugin directory.
+	Name string `hcl:",key"`
+
+	// Timeout bounds each call to the plugin.
+	Timeout    time.Duration `hcl:"-"`
+	TimeoutHCL string        `hcl:"timeout" json:"-"`
+
+	// FailurePolicy is either "open" to ignore failing calls to the plugin,
+	// or "closed" to treat nodes as infeasible when feasibility checks fail.
+	FailurePolicy string `hcl:"failure_policy"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (p *PlacementPluginConfig) Copy() *PlacementPluginConfig {
+	if p == nil {
+		return nil
+	}
+
+	np := *p
+	np.ExtraKeysHCL = slices.Clone(p.ExtraKeysHCL)
+	return &np
+}
+
+// mergePlacementPlugins merges two lists of placement plugins, the plugins of
+// b replacing the plugins of a with the same name.
+func mergePlacementPlugins(a, b []*PlacementPluginConfig) []*PlacementPluginConfig {
+	result := helper.CopySlice(a)
+	for _, plugin := range b {
+		idx := slices.IndexFunc(result, func(p *PlacementPluginConfig) bool {
+			return p.Name == plugin.Name
+		})
+		if idx >= 0 {
+			result[idx] = plugin.Copy()
+		} else {
+			result = append(result, plugin.Copy())
+		}
+	}
+	return result
+}
+
 // RaftBoltConfig is used in servers to configure parameters of the boltdb
 // used for raft consensus.
 type RaftBoltConfig struct {
@@ -1078,6 +1234,7 @@ type Ports struct {
 	HTTP int `hcl:"http"`
 	RPC  int `hcl:"rpc"`
 	Serf int `hcl:"serf"`
+	DNS  int `hcl:"dns"`
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -1098,6 +1255,7 @@ type Addresses struct {
 	HTTP string `hcl:"http"`
 	RPC  string `hcl:"rpc"`
 	Serf string `hcl:"serf"`
+	DNS  string `hcl:"dns"`
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -1119,6 +1277,7 @@ type NormalizedAddrs struct {
 	HTTP []string
 	RPC  string
 	Serf string
+	DNS  string
 }
 
 func (n *NormalizedAddrs) Copy() *NormalizedAddrs {
@@ -1303,6 +1462,7 @@ func DefaultConfig() *Config {
 			HTTP: 4646,
 			RPC:  4647,
 			Serf: 4648,
+			DNS:  4649,
 		},
 		Addresses:      &Addresses{},
 		AdvertiseAddrs: &AdvertiseAddrs{},
@@ -1372,6 +1532,9 @@ func DefaultConfig() *Config {
 			PolicyTTL: 30 * time.Second,
 			RoleTTL:   30 * time.Second,
 		},
+		DNS: &DNSConfig{
+			Domain: "nomad",
+		},
 		SyslogFacility: "LOCAL0",
 		Telemetry: &Telemetry{
 			CollectionInterval: "1s",
@@ -1526,6 +1689,14 @@ func (c *Config) Merge(b *Config) *Config {
 		result.ACL = result.ACL.Merge(b.ACL)
 	}
 
+	// Apply the dns config
+	if result.DNS == nil && b.DNS != nil {
+		dns := *b.DNS
+		result.DNS = &dns
+	} else if b.DNS != nil {
+		result.DNS = result.DNS.Merge(b.DNS)
+	}
+
 	// Apply the Audit config
 	if result.Audit == nil && b.Audit != nil {
 		audit := *b.Audit
@@ -1695,6 +1866,7 @@ func (c *Config) Copy() *Config {
 	nc.Client = c.Client.Copy()
 	nc.Server = c.Server.Copy()
 	nc.ACL = c.ACL.Copy()
+	nc.DNS = c.DNS.Copy()
 	nc.Telemetry = c.Telemetry.Copy()
 	nc.DisableUpdateCheck = pointer.Copy(c.DisableUpdateCheck)
 	nc.Consuls = helper.CopySlice(c.Consuls)
@@ -1747,10 +1919,17 @@ func (c *Config) normalizeAddrs() error {
 	}
 	c.Addresses.Serf = addr
 
+	addr, err = normalizeBind(c.Addresses.DNS, c.BindAddr)
+	if err != nil {
+		return fmt.Errorf("Failed to parse DNS address: %v", err)
+	}
+	c.Addresses.DNS = addr
+
 	c.normalizedAddrs = &NormalizedAddrs{
 		HTTP: joinHostPorts(httpAddrs, strconv.Itoa(c.Ports.HTTP)),
 		RPC:  net.JoinHostPort(c.Addresses.RPC, strconv.Itoa(c.Ports.RPC)),
 		Serf: net.JoinHostPort(c.Addresses.Serf, strconv.Itoa(c.Ports.Serf)),
+		DNS:  net.JoinHostPort(c.Addresses.DNS, strconv.Itoa(c.Ports.DNS)),
 	}
 
 	addr, err = normalizeAdvertise(c.AdvertiseAddrs.HTTP, httpAddrs[0], c.Ports.HTTP, c.DevMode)
@@ -2035,6 +2214,9 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.CSIPluginGCThreshold != "" {
 		result.CSIPluginGCThreshold = b.CSIPluginGCThreshold
 	}
+	if b.IngressPluginGCThreshold != "" {
+		result.IngressPluginGCThreshold = b.IngressPluginGCThreshold
+	}
 	if b.ACLTokenGCThreshold != "" {
 		result.ACLTokenGCThreshold = b.ACLTokenGCThreshold
 	}
@@ -2161,6 +2343,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.OIDCIssuer = b.OIDCIssuer
 	}
 
+	if len(b.PlacementPlugins) != 0 {
+		result.PlacementPlugins = mergePlacementPlugins(s.PlacementPlugins, b.PlacementPlugins)
+	}
+
 	// Add the schedulers
 	result.EnabledSchedulers = append(result.EnabledSchedulers, b.EnabledSchedulers...)
 
@@ -2329,6 +2515,11 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.HostNetworks = append(result.HostNetworks, b.HostNetworks...)
 	}
 
+	if len(b.MaintenanceWindows) != 0 {
+		result.MaintenanceWindows = append(helper.CopySlice(a.MaintenanceWindows),
+			helper.CopySlice(b.MaintenanceWindows)...)
+	}
+
 	if b.BindWildcardDefaultHostNetwork {
 		result.BindWildcardDefaultHostNetwork = true
 	}
@@ -2458,6 +2649,9 @@ func (a *Ports) Merge(b *Ports) *Ports {
 	if b.Serf != 0 {
 		result.Serf = b.Serf
 	}
+	if b.DNS != 0 {
+		result.DNS = b.DNS
+	}
 	return &result
 }
 
@@ -2474,6 +2668,9 @@ func (a *Addresses) Merge(b *Addresses) *Addresses {
 	if b.Serf != "" {
 		result.Serf = b.Serf
 	}
+	if b.DNS != "" {
+		result.DNS = b.DNS
+	}
 	return &result
 }
 
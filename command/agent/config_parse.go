@@ -56,6 +56,7 @@ func ParseConfigFile(path string) (*Config, error) {
 			ServerJoin:           &ServerJoin{},
 		},
 		ACL:       &ACLConfig{},
+		DNS:       &DNSConfig{},
 		Audit:     &config.AuditConfig{},
 		Consuls:   []*config.ConsulConfig{},
 		Autopilot: &config.AutopilotConfig{},
@@ -100,6 +101,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"acl.policy_ttl", &c.ACL.RoleTTL, &c.ACL.RoleTTLHCL, nil},
 		{"acl.token_min_expiration_ttl", &c.ACL.TokenMinExpirationTTL, &c.ACL.TokenMinExpirationTTLHCL, nil},
 		{"acl.token_max_expiration_ttl", &c.ACL.TokenMaxExpirationTTL, &c.ACL.TokenMaxExpirationTTLHCL, nil},
+		{"dns.ttl", &c.DNS.TTL, &c.DNS.TTLHCL, nil},
 		{"client.server_join.retry_interval", &c.Client.ServerJoin.RetryInterval, &c.Client.ServerJoin.RetryIntervalHCL, nil},
 		{"server.heartbeat_grace", &c.Server.HeartbeatGrace, &c.Server.HeartbeatGraceHCL, nil},
 		{"server.min_heartbeat_ttl", &c.Server.MinHeartbeatTTL, &c.Server.MinHeartbeatTTLHCL, nil},
@@ -217,6 +219,20 @@ func ParseConfigFile(path string) (*Config, error) {
 			fmt.Sprintf("audit.sink.%d", i), &sink.RotateDuration, &sink.RotateDurationHCL, nil})
 	}
 
+	for _, plugin := range c.Server.PlacementPlugins {
+		tds = append(tds, durationConversionMap{
+			fmt.Sprintf("server.placement_plugin.%s.timeout", plugin.Name), &plugin.Timeout, &plugin.TimeoutHCL, nil})
+	}
+
+	for _, w := range c.Client.MaintenanceWindows {
+		tds = append(tds,
+			durationConversionMap{
+				fmt.Sprintf("client.maintenance_window.%s.duration", w.Name), &w.Duration, &w.DurationHCL, nil},
+			durationConversionMap{
+				fmt.Sprintf("client.maintenance_window.%s.lead", w.Name), &w.Lead, &w.LeadHCL, nil},
+		)
+	}
+
 	// convert strings to time.Durations
 	err = convertDurations(tds)
 	if err != nil {
@@ -303,6 +319,11 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_network")
 	}
 
+	for _, w := range c.Client.MaintenanceWindows {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, w.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "maintenance_window")
+	}
+
 	// Remove AuditConfig extra keys
 	for _, f := range c.Audit.Filters {
 		helper.RemoveEqualFold(&c.Audit.ExtraKeysHCL, f.Name)
@@ -319,10 +340,15 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
 	}
 
-	for _, k := range []string{"preemption_config"} {
+	for _, k := range []string{"preemption_config", "rebalance_config"} {
 		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, k)
 	}
 
+	for _, p := range c.Server.PlacementPlugins {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, p.Name)
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "placement_plugin")
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "telemetry")
@@ -33,11 +33,13 @@ var basicConfig = &Config{
 		HTTP: 1234,
 		RPC:  2345,
 		Serf: 3456,
+		DNS:  4567,
 	},
 	Addresses: &Addresses{
 		HTTP: "127.0.0.1",
 		RPC:  "127.0.0.2",
 		Serf: "127.0.0.3",
+		DNS:  "127.0.0.4",
 	},
 	AdvertiseAddrs: &AdvertiseAddrs{
 		RPC:  "127.0.0.3",
@@ -91,6 +93,18 @@ var basicConfig = &Config{
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
+		MaintenanceWindows: []*MaintenanceWindowConfig{
+			{
+				Name:        "weekly",
+				Cron:        "0 2 * * SUN",
+				TimeZone:    "Europe/Berlin",
+				Duration:    4 * time.Hour,
+				DurationHCL: "4h",
+				Lead:        30 * time.Minute,
+				LeadHCL:     "30m",
+				MaxParallel: 2,
+			},
+		},
 		CNIPath:             "/tmp/cni_path",
 		BridgeNetworkName:   "custom_bridge_name",
 		BridgeNetworkSubnet: "custom_bridge_subnet",
@@ -112,6 +126,7 @@ var basicConfig = &Config{
 		CSIVolumeClaimGCInterval:  "3m",
 		CSIVolumeClaimGCThreshold: "12h",
 		CSIPluginGCThreshold:      "12h",
+		IngressPluginGCThreshold:  "12h",
 		ACLTokenGCThreshold:       "12h",
 		HeartbeatGrace:            30 * time.Second,
 		HeartbeatGraceHCL:         "30s",
@@ -151,10 +166,29 @@ var basicConfig = &Config{
 				BatchSchedulerEnabled:   true,
 				ServiceSchedulerEnabled: true,
 			},
+			RebalanceConfig: structs.RebalanceConfig{
+				Enabled:  true,
+				MaxMoves: 10,
+			},
+			FairShareConfig: structs.FairShareConfig{
+				Enabled:          true,
+				NamespaceWeights: map[string]int{"prod": 3},
+			},
+			ResourceWeights: structs.ResourceWeights{
+				CPU:     1,
+				Memory:  2,
+				Devices: 4,
+			},
 		},
 		LicensePath:        "/tmp/nomad.hclic",
 		JobDefaultPriority: pointer.Of(100),
 		JobMaxPriority:     pointer.Of(200),
+		PlacementPlugins: []*PlacementPluginConfig{{
+			Name:          "rack-inventory",
+			Timeout:       250 * time.Millisecond,
+			TimeoutHCL:    "250ms",
+			FailurePolicy: "closed",
+		}},
 	},
 	ACL: &ACLConfig{
 		Enabled:                  true,
@@ -170,6 +204,13 @@ var basicConfig = &Config{
 		TokenMaxExpirationTTL:    100 * time.Hour,
 		ReplicationToken:         "foobar",
 	},
+	DNS: &DNSConfig{
+		Enabled: true,
+		Domain:  "nomad.internal",
+		TTL:     5 * time.Second,
+		TTLHCL:  "5s",
+		Token:   "dns-token",
+	},
 	Audit: &config.AuditConfig{
 		Enabled: pointer.Of(true),
 		Sinks: []*config.AuditSink{
@@ -568,6 +609,9 @@ func (c *Config) addDefaults() {
 	if c.ACL == nil {
 		c.ACL = &ACLConfig{}
 	}
+	if c.DNS == nil {
+		c.DNS = &DNSConfig{}
+	}
 	if c.Audit == nil {
 		c.Audit = &config.AuditConfig{}
 	}
@@ -685,6 +729,7 @@ var sample0 = &Config{
 	ACL: &ACLConfig{
 		Enabled: true,
 	},
+	DNS: &DNSConfig{},
 	Audit: &config.AuditConfig{
 		Enabled: pointer.Of(true),
 		Sinks: []*config.AuditSink{
@@ -783,6 +828,7 @@ var sample1 = &Config{
 	ACL: &ACLConfig{
 		Enabled: true,
 	},
+	DNS: &DNSConfig{},
 	Audit: &config.AuditConfig{
 		Enabled: pointer.Of(true),
 		Sinks: []*config.AuditSink{
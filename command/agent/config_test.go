@@ -968,6 +968,7 @@ func TestConfig_normalizeAddrs_MultipleInterfaces(t *testing.T) {
 				HTTP: []string{"127.0.0.1:4646", "127.0.0.2:4646"},
 				RPC:  "127.0.0.1:4647",
 				Serf: "127.0.0.1:4648",
+				DNS:  "127.0.0.1:4649",
 			},
 			expectErr: false,
 		},
@@ -980,6 +981,7 @@ func TestConfig_normalizeAddrs_MultipleInterfaces(t *testing.T) {
 					HTTP: 4646,
 					RPC:  4647,
 					Serf: 4648,
+					DNS:  4649,
 				},
 				Addresses: tc.addressConfig,
 				AdvertiseAddrs: &AdvertiseAddrs{
@@ -0,0 +1,520 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/miekg/dns"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// dnsCacheRefreshInterval is the maximum time a blocking query for the
+	// registrations of a service waits before the cache entry is refreshed,
+	// so changes to the health of the allocations are picked up even if
+	// the registrations don't change.
+	dnsCacheRefreshInterval = 30 * time.Second
+
+	// dnsCacheIdleTimeout is the time after which the registrations of a
+	// service that isn't queried are evicted from the cache.
+	dnsCacheIdleTimeout = 10 * time.Minute
+
+	// dnsCacheFetchTimeout is the maximum time a DNS query waits for the
+	// registrations of a service to be fetched the first time.
+	dnsCacheFetchTimeout = 5 * time.Second
+
+	// dnsCacheRetryInterval is the time to wait before retrying to fetch
+	// registrations after an error.
+	dnsCacheRetryInterval = 1 * time.Second
+
+	// dnsCacheMaxEntries is the maximum number of services whose
+	// registrations are cached. The least recently queried service is
+	// evicted to watch a new one once the cache is full.
+	dnsCacheMaxEntries = 1024
+)
+
+var errDNSCacheTimeout = errors.New("timeout fetching service registrations")
+
+// DNSServer answers DNS queries for services registered with the Nomad
+// provider. Services are queried as <service>.<namespace>.service.<domain>,
+// or <service>.service.<domain> for services of the default namespace.
+type DNSServer struct {
+	logger log.Logger
+	domain string
+	ttl    uint32
+	cache  *dnsServiceCache
+
+	udp  *dns.Server
+	tcp  *dns.Server
+	addr string
+}
+
+// NewDNSServer starts a DNS server for the agent on the DNS address of the
+// configuration.
+func NewDNSServer(agent *Agent, config *Config) (*DNSServer, error) {
+	logger := agent.logger.Named("dns")
+	rpc := func(method string, args, reply interface{}) error {
+		return agent.RPC(method, args, reply)
+	}
+	cache := newDNSServiceCache(rpc, config.Region, config.DNS.Token, logger)
+
+	s, err := newDNSServer(config.normalizedAddrs.DNS, config.DNS, cache, logger)
+	if err != nil {
+		cache.shutdown()
+		return nil, err
+	}
+	return s, nil
+}
+
+func newDNSServer(addr string, config *DNSConfig, cache *dnsServiceCache, logger log.Logger) (*DNSServer, error) {
+	domain := config.Domain
+	if domain == "" {
+		domain = "nomad"
+	}
+
+	s := &DNSServer{
+		logger: logger,
+		domain: dns.Fqdn(strings.ToLower(domain)),
+		ttl:    uint32(config.TTL / time.Second),
+		cache:  cache,
+	}
+
+	// Bind the listeners before serving so errors are reported to the
+	// caller.
+	pc, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start DNS UDP listener: %v", err)
+	}
+	ln, err := net.Listen("tcp", pc.LocalAddr().String())
+	if err != nil {
+		pc.Close()
+		return nil, fmt.Errorf("failed to start DNS TCP listener: %v", err)
+	}
+	s.addr = pc.LocalAddr().String()
+
+	s.udp = &dns.Server{PacketConn: pc, Handler: s}
+	s.tcp = &dns.Server{Listener: ln, Handler: s}
+	for _, srv := range []*dns.Server{s.udp, s.tcp} {
+		go func(srv *dns.Server) {
+			if err := srv.ActivateAndServe(); err != nil {
+				logger.Error("DNS server failed", "error", err)
+			}
+		}(srv)
+	}
+
+	logger.Info("DNS server started", "address", s.addr, "domain", s.domain)
+	return s, nil
+}
+
+// Addr returns the address the DNS server listens on.
+func (s *DNSServer) Addr() string {
+	return s.addr
+}
+
+// Shutdown stops the DNS server.
+func (s *DNSServer) Shutdown() {
+	s.logger.Debug("shutting down DNS server")
+	for _, srv := range []*dns.Server{s.udp, s.tcp} {
+		if err := srv.Shutdown(); err != nil {
+			s.logger.Warn("failed to shutdown DNS server", "error", err)
+		}
+	}
+	s.cache.shutdown()
+}
+
+// ServeDNS implements the dns.Handler interface.
+func (s *DNSServer) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Authoritative = true
+	resp.RecursionAvailable = false
+
+	if len(req.Question) != 1 {
+		resp.SetRcode(req, dns.RcodeFormatError)
+		s.write(w, req, resp)
+		return
+	}
+
+	q := req.Question[0]
+	name := strings.ToLower(q.Name)
+	if !dns.IsSubDomain(s.domain, name) {
+		resp.SetRcode(req, dns.RcodeRefused)
+		s.write(w, req, resp)
+		return
+	}
+
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, s.domain))
+	switch {
+	case len(labels) == 2 && labels[1] == "service":
+		s.serviceLookup(resp, q, structs.DefaultNamespace, labels[0])
+	case len(labels) == 3 && labels[2] == "service":
+		s.serviceLookup(resp, q, labels[1], labels[0])
+	case len(labels) == 2 && labels[1] == "addr":
+		s.addrLookup(resp, q, labels[0])
+	default:
+		s.nameError(resp)
+	}
+
+	s.write(w, req, resp)
+}
+
+// serviceLookup answers a query for the registrations of a service.
+func (s *DNSServer) serviceLookup(resp *dns.Msg, q dns.Question, namespace, service string) {
+	services, err := s.cache.get(namespace, service)
+	if err != nil {
+		s.logger.Error("failed to lookup service", "namespace", namespace, "service", service, "error", err)
+		resp.Rcode = dns.RcodeServerFailure
+		return
+	}
+	if len(services) == 0 {
+		s.nameError(resp)
+		return
+	}
+
+	// Shuffle the registrations to spread load across instances.
+	services = append([]*structs.ServiceRegistration(nil), services...)
+	rand.Shuffle(len(services), func(i, j int) {
+		services[i], services[j] = services[j], services[i]
+	})
+
+	for _, reg := range services {
+		ip := net.ParseIP(reg.Address)
+		switch q.Qtype {
+		case dns.TypeA, dns.TypeAAAA, dns.TypeANY:
+			if rr := s.addrRecord(q.Name, ip, q.Qtype); rr != nil {
+				resp.Answer = append(resp.Answer, rr)
+			}
+		}
+		switch q.Qtype {
+		case dns.TypeSRV, dns.TypeANY:
+			target := dns.Fqdn(reg.Address)
+			if ip != nil {
+				target = addrName(ip) + ".addr." + s.domain
+				if rr := s.addrRecord(target, ip, dns.TypeANY); rr != nil {
+					resp.Extra = append(resp.Extra, rr)
+				}
+			}
+			resp.Answer = append(resp.Answer, &dns.SRV{
+				Hdr:      s.header(q.Name, dns.TypeSRV),
+				Priority: 1,
+				Weight:   1,
+				Port:     uint16(reg.Port),
+				Target:   target,
+			})
+		}
+	}
+
+	if len(resp.Answer) == 0 {
+		resp.Ns = append(resp.Ns, s.soa())
+	}
+}
+
+// addrLookup answers a query for a hex encoded address used as SRV target.
+func (s *DNSServer) addrLookup(resp *dns.Msg, q dns.Question, label string) {
+	b, err := hex.DecodeString(label)
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		s.nameError(resp)
+		return
+	}
+
+	if rr := s.addrRecord(q.Name, net.IP(b), q.Qtype); rr != nil {
+		resp.Answer = append(resp.Answer, rr)
+	} else {
+		resp.Ns = append(resp.Ns, s.soa())
+	}
+}
+
+// addrRecord returns the A or AAAA record of the IP if it matches the query
+// type, or nil otherwise.
+func (s *DNSServer) addrRecord(name string, ip net.IP, qtype uint16) dns.RR {
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		if qtype != dns.TypeA && qtype != dns.TypeANY {
+			return nil
+		}
+		return &dns.A{Hdr: s.header(name, dns.TypeA), A: ip4}
+	}
+	if qtype != dns.TypeAAAA && qtype != dns.TypeANY {
+		return nil
+	}
+	return &dns.AAAA{Hdr: s.header(name, dns.TypeAAAA), AAAA: ip}
+}
+
+// nameError sets the response to indicate the name doesn't exist.
+func (s *DNSServer) nameError(resp *dns.Msg) {
+	resp.Rcode = dns.RcodeNameError
+	resp.Ns = append(resp.Ns, s.soa())
+}
+
+func (s *DNSServer) header(name string, rrtype uint16) dns.RR_Header {
+	return dns.RR_Header{
+		Name:   name,
+		Rrtype: rrtype,
+		Class:  dns.ClassINET,
+		Ttl:    s.ttl,
+	}
+}
+
+func (s *DNSServer) soa() *dns.SOA {
+	return &dns.SOA{
+		Hdr:     s.header(s.domain, dns.TypeSOA),
+		Ns:      "ns." + s.domain,
+		Mbox:    "hostmaster." + s.domain,
+		Serial:  uint32(time.Now().Unix()),
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  s.ttl,
+	}
+}
+
+// write truncates the response to the size the client accepts over UDP and
+// writes it.
+func (s *DNSServer) write(w dns.ResponseWriter, req *dns.Msg, resp *dns.Msg) {
+	if _, ok := w.RemoteAddr().(*net.UDPAddr); ok {
+		size := dns.MinMsgSize
+		if opt := req.IsEdns0(); opt != nil {
+			size = int(opt.UDPSize())
+			resp.SetEdns0(opt.UDPSize(), false)
+		}
+		resp.Truncate(size)
+	}
+
+	if err := w.WriteMsg(resp); err != nil {
+		s.logger.Warn("failed to write DNS response", "error", err)
+	}
+}
+
+// addrName returns the hex encoding of the IP used in SRV targets.
+func addrName(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return hex.EncodeToString(ip4)
+	}
+	return hex.EncodeToString(ip.To16())
+}
+
+// dnsServiceCache caches the registrations of the services queried through
+// DNS, keeping them up to date with blocking queries.
+type dnsServiceCache struct {
+	rpc    func(method string, args, reply interface{}) error
+	region string
+	token  string
+	logger log.Logger
+
+	entries    map[string]*dnsCacheEntry
+	shutdownCh chan struct{}
+	l          sync.Mutex
+}
+
+// dnsCacheEntry holds the healthy registrations of a service.
+type dnsCacheEntry struct {
+	services   []*structs.ServiceRegistration
+	err        error
+	lastAccess time.Time
+
+	// ready is closed once the registrations were fetched the first time.
+	ready chan struct{}
+}
+
+func newDNSServiceCache(rpc func(method string, args, reply interface{}) error,
+	region, token string, logger log.Logger) *dnsServiceCache {
+
+	return &dnsServiceCache{
+		rpc:        rpc,
+		region:     region,
+		token:      token,
+		logger:     logger,
+		entries:    make(map[string]*dnsCacheEntry),
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// get returns the healthy registrations of the service, starting to watch
+// them if they're not cached yet.
+func (c *dnsServiceCache) get(namespace, service string) ([]*structs.ServiceRegistration, error) {
+	key := namespace + "/" + service
+
+	c.l.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		if len(c.entries) >= dnsCacheMaxEntries {
+			c.evictLocked()
+		}
+		e = &dnsCacheEntry{ready: make(chan struct{})}
+		c.entries[key] = e
+		go c.watch(key, namespace, service, e)
+	}
+	e.lastAccess = time.Now()
+	c.l.Unlock()
+
+	select {
+	case <-e.ready:
+	case <-time.After(dnsCacheFetchTimeout):
+		return nil, errDNSCacheTimeout
+	case <-c.shutdownCh:
+		return nil, errors.New("DNS server shutting down")
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+	return e.services, e.err
+}
+
+// evictLocked removes the least recently queried entry from the cache. Its
+// watcher stops once it notices the entry was removed. The lock must be held.
+func (c *dnsServiceCache) evictLocked() {
+	var oldestKey string
+	var oldest *dnsCacheEntry
+	for key, e := range c.entries {
+		if oldest == nil || e.lastAccess.Before(oldest.lastAccess) {
+			oldestKey, oldest = key, e
+		}
+	}
+	if oldest != nil {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// watch keeps the registrations of the entry up to date until the entry
+// becomes idle, is evicted, the service has no registrations, or the cache is
+// shutdown.
+func (c *dnsServiceCache) watch(key, namespace, service string, e *dnsCacheEntry) {
+	var index uint64
+	for {
+		c.l.Lock()
+		stop := c.entries[key] != e
+		if !stop && time.Since(e.lastAccess) > dnsCacheIdleTimeout {
+			delete(c.entries, key)
+			stop = true
+		}
+		c.l.Unlock()
+		if stop {
+			return
+		}
+
+		args := &structs.ServiceRegistrationByNameRequest{
+			ServiceName: service,
+			Healthy:     true,
+			QueryOptions: structs.QueryOptions{
+				Region:        c.region,
+				Namespace:     namespace,
+				AuthToken:     c.token,
+				AllowStale:    true,
+				MinQueryIndex: index,
+				MaxQueryTime:  dnsCacheRefreshInterval,
+			},
+		}
+		var resp structs.ServiceRegistrationByNameResponse
+		err := c.rpc("ServiceRegistration.GetService", args, &resp)
+
+		var services []*structs.ServiceRegistration
+		if err == nil {
+			services, err = c.healthy(namespace, resp.Services)
+		}
+
+		c.l.Lock()
+		e.services, e.err = services, err
+		select {
+		case <-e.ready:
+		default:
+			close(e.ready)
+		}
+
+		// Don't watch services without registrations, so queries for
+		// unknown names don't each hold a blocking query.
+		missing := err == nil && len(resp.Services) == 0
+		if missing && c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.l.Unlock()
+		if missing {
+			return
+		}
+
+		if err != nil {
+			c.logger.Warn("failed to fetch service registrations",
+				"namespace", namespace, "service", service, "error", err)
+			index = 0
+			select {
+			case <-time.After(dnsCacheRetryInterval):
+			case <-c.shutdownCh:
+				return
+			}
+			continue
+		}
+		index = resp.Index
+
+		select {
+		case <-c.shutdownCh:
+			return
+		default:
+		}
+	}
+}
+
+// healthy returns the registrations of allocations that are running and
+// have not been reported unhealthy. Registrations with failing or pending
+// checks are already filtered out by the servers. The allocations are looked
+// up with a single query per job rather than one per registration.
+func (c *dnsServiceCache) healthy(namespace string, services []*structs.ServiceRegistration) ([]*structs.ServiceRegistration, error) {
+	healthy := make(map[string]bool)
+	jobs := make(map[string]struct{})
+	for _, reg := range services {
+		if _, ok := jobs[reg.JobID]; ok {
+			continue
+		}
+		jobs[reg.JobID] = struct{}{}
+
+		args := &structs.JobSpecificRequest{
+			JobID: reg.JobID,
+			All:   true,
+			QueryOptions: structs.QueryOptions{
+				Region:     c.region,
+				Namespace:  namespace,
+				AuthToken:  c.token,
+				AllowStale: true,
+			},
+		}
+		var resp structs.JobAllocationsResponse
+		if err := c.rpc("Job.Allocations", args, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, alloc := range resp.Allocations {
+			healthy[alloc.ID] = alloc.ClientStatus == structs.AllocClientStatusRunning &&
+				!alloc.DeploymentStatus.IsUnhealthy()
+		}
+	}
+
+	out := make([]*structs.ServiceRegistration, 0, len(services))
+	for _, reg := range services {
+		if healthy[reg.AllocID] {
+			out = append(out, reg)
+		}
+	}
+	return out, nil
+}
+
+func (c *dnsServiceCache) shutdown() {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	select {
+	case <-c.shutdownCh:
+	default:
+		close(c.shutdownCh)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func TestDNSServer_ServiceLookup(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		testState := s.Agent.server.State()
+
+		// Three allocations of a service, one of which is unhealthy
+		allocs := []*structs.Allocation{mock.Alloc(), mock.Alloc(), mock.Alloc()}
+		for _, alloc := range allocs {
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+		}
+		allocs[2].DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(false)}
+		must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 10, allocs))
+
+		addrs := []string{"10.0.0.1", "fd00::1", "10.0.0.3"}
+		regs := make([]*structs.ServiceRegistration, len(allocs))
+		for i, alloc := range allocs {
+			regs[i] = &structs.ServiceRegistration{
+				ID:          "_nomad-task-" + alloc.ID + "-web",
+				ServiceName: "web",
+				Namespace:   structs.DefaultNamespace,
+				NodeID:      alloc.NodeID,
+				Datacenter:  "dc1",
+				JobID:       alloc.JobID,
+				AllocID:     alloc.ID,
+				Address:     addrs[i],
+				Port:        8080 + i,
+			}
+		}
+		must.NoError(t, testState.UpsertServiceRegistrations(structs.MsgTypeTestSetup, 11, regs))
+
+		logger := testlog.HCLogger(t)
+		cache := newDNSServiceCache(s.Agent.RPC, s.Config.Region, "", logger)
+		srv, err := newDNSServer("127.0.0.1:0", &DNSConfig{Domain: "nomad"}, cache, logger)
+		must.NoError(t, err)
+		t.Cleanup(srv.Shutdown)
+
+		query := func(name string, qtype uint16) *dns.Msg {
+			m := new(dns.Msg)
+			m.SetQuestion(name, qtype)
+			resp, _, err := new(dns.Client).Exchange(m, srv.Addr())
+			must.NoError(t, err)
+			return resp
+		}
+
+		// The A record of the healthy IPv4 instance
+		resp := query("web.service.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 1, resp.Answer)
+		must.Eq(t, "10.0.0.1", resp.Answer[0].(*dns.A).A.String())
+
+		// The namespace can be part of the name
+		resp = query("web.default.service.nomad.", dns.TypeAAAA)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 1, resp.Answer)
+		must.Eq(t, "fd00::1", resp.Answer[0].(*dns.AAAA).AAAA.String())
+
+		// SRV records target the address of each healthy instance
+		resp = query("web.service.nomad.", dns.TypeSRV)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 2, resp.Answer)
+		must.Len(t, 2, resp.Extra)
+		targets := map[string]uint16{}
+		for _, rr := range resp.Answer {
+			srv := rr.(*dns.SRV)
+			targets[srv.Target] = srv.Port
+		}
+		must.Eq(t, map[string]uint16{
+			"0a000001.addr.nomad.":                         8080,
+			"fd000000000000000000000000000001.addr.nomad.": 8081,
+		}, targets)
+
+		// SRV targets resolve to their address
+		resp = query("0a000001.addr.nomad.", dns.TypeA)
+		must.Len(t, 1, resp.Answer)
+		must.True(t, net.ParseIP("10.0.0.1").Equal(resp.Answer[0].(*dns.A).A))
+
+		// Unknown services and namespaces don't exist
+		resp = query("api.service.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+		resp = query("web.platform.service.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+
+		// Only the services with registrations are watched
+		must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+			cache.l.Lock()
+			defer cache.l.Unlock()
+			_, ok := cache.entries["default/web"]
+			return ok && len(cache.entries) == 1
+		}), wait.Timeout(5*time.Second), wait.Gap(50*time.Millisecond)))
+
+		// Names outside of the domain are refused
+		resp = query("example.com.", dns.TypeA)
+		must.Eq(t, dns.RcodeRefused, resp.Rcode)
+	})
+}
+
+func TestDNSServiceCache_Evict(t *testing.T) {
+	ci.Parallel(t)
+
+	cache := newDNSServiceCache(nil, "global", "", testlog.HCLogger(t))
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		key := fmt.Sprintf("default/web-%d", i)
+		cache.entries[key] = &dnsCacheEntry{lastAccess: now.Add(time.Duration(i-3) * time.Minute)}
+	}
+
+	// The least recently queried service is evicted
+	cache.evictLocked()
+	must.MapLen(t, 2, cache.entries)
+	must.MapNotContainsKey(t, cache.entries, "default/web-0")
+}
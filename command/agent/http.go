@@ -390,6 +390,13 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/node/pools", s.wrap(s.NodePoolsRequest))
 	s.mux.HandleFunc("/v1/node/pool/", s.wrap(s.NodePoolSpecificRequest))
 
+	s.mux.HandleFunc("/v1/reservations", s.wrap(s.ReservationsRequest))
+	s.mux.HandleFunc("/v1/reservation/", s.wrap(s.ReservationSpecificRequest))
+
+	s.mux.HandleFunc("/v1/service-mesh/intentions", s.wrap(s.MeshIntentionsRequest))
+	s.mux.HandleFunc("/v1/service-mesh/intention", s.wrap(s.MeshIntentionRequest))
+	s.mux.HandleFunc("/v1/service-mesh/intention/", s.wrap(s.MeshIntentionRequest))
+
 	s.mux.HandleFunc("/v1/allocations", s.wrap(s.AllocsRequest))
 	s.mux.HandleFunc("/v1/allocation/", s.wrap(s.AllocSpecificRequest))
 
@@ -408,6 +415,8 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/plugin/csi/", s.wrap(s.CSIPluginSpecificRequest))
 	s.mux.HandleFunc("/v1/plugins/ingress", s.wrap(s.IngressPluginsRequest))
 	s.mux.HandleFunc("/v1/plugins/ingress/specific", s.wrap(s.IngressPluginSpecificRequest))
+	s.mux.HandleFunc("/v1/ingress/routes", s.wrap(s.IngressRoutesRequest))
+	s.mux.HandleFunc("/v1/ingress/routes/ack", s.wrap(s.IngressRoutesAckRequest))
 	s.mux.HandleFunc("/v1/acl/policies", s.wrap(s.ACLPoliciesRequest))
 	s.mux.HandleFunc("/v1/acl/policy/", s.wrap(s.ACLPolicySpecificRequest))
 
@@ -493,6 +502,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/rebalance", s.wrap(s.OperatorSchedulerRebalance))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
 
@@ -1,8 +1,9 @@
 package agent
 
 import (
-	"github.com/hashicorp/nomad/nomad/structs"
 	"net/http"
+
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 func (s *HTTPServer) IngressPluginsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -26,12 +27,19 @@ func (s *HTTPServer) IngressPluginsRequest(resp http.ResponseWriter, req *http.R
 }
 
 func (s *HTTPServer) IngressPluginSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
-	if req.Method != http.MethodGet {
+	id := req.URL.Query().Get("id")
+
+	switch req.Method {
+	case http.MethodGet:
+		return s.ingressPluginGet(id, resp, req)
+	case http.MethodDelete:
+		return s.ingressPluginDelete(id, resp, req)
+	default:
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
+}
 
-	id := req.URL.Query().Get("id")
-
+func (s *HTTPServer) ingressPluginGet(id string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	args := structs.IngressPluginGetRequest{ID: id}
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
@@ -49,3 +57,59 @@ func (s *HTTPServer) IngressPluginSpecificRequest(resp http.ResponseWriter, req
 
 	return out.Plugin, nil
 }
+
+func (s *HTTPServer) ingressPluginDelete(id string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.IngressPluginDeleteRequest{ID: id}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.IngressPluginDeleteResponse
+	if err := s.agent.RPC("IngressPlugin.Delete", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return nil, nil
+}
+
+// IngressRoutesRequest lists the ingress routes of the request namespace.
+func (s *HTTPServer) IngressRoutesRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.IngressRouteListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.IngressRouteListResponse
+	if err := s.agent.RPC("IngressRoute.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out.Routes, nil
+}
+
+// IngressRoutesAckRequest acknowledges that ingress routes were programmed.
+func (s *HTTPServer) IngressRoutesAckRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.IngressRouteAckRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("IngressRoute.Ack", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
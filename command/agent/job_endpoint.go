@@ -1123,6 +1123,7 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	tg.Meta = taskGroup.Meta
 	tg.Constraints = ApiConstraintsToStructs(taskGroup.Constraints)
 	tg.Affinities = ApiAffinitiesToStructs(taskGroup.Affinities)
+	tg.JobAffinities = ApiJobAffinitiesToStructs(taskGroup.JobAffinities)
 	tg.Networks = ApiNetworkResourceToStructs(taskGroup.Networks)
 	tg.Services = ApiServicesToStructs(taskGroup.Services, true)
 	tg.Consul = apiConsulToStructs(taskGroup.Consul)
@@ -1147,6 +1148,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.MaxClientDisconnect = taskGroup.MaxClientDisconnect
 	}
 
+	if taskGroup.Gang != nil {
+		tg.Gang = *taskGroup.Gang
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -1457,12 +1462,19 @@ func ApiIngressPluginConfigToStructsConfig(apiConfig *api.TaskIngressPluginConfi
 	if apiConfig.Internal != nil {
 		sc.Internal = &structs.InternalIngressClassConfig{
 			LoadBalancerConfigurationPath: apiConfig.Internal.LoadBalancerConfigurationPath,
+			Template:                      apiConfig.Internal.Template,
+			PortLabel:                     apiConfig.Internal.PortLabel,
+			ReloadSignal:                  apiConfig.Internal.ReloadSignal,
 		}
 	}
 
-	// TODO add after filling external
 	if apiConfig.External != nil {
-		sc.External = &structs.ExternalIngressClassConfig{}
+		sc.External = &structs.ExternalIngressClassConfig{
+			TargetGroup: apiConfig.External.TargetGroup,
+		}
+		if apiConfig.External.DrainTimeout != nil {
+			sc.External.DrainTimeout = *apiConfig.External.DrainTimeout
+		}
 	}
 	return sc
 }
@@ -1627,6 +1639,7 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 					SuccessBeforePassing:   check.SuccessBeforePassing,
 					FailuresBeforeCritical: check.FailuresBeforeCritical,
 					OnUpdate:               onUpdate,
+					Role:                   check.Role,
 				}
 
 				if group {
@@ -1652,8 +1665,53 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 			out[i].Identity = apiWorkloadIdentityToStructs(s.Identity)
 		}
 
+		if s.Ingress != nil {
+			out[i].Ingress = apiServiceIngressToStructs(s.Ingress)
+		}
+
+		if s.Mesh != nil {
+			out[i].Mesh = apiServiceMeshToStructs(s.Mesh)
+		}
+
+	}
+
+	return out
+}
+
+func apiServiceIngressToStructs(in *api.ServiceIngress) *structs.ServiceIngress {
+	if in == nil {
+		return nil
+	}
+	out := &structs.ServiceIngress{
+		Hosts:        slices.Clone(in.Hosts),
+		Paths:        slices.Clone(in.Paths),
+		TLSSecret:    in.TLSSecret,
+		Class:        in.Class,
+		Weight:       structs.ServiceIngressDefaultWeight,
+		CanaryWeight: in.CanaryWeight,
 	}
+	if in.Weight != nil {
+		out.Weight = *in.Weight
+	}
+	return out
+}
 
+func apiServiceMeshToStructs(in *api.ServiceMesh) *structs.ServiceMesh {
+	if in == nil {
+		return nil
+	}
+	out := &structs.ServiceMesh{
+		LocalServicePort: in.LocalServicePort,
+	}
+	if len(in.Upstreams) > 0 {
+		out.Upstreams = make([]*structs.MeshUpstream, len(in.Upstreams))
+		for i, upstream := range in.Upstreams {
+			out.Upstreams[i] = &structs.MeshUpstream{
+				DestinationName: upstream.DestinationName,
+				LocalBindPort:   upstream.LocalBindPort,
+			}
+		}
+	}
 	return out
 }
 
@@ -2035,10 +2093,31 @@ func ApiAffinityToStructs(a1 *api.Affinity) *structs.Affinity {
 	}
 }
 
+func ApiJobAffinitiesToStructs(in []*api.JobAffinity) []*structs.JobAffinity {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*structs.JobAffinity, len(in))
+	for i, a := range in {
+		out[i] = &structs.JobAffinity{
+			Namespace: a.Namespace,
+			JobID:     a.JobID,
+			Meta:      maps.Clone(a.Meta),
+			Attribute: a.Attribute,
+			Weight:    *a.Weight,
+			Required:  a.Required,
+		}
+	}
+
+	return out
+}
+
 func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	ret.MaxSkew = a1.MaxSkew
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) MeshIntentionsRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.MeshListIntentionsRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.MeshListIntentionsResponse
+	if err := s.agent.RPC(structs.MeshListIntentionsRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Intentions == nil {
+		out.Intentions = make([]*structs.ServiceIntention, 0)
+	}
+	return out.Intentions, nil
+}
+
+func (s *HTTPServer) MeshIntentionRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	switch req.Method {
+	case http.MethodPut, http.MethodPost:
+		if req.URL.Path != "/v1/service-mesh/intention" {
+			return nil, CodedError(http.StatusNotFound, "unsupported path")
+		}
+		return s.meshIntentionUpsert(resp, req)
+	case http.MethodDelete:
+		return s.meshIntentionDelete(resp, req)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) meshIntentionUpsert(resp http.ResponseWriter, req *http.Request) (any, error) {
+	var intention structs.ServiceIntention
+	if err := decodeBody(req, &intention); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	args := structs.MeshUpsertIntentionsRequest{
+		Intentions: []*structs.ServiceIntention{&intention},
+	}
+	args.Namespace = intention.Namespace
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC(structs.MeshUpsertIntentionsRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) meshIntentionDelete(resp http.ResponseWriter, req *http.Request) (any, error) {
+	// The path is /v1/service-mesh/intention/<source>/<destination>.
+	path := strings.TrimPrefix(req.URL.Path, "/v1/service-mesh/intention/")
+	source, destination, ok := strings.Cut(path, "/")
+	if !ok || source == "" || destination == "" || strings.Contains(destination, "/") {
+		return nil, CodedError(http.StatusBadRequest, "must specify the source and destination services")
+	}
+
+	args := structs.MeshDeleteIntentionRequest{
+		SourceService:      source,
+		DestinationService: destination,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC(structs.MeshDeleteIntentionRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
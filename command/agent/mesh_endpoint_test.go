@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_MeshIntention_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		buf := encodeReq(&api.ServiceIntention{
+			SourceService:      "web",
+			DestinationService: "api",
+			Action:             api.ServiceIntentionActionAllow,
+		})
+		req, err := http.NewRequest(http.MethodPut, "/v1/service-mesh/intention", buf)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		_, err = s.Server.MeshIntentionRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/service-mesh/intentions", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		obj, err := s.Server.MeshIntentionsRequest(respW, req)
+		must.NoError(t, err)
+		intentions := obj.([]*structs.ServiceIntention)
+		must.Len(t, 1, intentions)
+		must.Eq(t, structs.DefaultNamespace, intentions[0].Namespace)
+		must.Eq(t, structs.ServiceIntentionActionAllow, intentions[0].Action)
+
+		req, err = http.NewRequest(http.MethodDelete, "/v1/service-mesh/intention/web", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.MeshIntentionRequest(respW, req)
+		must.ErrorContains(t, err, "must specify the source and destination services")
+
+		req, err = http.NewRequest(http.MethodDelete, "/v1/service-mesh/intention/web/api", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.MeshIntentionRequest(respW, req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/service-mesh/intentions", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.MeshIntentionsRequest(respW, req)
+		must.NoError(t, err)
+		must.SliceEmpty(t, obj.([]*structs.ServiceIntention))
+	})
+}
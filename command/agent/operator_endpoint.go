@@ -288,6 +288,30 @@ func (s *HTTPServer) OperatorSchedulerConfiguration(resp http.ResponseWriter, re
 	}
 }
 
+// OperatorSchedulerRebalance is used to preview the allocations the
+// rebalancer would migrate.
+func (s *HTTPServer) OperatorSchedulerRebalance(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.SchedulerRebalancePlanResponse
+	if err := s.agent.RPC("Operator.SchedulerRebalancePlan", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	if reply.Moves == nil {
+		reply.Moves = make([]*structs.RebalanceMove, 0)
+	}
+	return reply, nil
+}
+
 func (s *HTTPServer) schedulerGetConfig(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var args structs.GenericRequest
 	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
@@ -322,6 +346,22 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 			SysBatchSchedulerEnabled: conf.PreemptionConfig.SysBatchSchedulerEnabled,
 			BatchSchedulerEnabled:    conf.PreemptionConfig.BatchSchedulerEnabled,
 			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled},
+		RebalanceConfig: structs.RebalanceConfig{
+			Enabled:  conf.RebalanceConfig.Enabled,
+			MaxMoves: conf.RebalanceConfig.MaxMoves,
+		},
+		FairShareConfig: structs.FairShareConfig{
+			Enabled:          conf.FairShareConfig.Enabled,
+			NamespaceWeights: conf.FairShareConfig.NamespaceWeights,
+		},
+		ResourceWeights: structs.ResourceWeights{
+			CPU:     conf.ResourceWeights.CPU,
+			Memory:  conf.ResourceWeights.Memory,
+			Disk:    conf.ResourceWeights.Disk,
+			Network: conf.ResourceWeights.Network,
+			Cores:   conf.ResourceWeights.Cores,
+			Devices: conf.ResourceWeights.Devices,
+		},
 	}
 
 	if err := args.Config.Validate(); err != nil {
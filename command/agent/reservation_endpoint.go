@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) ReservationsRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.reservationList(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.reservationUpsert(resp, req, "")
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) ReservationSpecificRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	id := strings.TrimPrefix(req.URL.Path, "/v1/reservation/")
+	if id == "" {
+		return nil, CodedError(http.StatusBadRequest, "missing reservation ID")
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		return s.reservationQuery(resp, req, id)
+	case http.MethodPut, http.MethodPost:
+		return s.reservationUpsert(resp, req, id)
+	case http.MethodDelete:
+		return s.reservationDelete(resp, req, id)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) reservationList(resp http.ResponseWriter, req *http.Request) (any, error) {
+	args := structs.ReservationListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.ReservationListResponse
+	if err := s.agent.RPC("Reservation.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Reservations == nil {
+		out.Reservations = make([]*structs.Reservation, 0)
+	}
+	return out.Reservations, nil
+}
+
+func (s *HTTPServer) reservationQuery(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.ReservationSpecificRequest{
+		ID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleReservationResponse
+	if err := s.agent.RPC("Reservation.GetReservation", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Reservation == nil {
+		return nil, CodedError(http.StatusNotFound, "reservation not found")
+	}
+	return out.Reservation, nil
+}
+
+func (s *HTTPServer) reservationUpsert(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	var reservation structs.Reservation
+	if err := decodeBody(req, &reservation); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	if id != "" {
+		if reservation.ID == "" {
+			reservation.ID = id
+		} else if reservation.ID != id {
+			return nil, CodedError(http.StatusBadRequest, "Reservation ID does not match request path")
+		}
+	}
+
+	args := structs.ReservationUpsertRequest{
+		Reservations: []*structs.Reservation{&reservation},
+	}
+	args.Namespace = reservation.Namespace
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.ReservationUpsertResponse
+	if err := s.agent.RPC("Reservation.Upsert", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out.Reservations[0], nil
+}
+
+func (s *HTTPServer) reservationDelete(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.ReservationDeleteRequest{
+		IDs: []string{id},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Reservation.Delete", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
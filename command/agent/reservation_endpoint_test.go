@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_Reservation_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		buf := encodeReq(&api.Reservation{
+			Description: "headroom",
+			Resources:   &api.ReservationResources{CPU: 500},
+		})
+		req, err := http.NewRequest(http.MethodPut, "/v1/reservations", buf)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.ReservationsRequest(respW, req)
+		must.NoError(t, err)
+		created := obj.(*structs.Reservation)
+		must.NotEq(t, "", created.ID)
+		must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/reservation/"+created.ID, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.ReservationSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.Eq(t, "headroom", obj.(*structs.Reservation).Description)
+
+		req, err = http.NewRequest(http.MethodDelete, "/v1/reservation/"+created.ID, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.ReservationSpecificRequest(respW, req)
+		must.NoError(t, err)
+
+		// Querying the deleted reservation returns a not found error.
+		req, err = http.NewRequest(http.MethodGet, "/v1/reservation/"+created.ID, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.ReservationSpecificRequest(respW, req)
+		must.ErrorContains(t, err, "not found")
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/reservations", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.ReservationsRequest(respW, req)
+		must.NoError(t, err)
+		must.SliceEmpty(t, obj.([]*structs.Reservation))
+	})
+}
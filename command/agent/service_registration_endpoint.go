@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"net"
 	"net/http"
 	"strings"
 
@@ -29,6 +30,12 @@ func (s *HTTPServer) ServiceRegistrationListRequest(
 		return nil, nil
 	}
 
+	healthy, err := s.parseServiceHealthy(req)
+	if err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	args.Healthy = healthy
+
 	// Perform the RPC request.
 	var reply structs.ServiceRegistrationListResponse
 	if err := s.agent.RPC(structs.ServiceRegistrationListRPCMethod, &args, &reply); err != nil {
@@ -101,6 +108,12 @@ func (s *HTTPServer) serviceGetRequest(
 		return nil, nil
 	}
 
+	healthy, err := s.parseServiceHealthy(req)
+	if err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	args.Healthy = healthy
+
 	var reply structs.ServiceRegistrationByNameResponse
 	if err := s.agent.RPC(structs.ServiceRegistrationGetServiceRPCMethod, &args, &reply); err != nil {
 		return nil, err
@@ -128,3 +141,35 @@ func (s *HTTPServer) serviceDeleteRequest(
 	setIndex(resp, reply.Index)
 	return nil, nil
 }
+
+// parseServiceHealthy returns whether the request only wants service
+// registrations whose checks are passing. Callers opt in with the "healthy"
+// query parameter. Requests made by templates, which cannot set query
+// parameters on nomadService lookups, follow the client template
+// configuration unless they set the parameter.
+func (s *HTTPServer) parseServiceHealthy(req *http.Request) (bool, error) {
+	healthy, err := parseBool(req, "healthy")
+	if err != nil {
+		return false, err
+	}
+	if healthy != nil {
+		return *healthy, nil
+	}
+
+	if !isTemplateRequest(req) {
+		return false, nil
+	}
+	conf := s.agent.GetConfig()
+	if conf.Client == nil || conf.Client.TemplateConfig == nil {
+		return false, nil
+	}
+	return conf.Client.TemplateConfig.NomadServiceHealthyOnly, nil
+}
+
+// isTemplateRequest returns whether the request was made by the template
+// runner of a task, which reaches the agent over an in-memory connection
+// rather than a network or unix socket.
+func isTemplateRequest(req *http.Request) bool {
+	addr, ok := req.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	return ok && addr.Network() == "bufconn"
+}
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/ci"
+	client "github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/bufconndialer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
@@ -398,3 +401,57 @@ func TestHTTPServer_ServiceRegistrationRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPServer_ServiceRegistrationRequest_Healthy(t *testing.T) {
+	ci.Parallel(t)
+
+	cb := func(c *Config) {
+		c.Client.TemplateConfig = &client.ClientTemplateConfig{
+			NomadServiceHealthyOnly: true,
+		}
+	}
+
+	httpTest(t, cb, func(s *TestAgent) {
+		testState := s.Agent.server.State()
+
+		// Register a passing and a failing instance of the same service.
+		services := mock.ServiceRegistrations()[:1]
+		services = append(services, services[0].Copy())
+		services[0].CheckStatus = structs.CheckSuccess
+		services[1].ID = services[1].ID + "-failing"
+		services[1].CheckStatus = structs.CheckFailure
+		must.NoError(t, testState.UpsertServiceRegistrations(
+			structs.MsgTypeTestSetup, 10, services))
+
+		get := func(path string, template bool) []*structs.ServiceRegistration {
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			must.NoError(t, err)
+			if template {
+				// Requests of templates are served over the in-memory
+				// listener of the agent.
+				listener, _ := bufconndialer.New()
+				defer listener.Close()
+				req = req.WithContext(context.WithValue(
+					req.Context(), http.LocalAddrContextKey, listener.Addr()))
+			}
+			obj, err := s.Server.ServiceRegistrationRequest(httptest.NewRecorder(), req)
+			must.NoError(t, err)
+			return obj.([]*structs.ServiceRegistration)
+		}
+
+		path := "/v1/service/" + services[0].ServiceName
+		must.Len(t, 2, get(path, false))
+		must.Len(t, 1, get(path+"?healthy=true", false))
+		must.Len(t, 2, get(path+"?healthy=false", true))
+
+		// Template requests follow the client template configuration.
+		healthy := get(path, true)
+		must.Len(t, 1, healthy)
+		must.Eq(t, services[0].ID, healthy[0].ID)
+
+		req, err := http.NewRequest(http.MethodGet, path+"?healthy=maybe", nil)
+		must.NoError(t, err)
+		_, err = s.Server.ServiceRegistrationRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Failed to parse value")
+	})
+}
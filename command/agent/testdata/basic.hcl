@@ -28,12 +28,14 @@ ports {
   http = 1234
   rpc  = 2345
   serf = 3456
+  dns  = 4567
 }
 
 addresses {
   http = "127.0.0.1"
   rpc  = "127.0.0.2"
   serf = "127.0.0.3"
+  dns  = "127.0.0.4"
 }
 
 advertise {
@@ -101,6 +103,15 @@ client {
     path = "/tmp"
   }
 
+  maintenance_window "weekly" {
+    cron      = "0 2 * * SUN"
+    time_zone = "Europe/Berlin"
+    duration  = "4h"
+    lead      = "30m"
+
+    max_parallel = 2
+  }
+
   cni_path              = "/tmp/cni_path"
   bridge_network_name   = "custom_bridge_name"
   bridge_network_subnet = "custom_bridge_subnet"
@@ -122,6 +133,7 @@ server {
   csi_volume_claim_gc_interval  = "3m"
   csi_volume_claim_gc_threshold = "12h"
   csi_plugin_gc_threshold       = "12h"
+  ingress_plugin_gc_threshold   = "12h"
   acl_token_gc_threshold        = "12h"
   heartbeat_grace               = "30s"
   min_heartbeat_ttl             = "33s"
@@ -142,6 +154,11 @@ server {
   job_default_priority          = 100
   job_max_priority              = 200
 
+  placement_plugin "rack-inventory" {
+    timeout        = "250ms"
+    failure_policy = "closed"
+  }
+
   plan_rejection_tracker {
     enabled        = true
     node_threshold = 100
@@ -162,6 +179,25 @@ server {
       system_scheduler_enabled  = true
       service_scheduler_enabled = true
     }
+
+    rebalance_config {
+      enabled   = true
+      max_moves = 10
+    }
+
+    fair_share_config {
+      enabled = true
+
+      namespace_weights {
+        prod = 3
+      }
+    }
+
+    resource_weights {
+      cpu     = 1
+      memory  = 2
+      devices = 4
+    }
   }
 
   license_path = "/tmp/nomad.hclic"
@@ -177,6 +213,13 @@ acl {
   replication_token        = "foobar"
 }
 
+dns {
+  enabled = true
+  domain  = "nomad.internal"
+  ttl     = "5s"
+  token   = "dns-token"
+}
+
 audit {
   enabled = true
 
@@ -403,6 +403,26 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"ingress": func() (cli.Command, error) {
+			return &IngressCommand{
+				Meta: meta,
+			}, nil
+		},
+		"ingress plugin": func() (cli.Command, error) {
+			return &IngressPluginCommand{
+				Meta: meta,
+			}, nil
+		},
+		"ingress plugin status": func() (cli.Command, error) {
+			return &IngressPluginStatusCommand{
+				Meta: meta,
+			}, nil
+		},
+		"ingress routes": func() (cli.Command, error) {
+			return &IngressRoutesCommand{
+				Meta: meta,
+			}, nil
+		},
 		"init": func() (cli.Command, error) {
 			return &JobInitCommand{
 				Meta: meta,
@@ -794,6 +814,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler simulate": func() (cli.Command, error) {
+			return &OperatorSchedulerSimulateCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator root keyring": func() (cli.Command, error) {
 			return &OperatorRootKeyringCommand{
 				Meta: meta,
@@ -931,6 +956,27 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 			}, nil
 		},
 
+		"reservation": func() (cli.Command, error) {
+			return &ReservationCommand{
+				Meta: meta,
+			}, nil
+		},
+		"reservation create": func() (cli.Command, error) {
+			return &ReservationCreateCommand{
+				Meta: meta,
+			}, nil
+		},
+		"reservation delete": func() (cli.Command, error) {
+			return &ReservationDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
+		"reservation list": func() (cli.Command, error) {
+			return &ReservationListCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"run": func() (cli.Command, error) {
 			return &JobRunCommand{
 				Meta: meta,
@@ -1036,6 +1082,26 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"service intention": func() (cli.Command, error) {
+			return &ServiceIntentionCommand{
+				Meta: meta,
+			}, nil
+		},
+		"service intention create": func() (cli.Command, error) {
+			return &ServiceIntentionCreateCommand{
+				Meta: meta,
+			}, nil
+		},
+		"service intention delete": func() (cli.Command, error) {
+			return &ServiceIntentionDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
+		"service intention list": func() (cli.Command, error) {
+			return &ServiceIntentionListCommand{
+				Meta: meta,
+			}, nil
+		},
 		"setup": func() (cli.Command, error) {
 			return &SetupCommand{
 				Meta: meta,
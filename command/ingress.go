@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type IngressCommand struct {
+	Meta
+}
+
+func (c *IngressCommand) Help() string {
+	helpText := `
+Usage: nomad ingress <subcommand> [options]
+
+  This command groups subcommands for interacting with ingress plugins and
+  the routes they program.
+
+  List ingress plugins:
+
+      $ nomad ingress plugin status
+
+  Detail an individual ingress plugin:
+
+      $ nomad ingress plugin status <plugin_id>
+
+  List ingress routes:
+
+      $ nomad ingress routes
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IngressCommand) Name() string { return "ingress" }
+
+func (c *IngressCommand) Synopsis() string { return "Interact with ingress plugins and routes" }
+
+func (c *IngressCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+type IngressPluginCommand struct {
+	Meta
+}
+
+func (c *IngressPluginCommand) Help() string {
+	helpText := `
+Usage: nomad ingress plugin <subcommand> [options]
+
+  This command groups subcommands for interacting with ingress plugins.
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IngressPluginCommand) Name() string { return "ingress plugin" }
+
+func (c *IngressPluginCommand) Synopsis() string { return "Inspect ingress plugins" }
+
+func (c *IngressPluginCommand) Run(_ []string) int { return cli.RunResultHelp }
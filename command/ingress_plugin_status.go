@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type IngressPluginStatusCommand struct {
+	Meta
+	length   int
+	short    bool
+	verbose  bool
+	json     bool
+	template string
+}
+
+func (c *IngressPluginStatusCommand) Help() string {
+	helpText := `
+Usage nomad ingress plugin status [options] <plugin>
+
+  Display status information about an ingress plugin. If no plugin id is
+  given, a list of all ingress plugins will be displayed.
+
+  If ACLs are enabled, this command requires a token with the 'plugin:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Status Options:
+
+  -short
+    Display short output.
+
+  -verbose
+    Display full information.
+
+  -json
+    Output the plugin in its JSON format.
+
+  -t
+    Format and display the plugin using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IngressPluginStatusCommand) Synopsis() string {
+	return "Display status information about an ingress plugin"
+}
+
+func (c *IngressPluginStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-short":   complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *IngressPluginStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		plugs, _, err := client.IngressPlugins().List(&api.QueryOptions{Prefix: a.Last})
+		if err != nil {
+			return []string{}
+		}
+
+		ids := make([]string, 0, len(plugs))
+		for _, p := range plugs {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	})
+}
+
+func (c *IngressPluginStatusCommand) Name() string { return "ingress plugin status" }
+
+func (c *IngressPluginStatusCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&c.short, "short", false, "")
+	flags.BoolVar(&c.verbose, "verbose", false, "")
+	flags.BoolVar(&c.json, "json", false, "")
+	flags.StringVar(&c.template, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing arguments %s", err))
+		return 1
+	}
+
+	// Check that we either got no arguments or exactly one.
+	args = flags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("This command takes either no arguments or one: <plugin>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	c.length = shortId
+	if c.verbose {
+		c.length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if len(args) == 0 {
+		plugs, _, err := client.IngressPlugins().List(nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying ingress plugins: %s", err))
+			return 1
+		}
+
+		if len(plugs) == 0 {
+			// No output if we have no plugins
+			c.Ui.Error("No ingress plugins")
+			return 0
+		}
+
+		str, err := c.formatPlugins(plugs)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+			return 1
+		}
+		c.Ui.Output(str)
+		return 0
+	}
+
+	// filter by plugin if a plugin ID was passed
+	id := args[0]
+	plugs, _, err := client.IngressPlugins().List(&api.QueryOptions{Prefix: id})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying ingress plugins: %s", err))
+		return 1
+	}
+	if len(plugs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No plugins(s) with prefix or ID %q found", id))
+		return 1
+	}
+	if len(plugs) > 1 {
+		if id != plugs[0].ID {
+			out, err := c.formatPlugins(plugs)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+				return 1
+			}
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple plugins\n\n%s", out))
+			return 1
+		}
+	}
+	id = plugs[0].ID
+
+	// Lookup matched a single plugin
+	plug, _, err := client.IngressPlugins().Info(id, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying plugin: %s", err))
+		return 1
+	}
+
+	str, err := c.formatPlugin(plug)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting plugin: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(str)
+	return 0
+}
+
+func (c *IngressPluginStatusCommand) formatPlugins(plugs []*api.IngressPluginListStub) (string, error) {
+	// Sort the output by plugin ID
+	sort.Slice(plugs, func(i, j int) bool { return plugs[i].ID < plugs[j].ID })
+
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, plugs)
+		if err != nil {
+			return "", fmt.Errorf("format error: %v", err)
+		}
+		return out, nil
+	}
+
+	rows := make([]string, len(plugs)+1)
+	rows[0] = "ID|Provider|Controllers Healthy/Expected"
+	for i, p := range plugs {
+		rows[i+1] = fmt.Sprintf("%s|%s|%d/%d",
+			limit(p.ID, c.length),
+			p.Provider,
+			p.ControllersHealthy,
+			p.ControllersExpected,
+		)
+	}
+	return formatList(rows), nil
+}
+
+func (c *IngressPluginStatusCommand) formatPlugin(plug *api.IngressPlugin) (string, error) {
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, plug)
+		if err != nil {
+			return "", fmt.Errorf("format error: %v", err)
+		}
+		return out, nil
+	}
+
+	output := []string{
+		fmt.Sprintf("ID|%s", plug.ID),
+		fmt.Sprintf("Provider|%s", plug.Provider),
+		fmt.Sprintf("Version|%s", plug.Version),
+		fmt.Sprintf("Controllers Healthy|%d", plug.ControllersHealthy),
+		fmt.Sprintf("Controllers Expected|%d", plug.ControllersExpected),
+	}
+
+	// Exit early
+	if c.short {
+		return formatKV(output), nil
+	}
+
+	full := []string{formatKV(output)}
+
+	if c.verbose && len(plug.Controllers) > 0 {
+		full = append(full, c.Colorize().Color("\n[bold]Controllers[reset]"))
+		full = append(full, c.formatControllers(plug.Controllers))
+	}
+
+	// Format the allocs
+	banner := c.Colorize().Color("\n[bold]Allocations[reset]")
+	allocs := formatAllocListStubs(plug.Allocations, c.verbose, c.length)
+	full = append(full, banner)
+	full = append(full, allocs)
+	return strings.Join(full, "\n"), nil
+}
+
+func (c *IngressPluginStatusCommand) formatControllers(controllers map[string]*api.IngressInfo) string {
+	nodeIDs := make([]string, 0, len(controllers))
+	for nodeID := range controllers {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	rows := []string{"Node ID|Alloc ID|Healthy|Description|Updated|Node Status Updated"}
+	for _, nodeID := range nodeIDs {
+		info := controllers[nodeID]
+		rows = append(rows, fmt.Sprintf("%s|%s|%t|%s|%s|%s",
+			limit(nodeID, c.length),
+			limit(info.AllocID, c.length),
+			info.Healthy,
+			info.HealthDescription,
+			formatTime(info.UpdateTime),
+			formatTime(info.NodeStatusUpdateTime),
+		))
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestIngressPluginStatusCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &IngressPluginStatusCommand{}
+}
+
+func TestIngressPluginStatusCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &IngressPluginStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+}
+
+func TestIngressPluginStatusCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &IngressPluginStatusCommand{Meta: Meta{Ui: ui}}
+
+	// No plugins are registered
+	code := cmd.Run([]string{"-address=" + url})
+	must.Zero(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No ingress plugins")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "unknown"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `No plugins(s) with prefix or ID "unknown" found`)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure IngressRoutesCommand satisfies the cli.Command interface.
+var _ cli.Command = &IngressRoutesCommand{}
+
+// IngressRoutesCommand implements cli.Command.
+type IngressRoutesCommand struct {
+	Meta
+}
+
+// Help satisfies the cli.Command Help function.
+func (c *IngressRoutesCommand) Help() string {
+	helpText := `
+Usage: nomad ingress routes [options] [job]
+
+  List the ingress routes built from the ingress blocks of job services,
+  along with the addresses of the healthy allocations they send traffic to.
+  If a job ID is given, only the routes of jobs with that prefix are listed.
+
+  If ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the namespace of the routes.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Routes Options:
+
+  -verbose
+    Display full information.
+
+  -json
+    Output the routes in JSON format.
+
+  -t
+    Format and display the routes using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (c *IngressRoutesCommand) Synopsis() string {
+	return "Display the ingress routes of jobs"
+}
+
+func (c *IngressRoutesCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *IngressRoutesCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+// Name returns the name of this command.
+func (c *IngressRoutesCommand) Name() string { return "ingress routes" }
+
+// Run satisfies the cli.Command Run function.
+func (c *IngressRoutesCommand) Run(args []string) int {
+	var verbose, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("This command takes either no arguments or one: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	q := &api.QueryOptions{}
+	if len(args) == 1 {
+		q.Prefix = args[0]
+	}
+
+	routes, _, err := client.IngressRoutes().List(q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing ingress routes: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, routes)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(routes) == 0 {
+		c.Ui.Output("No ingress routes found")
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	c.Ui.Output(formatIngressRoutes(c.Meta.namespace, routes, length))
+	return 0
+}
+
+func formatIngressRoutes(cmdNS string, routes []*api.IngressRoute, length int) string {
+	header := "Job ID|Group|Service|Hosts|Paths|Class|Weight|Backends"
+	if cmdNS == api.AllNamespacesNamespace {
+		header = "Namespace|" + header
+	}
+
+	rows := []string{header}
+	for _, route := range routes {
+		ingress := route.Ingress
+		if ingress == nil {
+			ingress = &api.ServiceIngress{}
+		}
+
+		backends := make([]string, 0, len(route.Backends))
+		for _, b := range route.Backends {
+			backends = append(backends, fmt.Sprintf("%s=%s:%d", limit(b.AllocID, length), b.Address, b.Port))
+		}
+
+		class := ingress.Class
+		if class == "" {
+			class = "<all>"
+		}
+
+		weight := 0
+		if ingress.Weight != nil {
+			weight = *ingress.Weight
+		}
+
+		row := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%d|%s",
+			route.JobID,
+			route.TaskGroup,
+			route.Service,
+			strings.Join(ingress.Hosts, ","),
+			strings.Join(ingress.Paths, ","),
+			class,
+			weight,
+			strings.Join(backends, ","),
+		)
+		if cmdNS == api.AllNamespacesNamespace {
+			row = route.Namespace + "|" + row
+		}
+		rows = append(rows, row)
+	}
+	return formatList(rows)
+}
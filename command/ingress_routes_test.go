@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestIngressRoutesCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &IngressRoutesCommand{}
+}
+
+func TestIngressRoutesCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &IngressRoutesCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "too", "many"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No ingress routes found")
+	ui.OutputWriter.Reset()
+
+	// Register a job with an ingress service directly in state.
+	job := mock.Job()
+	job.TaskGroups[0].Services = []*structs.Service{{
+		Name:      "web",
+		PortLabel: "http",
+		Ingress: &structs.ServiceIngress{
+			Hosts:  []string{"example.com"},
+			Paths:  []string{"/api"},
+			Weight: 100,
+		},
+	}}
+	state := srv.Agent.Server().State()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	code = cmd.Run([]string{"-address=" + url, job.ID[:4]})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, job.ID)
+	must.StrContains(t, out, "example.com")
+	must.StrContains(t, out, "/api")
+	ui.OutputWriter.Reset()
+
+	// Routes of other jobs are filtered out.
+	code = cmd.Run([]string{"-address=" + url, "other"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No ingress routes found")
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-json"})
+	must.Zero(t, code)
+
+	var routes []*api.IngressRoute
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &routes))
+	must.Len(t, 1, routes)
+	must.Eq(t, job.ID, routes[0].JobID)
+	must.Eq(t, []string{"example.com"}, routes[0].Ingress.Hosts)
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-t", "{{range .}}{{.Service}}{{end}}"})
+	must.Zero(t, code)
+	must.Eq(t, "web", ui.OutputWriter.String()[:3])
+}
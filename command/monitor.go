@@ -339,6 +339,9 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 	for cs, num := range metrics.ConstraintFiltered {
 		out += fmt.Sprintf("%s* Constraint %q: %d nodes excluded by filter\n", prefix, cs, num)
 	}
+	for skew, num := range metrics.SkewFiltered {
+		out += fmt.Sprintf("%s* Spread %q: %d nodes excluded by max_skew\n", prefix, skew, num)
+	}
 
 	// Print exhaustion info
 	if ne := metrics.NodesExhausted; ne > 0 {
@@ -267,6 +267,20 @@ Node    score-1  score-2  score-3  score-4  final score
 node-1  1        2        0        0        1
 node-2  1        0        3        0        2
 node-3  0        0        0        4        3
+`,
+		},
+		{
+			Name: "display nodes filtered by max skew",
+			Metrics: &api.AllocationMetric{
+				NodesEvaluated: 3,
+				NodesFiltered:  2,
+				NodesInPool:    3,
+				SkewFiltered: map[string]int{
+					"${node.datacenter} = dc1: skew 2 > max_skew 1": 2,
+				},
+			},
+			Expected: `
+* Spread "${node.datacenter} = dc1: skew 2 > max_skew 1": 2 nodes excluded by max_skew
 `,
 		},
 	}
@@ -144,5 +144,17 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 		c.Ui.Output("No scheduler configuration")
 	}
 
+	c.Ui.Output(c.Colorize().Color("\n[bold]Maintenance Windows[reset]"))
+	if len(pool.MaintenanceWindows) > 0 {
+		windows := []string{"Name|Cron|Time Zone|Duration|Lead"}
+		for _, w := range pool.MaintenanceWindows {
+			windows = append(windows, fmt.Sprintf("%s|%s|%s|%s|%s",
+				w.Name, w.Cron, w.TimeZone, w.Duration, w.Lead))
+		}
+		c.Ui.Output(formatList(windows))
+	} else {
+		c.Ui.Output("No maintenance windows")
+	}
+
 	return 0
 }
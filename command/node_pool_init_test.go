@@ -21,7 +21,8 @@ func TestNodePoolInitCommand_Implements(t *testing.T) {
 }
 
 func TestNodePoolInitCommand_Run(t *testing.T) {
-	ci.Parallel(t)
+	// Not parallel, as changing the working directory affects the whole
+	// process and would make the other tests write their files here.
 	dir := t.TempDir()
 	origDir, err := os.Getwd()
 	must.NoError(t, err)
@@ -30,7 +31,6 @@ func TestNodePoolInitCommand_Run(t *testing.T) {
 	t.Cleanup(func() { os.Chdir(origDir) })
 
 	t.Run("hcl", func(t *testing.T) {
-		ci.Parallel(t)
 		dir := dir
 		ui := cli.NewMockUi()
 		cmd := &NodePoolInitCommand{Meta: Meta{Ui: ui}}
@@ -75,7 +75,6 @@ func TestNodePoolInitCommand_Run(t *testing.T) {
 	})
 
 	t.Run("json", func(t *testing.T) {
-		ci.Parallel(t)
 		dir := dir
 		ui := cli.NewMockUi()
 		cmd := &NodePoolInitCommand{Meta: Meta{Ui: ui}}
@@ -104,6 +104,7 @@ prod-1  <none>`,
 [
     {
         "Description": "",
+        "MaintenanceWindows": null,
         "Meta": null,
         "Name": "prod-1",
         "SchedulerConfiguration": null
@@ -395,6 +395,18 @@ func nodeCSIControllerNames(n *api.Node) []string {
 	return names
 }
 
+func nodeIngressPluginNames(n *api.Node) []string {
+	var names []string
+	for name, info := range n.IngressPlugins {
+		if !info.Healthy {
+			name += " (unhealthy)"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func nodeCSINodeNames(n *api.Node) []string {
 	var names []string
 	for name := range n.CSINodePlugins {
@@ -490,6 +502,7 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 		fmt.Sprintf("Status|%s", node.Status),
 		fmt.Sprintf("CSI Controllers|%s", strings.Join(nodeCSIControllerNames(node), ",")),
 		fmt.Sprintf("CSI Drivers|%s", strings.Join(nodeCSINodeNames(node), ",")),
+		fmt.Sprintf("Ingress Plugins|%s", strings.Join(nodeIngressPluginNames(node), ",")),
 	}
 
 	if c.short {
@@ -44,6 +44,7 @@ type testCases []testCase
 
 func runTestCases(t *testing.T, cases testCases) {
 	t.Helper()
+	chdirTemp(t)
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			ui := cli.NewMockUi()
@@ -73,6 +74,19 @@ func runTestCases(t *testing.T, cases testCases) {
 		})
 	}
 }
+
+// chdirTemp changes the working directory to a temporary directory for the
+// duration of the test, so that the archives the debug command writes to the
+// working directory are removed with it. It must not be called from parallel
+// tests, as the working directory is shared by the whole process.
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(origDir) })
+}
+
 func newClientAgentConfigFunc(region string, nodeClass string, srvRPCAddr string) func(*agent.Config) {
 	if region == "" {
 		region = "global"
@@ -571,6 +585,7 @@ func TestDebug_Fail_Pprof(t *testing.T) {
 	// Setup mock UI
 	ui := cli.NewMockUi()
 	cmd := &OperatorDebugCommand{Meta: Meta{Ui: ui}}
+	chdirTemp(t)
 
 	// Debug on server with endpoints disabled
 	code := cmd.Run([]string{"-address", url, "-duration", "250ms", "-interval", "250ms", "-server-id", "all"})
@@ -1053,6 +1068,7 @@ func TestDebug_EventStream(t *testing.T) {
 
 	archive := extractArchiveName(testOut.output)
 	require.NotEmpty(t, archive)
+	t.Cleanup(func() { os.Remove(archive) })
 	fmt.Println(archive)
 
 	// TODO dmay: verify evenstream.json output file contains expected content
@@ -5,8 +5,10 @@ package command
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 )
@@ -86,11 +88,43 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
+		fmt.Sprintf("Rebalance|%v", schedConfig.RebalanceConfig.Enabled),
+		fmt.Sprintf("Rebalance Max Moves|%v", schedConfig.RebalanceConfig.MaxMoves),
+		fmt.Sprintf("Fair Share|%v", schedConfig.FairShareConfig.Enabled),
+		fmt.Sprintf("Fair Share Weights|%s", formatFairShareWeights(schedConfig.FairShareConfig.NamespaceWeights)),
+		fmt.Sprintf("Resource Weights|%s", formatResourceWeights(schedConfig.ResourceWeights)),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
 	return 0
 }
 
+// formatFairShareWeights formats the fair share weights of namespaces, sorted
+// by namespace.
+func formatFairShareWeights(weights map[string]int) string {
+	if len(weights) == 0 {
+		return "<none>"
+	}
+
+	namespaces := make([]string, 0, len(weights))
+	for ns := range weights {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	pairs := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", ns, weights[ns]))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// formatResourceWeights formats the weights of the resources used by the weighted
+// scheduler algorithm.
+func formatResourceWeights(w api.ResourceWeights) string {
+	return fmt.Sprintf("cpu=%d, memory=%d, disk=%d, network=%d, cores=%d, devices=%d",
+		w.CPU, w.Memory, w.Disk, w.Network, w.Cores, w.Devices)
+}
+
 func (o *OperatorSchedulerGetConfig) Synopsis() string {
 	return "Display the current scheduler configuration"
 }
@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -31,6 +32,11 @@ type OperatorSchedulerSetConfig struct {
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
 	preemptSystemScheduler   flagHelper.BoolValue
+	rebalance                flagHelper.BoolValue
+	rebalanceMaxMoves        string
+	fairShare                flagHelper.BoolValue
+	fairShareWeights         flagHelper.StringFlag
+	resourceWeights          flagHelper.StringFlag
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -40,6 +46,7 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-scheduler-algorithm": complete.PredictSet(
 				string(api.SchedulerAlgorithmBinpack),
 				string(api.SchedulerAlgorithmSpread),
+				string(api.SchedulerAlgorithmWeighted),
 			),
 			"-memory-oversubscription":    complete.PredictSet("true", "false"),
 			"-reject-job-registration":    complete.PredictSet("true", "false"),
@@ -48,6 +55,11 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
 			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
 			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-rebalance":                  complete.PredictSet("true", "false"),
+			"-rebalance-max-moves":        complete.PredictAnything,
+			"-fair-share":                 complete.PredictSet("true", "false"),
+			"-fair-share-weight":          complete.PredictAnything,
+			"-resource-weight":            complete.PredictAnything,
 		},
 	)
 }
@@ -72,6 +84,11 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	flags.Var(&o.rebalance, "rebalance", "")
+	flags.StringVar(&o.rebalanceMaxMoves, "rebalance-max-moves", "", "")
+	flags.Var(&o.fairShare, "fair-share", "")
+	flags.Var(&o.fairShareWeights, "fair-share-weight", "")
+	flags.Var(&o.resourceWeights, "resource-weight", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -134,6 +151,68 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptServiceScheduler.Merge(&schedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
+	o.rebalance.Merge(&schedulerConfig.RebalanceConfig.Enabled)
+	if o.rebalanceMaxMoves != "" {
+		maxMoves, err := strconv.Atoi(o.rebalanceMaxMoves)
+		if err != nil || maxMoves < 0 {
+			o.Ui.Error(fmt.Sprintf("Error parsing rebalance-max-moves value %q", o.rebalanceMaxMoves))
+			return 1
+		}
+		schedulerConfig.RebalanceConfig.MaxMoves = maxMoves
+	}
+	o.fairShare.Merge(&schedulerConfig.FairShareConfig.Enabled)
+	for _, kv := range o.fairShareWeights {
+		namespace, raw, ok := strings.Cut(kv, "=")
+		if !ok || namespace == "" {
+			o.Ui.Error(fmt.Sprintf("Error parsing fair-share-weight value %q", kv))
+			return 1
+		}
+
+		// An empty weight unsets the weight of the namespace
+		if raw == "" {
+			delete(schedulerConfig.FairShareConfig.NamespaceWeights, namespace)
+			if len(schedulerConfig.FairShareConfig.NamespaceWeights) == 0 {
+				schedulerConfig.FairShareConfig.NamespaceWeights = nil
+			}
+			continue
+		}
+
+		weight, err := strconv.Atoi(raw)
+		if err != nil || weight <= 0 {
+			o.Ui.Error(fmt.Sprintf("Error parsing fair-share-weight value %q", kv))
+			return 1
+		}
+		if schedulerConfig.FairShareConfig.NamespaceWeights == nil {
+			schedulerConfig.FairShareConfig.NamespaceWeights = make(map[string]int)
+		}
+		schedulerConfig.FairShareConfig.NamespaceWeights[namespace] = weight
+	}
+	for _, kv := range o.resourceWeights {
+		resource, raw, ok := strings.Cut(kv, "=")
+		weight, err := strconv.Atoi(raw)
+		if !ok || err != nil || weight < 0 {
+			o.Ui.Error(fmt.Sprintf("Error parsing resource-weight value %q", kv))
+			return 1
+		}
+		weights := &schedulerConfig.ResourceWeights
+		switch resource {
+		case "cpu":
+			weights.CPU = weight
+		case "memory":
+			weights.Memory = weight
+		case "disk":
+			weights.Disk = weight
+		case "network":
+			weights.Network = weight
+		case "cores":
+			weights.Cores = weight
+		case "devices":
+			weights.Devices = weight
+		default:
+			o.Ui.Error(fmt.Sprintf("Error parsing resource-weight value %q: unknown resource %q", kv, resource))
+			return 1
+		}
+	}
 
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
@@ -173,9 +252,10 @@ Scheduler Set Config Options:
     matches the current server side version. If a non-zero value is passed, it
     ensures that the scheduler config is being updated from a known state.
 
-  -scheduler-algorithm=["binpack"|"spread"]
+  -scheduler-algorithm=["binpack"|"spread"|"weighted"]
     Specifies whether scheduler binpacks or spreads allocations on available
-    nodes.
+    nodes. The "weighted" algorithm binpacks allocations across every resource of
+    the nodes, weighted by the resource weights.
 
   -memory-oversubscription=[true|false]
     When true, tasks may exceed their reserved memory limit, if the client has
@@ -208,6 +288,34 @@ Scheduler Set Config Options:
   -preempt-system-scheduler=[true|false]
     Specifies whether preemption for system jobs is enabled. Note that if this
     is set to true, then system jobs can preempt any other jobs.
+
+  -rebalance=[true|false]
+    Specifies whether the rebalancer periodically migrates allocations off the
+    nodes imbalancing their node pool. Use the rebalance API to preview the
+    allocations it would migrate.
+
+  -rebalance-max-moves=<count>
+    Specifies the maximum number of allocations migrated by each run of the
+    rebalancer. Zero means no limit other than the migrate blocks of the task
+    groups.
+
+  -fair-share=[true|false]
+    Specifies whether the eval broker dequeues evaluations of equal priority
+    by weighted fair queueing across namespaces, rather than in the order they
+    were created.
+
+  -fair-share-weight=<namespace>=<weight>
+    Sets the fair share weight of a namespace, which is its share of the
+    schedulers relative to the other namespaces. Namespaces without a weight
+    have a weight of 1. An empty weight, as in "-fair-share-weight=prod=",
+    unsets the weight of the namespace. May be specified multiple times.
+
+  -resource-weight=<resource>=<weight>
+    Sets the weight of a resource when scoring nodes with the "weighted" scheduler
+    algorithm. The resource must be one of "cpu", "memory", "disk", "network",
+    "cores" or "devices". Resources with a weight of 0 are ignored, unless all
+    the weights are 0, in which case every resource has a weight of 1. May be
+    specified multiple times.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -51,6 +51,13 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		"-preempt-service-scheduler=true",
 		"-preempt-sysbatch-scheduler=true",
 		"-preempt-system-scheduler=false",
+		"-rebalance=true",
+		"-rebalance-max-moves=5",
+		"-fair-share=true",
+		"-fair-share-weight=prod=3",
+		"-fair-share-weight=batch=2",
+		"-resource-weight=memory=2",
+		"-resource-weight=devices=4",
 	}
 	require.EqualValues(t, 0, c.Run(modifyingArgs))
 	s := ui.OutputWriter.String()
@@ -69,6 +76,18 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		MemoryOversubscriptionEnabled: true,
 		RejectJobRegistration:         true,
 		PauseEvalBroker:               true,
+		RebalanceConfig: api.RebalanceConfig{
+			Enabled:  true,
+			MaxMoves: 5,
+		},
+		FairShareConfig: api.FairShareConfig{
+			Enabled:          true,
+			NamespaceWeights: map[string]int{"prod": 3, "batch": 2},
+		},
+		ResourceWeights: api.ResourceWeights{
+			Memory:  2,
+			Devices: 4,
+		},
 	}, modifiedConfig.SchedulerConfig)
 
 	ui.ErrorWriter.Reset()
@@ -100,6 +119,14 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 	require.Contains(t, ui.OutputWriter.String(), "Scheduler configuration updated!")
 	ui.ErrorWriter.Reset()
 	ui.OutputWriter.Reset()
+
+	// Unset the weight of a namespace with an empty weight.
+	require.EqualValues(t, 0, c.Run([]string{"-address=" + addr, "-fair-share-weight=batch="}))
+	unsetConfig, _, err := srv.APIClient().Operator().SchedulerGetConfiguration(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"prod": 3}, unsetConfig.SchedulerConfig.FairShareConfig.NamespaceWeights)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
 }
 
 func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfiguration) {
@@ -108,4 +135,7 @@ func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfigur
 	require.Equal(t, expected.MemoryOversubscriptionEnabled, actual.MemoryOversubscriptionEnabled)
 	require.Equal(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
 	require.Equal(t, expected.PreemptionConfig, actual.PreemptionConfig)
+	require.Equal(t, expected.RebalanceConfig, actual.RebalanceConfig)
+	require.Equal(t, expected.FairShareConfig, actual.FairShareConfig)
+	require.Equal(t, expected.ResourceWeights, actual.ResourceWeights)
 }
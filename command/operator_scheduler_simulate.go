@@ -0,0 +1,344 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] <snapshot>
+
+  Simulates scheduling against the state of a snapshot, without touching the
+  cluster. The snapshot is loaded in memory, nodes are removed and a job is
+  registered, and the schedulers report the allocations they would place,
+  stop and preempt, and the allocations they would fail to place.
+
+  Use "nomad operator snapshot save" to save a snapshot of the cluster.
+
+  To simulate the loss of a node and the registration of "example.nomad.hcl":
+
+    $ nomad operator scheduler simulate -remove-node=f7d3a1c2 \
+        -job=example.nomad.hcl backup.snap
+
+Simulate Options:
+
+  -job=<path>
+    Specifies a job file to register after removing nodes.
+
+  -remove-node=<node-id>
+    Specifies the ID or ID prefix of a node to remove, rescheduling the
+    allocations running on it. May be specified multiple times.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+
+  -json
+    Output the results in JSON format.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-job":         complete.PredictFiles("*"),
+		"-remove-node": complete.PredictAnything,
+		"-var":         complete.PredictAnything,
+		"-var-file":    complete.PredictFiles("*.var"),
+		"-json":        complete.PredictNothing,
+		"-verbose":     complete.PredictNothing,
+	}
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.snap")
+}
+
+func (c *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate scheduling against a snapshot"
+}
+
+func (c *OperatorSchedulerSimulateCommand) Name() string { return "operator scheduler simulate" }
+
+func (c *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	var jobPath string
+	var removeNodes flaghelper.StringFlag
+	var jsonOutput, verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&jobPath, "job", "", "")
+	flags.Var(&removeNodes, "remove-node", "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+	flags.BoolVar(&jsonOutput, "json", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <snapshot>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if jobPath == "" && len(removeNodes) == 0 {
+		c.Ui.Error("At least one of -job or -remove-node must be specified")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Parse the job before loading the snapshot, which may take a while.
+	var job *structs.Job
+	if jobPath != "" {
+		c.JobGetter.Strict = true
+		_, apiJob, err := c.JobGetter.Get(jobPath)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+			return 1
+		}
+		job = agent.ApiJobToStructJob(apiJob)
+		job.Canonicalize()
+		if job.NodePool == "" {
+			job.NodePool = structs.NodePoolDefault
+		}
+		if err := job.Validate(); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error validating job: %s", err))
+			return 1
+		}
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	store, meta, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read archive file: %s", err))
+		return 1
+	}
+
+	// The schedulers log at debug level, only show warnings and errors.
+	logger := hclog.New(&hclog.LoggerOptions{
+		Level:  hclog.Warn,
+		Output: os.Stderr,
+	})
+	sim, err := scheduler.NewSimulation(logger, store)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error starting simulation: %s", err))
+		return 1
+	}
+
+	var results []*scheduler.SimulationResult
+	for _, prefix := range removeNodes {
+		node, err := simulationNode(store, prefix)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		nodeResults, err := sim.RemoveNode(node.ID)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error removing node %q: %s", node.ID, err))
+			return 1
+		}
+		results = append(results, nodeResults...)
+	}
+	if job != nil {
+		result, err := sim.RegisterJob(job)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error registering job %q: %s", job.ID, err))
+			return 1
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput {
+		out, err := Format(true, "", results)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(fmt.Sprintf("Simulated against snapshot at index %d", meta.Index))
+	if len(results) == 0 {
+		c.Ui.Output("\nNo jobs were evaluated")
+		return 0
+	}
+	for _, result := range results {
+		out, err := formatSimulationResult(store, result, length)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(c.Colorize().Color(out))
+	}
+	return 0
+}
+
+// simulationNode returns the node of the snapshot with the ID or ID prefix.
+func simulationNode(store *state.StateStore, prefix string) (*structs.Node, error) {
+	iter, err := store.NodesByIDPrefix(nil, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up node %q: %s", prefix, err)
+	}
+
+	var nodes []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		nodes = append(nodes, raw.(*structs.Node))
+	}
+	switch len(nodes) {
+	case 0:
+		return nil, fmt.Errorf("No node(s) with prefix %q found", prefix)
+	case 1:
+		return nodes[0], nil
+	default:
+		return nil, fmt.Errorf("Prefix %q matched multiple nodes", prefix)
+	}
+}
+
+// formatSimulationResult formats the allocations placed, stopped, preempted
+// and failed to place by a simulated evaluation.
+func formatSimulationResult(store *state.StateStore, result *scheduler.SimulationResult, length int) (string, error) {
+	eval := result.Eval
+	out := fmt.Sprintf("\n[bold]==> Job %q (namespace %q, triggered by %s)[reset]\n",
+		eval.JobID, eval.Namespace, eval.TriggeredBy)
+
+	nodeName := func(id string) string {
+		if node, err := store.NodeByID(nil, id); err == nil && node != nil {
+			return node.Name
+		}
+		return ""
+	}
+
+	// Placements and stops are summarized by task group and node.
+	for _, changes := range []struct {
+		title  string
+		allocs map[string][]*structs.Allocation
+	}{
+		{"Placed", result.Placed},
+		{"Stopped", result.Stopped},
+	} {
+		if len(changes.allocs) == 0 {
+			continue
+		}
+		type key struct{ tg, node string }
+		counts := make(map[key]int)
+		for nodeID, allocs := range changes.allocs {
+			for _, alloc := range allocs {
+				counts[key{alloc.TaskGroup, nodeID}]++
+			}
+		}
+		keys := make([]key, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].tg != keys[j].tg {
+				return keys[i].tg < keys[j].tg
+			}
+			return keys[i].node < keys[j].node
+		})
+
+		rows := []string{"Task Group|Node ID|Node Name|Count"}
+		for _, k := range keys {
+			rows = append(rows, fmt.Sprintf("%s|%s|%s|%d",
+				k.tg, limit(k.node, length), nodeName(k.node), counts[k]))
+		}
+		out += fmt.Sprintf("\n[bold]%s[reset]\n%s\n", changes.title, formatList(rows))
+	}
+
+	if len(result.Preempted) > 0 {
+		var preempted []*structs.Allocation
+		for _, allocs := range result.Preempted {
+			preempted = append(preempted, allocs...)
+		}
+		sort.Slice(preempted, func(i, j int) bool { return preempted[i].ID < preempted[j].ID })
+
+		rows := []string{"Alloc ID|Job ID|Namespace|Task Group|Node ID"}
+		for _, alloc := range preempted {
+			rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s",
+				limit(alloc.ID, length), alloc.JobID, alloc.Namespace, alloc.TaskGroup, limit(alloc.NodeID, length)))
+		}
+		out += fmt.Sprintf("\n[bold]Preempted[reset]\n%s\n", formatList(rows))
+	}
+
+	if len(result.FailedTGAllocs) == 0 {
+		out += "\n[bold][green]- All tasks successfully allocated.[reset]"
+		return out, nil
+	}
+
+	out += "\n[bold][yellow]- WARNING: Failed to place all allocations.[reset]\n"
+	tgs := make([]string, 0, len(result.FailedTGAllocs))
+	for tg := range result.FailedTGAllocs {
+		tgs = append(tgs, tg)
+	}
+	sort.Strings(tgs)
+	for _, tg := range tgs {
+		metrics, err := apiAllocMetric(result.FailedTGAllocs[tg])
+		if err != nil {
+			return "", err
+		}
+
+		noun := "allocation"
+		if metrics.CoalescedFailures > 0 {
+			noun += "s"
+		}
+		out += fmt.Sprintf("%s[yellow]Task Group %q (failed to place %d %s):\n[reset]", strings.Repeat(" ", 2), tg, metrics.CoalescedFailures+1, noun)
+		out += fmt.Sprintf("[yellow]%s[reset]\n", formatAllocMetrics(metrics, false, strings.Repeat(" ", 4)))
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// apiAllocMetric converts metrics to their API representation, the way the
+// HTTP API encodes them.
+func apiAllocMetric(metrics *structs.AllocMetric) (*api.AllocationMetric, error) {
+	buf, err := json.Marshal(metrics)
+	if err != nil {
+		return nil, err
+	}
+	var out api.AllocationMetric
+	if err := json.Unmarshal(buf, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+	// One of -job or -remove-node is required.
+	code := cmd.Run([]string{"backup.snap"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "At least one of -job or -remove-node")
+	ui.ErrorWriter.Reset()
+
+	// The snapshot must exist.
+	code = cmd.Run([]string{"-remove-node=foo", filepath.Join(t.TempDir(), "foo.snap")})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "no such file")
+}
+
+func TestOperatorSchedulerSimulateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	snapPath := generateSnapshotFile(t, nil)
+
+	jobPath := filepath.Join(t.TempDir(), "example.nomad.hcl")
+	must.NoError(t, os.WriteFile(jobPath, []byte(`
+job "example" {
+  group "web" {
+    count = 2
+    task "web" {
+      driver = "exec"
+      config {
+        command = "/bin/sleep"
+      }
+    }
+  }
+}
+`), 0600))
+
+	// The snapshot has no nodes, so nothing can be placed.
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-job", jobPath, snapPath})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, `Job "example"`)
+	must.StrContains(t, out, "WARNING: Failed to place all allocations.")
+	must.StrContains(t, out, `Task Group "web" (failed to place 2 allocations)`)
+
+	// Unknown nodes are reported.
+	ui = cli.NewMockUi()
+	cmd = &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-remove-node", "abcd", snapPath})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `No node(s) with prefix "abcd" found`)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+type ReservationCommand struct {
+	Meta
+}
+
+func (c *ReservationCommand) Name() string {
+	return "reservation"
+}
+
+func (c *ReservationCommand) Synopsis() string {
+	return "Interact with capacity reservations"
+}
+
+func (c *ReservationCommand) Help() string {
+	helpText := `
+Usage: nomad reservation <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with reservations.
+  Reservations hold capacity for a namespace on the nodes of a node pool.
+  Allocations of other namespaces see the reserved capacity the namespace
+  doesn't use as used, without placeholder jobs running on the nodes.
+
+  Create a reservation:
+
+    $ nomad reservation create -namespace=<namespace> -node-pool=<pool> -cpu=<mhz>
+
+  List the reservations:
+
+    $ nomad reservation list
+
+  Delete a reservation:
+
+    $ nomad reservation delete <id>
+
+  Please refer to individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// formatReservationList formats reservations as a table, with IDs shortened
+// to the given length.
+func formatReservationList(reservations []*api.Reservation, length int) string {
+	out := make([]string, len(reservations)+1)
+	out[0] = "ID|Namespace|Node Pool|CPU|Memory MB|Disk MB|Expires"
+	for i, r := range reservations {
+		var cpu, memory, disk int
+		if r.Resources != nil {
+			cpu, memory, disk = r.Resources.CPU, r.Resources.MemoryMB, r.Resources.DiskMB
+		}
+
+		expires := "never"
+		if r.ExpirationTime != nil && !r.ExpirationTime.IsZero() {
+			expires = formatTime(*r.ExpirationTime)
+			if r.ExpirationTime.Before(time.Now()) {
+				expires += " (expired)"
+			}
+		}
+
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%d|%d|%d|%s",
+			limit(r.ID, length),
+			r.Namespace,
+			r.NodePool,
+			cpu,
+			memory,
+			disk,
+			expires,
+		)
+	}
+	return formatList(out)
+}
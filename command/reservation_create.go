@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	flagHelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+type ReservationCreateCommand struct {
+	Meta
+}
+
+func (c *ReservationCreateCommand) Name() string {
+	return "reservation create"
+}
+
+func (c *ReservationCreateCommand) Synopsis() string {
+	return "Create a capacity reservation"
+}
+
+func (c *ReservationCreateCommand) Help() string {
+	helpText := `
+Usage: nomad reservation create [options]
+
+  Create is used to reserve capacity for a namespace on every node of a node
+  pool matching the constraints of the reservation. Allocations of the
+  namespace use the reserved capacity first, while allocations of other
+  namespaces see the reserved capacity the namespace doesn't use as used.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Create Options:
+
+  -node-pool=<pool>
+    The node pool of the nodes holding the reservation. Defaults to the
+    "default" node pool.
+
+  -cpu=<mhz>
+    The CPU in MHz reserved on each node.
+
+  -memory=<mb>
+    The memory in MB reserved on each node.
+
+  -disk=<mb>
+    The disk in MB reserved on each node.
+
+  -constraint=<attribute>=<value>
+    Restricts the reservation to the nodes with the attribute set to the
+    value, such as "${node.class}=gpu". May be specified multiple times.
+
+  -description=<description>
+    A human-friendly description of the reservation.
+
+  -ttl=<duration>
+    The time after which the reservation expires, such as "72h". Defaults to
+    a reservation that doesn't expire.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationCreateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-node-pool":   nodePoolPredictor(c.Client, nil),
+			"-cpu":         complete.PredictAnything,
+			"-memory":      complete.PredictAnything,
+			"-disk":        complete.PredictAnything,
+			"-constraint":  complete.PredictAnything,
+			"-description": complete.PredictAnything,
+			"-ttl":         complete.PredictAnything,
+		})
+}
+
+func (c *ReservationCreateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ReservationCreateCommand) Run(args []string) int {
+	var pool, description string
+	var cpu, memory, disk int
+	var ttl time.Duration
+	var constraints flagHelper.StringFlag
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&pool, "node-pool", "", "")
+	flags.IntVar(&cpu, "cpu", 0, "")
+	flags.IntVar(&memory, "memory", 0, "")
+	flags.IntVar(&disk, "disk", 0, "")
+	flags.Var(&constraints, "constraint", "")
+	flags.StringVar(&description, "description", "", "")
+	flags.DurationVar(&ttl, "ttl", 0, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments.
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	reservation := &api.Reservation{
+		NodePool:    pool,
+		Description: description,
+		Resources: &api.ReservationResources{
+			CPU:      cpu,
+			MemoryMB: memory,
+			DiskMB:   disk,
+		},
+		ExpirationTTL: ttl,
+	}
+	for _, kv := range constraints {
+		attribute, value, ok := strings.Cut(kv, "=")
+		if !ok || attribute == "" {
+			c.Ui.Error(fmt.Sprintf("Error parsing constraint %q, must be <attribute>=<value>", kv))
+			return 1
+		}
+		reservation.Constraints = append(reservation.Constraints, api.NewConstraint(attribute, "=", value))
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	created, _, err := client.Reservations().Upsert(reservation, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating reservation: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully created reservation %q!", created.ID))
+	return 0
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservationCreateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ReservationCreateCommand{}
+}
+
+func TestReservationCreateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &ReservationCreateCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "extra"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-cpu=500", "-constraint=invalid"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "must be <attribute>=<value>")
+	ui.ErrorWriter.Reset()
+
+	// Reservations must reserve some resources.
+	code = cmd.Run([]string{"-address=" + url})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "must reserve cpu, memory or disk")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url,
+		"-cpu=500", "-memory=256", "-ttl=1h", "-description=headroom",
+		"-constraint=${node.class}=batch",
+	})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "Successfully created reservation")
+
+	reservations, _, err := client.Reservations().List(nil)
+	must.NoError(t, err)
+	must.Len(t, 1, reservations)
+
+	reservation := reservations[0]
+	must.Eq(t, "default", reservation.NodePool)
+	must.Eq(t, "headroom", reservation.Description)
+	must.Eq(t, 500, reservation.Resources.CPU)
+	must.Eq(t, 256, reservation.Resources.MemoryMB)
+	must.NotNil(t, reservation.ExpirationTime)
+	must.Len(t, 1, reservation.Constraints)
+	must.Eq(t, "${node.class}", reservation.Constraints[0].LTarget)
+	must.Eq(t, "batch", reservation.Constraints[0].RTarget)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ReservationDeleteCommand struct {
+	Meta
+}
+
+func (c *ReservationDeleteCommand) Name() string {
+	return "reservation delete"
+}
+
+func (c *ReservationDeleteCommand) Synopsis() string {
+	return "Delete a capacity reservation"
+}
+
+func (c *ReservationDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad reservation delete [options] <id>
+
+  Delete is used to remove a reservation, releasing its capacity to the other
+  namespaces. The ID may be a prefix matching a single reservation.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *ReservationDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ReservationDeleteCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	id := args[0]
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Resolve a prefix to the ID of a single reservation.
+	if len(id) < 36 {
+		reservations, _, err := client.Reservations().PrefixList(id, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying reservations: %s", err))
+			return 1
+		}
+		switch len(reservations) {
+		case 0:
+			c.Ui.Error(fmt.Sprintf("No reservation with prefix %q found", id))
+			return 1
+		case 1:
+			id = reservations[0].ID
+		default:
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple reservations\n\n%s",
+				formatReservationList(reservations, fullId)))
+			return 1
+		}
+	}
+
+	if _, err := client.Reservations().Delete(id, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting reservation: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted reservation %q!", id))
+	return 0
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservationDeleteCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ReservationDeleteCommand{}
+}
+
+func TestReservationDeleteCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &ReservationDeleteCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "abcd"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `No reservation with prefix "abcd" found`)
+	ui.ErrorWriter.Reset()
+
+	reservation, _, err := client.Reservations().Upsert(&api.Reservation{
+		Resources: &api.ReservationResources{MemoryMB: 256},
+	}, nil)
+	must.NoError(t, err)
+
+	code = cmd.Run([]string{"-address=" + url, reservation.ID[:8]})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), reservation.ID)
+
+	reservations, _, err := client.Reservations().List(nil)
+	must.NoError(t, err)
+	must.SliceEmpty(t, reservations)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ReservationListCommand struct {
+	Meta
+}
+
+func (c *ReservationListCommand) Name() string {
+	return "reservation list"
+}
+
+func (c *ReservationListCommand) Synopsis() string {
+	return "List capacity reservations"
+}
+
+func (c *ReservationListCommand) Help() string {
+	helpText := `
+Usage: nomad reservation list [options] [prefix]
+
+  List is used to list the reservations of a namespace, or of all namespaces
+  with the "*" namespace. If an ID prefix is given, only the reservations with
+  IDs starting with the prefix are listed.
+
+  If ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the namespace of the reservations.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+List Options:
+
+  -verbose
+    Display full information.
+
+  -json
+    Output the reservations in JSON format.
+
+  -t
+    Format and display the reservations using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *ReservationListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ReservationListCommand) Run(args []string) int {
+	var verbose, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("This command takes either no arguments or one: <prefix>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	q := &api.QueryOptions{}
+	if len(args) == 1 {
+		q.Prefix = args[0]
+	}
+
+	reservations, _, err := client.Reservations().List(q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing reservations: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, reservations)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(reservations) == 0 {
+		c.Ui.Output("No reservations found")
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+	c.Ui.Output(formatReservationList(reservations, length))
+	return 0
+}
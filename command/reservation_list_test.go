@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservationListCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ReservationListCommand{}
+}
+
+func TestReservationListCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &ReservationListCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "too", "many"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No reservations found")
+	ui.OutputWriter.Reset()
+
+	reservation, _, err := client.Reservations().Upsert(&api.Reservation{
+		Resources: &api.ReservationResources{CPU: 500, DiskMB: 1024},
+	}, nil)
+	must.NoError(t, err)
+
+	code = cmd.Run([]string{"-address=" + url, "-verbose", reservation.ID[:4]})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, reservation.ID)
+	must.StrContains(t, out, "never")
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-json"})
+	must.Zero(t, code)
+
+	var reservations []*api.Reservation
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &reservations))
+	must.Len(t, 1, reservations)
+	must.Eq(t, 1024, reservations[0].Resources.DiskMB)
+}
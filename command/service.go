@@ -31,6 +31,10 @@ Usage: nomad service <subcommand> [options]
 
       $ nomad service delete <service_name> <service_id>
 
+  Interact with the service intentions of the Nomad service mesh:
+
+      $ nomad service intention <subcommand>
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
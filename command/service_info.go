@@ -43,6 +43,9 @@ Service Info Options:
   -verbose
     Display full information.
 
+  -healthy
+    Only display service registrations whose checks are all passing.
+
   -per-page
     How many results to show per page.
 
@@ -75,6 +78,7 @@ func (s *ServiceInfoCommand) AutocompleteFlags() complete.Flags {
 			"-page-token": complete.PredictAnything,
 			"-t":          complete.PredictAnything,
 			"-verbose":    complete.PredictNothing,
+			"-healthy":    complete.PredictNothing,
 		})
 }
 
@@ -84,7 +88,7 @@ func (s *ServiceInfoCommand) Name() string { return "service info" }
 // Run satisfies the cli.Command Run function.
 func (s *ServiceInfoCommand) Run(args []string) int {
 	var (
-		json, verbose           bool
+		json, verbose, healthy  bool
 		perPage                 int
 		tmpl, filter, pageToken string
 	)
@@ -93,6 +97,7 @@ func (s *ServiceInfoCommand) Run(args []string) int {
 	flags.Usage = func() { s.Ui.Output(s.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&healthy, "healthy", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.StringVar(&filter, "filter", "", "")
 	flags.IntVar(&perPage, "per-page", 0, "")
@@ -150,6 +155,9 @@ func (s *ServiceInfoCommand) Run(args []string) int {
 		NextToken: pageToken,
 		Namespace: ns,
 	}
+	if healthy {
+		opts.Params = map[string]string{"healthy": "true"}
+	}
 
 	serviceInfo, qm, err := client.Services().Get(serviceID, &opts)
 	if err != nil {
@@ -228,6 +236,15 @@ func (s *ServiceInfoCommand) formatOutput(jobIDs []string, jobServices map[strin
 	s.Ui.Output(formatList(outputTable))
 }
 
+// formatServiceCheckStatus returns the check status of a service
+// registration, which is empty when the service has no checks.
+func formatServiceCheckStatus(status string) string {
+	if status == "" {
+		return "<none>"
+	}
+	return status
+}
+
 func formatAddress(address string, port int) string {
 	if port == 0 {
 		return address
@@ -249,6 +266,7 @@ func (s *ServiceInfoCommand) formatVerboseOutput(jobIDs []string, jobServices ma
 				fmt.Sprintf("Node ID|%s", service.NodeID),
 				fmt.Sprintf("Datacenter|%s", service.Datacenter),
 				fmt.Sprintf("Address|%v", fmt.Sprintf("%s:%v", service.Address, service.Port)),
+				fmt.Sprintf("Check Status|%s", formatServiceCheckStatus(service.CheckStatus)),
 				fmt.Sprintf("Tags|[%s]\n", strings.Join(service.Tags, ",")),
 			}
 			s.Ui.Output(formatKV(out))
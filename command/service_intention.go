@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+type ServiceIntentionCommand struct {
+	Meta
+}
+
+func (c *ServiceIntentionCommand) Help() string {
+	helpText := `
+Usage: nomad service intention <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with the service intentions
+  of the Nomad service mesh. Intentions allow or deny the connections from a
+  source service to a destination service of the same namespace. Connections
+  that no intention matches are denied.
+
+  Allow a service to connect to another:
+
+      $ nomad service intention create <source> <destination>
+
+  List the intentions:
+
+      $ nomad service intention list
+
+  Delete an intention:
+
+      $ nomad service intention delete <source> <destination>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceIntentionCommand) Name() string { return "service intention" }
+
+func (c *ServiceIntentionCommand) Synopsis() string {
+	return "Interact with service mesh intentions"
+}
+
+func (c *ServiceIntentionCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+// formatServiceIntentionList formats service intentions as a table.
+func formatServiceIntentionList(intentions []*api.ServiceIntention) string {
+	out := make([]string, len(intentions)+1)
+	out[0] = "Namespace|Source|Destination|Action|Description"
+	for i, intention := range intentions {
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			intention.Namespace,
+			intention.SourceService,
+			intention.DestinationService,
+			intention.Action,
+			intention.Description,
+		)
+	}
+	return formatList(out)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ServiceIntentionCreateCommand struct {
+	Meta
+}
+
+func (c *ServiceIntentionCreateCommand) Name() string {
+	return "service intention create"
+}
+
+func (c *ServiceIntentionCreateCommand) Synopsis() string {
+	return "Create or update a service mesh intention"
+}
+
+func (c *ServiceIntentionCreateCommand) Help() string {
+	helpText := `
+Usage: nomad service intention create [options] <source> <destination>
+
+  Create is used to allow or deny the connections from the source service to
+  the destination service in the Nomad service mesh. Either service may be the
+  "*" wildcard to match any service of the namespace. An existing intention
+  between the same services is replaced.
+
+  If ACLs are enabled, this command requires a token with the 'submit-job'
+  capability for the namespace of the intention.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Create Options:
+
+  -deny
+    Deny the connections instead of allowing them.
+
+  -description
+    A human-friendly description of the intention.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceIntentionCreateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-deny":        complete.PredictNothing,
+			"-description": complete.PredictAnything,
+		})
+}
+
+func (c *ServiceIntentionCreateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ServiceIntentionCreateCommand) Run(args []string) int {
+	var deny bool
+	var description string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&deny, "deny", false, "")
+	flags.StringVar(&description, "description", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <source> <destination>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	intention := &api.ServiceIntention{
+		SourceService:      args[0],
+		DestinationService: args[1],
+		Action:             api.ServiceIntentionActionAllow,
+		Description:        description,
+	}
+	if deny {
+		intention.Action = api.ServiceIntentionActionDeny
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.ServiceMeshIntentions().Upsert(intention, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing intention: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully wrote intention: %s %q to %q",
+		intention.Action, intention.SourceService, intention.DestinationService))
+	return 0
+}
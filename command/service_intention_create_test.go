@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceIntentionCreateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ServiceIntentionCreateCommand{}
+}
+
+func TestServiceIntentionCreateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &ServiceIntentionCreateCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "web"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-description=" + strings.Repeat("x", 300), "web", "api"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "invalid intention")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-description=frontend", "web", "api"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), `allow "web" to "api"`)
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-deny", "*", "api"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), `deny "*" to "api"`)
+
+	intentions, _, err := client.ServiceMeshIntentions().List(nil)
+	must.NoError(t, err)
+	must.Len(t, 2, intentions)
+	for _, intention := range intentions {
+		switch intention.SourceService {
+		case "web":
+			must.Eq(t, api.ServiceIntentionActionAllow, intention.Action)
+			must.Eq(t, "frontend", intention.Description)
+		case "*":
+			must.Eq(t, api.ServiceIntentionActionDeny, intention.Action)
+		default:
+			t.Fatalf("unexpected intention from %q", intention.SourceService)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ServiceIntentionDeleteCommand struct {
+	Meta
+}
+
+func (c *ServiceIntentionDeleteCommand) Name() string {
+	return "service intention delete"
+}
+
+func (c *ServiceIntentionDeleteCommand) Synopsis() string {
+	return "Delete a service mesh intention"
+}
+
+func (c *ServiceIntentionDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad service intention delete [options] <source> <destination>
+
+  Delete is used to remove the intention between the source and destination
+  services. Connections that no other intention matches are denied.
+
+  If ACLs are enabled, this command requires a token with the 'submit-job'
+  capability for the namespace of the intention.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceIntentionDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *ServiceIntentionDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ServiceIntentionDeleteCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <source> <destination>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.ServiceMeshIntentions().Delete(args[0], args[1], nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting intention: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted intention from %q to %q", args[0], args[1]))
+	return 0
+}
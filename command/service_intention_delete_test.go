@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceIntentionDeleteCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ServiceIntentionDeleteCommand{}
+}
+
+func TestServiceIntentionDeleteCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &ServiceIntentionDeleteCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "web"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "web", "api"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "intention from web to api not found")
+	ui.ErrorWriter.Reset()
+
+	_, err := client.ServiceMeshIntentions().Upsert(&api.ServiceIntention{
+		SourceService:      "web",
+		DestinationService: "api",
+		Action:             api.ServiceIntentionActionAllow,
+	}, nil)
+	must.NoError(t, err)
+
+	code = cmd.Run([]string{"-address=" + url, "web", "api"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), `Successfully deleted intention from "web" to "api"`)
+
+	intentions, _, err := client.ServiceMeshIntentions().List(nil)
+	must.NoError(t, err)
+	must.SliceEmpty(t, intentions)
+}
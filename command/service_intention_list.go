@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ServiceIntentionListCommand struct {
+	Meta
+}
+
+func (c *ServiceIntentionListCommand) Name() string {
+	return "service intention list"
+}
+
+func (c *ServiceIntentionListCommand) Synopsis() string {
+	return "List service mesh intentions"
+}
+
+func (c *ServiceIntentionListCommand) Help() string {
+	helpText := `
+Usage: nomad service intention list [options]
+
+  List is used to list the service intentions of a namespace, or of all
+  namespaces with the "*" namespace.
+
+  If ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the namespace of the intentions.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+List Options:
+
+  -json
+    Output the intentions in JSON format.
+
+  -t
+    Format and display the intentions using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceIntentionListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *ServiceIntentionListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ServiceIntentionListCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	intentions, _, err := client.ServiceMeshIntentions().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing intentions: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, intentions)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(intentions) == 0 {
+		c.Ui.Output("No intentions found")
+		return 0
+	}
+
+	c.Ui.Output(formatServiceIntentionList(intentions))
+	return 0
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceIntentionListCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ServiceIntentionListCommand{}
+}
+
+func TestServiceIntentionListCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &ServiceIntentionListCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "extra"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No intentions found")
+	ui.OutputWriter.Reset()
+
+	_, err := client.ServiceMeshIntentions().Upsert(&api.ServiceIntention{
+		SourceService:      "web",
+		DestinationService: "api",
+		Action:             api.ServiceIntentionActionAllow,
+		Description:        "frontend",
+	}, nil)
+	must.NoError(t, err)
+
+	code = cmd.Run([]string{"-address=" + url})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "web")
+	must.StrContains(t, out, "api")
+	must.StrContains(t, out, "frontend")
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-json"})
+	must.Zero(t, code)
+
+	var intentions []*api.ServiceIntention
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &intentions))
+	must.Len(t, 1, intentions)
+	must.Eq(t, api.ServiceIntentionActionAllow, intentions[0].Action)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/nomad"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
 	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
 )
@@ -90,6 +91,8 @@ func dummyFSM(logger hclog.Logger) (nomadFSM, error) {
 		Blocked:    blockedEvals,
 		Logger:     logger,
 		Region:     "default",
+
+		JobTrackedVersions: structs.JobDefaultTrackedVersions,
 	}
 
 	return nomad.NewFSM(fsmConfig)
@@ -196,6 +199,7 @@ func StateAsMap(store *state.StateStore) map[string][]interface{} {
 		"Allocs":           toArray(store.Allocs(nil, state.SortDefault)),
 		"CSIPlugins":       toArray(store.CSIPlugins(nil)),
 		"IngressPlugins":   toArray(store.IngressPlugins(nil)),
+		"IngressRoutes":    toArray(store.IngressRoutes(nil)),
 		"CSIVolumes":       toArray(store.CSIVolumes(nil)),
 		"Deployments":      toArray(store.Deployments(nil, state.SortDefault)),
 		"Evals":            toArray(store.Evals(nil, state.SortDefault)),
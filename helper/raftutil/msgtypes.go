@@ -65,6 +65,13 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.ACLBindingRulesDeleteRequestType:             "ACLBindingRulesDeleteRequestType",
 	structs.NodePoolUpsertRequestType:                    "NodePoolUpsertRequestType",
 	structs.NodePoolDeleteRequestType:                    "NodePoolDeleteRequestType",
+	structs.IngressPluginDeleteRequestType:               "IngressPluginDeleteRequestType",
+	structs.IngressRouteAckRequestType:                   "IngressRouteAckRequestType",
+	structs.IngressPluginSweepRequestType:                "IngressPluginSweepRequestType",
 	structs.NamespaceUpsertRequestType:                   "NamespaceUpsertRequestType",
 	structs.NamespaceDeleteRequestType:                   "NamespaceDeleteRequestType",
+	structs.ReservationUpsertRequestType:                 "ReservationUpsertRequestType",
+	structs.ReservationDeleteRequestType:                 "ReservationDeleteRequestType",
+	structs.ServiceIntentionUpsertRequestType:            "ServiceIntentionUpsertRequestType",
+	structs.ServiceIntentionDeleteRequestType:            "ServiceIntentionDeleteRequestType",
 }
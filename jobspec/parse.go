@@ -302,6 +302,37 @@ func parseAffinities(result *[]*api.Affinity, list *ast.ObjectList) error {
 	return nil
 }
 
+func parseJobAffinities(result *[]*api.JobAffinity, list *ast.ObjectList) error {
+	for _, o := range list.Elem().Items {
+		// Check for invalid keys
+		valid := []string{
+			"namespace",
+			"job_id",
+			"meta",
+			"attribute",
+			"weight",
+			"required",
+		}
+		if err := checkHCLKeys(o.Val, valid); err != nil {
+			return err
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		var a api.JobAffinity
+		if err := mapstructure.WeakDecode(m, &a); err != nil {
+			return err
+		}
+
+		*result = append(*result, &a)
+	}
+
+	return nil
+}
+
 func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 	for _, o := range list.Elem().Items {
 		// Check for invalid keys
@@ -309,6 +340,7 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 			"attribute",
 			"weight",
 			"target",
+			"max_skew",
 		}
 		if err := checkHCLKeys(o.Val, valid); err != nil {
 			return err
@@ -45,6 +45,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"constraint",
 			"consul",
 			"affinity",
+			"job_affinity",
 			"restart",
 			"meta",
 			"task",
@@ -61,6 +62,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"scaling",
 			"stop_after_client_disconnect",
 			"max_client_disconnect",
+			"gang",
 		}
 		if err := checkHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -74,6 +76,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "constraint")
 		delete(m, "consul")
 		delete(m, "affinity")
+		delete(m, "job_affinity")
 		delete(m, "meta")
 		delete(m, "task")
 		delete(m, "restart")
@@ -124,6 +127,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse job affinities
+		if o := listVal.Filter("job_affinity"); len(o.Items) > 0 {
+			if err := parseJobAffinities(&g.JobAffinities, o); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("'%s', job_affinity ->", n))
+			}
+		}
+
 		// Parse restart policy
 		if o := listVal.Filter("restart"); len(o.Items) > 0 {
 			if err := parseRestartPolicy(&g.RestartPolicy, o); err != nil {
@@ -219,6 +219,11 @@ func TestParse(t *testing.T) {
 									},
 								},
 							},
+							{
+								Attribute: "${meta.rack}",
+								Weight:    int8ToPtr(50),
+								MaxSkew:   1,
+							},
 						},
 						StopAfterClientDisconnect: timeToPtr(120 * time.Second),
 						MaxClientDisconnect:       timeToPtr(120 * time.Hour),
@@ -553,6 +558,33 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"job-affinity.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("cache"),
+						JobAffinities: []*api.JobAffinity{
+							{
+								JobID:  "app",
+								Weight: int8ToPtr(100),
+							},
+							{
+								Namespace: "databases",
+								Meta:      map[string]string{"role": "primary"},
+								Attribute: "${meta.rack}",
+								Weight:    int8ToPtr(-100),
+								Required:  true,
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"periodic-cron.hcl",
 			&api.Job{
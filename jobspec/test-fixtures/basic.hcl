@@ -162,6 +162,12 @@ job "binstore-storagelocker" {
       }
     }
 
+    spread {
+      attribute = "${meta.rack}"
+      weight    = 50
+      max_skew  = 1
+    }
+
     stop_after_client_disconnect = "120s"
     max_client_disconnect        = "120h"
 
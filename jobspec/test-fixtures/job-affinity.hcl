@@ -0,0 +1,22 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "foo" {
+  group "cache" {
+    job_affinity {
+      job_id = "app"
+      weight = 100
+    }
+
+    job_affinity {
+      namespace = "databases"
+      attribute = "${meta.rack}"
+      weight    = -100
+      required  = true
+
+      meta {
+        role = "primary"
+      }
+    }
+  }
+}
@@ -1050,6 +1050,162 @@ func TestParseServiceCheck(t *testing.T) {
 	require.Equal(t, expectedJob, parsedJob)
 }
 
+func TestParseServiceIngress(t *testing.T) {
+	ci.Parallel(t)
+
+	hcl := ` job "group_service_ingress" {
+  group "group" {
+    service {
+      name = "foo-service"
+      port = "http"
+      ingress {
+        hosts      = ["example.com", "*.example.com"]
+        paths      = ["/api"]
+        tls_secret = "certs/example"
+        class      = "haproxy"
+        weight     = 20
+      }
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	expectedJob := &api.Job{
+		ID:   pointer.Of("group_service_ingress"),
+		Name: pointer.Of("group_service_ingress"),
+		TaskGroups: []*api.TaskGroup{
+			{
+				Name: pointer.Of("group"),
+				Services: []*api.Service{
+					{
+						Name:      "foo-service",
+						PortLabel: "http",
+						Ingress: &api.ServiceIngress{
+							Hosts:     []string{"example.com", "*.example.com"},
+							Paths:     []string{"/api"},
+							TLSSecret: "certs/example",
+							Class:     "haproxy",
+							Weight:    pointer.Of(20),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, expectedJob, parsedJob)
+}
+
+func TestParseTaskIngressPluginExternal(t *testing.T) {
+	ci.Parallel(t)
+
+	hcl := ` job "ingress_plugin_external" {
+  group "group" {
+    task "controller" {
+      driver = "docker"
+      ingress_plugin {
+        id             = "aws-alb"
+        nomad_endpoint = "http://127.0.0.1:4646"
+        nomad_token    = ""
+        class          = "external"
+        external {
+          target_group  = "tg-web"
+          drain_timeout = "10s"
+        }
+      }
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, &api.TaskIngressPluginConfig{
+		ID:            "aws-alb",
+		NomadEndpoint: "http://127.0.0.1:4646",
+		Class:         api.ExternalIngressClass,
+		External: &api.ExternalIngressClassConfig{
+			TargetGroup:  "tg-web",
+			DrainTimeout: pointer.Of(10 * time.Second),
+		},
+	}, parsedJob.TaskGroups[0].Tasks[0].IngressPluginConfig)
+}
+
+func TestParseTaskIngressPluginTemplate(t *testing.T) {
+	ci.Parallel(t)
+
+	hcl := ` job "ingress_plugin_template" {
+  group "group" {
+    network {
+      port "http" {}
+    }
+    task "haproxy" {
+      driver = "docker"
+      ingress_plugin {
+        id             = "haproxy"
+        nomad_endpoint = "http://127.0.0.1:4646"
+        nomad_token    = ""
+        class          = "internal"
+        internal {
+          lb_conf_path  = "/usr/local/etc/haproxy"
+          template      = "haproxy"
+          port          = "http"
+          reload_signal = "SIGUSR2"
+        }
+      }
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, &api.TaskIngressPluginConfig{
+		ID:            "haproxy",
+		NomadEndpoint: "http://127.0.0.1:4646",
+		Class:         api.InternalIngressClass,
+		Internal: &api.InternalIngressClassConfig{
+			LoadBalancerConfigurationPath: "/usr/local/etc/haproxy",
+			Template:                      "haproxy",
+			PortLabel:                     "http",
+			ReloadSignal:                  "SIGUSR2",
+		},
+	}, parsedJob.TaskGroups[0].Tasks[0].IngressPluginConfig)
+}
+
+func TestParseGroupGang(t *testing.T) {
+	ci.Parallel(t)
+
+	hcl := `job "gang" {
+  type = "batch"
+  group "mpi" {
+    count = 64
+    gang  = true
+    task "worker" {
+      driver = "docker"
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+	require.Equal(t, pointer.Of(true), parsedJob.TaskGroups[0].Gang)
+}
+
 func TestWaitConfig(t *testing.T) {
 	ci.Parallel(t)
 
@@ -14,6 +14,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/memberlist"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
@@ -199,6 +200,18 @@ type Config struct {
 	// for GC. This gives users some time to debug plugins.
 	CSIPluginGCThreshold time.Duration
 
+	// IngressPluginGCInterval is how often we dispatch a job to GC unused
+	// ingress plugins.
+	IngressPluginGCInterval time.Duration
+
+	// IngressPluginGCThreshold is how "old" an ingress plugin must be to be
+	// eligible for GC. This gives users some time to debug plugins.
+	IngressPluginGCThreshold time.Duration
+
+	// ReservationGCInterval is how often we dispatch a job to GC expired
+	// reservations.
+	ReservationGCInterval time.Duration
+
 	// CSIVolumeClaimGCInterval is how often we dispatch a job to GC
 	// volume claims.
 	CSIVolumeClaimGCInterval time.Duration
@@ -235,6 +248,10 @@ type Config struct {
 	// rekey any variables associated with a key in the Rekeying state
 	VariablesRekeyInterval time.Duration
 
+	// RebalanceInterval is how often we dispatch a job to rebalance the
+	// allocations of node pools, when the rebalancer is enabled
+	RebalanceInterval time.Duration
+
 	// EvalNackTimeout controls how long we allow a sub-scheduler to
 	// work on an evaluation before we consider it failed and Nack it.
 	// This allows that evaluation to be handed to another sub-scheduler
@@ -430,6 +447,49 @@ type Config struct {
 	// If this is not configured the /.well-known/openid-configuration endpoint
 	// will not be available.
 	OIDCIssuer string
+
+	// PlacementPlugins are the placement plugins consulted by the schedulers,
+	// in order, after their builtin feasibility checks and scorers.
+	PlacementPlugins []*PlacementPluginConfig
+
+	// PluginLoader is used to load the placement plugins.
+	PluginLoader loader.PluginCatalog
+}
+
+const (
+	// PlacementPluginFailOpen ignores the placement plugin calls that fail
+	// or time out.
+	PlacementPluginFailOpen = "open"
+
+	// PlacementPluginFailClosed filters the nodes for which a feasibility
+	// check failed or timed out.
+	PlacementPluginFailClosed = "closed"
+
+	// DefaultPlacementPluginTimeout bounds each call to a placement plugin.
+	DefaultPlacementPluginTimeout = 100 * time.Millisecond
+)
+
+// PlacementPluginConfig configures a placement plugin consulted by the
+// schedulers.
+type PlacementPluginConfig struct {
+	// Name is the name of the plugin in the plugin catalog.
+	Name string
+
+	// Timeout bounds each call to the plugin.
+	Timeout time.Duration
+
+	// FailurePolicy is either PlacementPluginFailOpen or
+	// PlacementPluginFailClosed.
+	FailurePolicy string
+}
+
+func (p *PlacementPluginConfig) Copy() *PlacementPluginConfig {
+	if p == nil {
+		return nil
+	}
+
+	np := *p
+	return &np
 }
 
 func (c *Config) Copy() *Config {
@@ -458,6 +518,7 @@ func (c *Config) Copy() *Config {
 	nc.AutopilotConfig = c.AutopilotConfig.Copy()
 	nc.LicenseConfig = c.LicenseConfig.Copy()
 	nc.SearchConfig = c.SearchConfig.Copy()
+	nc.PlacementPlugins = helper.CopySlice(c.PlacementPlugins)
 
 	return &nc
 }
@@ -560,6 +621,9 @@ func DefaultConfig() *Config {
 		DeploymentGCThreshold:            1 * time.Hour,
 		CSIPluginGCInterval:              5 * time.Minute,
 		CSIPluginGCThreshold:             1 * time.Hour,
+		IngressPluginGCInterval:          5 * time.Minute,
+		IngressPluginGCThreshold:         1 * time.Hour,
+		ReservationGCInterval:            5 * time.Minute,
 		CSIVolumeClaimGCInterval:         5 * time.Minute,
 		CSIVolumeClaimGCThreshold:        5 * time.Minute,
 		OneTimeTokenGCInterval:           10 * time.Minute,
@@ -569,6 +633,7 @@ func DefaultConfig() *Config {
 		RootKeyGCThreshold:               1 * time.Hour,
 		RootKeyRotationThreshold:         720 * time.Hour, // 30 days
 		VariablesRekeyInterval:           10 * time.Minute,
+		RebalanceInterval:                5 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
 		EvalNackInitialReenqueueDelay:    1 * time.Second,
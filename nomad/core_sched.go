@@ -14,6 +14,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -56,6 +57,10 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.csiVolumeClaimGC(eval)
 	case structs.CoreJobCSIPluginGC:
 		return c.csiPluginGC(eval)
+	case structs.CoreJobIngressPluginGC:
+		return c.ingressPluginGC(eval)
+	case structs.CoreJobReservationGC:
+		return c.reservationGC(eval)
 	case structs.CoreJobOneTimeTokenGC:
 		return c.expiredOneTimeTokenGC(eval)
 	case structs.CoreJobLocalTokenExpiredGC:
@@ -66,6 +71,8 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.rootKeyRotateOrGC(eval)
 	case structs.CoreJobVariablesRekey:
 		return c.variablesRekey(eval)
+	case structs.CoreJobRebalance:
+		return c.rebalance(eval)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
 	default:
@@ -90,6 +97,12 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	if err := c.csiVolumeClaimGC(eval); err != nil {
 		return err
 	}
+	if err := c.ingressPluginGC(eval); err != nil {
+		return err
+	}
+	if err := c.reservationGC(eval); err != nil {
+		return err
+	}
 	if err := c.expiredOneTimeTokenGC(eval); err != nil {
 		return err
 	}
@@ -785,6 +798,188 @@ func (c *CoreScheduler) csiPluginGC(eval *structs.Evaluation) error {
 	return nil
 }
 
+// ingressPluginGC is used to garbage collect ingress plugins whose
+// controllers and controller jobs are gone. Controllers whose node or
+// allocation is gone, or whose node is down, are swept first.
+func (c *CoreScheduler) ingressPluginGC(eval *structs.Evaluation) error {
+
+	sweepReq := &structs.IngressPluginSweepRequest{
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.Region(),
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	if err := c.srv.RPC("IngressPlugin.Sweep", sweepReq, &structs.GenericResponse{}); err != nil {
+		c.logger.Error("failed to sweep ingress plugins", "error", err)
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+
+	iter, err := c.snap.IngressPlugins(ws)
+	if err != nil {
+		return err
+	}
+
+	oldThreshold := c.getThreshold(eval, "ingress plugin",
+		"ingress_plugin_gc_threshold", c.srv.config.IngressPluginGCThreshold)
+
+	for i := iter.Next(); i != nil; i = iter.Next() {
+		plugin := i.(*structs.IngressPlugin)
+
+		// Ignore new plugins
+		if plugin.CreateIndex > oldThreshold {
+			continue
+		}
+
+		req := &structs.IngressPluginDeleteRequest{ID: plugin.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    c.srv.Region(),
+				AuthToken: eval.LeaderACL,
+			}}
+		err := c.srv.RPC("IngressPlugin.Delete", req, &structs.IngressPluginDeleteResponse{})
+		if err != nil {
+			if strings.Contains(err.Error(), "plugin in use") {
+				continue
+			}
+			c.logger.Error("failed to GC ingress plugin", "plugin_id", plugin.ID, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// rebalance is used to migrate allocations off the nodes imbalancing their
+// node pool, when the rebalancer is enabled. Allocations are migrated like
+// when draining nodes, with an evaluation for each of their jobs.
+func (c *CoreScheduler) rebalance(eval *structs.Evaluation) error {
+	_, schedConfig, err := c.snap.SchedulerConfig()
+	if err != nil {
+		return err
+	}
+	if schedConfig == nil || !schedConfig.RebalanceConfig.Enabled {
+		return nil
+	}
+
+	moves, err := rebalancePlan(c.snap, schedConfig)
+	if err != nil {
+		return err
+	}
+	return c.rebalanceMoves(moves, eval.LeaderACL)
+}
+
+// rebalanceMoves marks the allocations of the moves for migration and creates
+// an evaluation for each of their jobs.
+func (c *CoreScheduler) rebalanceMoves(moves []*structs.RebalanceMove, leaderACL string) error {
+	transitions := make(map[string]*structs.DesiredTransition, len(moves))
+	jobs := make(map[structs.NamespacedID]*structs.Job)
+	var evals []*structs.Evaluation
+	now := time.Now().UTC().UnixNano()
+	for _, move := range moves {
+		id := structs.NamespacedID{ID: move.JobID, Namespace: move.Namespace}
+		job, seen := jobs[id]
+		if !seen {
+			var err error
+			job, err = c.snap.JobByID(nil, move.Namespace, move.JobID)
+			if err != nil {
+				return err
+			}
+
+			// Skip the moves of jobs that were purged or stopped since the
+			// plan was computed, as there is nothing to reschedule them.
+			if job != nil && job.Stop {
+				job = nil
+			}
+			jobs[id] = job
+		}
+		if job == nil {
+			continue
+		}
+
+		transitions[move.AllocID] = &structs.DesiredTransition{
+			Migrate:   pointer.Of(true),
+			Rebalance: pointer.Of(true),
+		}
+		if seen {
+			continue
+		}
+
+		evals = append(evals, &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   job.Namespace,
+			Priority:    job.Priority,
+			Type:        job.Type,
+			TriggeredBy: structs.EvalTriggerRebalance,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		})
+	}
+
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	c.logger.Debug("rebalancing allocations", "allocs", len(transitions), "jobs", len(evals))
+
+	req := &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs: transitions,
+		Evals:  evals,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.Region(),
+			AuthToken: leaderACL,
+		},
+	}
+	if err := c.srv.RPC("Alloc.UpdateDesiredTransition", req, &structs.GenericResponse{}); err != nil {
+		c.logger.Error("failed to migrate allocations", "error", err)
+		return err
+	}
+	return nil
+}
+
+// reservationGC is used to garbage collect expired reservations
+func (c *CoreScheduler) reservationGC(eval *structs.Evaluation) error {
+
+	ws := memdb.NewWatchSet()
+
+	iter, err := c.snap.Reservations(ws)
+	if err != nil {
+		return err
+	}
+
+	// Collect the expired reservations of each namespace
+	now := time.Now().UTC()
+	expired := map[string][]string{}
+	for i := iter.Next(); i != nil; i = iter.Next() {
+		reservation := i.(*structs.Reservation)
+		if reservation.IsExpired(now) {
+			expired[reservation.Namespace] = append(expired[reservation.Namespace], reservation.ID)
+		}
+	}
+
+	for namespace, ids := range expired {
+		c.logger.Debug("reservation GC found eligible reservations",
+			"namespace", namespace, "reservations", len(ids))
+
+		// Deleting the reservations through raft unblocks the evals waiting
+		// on the capacity they held.
+		req := &structs.ReservationDeleteRequest{
+			IDs: ids,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.Region(),
+				Namespace: namespace,
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		if err := c.srv.RPC("Reservation.Delete", req, &structs.GenericResponse{}); err != nil {
+			c.logger.Error("failed to GC reservations", "namespace", namespace, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *CoreScheduler) expiredOneTimeTokenGC(eval *structs.Evaluation) error {
 	req := &structs.OneTimeTokenExpireRequest{
 		WriteRequest: structs.WriteRequest{
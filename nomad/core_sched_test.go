@@ -2240,6 +2240,95 @@ func TestCoreScheduler_CSIPluginGC(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCoreScheduler_IngressPluginGC(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSRV := TestServer(t, nil)
+	defer cleanupSRV()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	srv.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	store := srv.fsm.State()
+	alloc := testIngressPluginController(t, store, "foo")
+
+	// Update the time tables to make this work
+	tt := srv.fsm.TimeTable()
+	index := uint64(2000)
+	tt.Witness(index, time.Now().UTC().Add(-1*srv.config.IngressPluginGCThreshold))
+
+	// Create a core scheduler
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+
+	// Attempt the GC
+	index++
+	gc := srv.coreJobEval(structs.CoreJobIngressPluginGC, index)
+	must.NoError(t, core.Process(gc))
+
+	// Should not be gone (controller running)
+	plug, err := store.IngressPluginByID(nil, "foo")
+	must.NoError(t, err)
+	must.NotNil(t, plug)
+
+	// Stop the controller without updating the node fingerprint
+	complete := alloc.Copy()
+	complete.ClientStatus = structs.AllocClientStatusComplete
+	index++
+	must.NoError(t, store.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index, []*structs.Allocation{complete}))
+
+	// Retry
+	index++
+	gc = srv.coreJobEval(structs.CoreJobIngressPluginGC, index)
+	must.NoError(t, core.Process(gc))
+
+	// Should be gone
+	plug, err = store.IngressPluginByID(nil, "foo")
+	must.NoError(t, err)
+	must.Nil(t, plug)
+}
+
+func TestCoreScheduler_IngressPluginGC_Sweep(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSRV := TestServer(t, nil)
+	defer cleanupSRV()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+	alloc := testIngressPluginController(t, store, "foo")
+
+	// Lose the node without its controllers being updated
+	node, err := store.NodeByID(nil, alloc.NodeID)
+	must.NoError(t, err)
+	node = node.Copy()
+	node.Status = structs.NodeStatusDown
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+	index++
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node))
+
+	plug, err := store.IngressPluginByID(nil, "foo")
+	must.NoError(t, err)
+	must.Eq(t, 1, plug.ControllersHealthy)
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+
+	index++
+	gc := srv.coreJobEval(structs.CoreJobIngressPluginGC, index)
+	must.NoError(t, core.Process(gc))
+
+	// The controller is stale but the plugin is kept while it's running
+	plug, err = store.IngressPluginByID(nil, "foo")
+	must.NoError(t, err)
+	must.NotNil(t, plug)
+	must.Eq(t, 0, plug.ControllersHealthy)
+	must.Eq(t, structs.IngressControllerNodeDown, plug.Controllers[node.ID].HealthDescription)
+}
+
 func TestCoreScheduler_CSIVolumeClaimGC(t *testing.T) {
 	srv, shutdown := TestServer(t, func(c *Config) {
 		c.NumSchedulers = 0 // Prevent automatic dequeue
@@ -2837,3 +2926,158 @@ func TestCoreScheduler_ExpiredACLTokenGC_Force(t *testing.T) {
 	tokens = fromIteratorFunc(iter)
 	require.ElementsMatch(t, append(nonExpiredGlobalTokens, nonExpiredLocalTokens...), tokens)
 }
+
+func TestCoreScheduler_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSRV := TestServer(t, nil)
+	defer cleanupSRV()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+	_, allocs := testRebalanceState(t, store, []int{4, 0, 0}, 1)
+	job := allocs[0].Job
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+	config := &structs.SchedulerConfiguration{SchedulerAlgorithm: structs.SchedulerAlgorithmSpread}
+	index++
+	must.NoError(t, store.SchedulerSetConfig(index, config))
+
+	migrating := func() int {
+		allocs, err := store.AllocsByJob(nil, job.Namespace, job.ID, false)
+		must.NoError(t, err)
+		n := 0
+		for _, alloc := range allocs {
+			if alloc.DesiredTransition.ShouldRebalance() {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Nothing is migrated while the rebalancer is disabled
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	index++
+	must.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobRebalance, index)))
+	must.Eq(t, 0, migrating())
+
+	config.RebalanceConfig.Enabled = true
+	index++
+	must.NoError(t, store.SchedulerSetConfig(index, config))
+
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+	core = NewCoreScheduler(srv, snap)
+	index++
+	must.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobRebalance, index)))
+
+	// One allocation is migrated, within max_parallel, and the job is
+	// evaluated
+	must.Eq(t, 1, migrating())
+	evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerRebalance, evals[0].TriggeredBy)
+}
+
+func TestCoreScheduler_RebalanceMoves_SkipStoppedJobs(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSRV := TestServer(t, nil)
+	defer cleanupSRV()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+	_, purgedAllocs := testRebalanceState(t, store, []int{1}, 1)
+	_, stoppedAllocs := testRebalanceState(t, store, []int{1}, 1)
+	_, runningAllocs := testRebalanceState(t, store, []int{1}, 1)
+
+	// Purge and stop the jobs after their moves were planned
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+	purged := purgedAllocs[0].Job
+	index++
+	must.NoError(t, store.DeleteJob(index, purged.Namespace, purged.ID))
+
+	stopped := stoppedAllocs[0].Job.Copy()
+	stopped.Stop = true
+	index++
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, stopped))
+
+	var moves []*structs.RebalanceMove
+	for _, alloc := range []*structs.Allocation{purgedAllocs[0], stoppedAllocs[0], runningAllocs[0]} {
+		moves = append(moves, &structs.RebalanceMove{
+			AllocID:   alloc.ID,
+			Namespace: alloc.Namespace,
+			JobID:     alloc.JobID,
+			TaskGroup: alloc.TaskGroup,
+			NodeID:    alloc.NodeID,
+		})
+	}
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(srv, snap).(*CoreScheduler)
+	must.NoError(t, core.rebalanceMoves(moves, ""))
+
+	// Only the allocation of the running job is migrated and evaluated
+	for _, alloc := range []*structs.Allocation{purgedAllocs[0], stoppedAllocs[0], runningAllocs[0]} {
+		out, err := store.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		must.Eq(t, alloc == runningAllocs[0], out.DesiredTransition.ShouldRebalance())
+
+		evals, err := store.EvalsByJob(nil, alloc.Namespace, alloc.JobID)
+		must.NoError(t, err)
+		if alloc == runningAllocs[0] {
+			must.Len(t, 1, evals)
+		} else {
+			must.Len(t, 0, evals)
+		}
+	}
+}
+
+func TestCoreScheduler_ReservationGC(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSRV := TestServer(t, nil)
+	defer cleanupSRV()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+
+	active := &structs.Reservation{
+		Namespace:     structs.DefaultNamespace,
+		Resources:     &structs.ReservationResources{CPU: 1000},
+		ExpirationTTL: time.Hour,
+	}
+	expired := &structs.Reservation{
+		Namespace: structs.DefaultNamespace,
+		Resources: &structs.ReservationResources{CPU: 1000},
+	}
+	active.Canonicalize()
+	expired.Canonicalize()
+	expired.ExpirationTime = pointer.Of(time.Now().UTC().Add(-time.Minute))
+
+	index := uint64(1000)
+	must.NoError(t, store.UpsertReservations(structs.MsgTypeTestSetup, index,
+		[]*structs.Reservation{active, expired}))
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+
+	index++
+	gc := srv.coreJobEval(structs.CoreJobReservationGC, index)
+	must.NoError(t, core.Process(gc))
+
+	out, err := store.ReservationByID(nil, structs.DefaultNamespace, expired.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	out, err = store.ReservationByID(nil, structs.DefaultNamespace, active.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+}
@@ -123,6 +123,34 @@ func (m *MockRaftApplierShim) NodesDrainComplete(
 	return index, err
 }
 
+// NodeDrainStart mocks a write to raft as a state store update
+func (m *MockRaftApplierShim) NodeDrainStart(nodeID string, drain *structs.DrainStrategy,
+	meta map[string]string, event *structs.NodeEvent) (uint64, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	index, _ := m.state.LatestIndex()
+	index++
+	err := m.state.UpdateNodeDrain(structs.MsgTypeTestSetup, index, nodeID,
+		drain, false, time.Now().Unix(), event, meta, "")
+	return index, err
+}
+
+// NodeEligibilityUpdate mocks a write to raft as a state store update
+func (m *MockRaftApplierShim) NodeEligibilityUpdate(nodeID, eligibility string,
+	event *structs.NodeEvent) (uint64, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	index, _ := m.state.LatestIndex()
+	index++
+	err := m.state.UpdateNodeEligibility(structs.MsgTypeTestSetup, index, nodeID,
+		eligibility, time.Now().Unix(), event)
+	return index, err
+}
+
 func testNodeDrainWatcher(t *testing.T) (*nodeDrainWatcher, *state.StateStore, *NodeDrainer) {
 	t.Helper()
 	store := state.TestStateStore(t)
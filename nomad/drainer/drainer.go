@@ -49,6 +49,8 @@ const (
 type RaftApplier interface {
 	AllocUpdateDesiredTransition(allocs map[string]*structs.DesiredTransition, evals []*structs.Evaluation) (uint64, error)
 	NodesDrainComplete(nodes []string, event *structs.NodeEvent) (uint64, error)
+	NodeDrainStart(nodeID string, drain *structs.DrainStrategy, meta map[string]string, event *structs.NodeEvent) (uint64, error)
+	NodeEligibilityUpdate(nodeID, eligibility string, event *structs.NodeEvent) (uint64, error)
 }
 
 // NodeTracker is the interface to notify an object that is tracking draining
@@ -199,6 +201,7 @@ func (n *NodeDrainer) SetEnabled(enabled bool, state *state.StateStore) {
 	if enabled {
 		n.flush(state)
 		go n.run(n.ctx)
+		go n.watchMaintenanceWindows(n.ctx)
 	} else if !enabled && n.exitFn != nil {
 		n.exitFn()
 	}
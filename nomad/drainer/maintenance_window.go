@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package drainer
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	// maintenanceWindowInterval is the interval at which nodes are checked
+	// for open maintenance windows.
+	maintenanceWindowInterval = 30 * time.Second
+)
+
+const (
+	// NodeDrainEventMaintenanceWindow is used to indicate that a node drain
+	// was started because a maintenance window opened.
+	NodeDrainEventMaintenanceWindow = "Node drain started for maintenance window"
+
+	// NodeDrainMetaMaintenanceWindow is the drain meta key holding the name
+	// of the maintenance window a drain was started for.
+	NodeDrainMetaMaintenanceWindow = "maintenance_window"
+
+	// NodeDrainMetaMaintenanceWindowStart is the drain meta key holding the
+	// time the maintenance window a drain was started for opened.
+	NodeDrainMetaMaintenanceWindowStart = "maintenance_window_start"
+
+	// NodeDrainMetaMaintenanceWindowEnd is the drain meta key holding the
+	// time the maintenance window a drain was started for closes.
+	NodeDrainMetaMaintenanceWindowEnd = "maintenance_window_end"
+
+	// NodeEligibilityEventMaintenanceWindowEnd is used to indicate that a
+	// node drained for a maintenance window was marked eligible again once
+	// the window closed.
+	NodeEligibilityEventMaintenanceWindowEnd = "Node marked as eligible after maintenance window"
+)
+
+// watchMaintenanceWindows periodically starts draining the nodes that entered
+// a maintenance window, and marks the nodes drained for a window that closed
+// eligible again, until the context is cancelled.
+func (n *NodeDrainer) watchMaintenanceWindows(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceWindowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n.startMaintenanceDrains(now)
+		}
+	}
+}
+
+// startMaintenanceDrains drains the ready nodes that are not draining and are
+// in an open maintenance window of their own or of their node pool. The drain
+// deadline is the time the window closes, and a node is only drained once per
+// window occurrence so that operators can cancel the drain. At most
+// max_parallel nodes of a node pool are drained for maintenance windows at
+// once, the others are drained as the drains complete. Nodes whose
+// maintenance drain completed are marked eligible again once the window
+// closes.
+func (n *NodeDrainer) startMaintenanceDrains(now time.Time) {
+	n.l.RLock()
+	store := n.state
+	n.l.RUnlock()
+	if store == nil {
+		return
+	}
+
+	iter, err := store.Nodes(nil)
+	if err != nil {
+		n.logger.Error("failed to list nodes for maintenance windows", "error", err)
+		return
+	}
+
+	// Count the nodes of each pool already drained for maintenance windows
+	// before starting new drains.
+	var nodes []*structs.Node
+	draining := make(map[string]int)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		nodes = append(nodes, node)
+		if node.DrainStrategy != nil && isMaintenanceDrain(node) {
+			draining[node.NodePool]++
+		}
+	}
+
+	poolWindows := make(map[string][]*structs.MaintenanceWindow)
+	for _, node := range nodes {
+		if node.DrainStrategy == nil && isMaintenanceDrain(node) {
+			n.restoreMaintenanceEligibility(node, now)
+		}
+		if node.Status != structs.NodeStatusReady || node.DrainStrategy != nil {
+			continue
+		}
+
+		pw, ok := poolWindows[node.NodePool]
+		if !ok {
+			pool, err := store.NodePoolByName(nil, node.NodePool)
+			if err != nil {
+				n.logger.Error("failed to lookup node pool", "node_pool", node.NodePool, "error", err)
+			}
+			if pool != nil {
+				pw = pool.MaintenanceWindows
+			}
+			poolWindows[node.NodePool] = pw
+		}
+
+		windows := make([]*structs.MaintenanceWindow, 0, len(node.MaintenanceWindows)+len(pw))
+		windows = append(windows, node.MaintenanceWindows...)
+		windows = append(windows, pw...)
+
+		window, start, end, err := structs.OpenMaintenanceWindow(windows, now)
+		if err != nil {
+			n.logger.Error("failed to evaluate maintenance windows", "node_id", node.ID, "error", err)
+			continue
+		}
+		if window == nil {
+			continue
+		}
+
+		// Skip nodes already drained for this occurrence of the window.
+		startStr := start.UTC().Format(time.RFC3339)
+		if node.LastDrain != nil && node.LastDrain.Meta[NodeDrainMetaMaintenanceWindowStart] == startStr {
+			continue
+		}
+
+		if draining[node.NodePool] >= window.EffectiveMaxParallel() {
+			n.logger.Debug("maximum number of nodes drained for maintenance windows reached",
+				"node_id", node.ID, "node_pool", node.NodePool, "maintenance_window", window.Name)
+			continue
+		}
+
+		deadline := end.Sub(now)
+		drain := &structs.DrainStrategy{
+			DrainSpec: structs.DrainSpec{
+				Deadline: deadline,
+			},
+			ForceDeadline: now.Add(deadline),
+			StartedAt:     now,
+		}
+		meta := map[string]string{
+			NodeDrainMetaMaintenanceWindow:      window.Name,
+			NodeDrainMetaMaintenanceWindowStart: startStr,
+			NodeDrainMetaMaintenanceWindowEnd:   end.UTC().Format(time.RFC3339),
+		}
+		event := structs.NewNodeEvent().
+			SetSubsystem(structs.NodeEventSubsystemDrain).
+			SetMessage(NodeDrainEventMaintenanceWindow).
+			AddDetail(NodeDrainMetaMaintenanceWindow, window.Name)
+
+		n.logger.Info("draining node for maintenance window",
+			"node_id", node.ID, "maintenance_window", window.Name, "deadline", deadline)
+		if _, err := n.raft.NodeDrainStart(node.ID, drain, meta, event); err != nil {
+			n.logger.Error("failed to start drain for maintenance window", "node_id", node.ID, "error", err)
+			continue
+		}
+		draining[node.NodePool]++
+	}
+}
+
+// restoreMaintenanceEligibility marks a node whose maintenance drain
+// completed eligible again once the window closed. Nodes whose drain was
+// cancelled, or whose eligibility was changed since the drain completed, are
+// left as they are.
+func (n *NodeDrainer) restoreMaintenanceEligibility(node *structs.Node, now time.Time) {
+	if node.SchedulingEligibility != structs.NodeSchedulingIneligible ||
+		node.LastDrain.Status != structs.DrainStatusComplete {
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, node.LastDrain.Meta[NodeDrainMetaMaintenanceWindowEnd])
+	if err != nil || now.Before(end) {
+		return
+	}
+
+	// Eligibility changes are recorded as cluster events, including the one
+	// emitted below.
+	for _, event := range node.Events {
+		if event.Subsystem == structs.NodeEventSubsystemCluster &&
+			!event.Timestamp.Before(node.LastDrain.UpdatedAt) {
+			return
+		}
+	}
+
+	window := node.LastDrain.Meta[NodeDrainMetaMaintenanceWindow]
+	event := structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemCluster).
+		SetMessage(NodeEligibilityEventMaintenanceWindowEnd).
+		AddDetail(NodeDrainMetaMaintenanceWindow, window)
+
+	n.logger.Info("marking node eligible after maintenance window",
+		"node_id", node.ID, "maintenance_window", window)
+	if _, err := n.raft.NodeEligibilityUpdate(node.ID, structs.NodeSchedulingEligible, event); err != nil {
+		n.logger.Error("failed to mark node eligible after maintenance window", "node_id", node.ID, "error", err)
+	}
+}
+
+// isMaintenanceDrain returns true if the last drain of the node was started
+// for a maintenance window.
+func isMaintenanceDrain(node *structs.Node) bool {
+	return node.LastDrain != nil && node.LastDrain.Meta[NodeDrainMetaMaintenanceWindowStart] != ""
+}
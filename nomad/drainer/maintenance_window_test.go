@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TestNodeDrainer_StartMaintenanceDrains tests that nodes in an open
+// maintenance window of their own or of their node pool are drained once per
+// window occurrence.
+func TestNodeDrainer_StartMaintenanceDrains(t *testing.T) {
+	ci.Parallel(t)
+	_, store, drainer := testNodeDrainWatcher(t)
+
+	window := &structs.MaintenanceWindow{
+		Name:     "nightly",
+		Cron:     "0 2 * * *",
+		Duration: 4 * time.Hour,
+	}
+	pool := mock.NodePool()
+	pool.MaintenanceWindows = []*structs.MaintenanceWindow{window}
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 100, []*structs.NodePool{pool}))
+
+	n1, n2, n3 := mock.Node(), mock.Node(), mock.Node()
+	n1.MaintenanceWindows = []*structs.MaintenanceWindow{window}
+	n2.NodePool = pool.Name
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 101, n1))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 102, n2))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 103, n3))
+
+	// Nothing is drained outside of the window
+	drainer.startMaintenanceDrains(time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC))
+	for _, id := range []string{n1.ID, n2.ID, n3.ID} {
+		node, err := store.NodeByID(nil, id)
+		must.NoError(t, err)
+		must.Nil(t, node.DrainStrategy)
+	}
+
+	// The nodes the window applies to are drained until it closes
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	drainer.startMaintenanceDrains(now)
+	for _, id := range []string{n1.ID, n2.ID} {
+		node, err := store.NodeByID(nil, id)
+		must.NoError(t, err)
+		must.NotNil(t, node.DrainStrategy)
+		must.Eq(t, 3*time.Hour, node.DrainStrategy.Deadline)
+		must.Eq(t, "nightly", node.LastDrain.Meta[NodeDrainMetaMaintenanceWindow])
+		must.Eq(t, "2024-05-01T02:00:00Z", node.LastDrain.Meta[NodeDrainMetaMaintenanceWindowStart])
+		must.Eq(t, NodeDrainEventMaintenanceWindow, node.Events[len(node.Events)-1].Message)
+	}
+	node, err := store.NodeByID(nil, n3.ID)
+	must.NoError(t, err)
+	must.Nil(t, node.DrainStrategy)
+
+	// A drain cancelled during the window is not started again
+	must.NoError(t, store.UpdateNodeDrain(structs.MsgTypeTestSetup, 200, n1.ID,
+		nil, false, now.Unix(), nil, nil, ""))
+	drainer.startMaintenanceDrains(now.Add(time.Hour))
+	node, err = store.NodeByID(nil, n1.ID)
+	must.NoError(t, err)
+	must.Nil(t, node.DrainStrategy)
+
+	// The next occurrence of the window drains the node again
+	drainer.startMaintenanceDrains(now.Add(24 * time.Hour))
+	node, err = store.NodeByID(nil, n1.ID)
+	must.NoError(t, err)
+	must.NotNil(t, node.DrainStrategy)
+	must.Eq(t, "2024-05-02T02:00:00Z", node.LastDrain.Meta[NodeDrainMetaMaintenanceWindowStart])
+}
+
+// TestNodeDrainer_StartMaintenanceDrains_MaxParallel tests that at most
+// max_parallel nodes of a node pool are drained for maintenance windows at
+// once, and that nodes are marked eligible again once the window closes.
+func TestNodeDrainer_StartMaintenanceDrains_MaxParallel(t *testing.T) {
+	ci.Parallel(t)
+	_, store, drainer := testNodeDrainWatcher(t)
+
+	pool := mock.NodePool()
+	pool.MaintenanceWindows = []*structs.MaintenanceWindow{{
+		Name:     "nightly",
+		Cron:     "0 2 * * *",
+		Duration: 4 * time.Hour,
+	}}
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 100, []*structs.NodePool{pool}))
+
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node()}
+	for i, node := range nodes {
+		node.NodePool = pool.Name
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(101+i), node))
+	}
+
+	drainingNodes := func() []string {
+		var ids []string
+		for _, node := range nodes {
+			out, err := store.NodeByID(nil, node.ID)
+			must.NoError(t, err)
+			if out.DrainStrategy != nil {
+				ids = append(ids, out.ID)
+			}
+		}
+		return ids
+	}
+
+	// Only one node of the pool is drained at once by default
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	drainer.startMaintenanceDrains(now)
+	first := drainingNodes()
+	must.Len(t, 1, first)
+
+	drainer.startMaintenanceDrains(now.Add(time.Minute))
+	must.Eq(t, first, drainingNodes())
+
+	// The next node is drained once the drain completes
+	_, err := drainer.raft.NodesDrainComplete(first, nil)
+	must.NoError(t, err)
+	drainer.startMaintenanceDrains(now.Add(2 * time.Minute))
+	second := drainingNodes()
+	must.Len(t, 1, second)
+	must.NotEq(t, first, second)
+
+	// Nodes aren't marked eligible while the window is open
+	node, err := store.NodeByID(nil, first[0])
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeSchedulingIneligible, node.SchedulingEligibility)
+
+	// Once it closes, the nodes whose drain completed are marked eligible
+	// again, unless their eligibility was changed since.
+	_, err = drainer.raft.NodesDrainComplete(second, nil)
+	must.NoError(t, err)
+	_, err = drainer.raft.NodeEligibilityUpdate(second[0], structs.NodeSchedulingIneligible,
+		structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster))
+	must.NoError(t, err)
+
+	drainer.startMaintenanceDrains(now.Add(4 * time.Hour))
+	node, err = store.NodeByID(nil, first[0])
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeSchedulingEligible, node.SchedulingEligibility)
+	must.Eq(t, NodeEligibilityEventMaintenanceWindowEnd, node.Events[len(node.Events)-1].Message)
+
+	node, err = store.NodeByID(nil, second[0])
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeSchedulingIneligible, node.SchedulingEligibility)
+
+	// Eligible nodes aren't updated again
+	index, err := store.Index("nodes")
+	must.NoError(t, err)
+	drainer.startMaintenanceDrains(now.Add(5 * time.Hour))
+	after, err := store.Index("nodes")
+	must.NoError(t, err)
+	must.Eq(t, index, after)
+}
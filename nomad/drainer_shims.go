@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -35,6 +36,54 @@ func (d drainerShim) NodesDrainComplete(nodes []string, event *structs.NodeEvent
 	return index, err
 }
 
+func (d drainerShim) NodeDrainStart(nodeID string, drain *structs.DrainStrategy, meta map[string]string, event *structs.NodeEvent) (uint64, error) {
+	args := &structs.NodeUpdateDrainRequest{
+		NodeID:        nodeID,
+		DrainStrategy: drain,
+		NodeEvent:     event,
+		Meta:          meta,
+		WriteRequest:  structs.WriteRequest{Region: d.s.config.Region},
+		UpdatedAt:     time.Now().Unix(),
+	}
+	_, index, err := d.s.raftApply(structs.NodeUpdateDrainRequestType, args)
+	return index, err
+}
+
+func (d drainerShim) NodeEligibilityUpdate(nodeID, eligibility string, event *structs.NodeEvent) (uint64, error) {
+	node, err := d.s.State().NodeByID(nil, nodeID)
+	if err != nil {
+		return 0, err
+	}
+	if node == nil {
+		return 0, fmt.Errorf("node %q not found", nodeID)
+	}
+
+	args := &structs.NodeUpdateEligibilityRequest{
+		NodeID:       nodeID,
+		Eligibility:  eligibility,
+		NodeEvent:    event,
+		WriteRequest: structs.WriteRequest{Region: d.s.config.Region},
+		UpdatedAt:    time.Now().Unix(),
+	}
+	resp, index, err := d.s.raftApply(structs.NodeUpdateEligibilityRequestType, args)
+	if err != nil {
+		return 0, err
+	}
+	if err, ok := resp.(error); ok && err != nil {
+		return 0, err
+	}
+
+	// System jobs may need to be placed on the node once it is eligible
+	// again, as done by Node.UpdateEligibility.
+	if node.SchedulingEligibility == structs.NodeSchedulingIneligible &&
+		eligibility == structs.NodeSchedulingEligible {
+		if _, _, err := NewNodeEndpoint(d.s, nil).createNodeEvals(node, index); err != nil {
+			return index, err
+		}
+	}
+	return index, nil
+}
+
 func (d drainerShim) AllocUpdateDesiredTransition(allocs map[string]*structs.DesiredTransition, evals []*structs.Evaluation) (uint64, error) {
 	args := &structs.AllocUpdateDesiredTransitionRequest{
 		Allocs:       allocs,
@@ -8,13 +8,20 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io/fs"
+	"math/big"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +31,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	kms "github.com/hashicorp/go-kms-wrapping/v2"
 	"github.com/hashicorp/go-kms-wrapping/v2/aead"
+	"github.com/hashicorp/go-uuid"
 	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/nomad/helper"
@@ -34,6 +42,10 @@ import (
 
 const nomadKeystoreExtension = ".nks.json"
 
+// meshCAValidity is the validity period of the certificate authority of the
+// Nomad service mesh derived from each root key.
+const meshCAValidity = 10 * 365 * 24 * time.Hour
+
 type claimSigner interface {
 	SignClaims(*structs.IdentityClaims) (string, string, error)
 }
@@ -507,6 +519,121 @@ func (e *Encrypter) GetPublicKey(keyID string) (*structs.KeyringPublicKey, error
 	return pubKey, nil
 }
 
+// meshCACertificate returns the DER encoded certificate of the Nomad service
+// mesh certificate authority of the keyset. The certificate is self-signed by
+// the ed25519 key of the keyset, and all its fields are derived from the root
+// key, so every server of the cluster builds the same certificate.
+func (ks *keyset) meshCACertificate() ([]byte, error) {
+	meta := ks.rootKey.Meta
+	keyID, err := uuid.ParseUUID(meta.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key ID %q: %w", meta.KeyID, err)
+	}
+
+	notBefore := time.Unix(0, meta.CreateTime).UTC().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber: new(big.Int).SetBytes(keyID),
+		Subject: pkix.Name{
+			CommonName: "Nomad Mesh CA " + meta.KeyID,
+		},
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: structs.MeshTrustDomain}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(meshCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	pub := ks.eddsaPrivateKey.Public()
+	return x509.CreateCertificate(nil, template, template, pub, ks.eddsaPrivateKey)
+}
+
+// MeshCACertificates returns the PEM encoded certificates of the Nomad
+// service mesh certificate authorities of all the keys of the keyring, so
+// certificates signed by keys that were rotated out remain trusted until the
+// keys are removed.
+func (e *Encrypter) MeshCACertificates() ([]string, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	keyIDs := make([]string, 0, len(e.keyring))
+	for keyID := range e.keyring {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+
+	certs := make([]string, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		der, err := e.keyring[keyID].meshCACertificate()
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	}
+	return certs, nil
+}
+
+// SignMeshCertificate signs the PEM encoded certificate signing request with
+// the active key of the keyring, and returns the PEM encoded certificate
+// identifying the service of the namespace in the Nomad service mesh along
+// with its expiration time. The subject and extensions of the request are
+// ignored; only its public key is used.
+func (e *Encrypter) SignMeshCertificate(csrPEM, namespace, service string) (string, time.Time, error) {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return "", time.Time{}, errors.New("failed to decode certificate signing request")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to parse certificate signing request: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid certificate signing request signature: %w", err)
+	}
+
+	ks, err := e.activeKeySet()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	caDER, err := ks.meshCACertificate()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	notAfter := now.Add(structs.MeshCertificateTTL)
+	if notAfter.After(ca.NotAfter) {
+		notAfter = ca.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName: service,
+		},
+		URIs:        []*url.URL{structs.MeshServiceURI(namespace, service)},
+		NotBefore:   now.Add(-time.Minute),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, csr.PublicKey, ks.eddsaPrivateKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), notAfter, nil
+}
+
 // newKMSWrapper returns a go-kms-wrapping interface the caller can use to
 // encrypt the RootKey with a key encryption key (KEK). This is a bit of
 // security theatre for local on-disk key material, but gives us a shim for
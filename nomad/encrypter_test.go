@@ -6,8 +6,10 @@ package nomad
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -492,6 +494,50 @@ func TestEncrypter_SignVerify_AlgNone(t *testing.T) {
 	must.Nil(t, got)
 }
 
+// TestEncrypter_SignMeshCertificate asserts mesh certificates are signed by
+// the mesh certificate authority of the active key, and that the certificate
+// authorities are derived deterministically from the keys.
+func TestEncrypter_SignMeshCertificate(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	e := srv.encrypter
+
+	certPEM, expiration, err := e.SignMeshCertificate(testMeshCSR(t), "prod", "api")
+	must.NoError(t, err)
+	must.True(t, expiration.After(time.Now().Add(structs.MeshCertificateTTL-time.Minute)))
+
+	caPEMs, err := e.MeshCACertificates()
+	must.NoError(t, err)
+	must.Len(t, 1, caPEMs)
+
+	again, err := e.MeshCACertificates()
+	must.NoError(t, err)
+	must.Eq(t, caPEMs, again)
+
+	roots := x509.NewCertPool()
+	must.True(t, roots.AppendCertsFromPEM([]byte(caPEMs[0])))
+
+	block, _ := pem.Decode([]byte(certPEM))
+	must.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	must.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	must.NoError(t, err)
+	must.Len(t, 1, cert.URIs)
+	must.Eq(t, "spiffe://nomad/ns/prod/svc/api", cert.URIs[0].String())
+
+	_, _, err = e.SignMeshCertificate("not a csr", "prod", "api")
+	must.ErrorContains(t, err, "failed to decode certificate signing request")
+}
+
 // TestEncrypter_Upgrade17 simulates upgrading from 1.6 -> 1.7 does not break
 // old (ed25519) or new (rsa) signing keys.
 func TestEncrypter_Upgrade17(t *testing.T) {
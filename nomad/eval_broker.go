@@ -46,6 +46,10 @@ var (
 // to only dequeue work they know how to handle. The broker is designed to be entirely
 // in-memory and is managed by the leader node.
 //
+// When fair share is enabled, evaluations of equal priority are dequeued by
+// weighted fair queueing across namespaces, so that a namespace creating many
+// evaluations cannot starve the other namespaces.
+//
 // The broker must provide at-least-once delivery semantics. It relies on explicit
 // Ack/Nack messages to handle this. If a delivery is not Ack'd in a sufficient time
 // span, it will be assumed Nack'd.
@@ -73,8 +77,12 @@ type EvalBroker struct {
 	// now safe for the Eval.Ack RPC to cancel in batches
 	cancelable []*structs.Evaluation
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]ReadyEvaluations
+	// ready tracks the ready jobs by scheduler in priority queues per
+	// namespace
+	ready map[string]*readyQueue
+
+	// fairShare configures the sharing of the schedulers between namespaces
+	fairShare structs.FairShareConfig
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -151,7 +159,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		jobEvals:             make(map[structs.NamespacedID]string),
 		pending:              make(map[structs.NamespacedID]PendingEvaluations),
 		cancelable:           make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest),
-		ready:                make(map[string]ReadyEvaluations),
+		ready:                make(map[string]*readyQueue),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -162,6 +170,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
@@ -197,6 +206,13 @@ func (b *EvalBroker) SetEnabled(enabled bool) {
 	b.enabledNotifier.Notify("eval broker enabled status changed to " + strconv.FormatBool(enabled))
 }
 
+// SetFairShare sets how the broker shares the schedulers between namespaces.
+func (b *EvalBroker) SetFairShare(config structs.FairShareConfig) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.fairShare = config.Copy()
+}
+
 // Enqueue is used to enqueue a new evaluation
 func (b *EvalBroker) Enqueue(eval *structs.Evaluation) {
 	b.l.Lock()
@@ -314,21 +330,22 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string) {
 		heap.Push(&pending, eval)
 		b.pending[namespacedID] = pending
 		b.stats.TotalPending += 1
+		b.namespaceStats(eval.Namespace).Pending += 1
 		return
 	}
 
 	// Find the next ready eval by scheduler class
 	readyQueue, ok := b.ready[sched]
 	if !ok {
-		readyQueue = make([]*structs.Evaluation, 0, 16)
+		readyQueue = newReadyQueue()
+		b.ready[sched] = readyQueue
 		if _, ok := b.waiting[sched]; !ok {
 			b.waiting[sched] = make(chan struct{}, 1)
 		}
 	}
 
 	// Push onto the heap
-	heap.Push(&readyQueue, eval)
-	b.ready[sched] = readyQueue
+	readyQueue.push(eval)
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -338,6 +355,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string) {
 		b.stats.ByScheduler[sched] = bySched
 	}
 	bySched.Ready += 1
+	b.namespaceStats(eval.Namespace).Ready += 1
 
 	// Unblock any pending dequeues
 	select {
@@ -408,7 +426,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		}
 
 		// Peek at the next item
-		ready := readyQueue.Peek()
+		ready := readyQueue.peek(&b.fairShare)
 		if ready == nil {
 			continue
 		}
@@ -447,10 +465,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
-	readyQueue := b.ready[sched]
-	raw := heap.Pop(&readyQueue)
-	b.ready[sched] = readyQueue
-	eval := raw.(*structs.Evaluation)
+	eval := b.ready[sched].pop(&b.fairShare)
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -476,6 +491,7 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	b.namespaceStats(eval.Namespace).Ready -= 1
 
 	return eval, token, nil
 }
@@ -603,12 +619,14 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 		b.cancelable = append(b.cancelable, cancelable...)
 		b.stats.TotalCancelable = len(b.cancelable)
 		b.stats.TotalPending -= len(cancelable)
+		b.namespaceStats(namespacedID.Namespace).Pending -= len(cancelable)
 
 		// If any remain, enqueue an eval
 		if len(pending) > 0 {
 			raw := heap.Pop(&pending)
 			eval := raw.(*structs.Evaluation)
 			b.stats.TotalPending -= 1
+			b.namespaceStats(eval.Namespace).Pending -= 1
 			b.enqueueLocked(eval, eval.Type)
 		}
 
@@ -759,11 +777,12 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalCancelable = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.pending = make(map[structs.NamespacedID]PendingEvaluations)
 	b.cancelable = make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest)
-	b.ready = make(map[string]ReadyEvaluations)
+	b.ready = make(map[string]*readyQueue)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -846,6 +865,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -864,9 +884,24 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for ns, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		stats.ByNamespace[ns] = &subStatCopy
+	}
 	return stats
 }
 
+// namespaceStats returns the stats of the namespace, creating them if needed.
+// It must be called with the lock held.
+func (b *EvalBroker) namespaceStats(namespace string) *NamespaceStats {
+	byNS, ok := b.stats.ByNamespace[namespace]
+	if !ok {
+		byNS = &NamespaceStats{}
+		b.stats.ByNamespace[namespace] = byNS
+	}
+	return byNS
+}
+
 // Cancelable retrieves a batch of previously-pending evaluations that are now
 // stale and ready to mark for canceling. The eval RPC will call this with a
 // batch size set to avoid sending overly large raft messages.
@@ -914,6 +949,11 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for ns, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: ns}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "pending"}, float32(nsStats.Pending), labels)
+			}
 
 		case <-stopCh:
 			return
@@ -930,6 +970,7 @@ type BrokerStats struct {
 	TotalCancelable int
 	DelayedEvals    map[string]*structs.Evaluation
 	ByScheduler     map[string]*SchedulerStats
+	ByNamespace     map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -938,6 +979,121 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Pending int
+}
+
+// readyQueue is the queue of ready evaluations of a scheduler. Evaluations
+// are queued by namespace, and the next evaluation is the highest priority
+// one. When fair share is enabled, ties in priority between namespaces are
+// broken by the virtual time of the namespaces, which advances by the inverse
+// of the weight of a namespace each time an evaluation is dequeued from it.
+// Otherwise ties are broken by the order of the evaluations.
+type readyQueue struct {
+	// namespaces are the ready evaluations by namespace
+	namespaces map[string]ReadyEvaluations
+
+	// pass is the virtual time of each namespace
+	pass map[string]float64
+
+	// vtime is the virtual time of the queue, which is the virtual time of
+	// the last namespace dequeued from before it was advanced. Namespaces
+	// that become ready start at the virtual time of the queue, so that idle
+	// namespaces do not accumulate credit.
+	vtime float64
+}
+
+func newReadyQueue() *readyQueue {
+	return &readyQueue{
+		namespaces: make(map[string]ReadyEvaluations),
+		pass:       make(map[string]float64),
+	}
+}
+
+// push adds a ready evaluation to the queue.
+func (q *readyQueue) push(eval *structs.Evaluation) {
+	ready := q.namespaces[eval.Namespace]
+	if len(ready) == 0 {
+		q.pass[eval.Namespace] = max(q.pass[eval.Namespace], q.vtime)
+	}
+	heap.Push(&ready, eval)
+	q.namespaces[eval.Namespace] = ready
+}
+
+// peek returns the next evaluation that would be popped, or nil if the queue
+// is empty.
+func (q *readyQueue) peek(fairShare *structs.FairShareConfig) *structs.Evaluation {
+	namespace, ok := q.next(fairShare)
+	if !ok {
+		return nil
+	}
+	return q.namespaces[namespace][0]
+}
+
+// pop removes and returns the next evaluation. The queue must not be empty.
+func (q *readyQueue) pop(fairShare *structs.FairShareConfig) *structs.Evaluation {
+	namespace, _ := q.next(fairShare)
+
+	ready := q.namespaces[namespace]
+	eval := heap.Pop(&ready).(*structs.Evaluation)
+	if len(ready) == 0 {
+		delete(q.namespaces, namespace)
+	} else {
+		q.namespaces[namespace] = ready
+	}
+
+	if fairShare.Enabled {
+		q.vtime = q.pass[namespace]
+		q.pass[namespace] += 1 / float64(fairShare.Weight(namespace))
+		q.prune()
+	}
+	return eval
+}
+
+// prune removes the virtual time of the namespaces without ready evaluations
+// that are not ahead of the queue. They start at the virtual time of the queue
+// once they become ready again, so only the namespaces ahead of it need to
+// keep their virtual time. Once the queue is empty no namespace is waiting
+// on the others, so the virtual times are reset.
+func (q *readyQueue) prune() {
+	if len(q.namespaces) == 0 {
+		q.pass = make(map[string]float64)
+		q.vtime = 0
+		return
+	}
+	for namespace, pass := range q.pass {
+		if _, ok := q.namespaces[namespace]; !ok && pass <= q.vtime {
+			delete(q.pass, namespace)
+		}
+	}
+}
+
+// next returns the namespace of the next evaluation.
+func (q *readyQueue) next(fairShare *structs.FairShareConfig) (string, bool) {
+	var next string
+	var head *structs.Evaluation
+	for namespace, ready := range q.namespaces {
+		eval := ready[0]
+		switch {
+		case head == nil:
+		case eval.Priority != head.Priority:
+			if eval.Priority < head.Priority {
+				continue
+			}
+		case fairShare.Enabled && q.pass[namespace] != q.pass[next]:
+			if q.pass[namespace] > q.pass[next] {
+				continue
+			}
+		case !readyLess(eval, head):
+			continue
+		}
+		next, head = namespace, eval
+	}
+	return next, head != nil
+}
+
 // Len is for the sorting interface
 func (r ReadyEvaluations) Len() int {
 	return len(r)
@@ -947,10 +1103,15 @@ func (r ReadyEvaluations) Len() int {
 // so that the "min" in the min-heap is the element with the
 // highest priority
 func (r ReadyEvaluations) Less(i, j int) bool {
-	if r[i].JobID != r[j].JobID && r[i].Priority != r[j].Priority {
-		return !(r[i].Priority < r[j].Priority)
+	return readyLess(r[i], r[j])
+}
+
+// readyLess returns whether the ready evaluation a is dequeued before b.
+func readyLess(a, b *structs.Evaluation) bool {
+	if a.JobID != b.JobID && a.Priority != b.Priority {
+		return !(a.Priority < b.Priority)
 	}
-	return r[i].CreateIndex < r[j].CreateIndex
+	return a.CreateIndex < b.CreateIndex
 }
 
 // Swap is for the sorting interface
@@ -435,6 +435,7 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 		stats := b.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -1566,6 +1567,7 @@ func TestEvalBroker_IntegrationTest(t *testing.T) {
 		stats := srv.evalBroker.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -1621,3 +1623,142 @@ func TestEvalBroker_IntegrationTest(t *testing.T) {
 	must.Eq(t, BrokerStats{TotalReady: 0, TotalUnacked: 0,
 		TotalPending: 0, TotalCancelable: 0}, getStats())
 }
+
+func TestEvalBroker_FairShare(t *testing.T) {
+	ci.Parallel(t)
+
+	// enqueue enqueues evals of 6 jobs of namespace "flood", followed by evals
+	// of 2 jobs of namespace "quiet", all of equal priority.
+	enqueue := func(b *EvalBroker) {
+		var index uint64
+		for _, ns := range []string{"flood", "flood", "flood", "flood", "flood", "flood", "quiet", "quiet"} {
+			index++
+			eval := mock.Eval()
+			eval.Namespace = ns
+			eval.CreateIndex = index
+			b.Enqueue(eval)
+		}
+	}
+
+	dequeue := func(b *EvalBroker) []string {
+		var namespaces []string
+		for {
+			eval, token, err := b.Dequeue(defaultSched, 5*time.Millisecond)
+			must.NoError(t, err)
+			if eval == nil {
+				return namespaces
+			}
+			namespaces = append(namespaces, eval.Namespace)
+			must.NoError(t, b.Ack(eval.ID, token))
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		b := testBroker(t, 0)
+		b.SetEnabled(true)
+		enqueue(b)
+
+		must.Eq(t, 6, b.Stats().ByNamespace["flood"].Ready)
+		must.Eq(t, 2, b.Stats().ByNamespace["quiet"].Ready)
+		must.Eq(t, []string{"flood", "flood", "flood", "flood", "flood", "flood", "quiet", "quiet"}, dequeue(b))
+		must.Eq(t, 0, b.Stats().ByNamespace["flood"].Ready)
+	})
+
+	t.Run("equal weights", func(t *testing.T) {
+		b := testBroker(t, 0)
+		b.SetFairShare(structs.FairShareConfig{Enabled: true})
+		b.SetEnabled(true)
+		enqueue(b)
+
+		must.Eq(t, []string{"flood", "quiet", "flood", "quiet", "flood", "flood", "flood", "flood"}, dequeue(b))
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		b := testBroker(t, 0)
+		b.SetFairShare(structs.FairShareConfig{
+			Enabled:          true,
+			NamespaceWeights: map[string]int{"flood": 2},
+		})
+		b.SetEnabled(true)
+		enqueue(b)
+
+		must.Eq(t, []string{"flood", "quiet", "flood", "flood", "quiet", "flood", "flood", "flood"}, dequeue(b))
+	})
+
+	t.Run("priority first", func(t *testing.T) {
+		b := testBroker(t, 0)
+		b.SetFairShare(structs.FairShareConfig{Enabled: true})
+		b.SetEnabled(true)
+		enqueue(b)
+
+		eval := mock.Eval()
+		eval.Namespace = "flood"
+		eval.Priority = 90
+		b.Enqueue(eval)
+
+		out, token, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.Eq(t, eval.ID, out.ID)
+		must.NoError(t, b.Ack(out.ID, token))
+	})
+}
+
+func TestReadyQueue_PrunePass(t *testing.T) {
+	ci.Parallel(t)
+
+	fairShare := &structs.FairShareConfig{Enabled: true}
+	q := newReadyQueue()
+	for i, ns := range []string{"a", "a", "a", "b"} {
+		eval := mock.Eval()
+		eval.Namespace = ns
+		eval.CreateIndex = uint64(i + 1)
+		q.push(eval)
+	}
+
+	// The virtual time of an idle namespace is kept while it is ahead of the
+	// queue.
+	must.Eq(t, "a", q.pop(fairShare).Namespace)
+	must.Eq(t, "b", q.pop(fairShare).Namespace)
+	must.MapContainsKey(t, q.pass, "b")
+
+	// It is removed once the queue catches up.
+	must.Eq(t, "a", q.pop(fairShare).Namespace)
+	must.MapNotContainsKey(t, q.pass, "b")
+	must.MapLen(t, 1, q.pass)
+
+	// Virtual times are reset once the queue is empty.
+	must.Eq(t, "a", q.pop(fairShare).Namespace)
+	must.MapEmpty(t, q.pass)
+	must.Zero(t, q.vtime)
+}
+
+func TestEvalBroker_NamespaceStats_Pending(t *testing.T) {
+	ci.Parallel(t)
+
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	eval := mock.Eval()
+	eval.Namespace = "prod"
+	b.Enqueue(eval)
+
+	// A second eval of the same job is pending until the first is acked.
+	eval2 := mock.Eval()
+	eval2.Namespace = "prod"
+	eval2.JobID = eval.JobID
+	eval2.CreateIndex = eval.CreateIndex + 1
+	eval2.ModifyIndex = eval.ModifyIndex + 1
+	b.Enqueue(eval2)
+
+	stats := b.Stats().ByNamespace["prod"]
+	must.Eq(t, 1, stats.Ready)
+	must.Eq(t, 1, stats.Pending)
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.NoError(t, b.Ack(out.ID, token))
+
+	stats = b.Stats().ByNamespace["prod"]
+	must.Eq(t, 1, stats.Ready)
+	must.Eq(t, 0, stats.Pending)
+}
@@ -66,6 +66,9 @@ const (
 	ACLBindingRuleSnapshot               SnapshotType = 27
 	NodePoolSnapshot                     SnapshotType = 28
 	IngressPluginSnapShot                SnapshotType = 29
+	IngressRouteSnapshot                 SnapshotType = 30
+	ReservationSnapshot                  SnapshotType = 31
+	ServiceIntentionSnapshot             SnapshotType = 32
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
 )
@@ -312,10 +315,22 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyCSIPluginDelete(buf[1:], log.Index)
 	case structs.IngressPluginDeleteRequestType:
 		return n.applyIngressPluginDelete(buf[1:], log.Index)
+	case structs.IngressPluginSweepRequestType:
+		return n.applyIngressPluginSweep(msgType, buf[1:], log.Index)
+	case structs.IngressRouteAckRequestType:
+		return n.applyIngressRouteAck(msgType, buf[1:], log.Index)
 	case structs.NamespaceUpsertRequestType:
 		return n.applyNamespaceUpsert(buf[1:], log.Index)
 	case structs.NamespaceDeleteRequestType:
 		return n.applyNamespaceDelete(buf[1:], log.Index)
+	case structs.ReservationUpsertRequestType:
+		return n.applyReservationUpsert(msgType, buf[1:], log.Index)
+	case structs.ReservationDeleteRequestType:
+		return n.applyReservationDelete(msgType, buf[1:], log.Index)
+	case structs.ServiceIntentionUpsertRequestType:
+		return n.applyServiceIntentionUpsert(msgType, buf[1:], log.Index)
+	case structs.ServiceIntentionDeleteRequestType:
+		return n.applyServiceIntentionDelete(msgType, buf[1:], log.Index)
 	// COMPAT(1.0): These messages were added and removed during the 1.0-beta
 	// series and should not be immediately reused for other purposes
 	case structs.EventSinkUpsertRequestType,
@@ -1449,6 +1464,137 @@ func (n *nomadFSM) applyIngressPluginDelete(buf []byte, index uint64) interface{
 	return nil
 }
 
+func (n *nomadFSM) applyIngressPluginSweep(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	var req structs.IngressPluginSweepRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.SweepIngressPlugins(msgType, index); err != nil {
+		n.logger.Error("SweepIngressPlugins failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (n *nomadFSM) applyIngressRouteAck(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_ingress_route_ack"}, time.Now())
+	var req structs.IngressRouteAckRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.AckIngressRoutes(msgType, index, req.Acks); err != nil {
+		n.logger.Error("AckIngressRoutes failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyReservationUpsert is used to upsert a set of reservations
+func (n *nomadFSM) applyReservationUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_reservation_upsert"}, time.Now())
+	var req structs.ReservationUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertReservations(msgType, index, req.Reservations); err != nil {
+		n.logger.Error("UpsertReservations failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyReservationDelete is used to delete a set of reservations
+func (n *nomadFSM) applyReservationDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_reservation_delete"}, time.Now())
+	var req structs.ReservationDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	// Capture the node pools of the reservations before they are removed so
+	// the evals blocked on their capacity can be unblocked.
+	ws := memdb.NewWatchSet()
+	pools := map[string]struct{}{}
+	for _, id := range req.IDs {
+		reservation, err := n.state.ReservationByID(ws, req.RequestNamespace(), id)
+		if err != nil {
+			n.logger.Error("looking up reservation failed", "reservation_id", id, "error", err)
+			return err
+		}
+		if reservation != nil {
+			pools[reservation.NodePool] = struct{}{}
+		}
+	}
+
+	if err := n.state.DeleteReservations(msgType, index, req.RequestNamespace(), req.IDs); err != nil {
+		n.logger.Error("DeleteReservations failed", "error", err)
+		return err
+	}
+
+	// Unblock evals for the nodes that held the released capacity.
+	if err := n.unblockNodePools(pools, index); err != nil {
+		return err
+	}
+	return nil
+}
+
+// unblockNodePools unblocks the evals blocked on the computed node classes and
+// nodes of the given node pools.
+func (n *nomadFSM) unblockNodePools(pools map[string]struct{}, index uint64) error {
+	ws := memdb.NewWatchSet()
+	classes := map[string]struct{}{}
+	for pool := range pools {
+		iter, err := n.state.NodesByNodePool(ws, pool)
+		if err != nil {
+			n.logger.Error("looking up nodes failed", "node_pool", pool, "error", err)
+			return err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			node := raw.(*structs.Node)
+			if _, ok := classes[node.ComputedClass]; !ok {
+				classes[node.ComputedClass] = struct{}{}
+				n.blockedEvals.Unblock(node.ComputedClass, index)
+			}
+			n.blockedEvals.UnblockNode(node.ID, index)
+		}
+	}
+	return nil
+}
+
+// applyServiceIntentionUpsert is used to upsert a set of service intentions
+func (n *nomadFSM) applyServiceIntentionUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_service_intention_upsert"}, time.Now())
+	var req structs.MeshUpsertIntentionsRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertServiceIntentions(msgType, index, req.Intentions); err != nil {
+		n.logger.Error("UpsertServiceIntentions failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyServiceIntentionDelete is used to delete a service intention
+func (n *nomadFSM) applyServiceIntentionDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_service_intention_delete"}, time.Now())
+	var req structs.MeshDeleteIntentionRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteServiceIntention(msgType, index,
+		req.RequestNamespace(), req.SourceService, req.DestinationService); err != nil {
+		n.logger.Error("DeleteServiceIntention failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 // applyNamespaceUpsert is used to upsert a set of namespaces
 func (n *nomadFSM) applyNamespaceUpsert(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_namespace_upsert"}, time.Now())
@@ -1793,6 +1939,39 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				}
 			}
 
+		case IngressRouteSnapshot:
+			route := new(structs.IngressRoute)
+			if err := dec.Decode(route); err != nil {
+				return err
+			}
+			if filter.Include(route) {
+				if err := restore.IngressRouteRestore(route); err != nil {
+					return err
+				}
+			}
+
+		case ReservationSnapshot:
+			reservation := new(structs.Reservation)
+			if err := dec.Decode(reservation); err != nil {
+				return err
+			}
+			if filter.Include(reservation) {
+				if err := restore.ReservationRestore(reservation); err != nil {
+					return err
+				}
+			}
+
+		case ServiceIntentionSnapshot:
+			intention := new(structs.ServiceIntention)
+			if err := dec.Decode(intention); err != nil {
+				return err
+			}
+			if filter.Include(intention) {
+				if err := restore.ServiceIntentionRestore(intention); err != nil {
+					return err
+				}
+			}
+
 		case CSIVolumeSnapshot:
 			volume := new(structs.CSIVolume)
 			if err := dec.Decode(volume); err != nil {
@@ -2459,6 +2638,18 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistIngressRoutes(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistReservations(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistServiceIntentions(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistVariables(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -3026,7 +3217,7 @@ func (s *nomadSnapshot) persistIngressPlugins(sink raft.SnapshotSink,
 		plugin := raw.(*structs.IngressPlugin)
 
 		// Write out a plugin snapshot
-		sink.Write([]byte{byte(IndexSnapshot)})
+		sink.Write([]byte{byte(IngressPluginSnapShot)})
 		if err := encoder.Encode(plugin); err != nil {
 			return err
 		}
@@ -3090,6 +3281,72 @@ func (s *nomadSnapshot) persistServiceRegistrations(sink raft.SnapshotSink,
 	}
 }
 
+func (s *nomadSnapshot) persistIngressRoutes(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the ingress routes.
+	ws := memdb.NewWatchSet()
+	routes, err := s.snap.IngressRoutes(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := routes.Next(); raw != nil; raw = routes.Next() {
+		route := raw.(*structs.IngressRoute)
+
+		// Write out an ingress route snapshot.
+		sink.Write([]byte{byte(IngressRouteSnapshot)})
+		if err := encoder.Encode(route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistReservations(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the reservations.
+	ws := memdb.NewWatchSet()
+	reservations, err := s.snap.Reservations(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := reservations.Next(); raw != nil; raw = reservations.Next() {
+		reservation := raw.(*structs.Reservation)
+
+		// Write out a reservation snapshot.
+		sink.Write([]byte{byte(ReservationSnapshot)})
+		if err := encoder.Encode(reservation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistServiceIntentions(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the service intentions.
+	ws := memdb.NewWatchSet()
+	intentions, err := s.snap.ServiceIntentions(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := intentions.Next(); raw != nil; raw = intentions.Next() {
+		intention := raw.(*structs.ServiceIntention)
+
+		// Write out a service intention snapshot.
+		sink.Write([]byte{byte(ServiceIntentionSnapshot)})
+		if err := encoder.Encode(intention); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistVariables(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 
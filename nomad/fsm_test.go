@@ -2841,6 +2841,156 @@ func TestFSM_SnapshotRestore_ServiceRegistrations(t *testing.T) {
 	require.ElementsMatch(t, restoredRegs, serviceRegs)
 }
 
+func TestFSM_SnapshotRestore_IngressRoutes(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Register a job with an ingress service so a route is materialized.
+	job := mock.Job()
+	job.TaskGroups[0].Services = []*structs.Service{{
+		Name:      "web",
+		PortLabel: "http",
+		Ingress:   &structs.ServiceIngress{Hosts: []string{"example.com"}},
+	}}
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	routes, err := testState.IngressRoutes(memdb.NewWatchSet())
+	must.NoError(t, err)
+	route := routes.Next().(*structs.IngressRoute)
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	restored, err := restoredState.IngressRouteByID(memdb.NewWatchSet(), route.Namespace, route.ID)
+	must.NoError(t, err)
+	must.Eq(t, route, restored)
+}
+
+func TestFSM_SnapshotRestore_Reservations(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	reservation := &structs.Reservation{
+		Namespace: structs.DefaultNamespace,
+		Resources: &structs.ReservationResources{CPU: 1000, MemoryMB: 512},
+	}
+	reservation.Canonicalize()
+	must.NoError(t, testState.UpsertReservations(structs.MsgTypeTestSetup, 10,
+		[]*structs.Reservation{reservation}))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	restored, err := restoredState.ReservationByID(memdb.NewWatchSet(), reservation.Namespace, reservation.ID)
+	must.NoError(t, err)
+	must.Eq(t, reservation, restored)
+}
+
+func TestFSM_DeleteReservations_UnblockEvals(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	fsm.blockedEvals.SetEnabled(true)
+
+	node := mock.Node()
+	must.NoError(t, fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1, node))
+
+	reservation := &structs.Reservation{
+		Namespace: structs.DefaultNamespace,
+		NodePool:  node.NodePool,
+		Resources: &structs.ReservationResources{CPU: 1000, MemoryMB: 512},
+	}
+	reservation.Canonicalize()
+	must.NoError(t, fsm.State().UpsertReservations(structs.MsgTypeTestSetup, 2,
+		[]*structs.Reservation{reservation}))
+
+	// Mark an eval as blocked on the node's class.
+	eval := mock.Eval()
+	eval.ClassEligibility = map[string]bool{node.ComputedClass: true}
+	fsm.blockedEvals.Block(eval)
+
+	req := structs.ReservationDeleteRequest{
+		IDs:          []string{reservation.ID},
+		WriteRequest: structs.WriteRequest{Namespace: structs.DefaultNamespace},
+	}
+	buf, err := structs.Encode(structs.ReservationDeleteRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().ReservationByID(nil, reservation.Namespace, reservation.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// Verify the eval was unblocked.
+	testutil.WaitForResult(func() (bool, error) {
+		bStats := fsm.blockedEvals.Stats()
+		if bStats.TotalBlocked != 0 {
+			return false, fmt.Errorf("bad: %#v", bStats)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+}
+
+func TestFSM_SnapshotRestore_ServiceIntentions(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	intention := &structs.ServiceIntention{
+		Namespace:          structs.DefaultNamespace,
+		SourceService:      "web",
+		DestinationService: "api",
+		Action:             structs.ServiceIntentionActionAllow,
+	}
+	must.NoError(t, testState.UpsertServiceIntentions(structs.MsgTypeTestSetup, 10,
+		[]*structs.ServiceIntention{intention}))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	restored, err := restoredState.ServiceIntentionByName(memdb.NewWatchSet(), intention.Namespace, "web", "api")
+	must.NoError(t, err)
+	must.Eq(t, intention, restored)
+}
+
+func TestFSM_SnapshotRestore_IngressPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	node := mock.Node()
+	info := &structs.IngressInfo{PluginID: "haproxy", AllocID: uuid.Generate()}
+	info.SetHealthy(true)
+	node.IngressPlugins = map[string]*structs.IngressInfo{"haproxy": info}
+	must.NoError(t, testState.UpsertNode(structs.MsgTypeTestSetup, 10, node))
+
+	plug, err := testState.IngressPluginByID(memdb.NewWatchSet(), "haproxy")
+	must.NoError(t, err)
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	restored, err := restoredState.IngressPluginByID(memdb.NewWatchSet(), "haproxy")
+	must.NoError(t, err)
+	must.Eq(t, plug, restored)
+}
+
 func TestFSM_SnapshotRestore_ACLRoles(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2,13 +2,18 @@ package nomad
 
 import (
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-const ingressPluginTable = "ingress_plugin"
+const ingressPluginTable = "ingress_plugins"
 
 type IngressPlugin struct {
 	srv    *Server
@@ -22,9 +27,23 @@ func NewIngressPluginEndpoint(srv *Server, ctx *RPCContext) *IngressPlugin {
 
 func (i *IngressPlugin) List(args *structs.IngressPluginListRequest, reply *structs.IngressPluginListResponse) error {
 
+	authErr := i.srv.Authenticate(i.ctx, args)
 	if done, err := i.srv.forward("IngressPlugin.List", args, args, reply); done {
 		return err
 	}
+	i.srv.MeasureRPCRate("ingress_plugin", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "ingress_plugin", "list"}, time.Now())
+
+	aclObj, err := i.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowPluginList() {
+		return structs.ErrPermissionDenied
+	}
 
 	opts := blockingOptions{
 		queryOpts: &args.QueryOptions,
@@ -33,10 +52,17 @@ func (i *IngressPlugin) List(args *structs.IngressPluginListRequest, reply *stru
 
 			var iter memdb.ResultIterator
 			var err error
-			// Query all plugins
-			iter, err = state.IngressPlugins(ws)
-			if err != nil {
-				return err
+			if args.Prefix != "" {
+				iter, err = state.IngressPluginsByIDPrefix(ws, args.Prefix)
+				if err != nil {
+					return err
+				}
+			} else {
+				// Query all plugins
+				iter, err = state.IngressPlugins(ws)
+				if err != nil {
+					return err
+				}
 			}
 
 			// Collect results
@@ -59,9 +85,26 @@ func (i *IngressPlugin) List(args *structs.IngressPluginListRequest, reply *stru
 
 func (v *IngressPlugin) Get(args *structs.IngressPluginGetRequest, reply *structs.IngressPluginGetResponse) error {
 
+	authErr := v.srv.Authenticate(v.ctx, args)
 	if done, err := v.srv.forward("IngressPlugin.Get", args, args, reply); done {
 		return err
 	}
+	v.srv.MeasureRPCRate("ingress_plugin", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "ingress_plugin", "get"}, time.Now())
+
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowPluginRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	withAllocs := aclObj == nil ||
+		aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob)
 
 	if args.ID == "" {
 		return fmt.Errorf("missing plugin ID")
@@ -85,23 +128,275 @@ func (v *IngressPlugin) Get(args *structs.IngressPluginGetRequest, reply *struct
 				return nil
 			}
 
-			plug, err = snap.IngressPluginDenormalize(ws, plug.Copy())
-			if err != nil {
-				return err
-			}
+			if withAllocs {
+				plug, err = snap.IngressPluginDenormalize(ws, plug.Copy())
+				if err != nil {
+					return err
+				}
 
-			// Filter the allocation stubs by our namespace. withAllocs
-			// means we're allowed
-			var as []*structs.AllocListStub
-			for _, a := range plug.Allocations {
-				if a.Namespace == args.RequestNamespace() {
-					as = append(as, a)
+				// Filter the allocation stubs by our namespace. withAllocs
+				// means we're allowed
+				var as []*structs.AllocListStub
+				for _, a := range plug.Allocations {
+					if a.Namespace == args.RequestNamespace() {
+						as = append(as, a)
+					}
 				}
+				plug.Allocations = as
 			}
-			plug.Allocations = as
 
 			reply.Plugin = plug
 			return v.srv.replySetIndex(ingressPluginTable, &reply.QueryMeta)
 		}}
 	return v.srv.blockingRPC(&opts)
 }
+
+// Delete deletes a plugin if it is unused
+func (v *IngressPlugin) Delete(args *structs.IngressPluginDeleteRequest, reply *structs.IngressPluginDeleteResponse) error {
+
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("IngressPlugin.Delete", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("ingress_plugin", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "ingress_plugin", "delete"}, time.Now())
+
+	if aclObj, err := v.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowPluginWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.ID == "" {
+		return fmt.Errorf("missing plugin ID")
+	}
+
+	_, index, err := v.srv.raftApply(structs.IngressPluginDeleteRequestType, args)
+	if err != nil {
+		v.logger.Error("ingress raft apply failed", "error", err, "method", "delete")
+		return err
+	}
+
+	reply.Index = index
+	v.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// Sweep reconciles the controllers of all ingress plugins with the nodes and
+// allocations running them. It is only used by the core scheduler.
+func (v *IngressPlugin) Sweep(args *structs.IngressPluginSweepRequest, reply *structs.GenericResponse) error {
+
+	aclObj, err := v.srv.AuthenticateServerOnly(v.ctx, args)
+	v.srv.MeasureRPCRate("ingress_plugin", structs.RateMetricWrite, args)
+	if err != nil || !aclObj.AllowServerOp() {
+		return structs.ErrPermissionDenied
+	}
+
+	if done, err := v.srv.forward("IngressPlugin.Sweep", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "ingress_plugin", "sweep"}, time.Now())
+
+	_, index, err := v.srv.raftApply(structs.IngressPluginSweepRequestType, args)
+	if err != nil {
+		v.logger.Error("ingress raft apply failed", "error", err, "method", "sweep")
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// IngressRoute endpoint is used for listing the routes materialized from the
+// ingress blocks of job services.
+type IngressRoute struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger hclog.Logger
+}
+
+func NewIngressRouteEndpoint(srv *Server, ctx *RPCContext) *IngressRoute {
+	return &IngressRoute{srv: srv, ctx: ctx, logger: srv.logger.Named("ingress_route")}
+}
+
+// List the ingress routes of a namespace, or of all namespaces when the
+// wildcard namespace is used. The Prefix query option filters routes by job
+// ID. Routes expose the addresses of the allocations of a job, so the caller
+// needs the read-job capability on their namespace.
+func (r *IngressRoute) List(args *structs.IngressRouteListRequest, reply *structs.IngressRouteListResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("IngressRoute.List", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("ingress_route", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "ingress_route", "list"}, time.Now())
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	// allowFunc checks whether the caller has the read-job capability on the
+	// passed namespace.
+	allowFunc := func(ns string) bool {
+		return aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob)
+	}
+
+	allNamespaces := args.RequestNamespace() == structs.AllNamespacesSentinel
+	if !allNamespaces && !allowFunc(args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+
+			// nil allowableNamespaces means the caller can view all
+			// namespaces.
+			var allowableNamespaces map[string]bool
+			var iter memdb.ResultIterator
+			var err error
+			if allNamespaces {
+				allowableNamespaces, err = allowedNSes(aclObj, store, allowFunc)
+				switch err {
+				case structs.ErrPermissionDenied:
+					reply.Routes = []*structs.IngressRoute{}
+					return nil
+				case nil:
+					// Fallthrough.
+				default:
+					return err
+				}
+				iter, err = store.IngressRoutes(ws)
+			} else {
+				iter, err = store.IngressRoutesByNamespace(ws, args.RequestNamespace())
+			}
+			if err != nil {
+				return err
+			}
+
+			routes := []*structs.IngressRoute{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				route := raw.(*structs.IngressRoute)
+				if allowableNamespaces != nil && !allowableNamespaces[route.Namespace] {
+					continue
+				}
+				if !strings.HasPrefix(route.JobID, args.Prefix) {
+					continue
+				}
+				routes = append(routes, route)
+			}
+
+			reply.Routes = routes
+			return r.srv.setReplyQueryMeta(store, state.TableIngressRoutes, &reply.QueryMeta)
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// Ack records that an ingress plugin programmed routes in its load balancer.
+// Healthy allocations of a deployment are only considered healthy once the
+// routes they are a backend of are acknowledged. Acknowledgements are sent by
+// the clients supervising ingress plugins which program routes themselves,
+// or by plugins with a token allowing to write plugins. Clients can only
+// acknowledge the routes of the plugins they run.
+func (r *IngressRoute) Ack(args *structs.IngressRouteAckRequest, reply *structs.GenericResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("IngressRoute.Ack", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("ingress_route", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "ingress_route", "ack"}, time.Now())
+
+	if identity := args.GetIdentity(); identity == nil || identity.ClientID == "" {
+		if aclObj, err := r.srv.ResolveACL(args); err != nil {
+			return err
+		} else if !aclObj.AllowPluginWrite() {
+			return structs.ErrPermissionDenied
+		}
+	} else if allowed, err := nodeServesIngressRoutes(r.srv.State(), identity.ClientID, args.Acks); err != nil {
+		return err
+	} else if !allowed {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.Acks) == 0 {
+		return fmt.Errorf("missing route acknowledgements")
+	}
+	for _, ack := range args.Acks {
+		if ack.Namespace == "" || ack.RouteID == "" {
+			return fmt.Errorf("route acknowledgements require a namespace and a route ID")
+		}
+	}
+
+	_, index, err := r.srv.raftApply(structs.IngressRouteAckRequestType, args)
+	if err != nil {
+		r.logger.Error("ingress raft apply failed", "error", err, "method", "ack")
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// nodeServesIngressRoutes returns true if the node runs a live allocation of
+// the ingress plugin programming each of the acknowledged routes. Routes which
+// no longer exist are ignored, as acknowledging them is a no-op.
+func nodeServesIngressRoutes(store *state.StateStore, nodeID string, acks []*structs.IngressRouteAck) (bool, error) {
+	served := map[string]bool{}
+	for _, ack := range acks {
+		route, err := store.IngressRouteByID(nil, ack.Namespace, ack.RouteID)
+		if err != nil {
+			return false, err
+		}
+		if route == nil {
+			continue
+		}
+		if route.Ingress == nil || route.Ingress.Class == "" {
+			return false, nil
+		}
+
+		class := route.Ingress.Class
+		ok, checked := served[class]
+		if !checked {
+			ok, err = nodeRunsIngressPlugin(store, nodeID, class)
+			if err != nil {
+				return false, err
+			}
+			served[class] = ok
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// nodeRunsIngressPlugin returns true if the node runs a live controller
+// allocation of the ingress plugin.
+func nodeRunsIngressPlugin(store *state.StateStore, nodeID, pluginID string) (bool, error) {
+	plug, err := store.IngressPluginByID(nil, pluginID)
+	if err != nil || plug == nil {
+		return false, err
+	}
+	info, ok := plug.Controllers[nodeID]
+	if !ok || info.AllocID == "" {
+		return false, nil
+	}
+	alloc, err := store.AllocByID(nil, info.AllocID)
+	if err != nil || alloc == nil {
+		return false, err
+	}
+	return alloc.NodeID == nodeID && !alloc.TerminalStatus(), nil
+}
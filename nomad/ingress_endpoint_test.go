@@ -0,0 +1,362 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestIngressPluginEndpoint_List(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanup := TestServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+
+	// Registering a controller job creates the plugins.
+	for i, id := range []string{"haproxy", "nginx"} {
+		job := mock.Job()
+		job.TaskGroups[0].Tasks[0].IngressPluginConfig = &structs.TaskIngressPluginConfig{
+			ID:    id,
+			Class: structs.InternalIngressClass,
+		}
+		must.NoError(t, s.State().UpsertJob(structs.MsgTypeTestSetup, uint64(100+i), nil, job))
+	}
+
+	req := &structs.IngressPluginListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.IngressPluginListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressPlugin.List", req, &resp))
+	must.Len(t, 2, resp.Plugins)
+	must.Eq(t, 101, resp.Index)
+
+	req.Prefix = "ha"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressPlugin.List", req, &resp))
+	must.Len(t, 1, resp.Plugins)
+	must.Eq(t, "haproxy", resp.Plugins[0].ID)
+}
+
+// testIngressPluginController registers a node running a healthy controller
+// of the plugin and returns the running controller allocation. The plugin is
+// only known from the node fingerprint and has no controller job.
+func testIngressPluginController(t *testing.T, store *state.StateStore, pluginID string) *structs.Allocation {
+	t.Helper()
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	index++
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index, []*structs.Allocation{alloc}))
+
+	node := mock.Node()
+	node.ID = alloc.NodeID
+	node.IngressPlugins = map[string]*structs.IngressInfo{
+		pluginID: {
+			PluginID: pluginID,
+			AllocID:  alloc.ID,
+			Healthy:  true,
+		},
+	}
+	index++
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node))
+	return alloc
+}
+
+func TestIngressPluginEndpoint_Delete(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanup := TestACLServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+	store := s.fsm.State()
+
+	alloc := testIngressPluginController(t, store, "foo")
+
+	token := mock.CreatePolicyAndToken(t, store, 1001, "plugin-read", mock.PluginPolicy("read"))
+	req := &structs.IngressPluginDeleteRequest{
+		ID: "foo",
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.IngressPluginDeleteResponse
+
+	// Improper permissions
+	err := msgpackrpc.CallWithCodec(codec, "IngressPlugin.Delete", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// The controller allocation is still running
+	token = mock.CreatePolicyAndToken(t, store, 1002, "plugin-write", mock.PluginPolicy("write"))
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "IngressPlugin.Delete", req, &resp)
+	must.EqError(t, err, "plugin in use")
+
+	// A stale fingerprint of a terminal controller doesn't keep the plugin
+	complete := alloc.Copy()
+	complete.ClientStatus = structs.AllocClientStatusComplete
+	index, _ := store.LatestIndex()
+	must.NoError(t, store.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index+1, []*structs.Allocation{complete}))
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressPlugin.Delete", req, &resp))
+	must.Positive(t, resp.Index)
+
+	plug, err := store.IngressPluginByID(nil, "foo")
+	must.NoError(t, err)
+	must.Nil(t, plug)
+}
+
+// testIngressRouteJob returns a job exposing a single group service through
+// an ingress block.
+func testIngressRouteJob(id, namespace string) *structs.Job {
+	job := mock.Job()
+	job.ID = id
+	job.Namespace = namespace
+	job.TaskGroups[0].Services = []*structs.Service{{
+		Name:      "web",
+		PortLabel: "http",
+		Ingress:   &structs.ServiceIngress{Hosts: []string{"example.com"}, Class: "haproxy"},
+	}}
+	return job
+}
+
+func TestIngressRouteEndpoint_List(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanup := TestServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+
+	ns := mock.Namespace()
+	must.NoError(t, s.State().UpsertNamespaces(100, []*structs.Namespace{ns}))
+
+	must.NoError(t, s.State().UpsertJob(structs.MsgTypeTestSetup, 101, nil, testIngressRouteJob("web", structs.DefaultNamespace)))
+	must.NoError(t, s.State().UpsertJob(structs.MsgTypeTestSetup, 102, nil, testIngressRouteJob("api", structs.DefaultNamespace)))
+	must.NoError(t, s.State().UpsertJob(structs.MsgTypeTestSetup, 103, nil, testIngressRouteJob("web", ns.Name)))
+
+	req := &structs.IngressRouteListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.IngressRouteListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp))
+	must.Len(t, 2, resp.Routes)
+	must.Eq(t, 103, resp.Index)
+
+	req.Prefix = "we"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp))
+	must.Len(t, 1, resp.Routes)
+	must.Eq(t, "web", resp.Routes[0].JobID)
+	must.Eq(t, structs.DefaultNamespace, resp.Routes[0].Namespace)
+
+	req.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp))
+	must.Len(t, 2, resp.Routes)
+}
+
+func TestIngressPluginEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanup := TestACLServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+	store := s.fsm.State()
+
+	alloc := testIngressPluginController(t, store, "foo")
+
+	listToken := mock.CreatePolicyAndToken(t, store, 1001, "plugin-list", mock.PluginPolicy("list"))
+	readToken := mock.CreatePolicyAndToken(t, store, 1002, "plugin-read", mock.PluginPolicy("read"))
+	readJobToken := mock.CreatePolicyAndToken(t, store, 1003, "plugin-read-job",
+		mock.PluginPolicy("read")+mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	listReq := &structs.IngressPluginListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.IngressPluginListResponse
+
+	// Anonymous callers can't enumerate the plugins.
+	err := msgpackrpc.CallWithCodec(codec, "IngressPlugin.List", listReq, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	listReq.AuthToken = listToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressPlugin.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Plugins)
+
+	getReq := &structs.IngressPluginGetRequest{
+		ID: "foo",
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: listToken.SecretID,
+		},
+	}
+	var getResp structs.IngressPluginGetResponse
+
+	// Listing doesn't allow to inspect a plugin.
+	err = msgpackrpc.CallWithCodec(codec, "IngressPlugin.Get", getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Allocations are only shown with the read-job capability.
+	getReq.AuthToken = readToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressPlugin.Get", getReq, &getResp))
+	must.NotNil(t, getResp.Plugin)
+	must.SliceEmpty(t, getResp.Plugin.Allocations)
+
+	getReq.AuthToken = readJobToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressPlugin.Get", getReq, &getResp))
+	must.Len(t, 1, getResp.Plugin.Allocations)
+	must.Eq(t, alloc.ID, getResp.Plugin.Allocations[0].ID)
+
+	getReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressPlugin.Get", getReq, &getResp))
+	must.Len(t, 1, getResp.Plugin.Allocations)
+}
+
+func TestIngressRouteEndpoint_List_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanup := TestACLServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+	store := s.fsm.State()
+
+	ns := mock.Namespace()
+	must.NoError(t, store.UpsertNamespaces(100, []*structs.Namespace{ns}))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, testIngressRouteJob("web", structs.DefaultNamespace)))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, testIngressRouteJob("web", ns.Name)))
+
+	readJobToken := mock.CreatePolicyAndToken(t, store, 1001, "read-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	listJobsToken := mock.CreatePolicyAndToken(t, store, 1002, "list-jobs",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+
+	req := &structs.IngressRouteListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.IngressRouteListResponse
+
+	// Anonymous callers can't see the backends of the jobs.
+	err := msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = listJobsToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = readJobToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp))
+	must.Len(t, 1, resp.Routes)
+
+	req.Namespace = ns.Name
+	err = msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// The wildcard namespace is filtered down to the readable namespaces.
+	req.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp))
+	must.Len(t, 1, resp.Routes)
+	must.Eq(t, structs.DefaultNamespace, resp.Routes[0].Namespace)
+
+	req.AuthToken = listJobsToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp))
+	must.SliceEmpty(t, resp.Routes)
+
+	req.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.List", req, &resp))
+	must.Len(t, 2, resp.Routes)
+}
+
+func TestIngressRouteEndpoint_Ack_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanup := TestACLServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+	store := s.fsm.State()
+
+	job := testIngressRouteJob("web", structs.DefaultNamespace)
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 101, node))
+
+	readToken := mock.CreatePolicyAndToken(t, store, 1001, "plugin-read", mock.PluginPolicy("read"))
+	writeToken := mock.CreatePolicyAndToken(t, store, 1002, "plugin-write", mock.PluginPolicy("write"))
+
+	iter, err := store.IngressRoutesByJobID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	route := iter.Next().(*structs.IngressRoute)
+
+	req := &structs.IngressRouteAckRequest{
+		Acks: []*structs.IngressRouteAck{{
+			Namespace: route.Namespace,
+			RouteID:   route.ID,
+			Index:     route.ModifyIndex,
+		}},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+
+	// Anonymous callers and tokens without plugin write can't acknowledge
+	// routes.
+	err = msgpackrpc.CallWithCodec(codec, "IngressRoute.Ack", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "IngressRoute.Ack", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = writeToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.Ack", req, &resp))
+
+	// Clients can only acknowledge the routes of the ingress plugins they
+	// run.
+	req.AuthToken = node.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "IngressRoute.Ack", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Clients supervising ingress plugins acknowledge routes with their
+	// secret.
+	controller := mock.Alloc()
+	controller.NodeID = node.ID
+	controller.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{controller}))
+
+	info := &structs.IngressInfo{PluginID: "haproxy", AllocID: controller.ID}
+	info.SetHealthy(true)
+	node = node.Copy()
+	node.IngressPlugins = map[string]*structs.IngressInfo{"haproxy": info}
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1004, node))
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "IngressRoute.Ack", req, &resp))
+
+	route, err = store.IngressRouteByID(nil, route.Namespace, route.ID)
+	must.NoError(t, err)
+	must.Eq(t, route.ModifyIndex, route.ProgrammedIndex)
+
+	req.Acks = nil
+	err = msgpackrpc.CallWithCodec(codec, "IngressRoute.Ack", req, &resp)
+	must.ErrorContains(t, err, "missing route acknowledgements")
+}
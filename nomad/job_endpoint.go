@@ -85,6 +85,7 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 			jobConsulHook{srv: s},
 			jobNamespaceConstraintCheckHook{srv: s},
 			jobNodePoolValidatingHook{srv: s},
+			jobIngressValidateHook{srv: s},
 			&jobValidate{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
 			jobNumaHook{},
@@ -194,6 +195,10 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 			}
 		}
 
+		if !allowJobAffinities(aclObj, tg) {
+			return structs.ErrPermissionDenied
+		}
+
 		// Check if override is set and we do not have permissions
 		if args.PolicyOverride {
 			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
@@ -1813,6 +1818,11 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
 			return structs.ErrPermissionDenied
 		}
+		for _, tg := range args.Job.TaskGroups {
+			if !allowJobAffinities(aclObj, tg) {
+				return structs.ErrPermissionDenied
+			}
+		}
 		// Check if override is set and we do not have permissions
 		if args.PolicyOverride {
 			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
@@ -2421,3 +2431,16 @@ func (j *Job) GetServiceRegistrations(
 		},
 	})
 }
+
+// allowJobAffinities checks that the job affinities of the task group only
+// target namespaces whose jobs can be read, as placements reveal where the
+// targeted jobs run. Affinities without namespace target the namespace of the
+// job.
+func allowJobAffinities(aclObj *acl.ACL, tg *structs.TaskGroup) bool {
+	for _, affinity := range tg.JobAffinities {
+		if affinity.Namespace != "" && !aclObj.AllowNsOp(affinity.Namespace, acl.NamespaceCapabilityReadJob) {
+			return false
+		}
+	}
+	return true
+}
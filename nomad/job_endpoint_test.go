@@ -990,6 +990,17 @@ func TestJobEndpoint_Register_ACL(t *testing.T) {
 	pluginPolicy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityCSIRegisterPlugin})
 	pluginToken := mock.CreatePolicyAndToken(t, s1.State(), 1005, "test-csi-register-plugin", submitJobPolicy+pluginPolicy)
 
+	newJobAffinityJob := func() *structs.Job {
+		j := mock.Job()
+		j.TaskGroups[0].JobAffinities = []*structs.JobAffinity{
+			{Namespace: "platform", JobID: "cache", Weight: 50},
+		}
+		return j
+	}
+
+	readPlatformPolicy := mock.NamespacePolicy("platform", "", []string{acl.NamespaceCapabilityReadJob})
+	readPlatformToken := mock.CreatePolicyAndToken(t, s1.State(), 1006, "test-read-platform", submitJobPolicy+readPlatformPolicy)
+
 	cases := []struct {
 		Name        string
 		Job         *structs.Job
@@ -1044,6 +1055,18 @@ func TestJobEndpoint_Register_ACL(t *testing.T) {
 			Token:       pluginToken.SecretID,
 			ErrExpected: false,
 		},
+		{
+			Name:        "with a token that can submit a job, job affinity namespace rejected",
+			Job:         newJobAffinityJob(),
+			Token:       submitJobToken.SecretID,
+			ErrExpected: true,
+		},
+		{
+			Name:        "with a token that can also read the job affinity namespace, accepted",
+			Job:         newJobAffinityJob(),
+			Token:       readPlatformToken.SecretID,
+			ErrExpected: false,
+		},
 	}
 
 	for _, tt := range cases {
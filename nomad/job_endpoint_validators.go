@@ -5,7 +5,9 @@ package nomad
 
 import (
 	"fmt"
+	"slices"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -69,3 +71,98 @@ func taskValidateDriver(task *structs.Task, ns *structs.Namespace) bool {
 	}
 	return allow
 }
+
+// jobIngressValidateHook validates the ingress blocks of the job's services
+// against each other and against the ingress plugins known to the cluster.
+type jobIngressValidateHook struct {
+	srv *Server
+}
+
+func (jobIngressValidateHook) Name() string {
+	return "ingress-validate"
+}
+
+func (h jobIngressValidateHook) Validate(job *structs.Job) (warnings []error, err error) {
+	var mErr multierror.Error
+
+	// routes tracks which service claimed each host and path pair so that
+	// overlapping routes can be reported
+	routes := map[string]string{}
+	classes := map[string]struct{}{}
+
+	for _, tg := range job.TaskGroups {
+		for _, service := range allServices(tg) {
+			ingress := service.Ingress
+			if ingress == nil {
+				continue
+			}
+
+			if ingress.TLSSecret != "" && len(ingress.Hosts) == 0 {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf(
+					"Service %q ingress sets tls_secret but has no hosts", service.Name))
+			}
+
+			if ingress.Class != "" {
+				classes[ingress.Class] = struct{}{}
+			}
+
+			for _, route := range ingressRouteKeys(ingress) {
+				if prev, ok := routes[route]; ok && prev != service.Name {
+					warnings = append(warnings, fmt.Errorf(
+						"Services %q and %q both route %q, traffic will be split by weight",
+						prev, service.Name, route))
+					continue
+				}
+				routes[route] = service.Name
+			}
+		}
+	}
+
+	if err := mErr.ErrorOrNil(); err != nil {
+		return warnings, err
+	}
+
+	for class := range classes {
+		plug, err := h.srv.State().IngressPluginByID(nil, class)
+		if err != nil {
+			return warnings, err
+		}
+		if plug == nil {
+			warnings = append(warnings, fmt.Errorf(
+				"Ingress class %q does not match any ingress plugin, routes will not be programmed until it is running", class))
+		}
+	}
+
+	return warnings, nil
+}
+
+// allServices returns the group services and the services of every task in
+// the group.
+func allServices(tg *structs.TaskGroup) []*structs.Service {
+	services := slices.Clone(tg.Services)
+	for _, task := range tg.Tasks {
+		services = append(services, task.Services...)
+	}
+	return services
+}
+
+// ingressRouteKeys returns a "host/path" key per host and path pair matched
+// by the ingress, with an empty host or path matching anything.
+func ingressRouteKeys(ingress *structs.ServiceIngress) []string {
+	hosts := ingress.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{"*"}
+	}
+	paths := ingress.Paths
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+
+	keys := make([]string, 0, len(hosts)*len(paths))
+	for _, host := range hosts {
+		for _, path := range paths {
+			keys = append(keys, host+path)
+		}
+	}
+	return keys
+}
@@ -120,3 +120,45 @@ func TestJobNamespaceConstraintCheckHook_validate(t *testing.T) {
 	_, err = hook.Validate(job)
 	require.Equal(t, err.Error(), "used task drivers [\"exec\" \"raw_exec\"] are not allowed in namespace \"default\"")
 }
+
+func TestJobIngressValidateHook_validate(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	hook := jobIngressValidateHook{srv: s1}
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Services[0].Ingress = &structs.ServiceIngress{
+		Hosts: []string{"example.com"},
+		Paths: []string{"/"},
+	}
+	warnings, err := hook.Validate(job)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	// an unknown class is only a warning
+	job.TaskGroups[0].Tasks[0].Services[0].Ingress.Class = "haproxy"
+	warnings, err = hook.Validate(job)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0].Error(), `Ingress class "haproxy" does not match any ingress plugin`)
+
+	// overlapping routes are a warning
+	job.TaskGroups[0].Tasks[0].Services[0].Ingress.Class = ""
+	job.TaskGroups[0].Tasks[0].Services[1].Ingress = &structs.ServiceIngress{
+		Hosts: []string{"example.com"},
+	}
+	warnings, err = hook.Validate(job)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0].Error(), `both route "example.com/"`)
+
+	// TLS requires a host
+	job.TaskGroups[0].Tasks[0].Services[1].Ingress = &structs.ServiceIngress{
+		Paths:     []string{"/api"},
+		TLSSecret: "certs/example",
+	}
+	_, err = hook.Validate(job)
+	require.ErrorContains(t, err, "sets tls_secret but has no hosts")
+}
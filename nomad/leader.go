@@ -1040,6 +1040,10 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer deploymentGC.Stop()
 	csiPluginGC := time.NewTicker(s.config.CSIPluginGCInterval)
 	defer csiPluginGC.Stop()
+	ingressPluginGC := time.NewTicker(s.config.IngressPluginGCInterval)
+	defer ingressPluginGC.Stop()
+	reservationGC := time.NewTicker(s.config.ReservationGCInterval)
+	defer reservationGC.Stop()
 	csiVolumeClaimGC := time.NewTicker(s.config.CSIVolumeClaimGCInterval)
 	defer csiVolumeClaimGC.Stop()
 	oneTimeTokenGC := time.NewTicker(s.config.OneTimeTokenGCInterval)
@@ -1048,6 +1052,8 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer rootKeyGC.Stop()
 	variablesRekey := time.NewTicker(s.config.VariablesRekeyInterval)
 	defer variablesRekey.Stop()
+	rebalance := time.NewTicker(s.config.RebalanceInterval)
+	defer rebalance.Stop()
 
 	// Set up the expired ACL local token garbage collection timer.
 	localTokenExpiredGC, localTokenExpiredGCStop := helper.NewSafeTimer(s.config.ACLTokenExpirationGCInterval)
@@ -1076,6 +1082,14 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := s.getLatestIndex(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobCSIPluginGC, index))
 			}
+		case <-ingressPluginGC.C:
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobIngressPluginGC, index))
+			}
+		case <-reservationGC.C:
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobReservationGC, index))
+			}
 		case <-csiVolumeClaimGC.C:
 			if index, ok := s.getLatestIndex(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobCSIVolumeClaimGC, index))
@@ -1101,6 +1115,14 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := s.getLatestIndex(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobVariablesRekey, index))
 			}
+		case <-rebalance.C:
+			_, schedConfig, _ := s.fsm.State().SchedulerConfig()
+			if schedConfig == nil || !schedConfig.RebalanceConfig.Enabled {
+				continue
+			}
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobRebalance, index))
+			}
 		case <-stopCh:
 			return
 		}
@@ -2800,7 +2822,8 @@ func (s *Server) generateClusterMetadata() (structs.ClusterMetadata, error) {
 }
 
 // handleEvalBrokerStateChange handles changing the evalBroker and blockedEvals
-// enabled status based on the passed scheduler configuration. The boolean
+// enabled status, and the fair share of the evalBroker, based on the passed
+// scheduler configuration. The boolean
 // response indicates whether the caller needs to call restoreEvals() due to
 // the brokers being enabled. It is for use when the change must take the
 // scheduler configuration into account. This is not needed when calling
@@ -2841,8 +2864,10 @@ func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfi
 	switch schedConfig {
 	case nil:
 		enableBrokers = !s.config.DefaultSchedulerConfig.PauseEvalBroker
+		s.evalBroker.SetFairShare(s.config.DefaultSchedulerConfig.FairShareConfig)
 	default:
 		enableBrokers = !schedConfig.PauseEvalBroker
+		s.evalBroker.SetFairShare(schedConfig.FairShareConfig)
 	}
 
 	// If the evalBroker status is changing, set the new state.
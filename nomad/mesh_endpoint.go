@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Mesh endpoint is used to manage the service intentions of the Nomad service
+// mesh, and to sign the certificates of the services of the mesh.
+type Mesh struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger hclog.Logger
+}
+
+func NewMeshEndpoint(srv *Server, ctx *RPCContext) *Mesh {
+	return &Mesh{srv: srv, ctx: ctx, logger: srv.logger.Named("mesh")}
+}
+
+// ListIntentions lists the service intentions of a namespace, or of all
+// namespaces when the wildcard namespace is used. Nomad clients are allowed
+// to list intentions, as the proxies they run for the services of the mesh
+// enforce them.
+func (m *Mesh) ListIntentions(args *structs.MeshListIntentionsRequest, reply *structs.MeshListIntentionsResponse) error {
+
+	authErr := m.srv.Authenticate(m.ctx, args)
+	if done, err := m.srv.forward(structs.MeshListIntentionsRPCMethod, args, args, reply); done {
+		return err
+	}
+	m.srv.MeasureRPCRate("mesh", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "mesh", "list_intentions"}, time.Now())
+
+	aclObj, err := m.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	// allowFunc checks whether the caller is a client or has the read-job
+	// capability on the passed namespace.
+	allowFunc := func(ns string) bool {
+		return aclObj.AllowClientOp() || aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob)
+	}
+
+	allNamespaces := args.RequestNamespace() == structs.AllNamespacesSentinel
+	if !allNamespaces && !allowFunc(args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+
+			// nil allowableNamespaces means the caller can view all
+			// namespaces.
+			var allowableNamespaces map[string]bool
+			var iter memdb.ResultIterator
+			var err error
+			if allNamespaces {
+				if !aclObj.AllowClientOp() {
+					allowableNamespaces, err = allowedNSes(aclObj, store, allowFunc)
+				}
+				switch err {
+				case structs.ErrPermissionDenied:
+					reply.Intentions = []*structs.ServiceIntention{}
+					return nil
+				case nil:
+					// Fallthrough.
+				default:
+					return err
+				}
+				iter, err = store.ServiceIntentions(ws)
+			} else {
+				iter, err = store.ServiceIntentionsByNamespace(ws, args.RequestNamespace())
+			}
+			if err != nil {
+				return err
+			}
+
+			intentions := []*structs.ServiceIntention{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				intention := raw.(*structs.ServiceIntention)
+				if allowableNamespaces != nil && !allowableNamespaces[intention.Namespace] {
+					continue
+				}
+				intentions = append(intentions, intention)
+			}
+
+			reply.Intentions = intentions
+			return m.srv.setReplyQueryMeta(store, state.TableServiceIntentions, &reply.QueryMeta)
+		}}
+	return m.srv.blockingRPC(&opts)
+}
+
+// UpsertIntentions creates or updates service intentions of the request
+// namespace. Intentions are identified by their source and destination
+// services, so writing an intention for an existing pair of services replaces
+// it.
+func (m *Mesh) UpsertIntentions(args *structs.MeshUpsertIntentionsRequest, reply *structs.GenericResponse) error {
+
+	authErr := m.srv.Authenticate(m.ctx, args)
+	if done, err := m.srv.forward(structs.MeshUpsertIntentionsRPCMethod, args, args, reply); done {
+		return err
+	}
+	m.srv.MeasureRPCRate("mesh", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "mesh", "upsert_intentions"}, time.Now())
+
+	aclObj, err := m.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.Intentions) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one intention")
+	}
+
+	snap, err := m.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	namespace := args.RequestNamespace()
+	if ns, err := snap.NamespaceByName(nil, namespace); err != nil {
+		return err
+	} else if ns == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "namespace %q does not exist", namespace)
+	}
+
+	for _, intention := range args.Intentions {
+		if intention.Namespace == "" {
+			intention.Namespace = namespace
+		} else if intention.Namespace != namespace {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest,
+				"intention namespace %q does not match request namespace %q", intention.Namespace, namespace)
+		}
+		if err := intention.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid intention: %v", err)
+		}
+	}
+
+	_, index, err := m.srv.raftApply(structs.ServiceIntentionUpsertRequestType, args)
+	if err != nil {
+		m.logger.Error("raft apply failed", "error", err, "method", "upsert_intentions")
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// DeleteIntention removes the service intention of the request namespace
+// between the source and destination services.
+func (m *Mesh) DeleteIntention(args *structs.MeshDeleteIntentionRequest, reply *structs.GenericResponse) error {
+
+	authErr := m.srv.Authenticate(m.ctx, args)
+	if done, err := m.srv.forward(structs.MeshDeleteIntentionRPCMethod, args, args, reply); done {
+		return err
+	}
+	m.srv.MeasureRPCRate("mesh", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "mesh", "delete_intention"}, time.Now())
+
+	aclObj, err := m.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.SourceService == "" || args.DestinationService == "" {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify the source and destination services")
+	}
+
+	existing, err := m.srv.State().ServiceIntentionByName(nil,
+		args.RequestNamespace(), args.SourceService, args.DestinationService)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound,
+			"intention from %s to %s not found", args.SourceService, args.DestinationService)
+	}
+
+	_, index, err := m.srv.raftApply(structs.ServiceIntentionDeleteRequestType, args)
+	if err != nil {
+		m.logger.Error("raft apply failed", "error", err, "method", "delete_intention")
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// SignCertificate signs the certificate of a mesh service of an allocation
+// with the active key of the keyring. The certificate identifies the service
+// in the mesh, so only the client running the allocation may request it, and
+// only for the mesh services of the allocation's task group.
+//
+// This is an internal-only RPC and not exposed via the HTTP API.
+func (m *Mesh) SignCertificate(args *structs.MeshSignCertificateRequest, reply *structs.MeshSignCertificateResponse) error {
+
+	aclObj, err := m.srv.AuthenticateClientOnly(m.ctx, args)
+	m.srv.MeasureRPCRate("mesh", structs.RateMetricWrite, args)
+	if err != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	if done, err := m.srv.forward(structs.MeshSignCertificateRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "mesh", "sign_certificate"}, time.Now())
+
+	if !aclObj.AllowClientOp() {
+		return structs.ErrPermissionDenied
+	}
+
+	alloc, err := m.srv.State().AllocByID(nil, args.AllocID)
+	if err != nil {
+		return err
+	}
+	if alloc == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound, "allocation %s not found", args.AllocID)
+	}
+	if alloc.NodeID != args.GetIdentity().ClientID {
+		return structs.ErrPermissionDenied
+	}
+	if alloc.TerminalStatus() {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "allocation %s is terminal", args.AllocID)
+	}
+
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "task group %q not found", alloc.TaskGroup)
+	}
+	var found bool
+	for _, service := range tg.Services {
+		if service.Mesh != nil && service.Name == args.Service {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"task group %q has no mesh service %q", alloc.TaskGroup, args.Service)
+	}
+
+	cert, expiration, err := m.srv.encrypter.SignMeshCertificate(args.CSR, alloc.Namespace, args.Service)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to sign certificate: %v", err)
+	}
+	caCerts, err := m.srv.encrypter.MeshCACertificates()
+	if err != nil {
+		return err
+	}
+
+	reply.Certificate = cert
+	reply.CACertificates = caCerts
+	reply.Expiration = expiration
+	return nil
+}
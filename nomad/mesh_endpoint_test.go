@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+// testMeshCSR returns a PEM encoded certificate signing request for a new
+// key.
+func testMeshCSR(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	must.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+func TestMeshEndpoint_Intentions(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanup := TestServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+
+	upsertReq := &structs.MeshUpsertIntentionsRequest{
+		Intentions: []*structs.ServiceIntention{
+			{
+				SourceService:      "web",
+				DestinationService: "api",
+				Action:             structs.ServiceIntentionActionAllow,
+			},
+			{
+				SourceService:      "*",
+				DestinationService: "*",
+				Action:             structs.ServiceIntentionActionDeny,
+			},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshUpsertIntentionsRPCMethod, upsertReq, &upsertResp))
+	must.NotEq(t, 0, upsertResp.Index)
+
+	listReq := &structs.MeshListIntentionsRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.MeshListIntentionsResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshListIntentionsRPCMethod, listReq, &listResp))
+	must.Len(t, 2, listResp.Intentions)
+	must.Eq(t, upsertResp.Index, listResp.Index)
+	for _, intention := range listResp.Intentions {
+		must.Eq(t, structs.DefaultNamespace, intention.Namespace)
+	}
+
+	// Invalid intentions and unknown namespaces are rejected.
+	upsertReq.Intentions = []*structs.ServiceIntention{{
+		SourceService:      "web",
+		DestinationService: "api",
+		Action:             "permit",
+	}}
+	err := msgpackrpc.CallWithCodec(codec, structs.MeshUpsertIntentionsRPCMethod, upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "invalid intention")
+
+	upsertReq.Intentions[0].Action = structs.ServiceIntentionActionAllow
+	upsertReq.Namespace = "unknown"
+	err = msgpackrpc.CallWithCodec(codec, structs.MeshUpsertIntentionsRPCMethod, upsertReq, &upsertResp)
+	must.ErrorContains(t, err, `namespace "unknown" does not exist`)
+
+	deleteReq := &structs.MeshDeleteIntentionRequest{
+		SourceService:      "web",
+		DestinationService: "api",
+		WriteRequest:       structs.WriteRequest{Region: "global"},
+	}
+	var deleteResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshDeleteIntentionRPCMethod, deleteReq, &deleteResp))
+
+	err = msgpackrpc.CallWithCodec(codec, structs.MeshDeleteIntentionRPCMethod, deleteReq, &deleteResp)
+	must.ErrorContains(t, err, "not found")
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshListIntentionsRPCMethod, listReq, &listResp))
+	must.Len(t, 1, listResp.Intentions)
+}
+
+func TestMeshEndpoint_Intentions_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanup := TestACLServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+	store := s.fsm.State()
+
+	ns := mock.Namespace()
+	must.NoError(t, store.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+	must.NoError(t, store.UpsertServiceIntentions(structs.MsgTypeTestSetup, 1001,
+		[]*structs.ServiceIntention{
+			{
+				Namespace:          structs.DefaultNamespace,
+				SourceService:      "web",
+				DestinationService: "api",
+				Action:             structs.ServiceIntentionActionAllow,
+			},
+			{
+				Namespace:          ns.Name,
+				SourceService:      "web",
+				DestinationService: "api",
+				Action:             structs.ServiceIntentionActionAllow,
+			},
+		}))
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1002, node))
+
+	readToken := mock.CreatePolicyAndToken(t, store, 1003, "read-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	submitToken := mock.CreatePolicyAndToken(t, store, 1004, "submit-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	listReq := &structs.MeshListIntentionsRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.MeshListIntentionsResponse
+
+	err := msgpackrpc.CallWithCodec(codec, structs.MeshListIntentionsRPCMethod, listReq, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Listing all namespaces only returns the readable ones.
+	listReq.AuthToken = readToken.SecretID
+	listReq.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshListIntentionsRPCMethod, listReq, &listResp))
+	must.Len(t, 1, listResp.Intentions)
+	must.Eq(t, structs.DefaultNamespace, listResp.Intentions[0].Namespace)
+
+	listReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshListIntentionsRPCMethod, listReq, &listResp))
+	must.Len(t, 2, listResp.Intentions)
+
+	// Clients can list the intentions of any namespace.
+	listReq.AuthToken = node.SecretID
+	listReq.Namespace = ns.Name
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshListIntentionsRPCMethod, listReq, &listResp))
+	must.Len(t, 1, listResp.Intentions)
+
+	// Writing intentions requires the submit-job capability.
+	upsertReq := &structs.MeshUpsertIntentionsRequest{
+		Intentions: []*structs.ServiceIntention{{
+			SourceService:      "*",
+			DestinationService: "api",
+			Action:             structs.ServiceIntentionActionDeny,
+		}},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var upsertResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.MeshUpsertIntentionsRPCMethod, upsertReq, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = submitToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshUpsertIntentionsRPCMethod, upsertReq, &upsertResp))
+
+	deleteReq := &structs.MeshDeleteIntentionRequest{
+		SourceService:      "web",
+		DestinationService: "api",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns.Name,
+			AuthToken: submitToken.SecretID,
+		},
+	}
+	var deleteResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.MeshDeleteIntentionRPCMethod, deleteReq, &deleteResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	deleteReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshDeleteIntentionRPCMethod, deleteReq, &deleteResp))
+}
+
+func TestMeshEndpoint_SignCertificate(t *testing.T) {
+	ci.Parallel(t)
+
+	// Use non-ACL server because auth should always be enforced on this endpoint
+	s, cleanup := TestServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	testutil.WaitForKeyring(t, s.RPC, "global")
+	codec := rpcClient(t, s)
+	store := s.fsm.State()
+
+	node := mock.Node()
+	otherNode := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 101, otherNode))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job.TaskGroups[0].Services = []*structs.Service{{
+		Name:      "web",
+		PortLabel: "http",
+		Provider:  structs.ServiceProviderNomad,
+		Mesh:      &structs.ServiceMesh{LocalServicePort: 8080},
+	}}
+	must.NoError(t, store.UpsertJobSummary(102, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, []*structs.Allocation{alloc}))
+
+	req := &structs.MeshSignCertificateRequest{
+		AllocID: alloc.ID,
+		Service: "web",
+		CSR:     testMeshCSR(t),
+		WriteRequest: structs.WriteRequest{
+			Region: "global",
+		},
+	}
+	var resp structs.MeshSignCertificateResponse
+
+	// Only clients may get certificates signed.
+	err := msgpackrpc.CallWithCodec(codec, structs.MeshSignCertificateRPCMethod, req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Only the client running the allocation.
+	req.AuthToken = otherNode.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.MeshSignCertificateRPCMethod, req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Only for mesh services of the allocation.
+	req.AuthToken = node.SecretID
+	req.Service = "api"
+	err = msgpackrpc.CallWithCodec(codec, structs.MeshSignCertificateRPCMethod, req, &resp)
+	must.ErrorContains(t, err, `has no mesh service "api"`)
+
+	req.Service = "web"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshSignCertificateRPCMethod, req, &resp))
+	must.Len(t, 1, resp.CACertificates)
+
+	roots := x509.NewCertPool()
+	must.True(t, roots.AppendCertsFromPEM([]byte(resp.CACertificates[0])))
+	block, _ := pem.Decode([]byte(resp.Certificate))
+	must.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	must.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	must.NoError(t, err)
+	must.Eq(t, structs.MeshServiceURI(alloc.Namespace, "web").String(), cert.URIs[0].String())
+	must.Eq(t, cert.NotAfter, resp.Expiration.Truncate(time.Second))
+}
@@ -606,6 +606,34 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	require.True(reply.SchedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
 }
 
+func TestOperator_SchedulerRebalancePlan(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// The default binpack algorithm empties the least utilized node
+	nodes, _ := testRebalanceState(t, s1.fsm.State(), []int{3, 1}, 1)
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerRebalancePlanResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalancePlan", &arg, &reply))
+	must.NonZero(t, reply.Index)
+	must.Len(t, 1, reply.Moves)
+	must.Eq(t, nodes[1].ID, reply.Moves[0].NodeID)
+
+	// Previewing doesn't migrate anything
+	allocs, err := s1.fsm.State().AllocsByNode(nil, nodes[1].ID)
+	must.NoError(t, err)
+	must.False(t, allocs[0].DesiredTransition.ShouldMigrate())
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	ci.Parallel(t)
 
@@ -651,6 +679,53 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	require.False(t, s1.blockedEvals.Enabled())
 }
 
+func TestOperator_SchedulerSetConfiguration_FairShare(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	setFairShare := func(fairShare structs.FairShareConfig) {
+		arg := structs.SchedulerSetConfigRequest{
+			Config: structs.SchedulerConfiguration{FairShareConfig: fairShare},
+			WriteRequest: structs.WriteRequest{
+				Region: s1.config.Region,
+			},
+		}
+		var resp structs.SchedulerSetConfigurationResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSetConfiguration", &arg, &resp))
+		must.Positive(t, resp.Index)
+	}
+	getFairShare := func() structs.FairShareConfig {
+		arg := structs.GenericRequest{
+			QueryOptions: structs.QueryOptions{
+				Region: s1.config.Region,
+			},
+		}
+		var resp structs.SchedulerConfigurationResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetConfiguration", &arg, &resp))
+		return resp.SchedulerConfig.FairShareConfig
+	}
+
+	// Enabling fair share applies the weights to the eval broker.
+	fairShare := structs.FairShareConfig{
+		Enabled:          true,
+		NamespaceWeights: map[string]int{"prod": 3, "batch": 2},
+	}
+	setFairShare(fairShare)
+	must.Eq(t, fairShare, getFairShare())
+	must.Eq(t, fairShare, s1.evalBroker.fairShare)
+
+	// Weights are unset by omitting them.
+	fairShare.NamespaceWeights = map[string]int{"prod": 3}
+	setFairShare(fairShare)
+	must.Eq(t, fairShare, getFairShare())
+	must.Eq(t, fairShare, s1.evalBroker.fairShare)
+	must.Eq(t, 1, s1.evalBroker.fairShare.Weight("batch"))
+}
+
 func TestOperator_SchedulerGetConfiguration_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,324 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/placement"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+const (
+	// placementPluginCheckInterval is the interval at which the placement
+	// plugin processes are checked for having exited.
+	placementPluginCheckInterval = 1 * time.Second
+
+	// placementPluginRestartBackoffBase and placementPluginRestartBackoffLimit
+	// bound the exponential backoff between failed restarts of a placement
+	// plugin process.
+	placementPluginRestartBackoffBase  = 1 * time.Second
+	placementPluginRestartBackoffLimit = 1 * time.Minute
+)
+
+// placementPlugin adapts a placement plugin to the scheduler's
+// PlacementExtension interface, bounding each call by the timeout of the
+// plugin and applying its failure policy to the calls that fail. The plugin
+// process is restarted if it exits.
+type placementPlugin struct {
+	name       string
+	timeout    time.Duration
+	failClosed bool
+	logger     log.Logger
+
+	// dispense starts a new plugin process. It is nil if the plugin can't
+	// be restarted.
+	dispense func() (loader.PluginInstance, error)
+
+	// instance is the plugin process, killed on shutdown
+	instance loader.PluginInstance
+	plugin   placement.PlacementPlugin
+	caps     *placement.Capabilities
+	l        sync.RWMutex
+}
+
+// setupPlacementPlugins dispenses the configured placement plugins.
+func (s *Server) setupPlacementPlugins() error {
+	if len(s.config.PlacementPlugins) == 0 {
+		return nil
+	}
+	if s.config.PluginLoader == nil {
+		return fmt.Errorf("placement plugins configured without a plugin loader")
+	}
+
+	for _, conf := range s.config.PlacementPlugins {
+		conf := conf
+		dispense := func() (loader.PluginInstance, error) {
+			instance, err := s.config.PluginLoader.Dispense(conf.Name, base.PluginTypePlacement, nil, s.logger)
+			if errors.Is(err, singleton.SingletonPluginExited) {
+				// Retry as the error just indicates the singleton has exited
+				instance, err = s.config.PluginLoader.Dispense(conf.Name, base.PluginTypePlacement, nil, s.logger)
+			}
+			return instance, err
+		}
+
+		instance, err := dispense()
+		if err != nil {
+			return fmt.Errorf("failed to dispense placement plugin %q: %v", conf.Name, err)
+		}
+
+		p, err := newPlacementPlugin(conf, instance, s.logger)
+		if err != nil {
+			instance.Kill()
+			return err
+		}
+		p.dispense = dispense
+		s.placementPlugins = append(s.placementPlugins, p)
+		s.logger.Info("loaded placement plugin", "name", conf.Name,
+			"feasibility", p.caps.Feasibility, "scoring", p.caps.Scoring)
+
+		go p.supervise(s.shutdownCtx)
+	}
+	return nil
+}
+
+// shutdownPlacementPlugins kills the placement plugin processes.
+func (s *Server) shutdownPlacementPlugins() {
+	for _, p := range s.placementPlugins {
+		p.l.Lock()
+		if p.instance != nil {
+			p.instance.Kill()
+		}
+		p.l.Unlock()
+	}
+}
+
+// placementExtensions returns the placement plugins as extensions of the
+// scheduler stacks.
+func (s *Server) placementExtensions() []scheduler.PlacementExtension {
+	if len(s.placementPlugins) == 0 {
+		return nil
+	}
+
+	extensions := make([]scheduler.PlacementExtension, 0, len(s.placementPlugins))
+	for _, p := range s.placementPlugins {
+		extensions = append(extensions, p)
+	}
+	return extensions
+}
+
+func newPlacementPlugin(conf *PlacementPluginConfig, instance loader.PluginInstance, logger log.Logger) (*placementPlugin, error) {
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = DefaultPlacementPluginTimeout
+	}
+
+	p := &placementPlugin{
+		name:       conf.Name,
+		timeout:    timeout,
+		failClosed: conf.FailurePolicy == PlacementPluginFailClosed,
+		logger:     logger.Named("placement_plugin").With("plugin", conf.Name),
+	}
+	if err := p.load(instance); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// load sets the plugin process used by the plugin, after fetching its
+// capabilities.
+func (p *placementPlugin) load(instance loader.PluginInstance) error {
+	impl, ok := instance.Plugin().(placement.PlacementPlugin)
+	if !ok {
+		return fmt.Errorf("plugin %q is not a placement plugin", p.name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*p.timeout)
+	defer cancel()
+	caps, err := impl.Capabilities(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get capabilities of placement plugin %q: %v", p.name, err)
+	}
+
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.instance = instance
+	p.plugin = impl
+	p.caps = caps
+	return nil
+}
+
+// supervise restarts the plugin process when it exits, backing off between
+// failed restarts, until the context is done. Calls made while the process is
+// down fail and are handled by the failure policy.
+func (p *placementPlugin) supervise(ctx context.Context) {
+	timer, stop := helper.NewSafeTimer(placementPluginCheckInterval)
+	defer stop()
+
+	var attempt uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		wait := placementPluginCheckInterval
+		if p.exited() {
+			if err := p.restart(); err != nil {
+				attempt++
+				wait = helper.Backoff(placementPluginRestartBackoffBase, placementPluginRestartBackoffLimit, attempt)
+				p.logger.Error("failed to restart placement plugin", "error", err, "retry", wait)
+			} else {
+				attempt = 0
+				p.logger.Info("restarted placement plugin")
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// exited returns whether the plugin process exited.
+func (p *placementPlugin) exited() bool {
+	p.l.RLock()
+	defer p.l.RUnlock()
+	return p.instance != nil && p.instance.Exited()
+}
+
+// restart starts a new plugin process replacing the exited one.
+func (p *placementPlugin) restart() error {
+	if p.dispense == nil {
+		return errors.New("placement plugin can't be restarted")
+	}
+
+	instance, err := p.dispense()
+	if err != nil {
+		return fmt.Errorf("failed to dispense placement plugin %q: %v", p.name, err)
+	}
+	if err := p.load(instance); err != nil {
+		instance.Kill()
+		return err
+	}
+	return nil
+}
+
+// current returns the plugin and its capabilities.
+func (p *placementPlugin) current() (placement.PlacementPlugin, *placement.Capabilities) {
+	p.l.RLock()
+	defer p.l.RUnlock()
+	return p.plugin, p.caps
+}
+
+func (p *placementPlugin) Name() string {
+	return p.name
+}
+
+func (p *placementPlugin) Feasible(node *structs.Node, job *structs.Job, tg *structs.TaskGroup) (bool, string) {
+	plugin, caps := p.current()
+	if !caps.Feasibility {
+		return true, ""
+	}
+
+	labels := []metrics.Label{{Name: "plugin", Value: p.name}}
+	defer metrics.MeasureSinceWithLabels([]string{"nomad", "placement_plugin", "feasible"}, time.Now(), labels)
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	resp, err := plugin.Feasible(ctx, placementRequest(node, job, tg))
+	if err != nil {
+		metrics.IncrCounterWithLabels([]string{"nomad", "placement_plugin", "errors"}, 1, labels)
+		p.logger.Warn("failed to check node feasibility", "node_id", node.ID, "error", err)
+		if p.failClosed {
+			return false, fmt.Sprintf("placement plugin %q failed", p.name)
+		}
+		return true, ""
+	}
+
+	if resp.Feasible {
+		return true, ""
+	}
+	if resp.Reason == "" {
+		return false, fmt.Sprintf("placement plugin %q", p.name)
+	}
+	return false, fmt.Sprintf("%s: %s", p.name, resp.Reason)
+}
+
+func (p *placementPlugin) Score(node *structs.Node, job *structs.Job, tg *structs.TaskGroup) (float64, bool) {
+	plugin, caps := p.current()
+	if !caps.Scoring {
+		return 0, false
+	}
+
+	labels := []metrics.Label{{Name: "plugin", Value: p.name}}
+	defer metrics.MeasureSinceWithLabels([]string{"nomad", "placement_plugin", "score"}, time.Now(), labels)
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	// Scores never filter nodes, so a failing call leaves the node unscored
+	// regardless of the failure policy.
+	resp, err := plugin.Score(ctx, placementRequest(node, job, tg))
+	if err != nil {
+		metrics.IncrCounterWithLabels([]string{"nomad", "placement_plugin", "errors"}, 1, labels)
+		p.logger.Warn("failed to score node", "node_id", node.ID, "error", err)
+		return 0, false
+	}
+	return resp.Score, true
+}
+
+// placementRequest returns the request sent to placement plugins for the
+// node and task group.
+func placementRequest(node *structs.Node, job *structs.Job, tg *structs.TaskGroup) *placement.PlacementRequest {
+	meta := make(map[string]string, len(job.Meta)+len(tg.Meta))
+	for k, v := range job.Meta {
+		meta[k] = v
+	}
+	for k, v := range tg.Meta {
+		meta[k] = v
+	}
+
+	drivers := make([]string, 0, len(tg.Tasks))
+	seen := make(map[string]struct{}, len(tg.Tasks))
+	for _, task := range tg.Tasks {
+		if _, ok := seen[task.Driver]; !ok {
+			seen[task.Driver] = struct{}{}
+			drivers = append(drivers, task.Driver)
+		}
+	}
+	sort.Strings(drivers)
+
+	return &placement.PlacementRequest{
+		Node: &placement.Node{
+			ID:         node.ID,
+			Name:       node.Name,
+			Datacenter: node.Datacenter,
+			NodePool:   node.NodePool,
+			NodeClass:  node.NodeClass,
+			Attributes: node.Attributes,
+			Meta:       node.Meta,
+		},
+		TaskGroup: &placement.TaskGroup{
+			Namespace: job.Namespace,
+			JobID:     job.ID,
+			JobType:   job.Type,
+			Name:      tg.Name,
+			Count:     tg.Count,
+			Meta:      meta,
+			Drivers:   drivers,
+		},
+	}
+}
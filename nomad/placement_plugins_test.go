@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/placement"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// testPlacementPlugin returns a placement plugin adapting the mock.
+func testPlacementPlugin(t *testing.T, conf *PlacementPluginConfig, mock *placement.MockPlacementPlugin) *placementPlugin {
+	if mock.CapabilitiesF == nil {
+		mock.CapabilitiesF = func(context.Context) (*placement.Capabilities, error) {
+			return &placement.Capabilities{Feasibility: true, Scoring: true}, nil
+		}
+	}
+
+	instance := loader.MockBasicExternalPlugin(mock, placement.ApiVersion010)
+	p, err := newPlacementPlugin(conf, instance, testlog.HCLogger(t))
+	must.NoError(t, err)
+	return p
+}
+
+func TestPlacementPlugin_Feasible(t *testing.T) {
+	ci.Parallel(t)
+
+	node := mock.Node()
+	node.Meta["rack"] = "r1"
+	job := mock.Job()
+	job.Meta = map[string]string{"owner": "team-a", "tier": "web"}
+	tg := job.TaskGroups[0]
+	tg.Meta = map[string]string{"tier": "api"}
+
+	mockPlugin := &placement.MockPlacementPlugin{
+		FeasibleF: func(_ context.Context, req *placement.PlacementRequest) (*placement.FeasibleResponse, error) {
+			must.Eq(t, node.ID, req.Node.ID)
+			must.Eq(t, map[string]string{"owner": "team-a", "tier": "api"}, req.TaskGroup.Meta)
+			must.Eq(t, []string{"exec"}, req.TaskGroup.Drivers)
+
+			if req.Node.Meta["rack"] == "r1" {
+				return &placement.FeasibleResponse{Reason: "rack is reserved"}, nil
+			}
+			return &placement.FeasibleResponse{Feasible: true}, nil
+		},
+	}
+	p := testPlacementPlugin(t, &PlacementPluginConfig{Name: "site"}, mockPlugin)
+
+	ok, reason := p.Feasible(node, job, tg)
+	must.False(t, ok)
+	must.Eq(t, "site: rack is reserved", reason)
+
+	node.Meta["rack"] = "r2"
+	ok, _ = p.Feasible(node, job, tg)
+	must.True(t, ok)
+}
+
+func TestPlacementPlugin_FailurePolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	node := mock.Node()
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	failing := func() *placement.MockPlacementPlugin {
+		return &placement.MockPlacementPlugin{
+			FeasibleF: func(context.Context, *placement.PlacementRequest) (*placement.FeasibleResponse, error) {
+				return nil, errors.New("inventory unavailable")
+			},
+			ScoreF: func(context.Context, *placement.PlacementRequest) (*placement.ScoreResponse, error) {
+				return nil, errors.New("inventory unavailable")
+			},
+		}
+	}
+
+	// Failing calls are ignored by default.
+	p := testPlacementPlugin(t, &PlacementPluginConfig{Name: "site"}, failing())
+	ok, _ := p.Feasible(node, job, tg)
+	must.True(t, ok)
+	_, scored := p.Score(node, job, tg)
+	must.False(t, scored)
+
+	// Failing feasibility checks filter the node when failing closed, but
+	// scores are still ignored.
+	p = testPlacementPlugin(t, &PlacementPluginConfig{
+		Name:          "site",
+		FailurePolicy: PlacementPluginFailClosed,
+	}, failing())
+	ok, reason := p.Feasible(node, job, tg)
+	must.False(t, ok)
+	must.Eq(t, `placement plugin "site" failed`, reason)
+	_, scored = p.Score(node, job, tg)
+	must.False(t, scored)
+}
+
+func TestPlacementPlugin_Timeout(t *testing.T) {
+	ci.Parallel(t)
+
+	blocking := &placement.MockPlacementPlugin{
+		FeasibleF: func(ctx context.Context, _ *placement.PlacementRequest) (*placement.FeasibleResponse, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	p := testPlacementPlugin(t, &PlacementPluginConfig{
+		Name:          "site",
+		Timeout:       10 * time.Millisecond,
+		FailurePolicy: PlacementPluginFailClosed,
+	}, blocking)
+
+	job := mock.Job()
+	start := time.Now()
+	ok, _ := p.Feasible(mock.Node(), job, job.TaskGroups[0])
+	must.False(t, ok)
+	must.Less(t, time.Second, time.Since(start))
+}
+
+func TestPlacementPlugin_Capabilities(t *testing.T) {
+	ci.Parallel(t)
+
+	var calls int
+	scorer := &placement.MockPlacementPlugin{
+		CapabilitiesF: func(context.Context) (*placement.Capabilities, error) {
+			return &placement.Capabilities{Scoring: true}, nil
+		},
+		FeasibleF: func(context.Context, *placement.PlacementRequest) (*placement.FeasibleResponse, error) {
+			calls++
+			return &placement.FeasibleResponse{}, nil
+		},
+		ScoreF: func(context.Context, *placement.PlacementRequest) (*placement.ScoreResponse, error) {
+			return &placement.ScoreResponse{Score: 0.5}, nil
+		},
+	}
+	p := testPlacementPlugin(t, &PlacementPluginConfig{Name: "site"}, scorer)
+
+	// Plugins are only called for the extension points they implement.
+	job := mock.Job()
+	ok, _ := p.Feasible(mock.Node(), job, job.TaskGroups[0])
+	must.True(t, ok)
+	must.Zero(t, calls)
+
+	score, scored := p.Score(mock.Node(), job, job.TaskGroups[0])
+	must.True(t, scored)
+	must.Eq(t, 0.5, score)
+}
+
+func TestPlacementPlugin_Restart(t *testing.T) {
+	ci.Parallel(t)
+
+	node := mock.Node()
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	crashed := &placement.MockPlacementPlugin{
+		FeasibleF: func(context.Context, *placement.PlacementRequest) (*placement.FeasibleResponse, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	p := testPlacementPlugin(t, &PlacementPluginConfig{
+		Name:          "site",
+		FailurePolicy: PlacementPluginFailClosed,
+	}, crashed)
+
+	restarted := testPlacementPlugin(t, &PlacementPluginConfig{Name: "site"}, &placement.MockPlacementPlugin{
+		FeasibleF: func(context.Context, *placement.PlacementRequest) (*placement.FeasibleResponse, error) {
+			return &placement.FeasibleResponse{Feasible: true}, nil
+		},
+	}).instance
+	p.dispense = func() (loader.PluginInstance, error) {
+		return restarted, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.supervise(ctx)
+
+	// The crashed process fails the calls until it is restarted.
+	p.instance.Kill()
+	ok, _ := p.Feasible(node, job, tg)
+	must.False(t, ok)
+
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		ok, _ := p.Feasible(node, job, tg)
+		return ok
+	}), wait.Timeout(5*time.Second), wait.Gap(100*time.Millisecond)))
+	must.False(t, p.exited())
+}
+
+func TestServer_SetupPlacementPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	mockPlugin := &placement.MockPlacementPlugin{
+		CapabilitiesF: func(context.Context) (*placement.Capabilities, error) {
+			return &placement.Capabilities{Feasibility: true}, nil
+		},
+	}
+	instance := loader.MockBasicExternalPlugin(mockPlugin, placement.ApiVersion010)
+
+	s, cleanup := TestServer(t, func(c *Config) {
+		c.PlacementPlugins = []*PlacementPluginConfig{{Name: "site"}}
+		c.PluginLoader = &loader.MockCatalog{
+			DispenseF: func(name, pluginType string, _ *base.AgentConfig, _ log.Logger) (loader.PluginInstance, error) {
+				must.Eq(t, "site", name)
+				must.Eq(t, base.PluginTypePlacement, pluginType)
+				return instance, nil
+			},
+		}
+	})
+	defer cleanup()
+
+	extensions := s.placementExtensions()
+	must.Len(t, 1, extensions)
+	must.Eq(t, "site", extensions[0].Name())
+
+	must.NoError(t, s.Shutdown())
+	must.True(t, instance.Exited())
+}
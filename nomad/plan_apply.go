@@ -662,6 +662,10 @@ OUTER:
 			mErr.Errors = append(mErr.Errors, err)
 		}
 
+		// Gang task groups can't be partially placed, so reject all the
+		// placements of the groups which lost some.
+		rejectPartialGangPlacements(plan, result)
+
 		// If there was a partial commit and we are operating within a
 		// deployment correct for any canary that may have been desired to be
 		// placed but wasn't actually placed
@@ -674,6 +678,118 @@ OUTER:
 	return result, mErr.ErrorOrNil()
 }
 
+// rejectPartialGangPlacements removes from the result all the placements of
+// the gang task groups which were not entirely committed, along with all the
+// stops of allocations of those groups and the preemptions made for them. This
+// could happen if the plan had a partial commit. Preemptions are kept on the
+// nodes which still have placements, since those were evaluated with the
+// resources they free.
+func rejectPartialGangPlacements(plan *structs.Plan, result *structs.PlanResult) {
+	if plan.Job == nil || len(result.NodeAllocation) == 0 {
+		return
+	}
+
+	gangs := map[string]struct{}{}
+	for _, tg := range plan.Job.TaskGroups {
+		if tg.Gang {
+			gangs[tg.Name] = struct{}{}
+		}
+	}
+	if len(gangs) == 0 {
+		return
+	}
+
+	countGangAllocs := func(nodeAllocs map[string][]*structs.Allocation) map[string]int {
+		counts := map[string]int{}
+		for _, allocs := range nodeAllocs {
+			for _, alloc := range allocs {
+				if _, ok := gangs[alloc.TaskGroup]; ok {
+					counts[alloc.TaskGroup]++
+				}
+			}
+		}
+		return counts
+	}
+	planned := countGangAllocs(plan.NodeAllocation)
+	committed := countGangAllocs(result.NodeAllocation)
+
+	partial := map[string]struct{}{}
+	for tg, n := range planned {
+		if committed[tg] != n {
+			partial[tg] = struct{}{}
+		}
+	}
+	if len(partial) == 0 {
+		return
+	}
+
+	// Collect the allocations replaced by any planned placement of the
+	// rejected gangs, including the placements already dropped by the partial
+	// commit.
+	replaced := map[string]struct{}{}
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if _, ok := partial[alloc.TaskGroup]; ok && alloc.PreviousAllocation != "" {
+				replaced[alloc.PreviousAllocation] = struct{}{}
+			}
+		}
+	}
+
+	// Build new slices since the result shares them with the plan.
+	rejected := map[string]struct{}{}
+	for nodeID, allocs := range result.NodeAllocation {
+		var kept []*structs.Allocation
+		for _, alloc := range allocs {
+			if _, ok := partial[alloc.TaskGroup]; ok {
+				rejected[alloc.ID] = struct{}{}
+				continue
+			}
+			kept = append(kept, alloc)
+		}
+		if len(kept) > 0 {
+			result.NodeAllocation[nodeID] = kept
+		} else {
+			delete(result.NodeAllocation, nodeID)
+		}
+	}
+
+	for nodeID, preempted := range result.NodePreemptions {
+		if _, ok := result.NodeAllocation[nodeID]; ok {
+			continue
+		}
+		var kept []*structs.Allocation
+		for _, alloc := range preempted {
+			if _, ok := rejected[alloc.PreemptedByAllocation]; !ok {
+				kept = append(kept, alloc)
+			}
+		}
+		if len(kept) > 0 {
+			result.NodePreemptions[nodeID] = kept
+		} else {
+			delete(result.NodePreemptions, nodeID)
+		}
+	}
+
+	// The gang is rejected as a whole, so none of its allocations are stopped.
+	for nodeID, updates := range result.NodeUpdate {
+		var kept []*structs.Allocation
+		for _, alloc := range updates {
+			if _, ok := partial[alloc.TaskGroup]; ok {
+				continue
+			}
+			if _, ok := replaced[alloc.ID]; ok {
+				continue
+			}
+			kept = append(kept, alloc)
+		}
+		if len(kept) > 0 {
+			result.NodeUpdate[nodeID] = kept
+		} else {
+			delete(result.NodeUpdate, nodeID)
+		}
+	}
+}
+
 // correctDeploymentCanaries ensures that the deployment object doesn't list any
 // canaries as placed if they didn't actually get placed. This could happen if
 // the plan had a partial commit.
@@ -738,6 +738,92 @@ func TestPlanApply_EvalPlan_Partial_AllAtOnce(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalPlan_Partial_Gang(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	node2 := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2))
+	node3 := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1002, node3))
+	node4 := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1003, node4))
+
+	// The gang group replaces an allocation, and one of its allocations
+	// doesn't fit
+	prev := mock.BatchAlloc()
+	prev.NodeID = node3.ID
+
+	// The gang group also stops an allocation on a node which keeps the
+	// placement of the other group
+	gangPrev := mock.BatchAlloc()
+	gangPrev.NodeID = node4.ID
+	otherPrev := mock.BatchAlloc()
+	otherPrev.NodeID = node4.ID
+	otherPrev.TaskGroup = "other"
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1004,
+		[]*structs.Allocation{prev, gangPrev, otherPrev}))
+	snap, _ := state.Snapshot()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Gang = true
+	other := job.TaskGroups[0].Copy()
+	other.Name = "other"
+	other.Gang = false
+	job.TaskGroups = append(job.TaskGroups, other)
+
+	stop := prev.Copy()
+	stop.DesiredStatus = structs.AllocDesiredStatusStop
+	gangStop := gangPrev.Copy()
+	gangStop.DesiredStatus = structs.AllocDesiredStatusStop
+	otherStop := otherPrev.Copy()
+	otherStop.DesiredStatus = structs.AllocDesiredStatusStop
+
+	alloc := mock.BatchAlloc()
+	alloc.NodeID = node.ID
+	alloc.Job = job
+	alloc.PreviousAllocation = prev.ID
+	alloc2 := mock.BatchAlloc()
+	alloc2.NodeID = node2.ID
+	alloc2.Job = job
+	alloc2.AllocatedResources = structs.NodeResourcesToAllocatedResources(node2.NodeResources)
+	alloc3 := mock.BatchAlloc()
+	alloc3.NodeID = node4.ID
+	alloc3.Job = job
+	alloc3.TaskGroup = "other"
+
+	plan := &structs.Plan{
+		Job: job,
+		NodeUpdate: map[string][]*structs.Allocation{
+			node3.ID: {stop},
+			node4.ID: {gangStop, otherStop},
+		},
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID:  {alloc},
+			node2.ID: {alloc2},
+			node4.ID: {alloc3},
+		},
+	}
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.NotNil(t, result)
+
+	// Only the allocation of the other group is kept, and none of the
+	// allocations of the gang group or replaced by it are stopped
+	must.Eq(t, map[string][]*structs.Allocation{node4.ID: {alloc3}}, result.NodeAllocation)
+	must.Eq(t, map[string][]*structs.Allocation{node4.ID: {otherStop}}, result.NodeUpdate)
+
+	// The plan is left untouched
+	must.MapLen(t, 3, plan.NodeAllocation)
+	must.Len(t, 1, plan.NodeUpdate[node3.ID])
+	must.Len(t, 2, plan.NodeUpdate[node4.ID])
+}
+
 func TestPlanApply_EvalNodePlan_Simple(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
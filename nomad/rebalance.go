@@ -0,0 +1,499 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"sort"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// rebalanceSpreadThreshold is how far above the average utilization of
+	// its pool a node must be for allocations to be moved off it, when the
+	// pool spreads allocations.
+	rebalanceSpreadThreshold = 0.1
+
+	// rebalanceBinpackThreshold is the utilization below which nodes are
+	// emptied, when the pool binpacks allocations.
+	rebalanceBinpackThreshold = 0.5
+)
+
+// rebalanceNode is a node considered by the rebalancer, with the resources
+// of its allocations.
+type rebalanceNode struct {
+	node *structs.Node
+
+	// capacity is the schedulable CPU and memory of the node.
+	cpu, mem float64
+
+	// usedCPU and usedMem are the resources of the allocations of the node.
+	usedCPU, usedMem float64
+
+	allocs []*structs.Allocation
+}
+
+// utilization returns the average of the CPU and memory utilization of the
+// node.
+func (n *rebalanceNode) utilization() float64 {
+	return (n.usedCPU/n.cpu + n.usedMem/n.mem) / 2
+}
+
+// share returns how much of the utilization of the node an allocation
+// accounts for.
+func (n *rebalanceNode) share(alloc *structs.Allocation) float64 {
+	cpu, mem := allocResources(alloc)
+	return (cpu/n.cpu + mem/n.mem) / 2
+}
+
+// fits returns whether the allocation fits in the free resources of the node.
+func (n *rebalanceNode) fits(alloc *structs.Allocation) bool {
+	cpu, mem := allocResources(alloc)
+	return n.usedCPU+cpu <= n.cpu && n.usedMem+mem <= n.mem
+}
+
+func (n *rebalanceNode) add(alloc *structs.Allocation) {
+	cpu, mem := allocResources(alloc)
+	n.usedCPU += cpu
+	n.usedMem += mem
+}
+
+func (n *rebalanceNode) remove(alloc *structs.Allocation) {
+	cpu, mem := allocResources(alloc)
+	n.usedCPU -= cpu
+	n.usedMem -= mem
+}
+
+func allocResources(alloc *structs.Allocation) (cpu, mem float64) {
+	if alloc.AllocatedResources == nil {
+		return 0, 0
+	}
+	r := alloc.AllocatedResources.Comparable()
+	return float64(r.Flattened.Cpu.CpuShares), float64(r.Flattened.Memory.MemoryMB)
+}
+
+// rebalancer computes the allocations to migrate to rebalance the nodes of
+// each node pool, following the scheduler algorithm of the pool. Pools
+// spreading allocations are rebalanced by moving allocations off the nodes
+// most utilized, and pools binpacking allocations by emptying the nodes
+// least utilized. Only healthy allocations of service jobs are moved, no
+// more at a time than the migrate block of their group allows.
+type rebalancer struct {
+	snap        *state.StateSnapshot
+	ws          memdb.WatchSet
+	schedConfig *structs.SchedulerConfiguration
+
+	// jobs caches the jobs of allocations, nil for jobs whose allocations
+	// can't be moved.
+	jobs map[structs.NamespacedID]*structs.Job
+
+	// budgets is how many more allocations of each group can be moved,
+	// keyed by job and group name.
+	budgets map[structs.NamespacedID]map[string]int
+
+	moves []*structs.RebalanceMove
+}
+
+// rebalancePlan returns the allocations to migrate to rebalance the nodes of
+// each node pool.
+func rebalancePlan(snap *state.StateSnapshot, schedConfig *structs.SchedulerConfiguration) ([]*structs.RebalanceMove, error) {
+	r := &rebalancer{
+		snap:        snap,
+		ws:          memdb.NewWatchSet(),
+		schedConfig: schedConfig,
+		jobs:        make(map[structs.NamespacedID]*structs.Job),
+		budgets:     make(map[structs.NamespacedID]map[string]int),
+	}
+
+	pools, err := r.nodesByPool()
+	if err != nil {
+		return nil, err
+	}
+
+	// Rebalance the pools in a stable order so the moves are deterministic
+	// when limited.
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if r.full() {
+			break
+		}
+
+		pool, err := snap.NodePoolByName(r.ws, name)
+		if err != nil {
+			return nil, err
+		}
+
+		switch schedConfig.WithNodePool(pool).EffectiveSchedulerAlgorithm() {
+		case structs.SchedulerAlgorithmSpread:
+			err = r.spread(name, pools[name])
+		default:
+			err = r.binpack(name, pools[name])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.moves, nil
+}
+
+// nodesByPool returns the nodes able to run allocations, grouped by pool.
+func (r *rebalancer) nodesByPool() (map[string][]*rebalanceNode, error) {
+	iter, err := r.snap.Nodes(r.ws)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make(map[string][]*rebalanceNode)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() || node.NodeResources == nil {
+			continue
+		}
+
+		available := node.NodeResources.Comparable()
+		available.Subtract(node.ReservedResources.Comparable())
+		n := &rebalanceNode{
+			node: node,
+			cpu:  float64(available.Flattened.Cpu.CpuShares),
+			mem:  float64(available.Flattened.Memory.MemoryMB),
+		}
+		if n.cpu <= 0 || n.mem <= 0 {
+			continue
+		}
+
+		allocs, err := r.snap.AllocsByNode(r.ws, node.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, alloc := range allocs {
+			if alloc.TerminalStatus() {
+				continue
+			}
+			n.add(alloc)
+			n.allocs = append(n.allocs, alloc)
+		}
+
+		pools[node.NodePool] = append(pools[node.NodePool], n)
+	}
+	return pools, nil
+}
+
+// spread moves allocations off the nodes whose utilization is above the
+// average of the pool, as long as the nodes below the average can absorb
+// them.
+func (r *rebalancer) spread(pool string, nodes []*rebalanceNode) error {
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	var mean float64
+	for _, n := range nodes {
+		mean += n.utilization()
+	}
+	mean /= float64(len(nodes))
+
+	// deficit approximates how much utilization the nodes below the average
+	// can absorb before reaching it.
+	var deficit float64
+	var sources []*rebalanceNode
+	for _, n := range nodes {
+		util := n.utilization()
+		if util < mean {
+			deficit += mean - util
+		} else if util > mean+rebalanceSpreadThreshold {
+			sources = append(sources, n)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		a, b := sources[i].utilization(), sources[j].utilization()
+		if a != b {
+			return a > b
+		}
+		return sources[i].node.ID < sources[j].node.ID
+	})
+
+	for _, n := range sources {
+		allocs := n.allocs
+		sort.Slice(allocs, func(i, j int) bool {
+			a, b := n.share(allocs[i]), n.share(allocs[j])
+			if a != b {
+				return a > b
+			}
+			return allocs[i].ID < allocs[j].ID
+		})
+
+		for _, alloc := range allocs {
+			if r.full() || n.utilization() <= mean+rebalanceSpreadThreshold {
+				break
+			}
+
+			// Don't move allocations that would leave the node below the
+			// average or overload the other nodes.
+			share := n.share(alloc)
+			if share == 0 || n.utilization()-share < mean || share > deficit {
+				continue
+			}
+
+			ok, err := r.movable(alloc)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			r.move(alloc, pool, structs.RebalanceReasonSpread)
+			n.remove(alloc)
+			deficit -= share
+		}
+	}
+	return nil
+}
+
+// binpack empties the nodes whose utilization is below the binpack
+// threshold, least utilized first, when all their allocations fit on the
+// most utilized nodes of the pool.
+func (r *rebalancer) binpack(pool string, nodes []*rebalanceNode) error {
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i].utilization(), nodes[j].utilization()
+		if a != b {
+			return a < b
+		}
+		return nodes[i].node.ID < nodes[j].node.ID
+	})
+
+	// Nodes either get emptied or receive allocations, never both.
+	emptied := make(map[string]bool)
+	receiving := make(map[string]bool)
+
+	for _, n := range nodes {
+		if r.full() {
+			break
+		}
+		if receiving[n.node.ID] || n.utilization() >= rebalanceBinpackThreshold {
+			continue
+		}
+
+		// System allocations run on every node so they don't keep a node
+		// from being emptied.
+		var allocs []*structs.Allocation
+		for _, alloc := range n.allocs {
+			if alloc.Job == nil || (alloc.Job.Type != structs.JobTypeSystem && alloc.Job.Type != structs.JobTypeSysBatch) {
+				allocs = append(allocs, alloc)
+			}
+		}
+		if len(allocs) == 0 {
+			continue
+		}
+		sort.Slice(allocs, func(i, j int) bool {
+			a, b := n.share(allocs[i]), n.share(allocs[j])
+			if a != b {
+				return a > b
+			}
+			return allocs[i].ID < allocs[j].ID
+		})
+
+		placements, err := r.binpackNode(n, allocs, nodes, emptied)
+		if err != nil {
+			return err
+		}
+		if placements == nil {
+			continue
+		}
+
+		emptied[n.node.ID] = true
+		for i, alloc := range allocs {
+			placements[i].add(alloc)
+			n.remove(alloc)
+			receiving[placements[i].node.ID] = true
+			r.move(alloc, pool, structs.RebalanceReasonBinpack)
+		}
+	}
+	return nil
+}
+
+// binpackNode returns the nodes the allocations of a node fit on, the most
+// utilized first, or nil if the node can't be emptied.
+func (r *rebalancer) binpackNode(n *rebalanceNode, allocs []*structs.Allocation,
+	nodes []*rebalanceNode, emptied map[string]bool) ([]*rebalanceNode, error) {
+
+	if limit := r.schedConfig.RebalanceConfig.MaxMoves; limit > 0 && len(r.moves)+len(allocs) > limit {
+		return nil, nil
+	}
+
+	// Every allocation must be movable within the budget of its group.
+	needed := make(map[structs.NamespacedID]map[string]int)
+	for _, alloc := range allocs {
+		ok, err := r.movable(alloc)
+		if err != nil || !ok {
+			return nil, err
+		}
+		id := alloc.JobNamespacedID()
+		if needed[id] == nil {
+			needed[id] = make(map[string]int)
+		}
+		needed[id][alloc.TaskGroup]++
+		if needed[id][alloc.TaskGroup] > r.budgets[id][alloc.TaskGroup] {
+			return nil, nil
+		}
+	}
+
+	// Place the allocations on copies of the destination nodes so a node
+	// that can't be emptied leaves them untouched.
+	dests := make([]*rebalanceNode, 0, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if d := nodes[i]; d != n && !emptied[d.node.ID] {
+			cp := *d
+			dests = append(dests, &cp)
+		}
+	}
+
+	placements := make([]*rebalanceNode, len(allocs))
+	for i, alloc := range allocs {
+		for _, d := range dests {
+			if d.fits(alloc) {
+				d.add(alloc)
+				placements[i] = d
+				break
+			}
+		}
+		if placements[i] == nil {
+			return nil, nil
+		}
+	}
+
+	// Map the copies back to the nodes.
+	byID := make(map[string]*rebalanceNode, len(nodes))
+	for _, d := range nodes {
+		byID[d.node.ID] = d
+	}
+	for i, d := range placements {
+		placements[i] = byID[d.node.ID]
+	}
+	return placements, nil
+}
+
+// movable returns whether an allocation can be moved within the budget of its
+// group.
+func (r *rebalancer) movable(alloc *structs.Allocation) (bool, error) {
+	if alloc.ClientStatus != structs.AllocClientStatusRunning ||
+		alloc.DesiredTransition.ShouldMigrate() ||
+		!alloc.DeploymentStatus.IsHealthy() {
+		return false, nil
+	}
+
+	job, err := r.job(alloc)
+	if err != nil || job == nil {
+		return false, err
+	}
+	tg := job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.Migrate == nil {
+		return false, nil
+	}
+
+	budget, err := r.budget(job, tg)
+	return budget > 0, err
+}
+
+// job returns the job of an allocation if its allocations can be moved: it
+// is a running service job without active deployment.
+func (r *rebalancer) job(alloc *structs.Allocation) (*structs.Job, error) {
+	id := alloc.JobNamespacedID()
+	if job, ok := r.jobs[id]; ok {
+		return job, nil
+	}
+
+	job, err := r.snap.JobByID(r.ws, id.Namespace, id.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil && (job.Type != structs.JobTypeService || job.Stopped()) {
+		job = nil
+	}
+	if job != nil {
+		deployment, err := r.snap.LatestDeploymentByJobID(r.ws, id.Namespace, id.ID)
+		if err != nil {
+			return nil, err
+		}
+		if deployment != nil && deployment.Active() {
+			job = nil
+		}
+	}
+
+	r.jobs[id] = job
+	return job, nil
+}
+
+// budget returns how many more allocations of a group can be moved. Like
+// when draining nodes, no more than max_parallel allocations of the group
+// are allowed to be unhealthy or migrating at a time, so the allocations
+// already migrating or unhealthy are subtracted from max_parallel.
+func (r *rebalancer) budget(job *structs.Job, tg *structs.TaskGroup) (int, error) {
+	id := job.NamespacedID()
+	if budget, ok := r.budgets[id][tg.Name]; ok {
+		return budget, nil
+	}
+
+	allocs, err := r.snap.AllocsByJob(r.ws, job.Namespace, job.ID, false)
+	if err != nil {
+		return 0, err
+	}
+	healthy, unavailable := 0, 0
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != tg.Name || alloc.TerminalStatus() {
+			continue
+		}
+		if alloc.DesiredTransition.ShouldMigrate() || !alloc.DeploymentStatus.IsHealthy() {
+			unavailable++
+		} else {
+			healthy++
+		}
+	}
+
+	budget := tg.Migrate.MaxParallel - unavailable
+	if floor := healthy - (tg.Count - tg.Migrate.MaxParallel); floor < budget {
+		budget = floor
+	}
+	if budget < 0 {
+		budget = 0
+	}
+	if r.budgets[id] == nil {
+		r.budgets[id] = make(map[string]int)
+	}
+	r.budgets[id][tg.Name] = budget
+	return budget, nil
+}
+
+// move records the move of an allocation.
+func (r *rebalancer) move(alloc *structs.Allocation, pool, reason string) {
+	r.budgets[alloc.JobNamespacedID()][alloc.TaskGroup]--
+	r.moves = append(r.moves, &structs.RebalanceMove{
+		AllocID:   alloc.ID,
+		AllocName: alloc.Name,
+		Namespace: alloc.Namespace,
+		JobID:     alloc.JobID,
+		TaskGroup: alloc.TaskGroup,
+		NodeID:    alloc.NodeID,
+		NodePool:  pool,
+		Reason:    reason,
+	})
+}
+
+// full returns whether the rebalancer reached the maximum number of moves.
+func (r *rebalancer) full() bool {
+	limit := r.schedConfig.RebalanceConfig.MaxMoves
+	return limit > 0 && len(r.moves) >= limit
+}
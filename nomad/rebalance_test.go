@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// testRebalanceState upserts a node for each entry of allocsPerNode running
+// that many healthy allocations of a service job, and returns the nodes and
+// allocations.
+func testRebalanceState(t *testing.T, store *state.StateStore, allocsPerNode []int,
+	maxParallel int) ([]*structs.Node, []*structs.Allocation) {
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 0
+	for _, n := range allocsPerNode {
+		job.TaskGroups[0].Count += n
+	}
+	job.TaskGroups[0].Migrate.MaxParallel = maxParallel
+	index++
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, job))
+
+	var nodes []*structs.Node
+	var allocs []*structs.Allocation
+	for _, n := range allocsPerNode {
+		node := mock.Node()
+		index++
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node))
+		nodes = append(nodes, node)
+
+		for i := 0; i < n; i++ {
+			alloc := mock.Alloc()
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.NodeID = node.ID
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+			alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+				Healthy: pointer.Of(true),
+			}
+			alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = 2000
+			alloc.AllocatedResources.Tasks["web"].Memory.MemoryMB = 1024
+			allocs = append(allocs, alloc)
+		}
+	}
+	index++
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index, allocs))
+
+	return nodes, allocs
+}
+
+func TestRebalancePlan_Spread(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	nodes, _ := testRebalanceState(t, store, []int{4, 0, 0}, 2)
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+
+	// Allocations are moved off the most utilized node until it is close to
+	// the average, no more than max_parallel at a time.
+	config := &structs.SchedulerConfiguration{SchedulerAlgorithm: structs.SchedulerAlgorithmSpread}
+	moves, err := rebalancePlan(snap, config)
+	must.NoError(t, err)
+	must.Len(t, 2, moves)
+	for _, move := range moves {
+		must.Eq(t, nodes[0].ID, move.NodeID)
+		must.Eq(t, structs.NodePoolDefault, move.NodePool)
+		must.Eq(t, structs.RebalanceReasonSpread, move.Reason)
+	}
+
+	// The number of moves is limited.
+	config.RebalanceConfig.MaxMoves = 1
+	moves, err = rebalancePlan(snap, config)
+	must.NoError(t, err)
+	must.Len(t, 1, moves)
+
+	// Balanced pools are left alone.
+	store = state.TestStateStore(t)
+	testRebalanceState(t, store, []int{2, 1, 1}, 2)
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+
+	moves, err = rebalancePlan(snap, &structs.SchedulerConfiguration{SchedulerAlgorithm: structs.SchedulerAlgorithmSpread})
+	must.NoError(t, err)
+	must.SliceEmpty(t, moves)
+}
+
+func TestRebalancePlan_Spread_MaxParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	_, allocs := testRebalanceState(t, store, []int{4, 0, 0}, 2)
+
+	// An allocation already migrating counts against max_parallel.
+	alloc := allocs[0].Copy()
+	alloc.DesiredTransition.Migrate = pointer.Of(true)
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index+1, []*structs.Allocation{alloc}))
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+
+	moves, err := rebalancePlan(snap, &structs.SchedulerConfiguration{SchedulerAlgorithm: structs.SchedulerAlgorithmSpread})
+	must.NoError(t, err)
+	must.Len(t, 1, moves)
+	must.NotEq(t, alloc.ID, moves[0].AllocID)
+
+	// An unhealthy allocation counts against max_parallel even when the
+	// group has more allocations than its count.
+	store = state.TestStateStore(t)
+	nodes, allocs := testRebalanceState(t, store, []int{4, 0, 0}, 2)
+	unhealthy := allocs[0].Copy()
+	unhealthy.ID = uuid.Generate()
+	unhealthy.NodeID = nodes[1].ID
+	unhealthy.DeploymentStatus = nil
+	index, err = store.LatestIndex()
+	must.NoError(t, err)
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index+1, []*structs.Allocation{unhealthy}))
+
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+
+	moves, err = rebalancePlan(snap, &structs.SchedulerConfiguration{SchedulerAlgorithm: structs.SchedulerAlgorithmSpread})
+	must.NoError(t, err)
+	must.Len(t, 1, moves)
+}
+
+func TestRebalancePlan_Binpack(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	nodes, allocs := testRebalanceState(t, store, []int{3, 1}, 1)
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+
+	// The least utilized node is emptied onto the other node.
+	moves, err := rebalancePlan(snap, &structs.SchedulerConfiguration{})
+	must.NoError(t, err)
+	must.Len(t, 1, moves)
+	must.Eq(t, allocs[3].ID, moves[0].AllocID)
+	must.Eq(t, nodes[1].ID, moves[0].NodeID)
+	must.Eq(t, structs.RebalanceReasonBinpack, moves[0].Reason)
+
+	// Nodes are only emptied within max_parallel.
+	store = state.TestStateStore(t)
+	testRebalanceState(t, store, []int{3, 2}, 1)
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+
+	moves, err = rebalancePlan(snap, &structs.SchedulerConfiguration{})
+	must.NoError(t, err)
+	must.SliceEmpty(t, moves)
+}
@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Reservation endpoint is used to manage the capacity reserved for namespaces
+// on the nodes of node pools.
+type Reservation struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger hclog.Logger
+}
+
+func NewReservationEndpoint(srv *Server, ctx *RPCContext) *Reservation {
+	return &Reservation{srv: srv, ctx: ctx, logger: srv.logger.Named("reservation")}
+}
+
+// List the reservations of a namespace, or of all namespaces when the
+// wildcard namespace is used. The Prefix query option filters reservations
+// by ID.
+func (r *Reservation) List(args *structs.ReservationListRequest, reply *structs.ReservationListResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.List", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "list"}, time.Now())
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	// allowFunc checks whether the caller has the read-job capability on the
+	// passed namespace.
+	allowFunc := func(ns string) bool {
+		return aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob)
+	}
+
+	allNamespaces := args.RequestNamespace() == structs.AllNamespacesSentinel
+	if !allNamespaces && !allowFunc(args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+
+			// nil allowableNamespaces means the caller can view all
+			// namespaces.
+			var allowableNamespaces map[string]bool
+			var iter memdb.ResultIterator
+			var err error
+			if allNamespaces {
+				allowableNamespaces, err = allowedNSes(aclObj, store, allowFunc)
+				switch err {
+				case structs.ErrPermissionDenied:
+					reply.Reservations = []*structs.Reservation{}
+					return nil
+				case nil:
+					// Fallthrough.
+				default:
+					return err
+				}
+				iter, err = store.Reservations(ws)
+			} else {
+				iter, err = store.ReservationsByNamespace(ws, args.RequestNamespace())
+			}
+			if err != nil {
+				return err
+			}
+
+			reservations := []*structs.Reservation{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reservation := raw.(*structs.Reservation)
+				if allowableNamespaces != nil && !allowableNamespaces[reservation.Namespace] {
+					continue
+				}
+				if !strings.HasPrefix(reservation.ID, args.Prefix) {
+					continue
+				}
+				reservations = append(reservations, reservation)
+			}
+
+			reply.Reservations = reservations
+			return r.srv.setReplyQueryMeta(store, state.TableReservations, &reply.QueryMeta)
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// GetReservation returns the reservation requested, or nil if the
+// reservation doesn't exist.
+func (r *Reservation) GetReservation(args *structs.ReservationSpecificRequest, reply *structs.SingleReservationResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.GetReservation", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "get_reservation"}, time.Now())
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			reservation, err := store.ReservationByID(ws, args.RequestNamespace(), args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Reservation = reservation
+			if reservation != nil {
+				reply.Index = reservation.ModifyIndex
+				r.srv.setQueryMeta(&reply.QueryMeta)
+				return nil
+			}
+			return r.srv.setReplyQueryMeta(store, state.TableReservations, &reply.QueryMeta)
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// Upsert creates or updates reservations of the request namespace. Reserving
+// capacity takes it away from the other namespaces, so the caller needs the
+// operator write capability.
+func (r *Reservation) Upsert(args *structs.ReservationUpsertRequest, reply *structs.ReservationUpsertResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.Upsert", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "upsert"}, time.Now())
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.Reservations) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one reservation")
+	}
+
+	snap, err := r.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	namespace := args.RequestNamespace()
+	if ns, err := snap.NamespaceByName(nil, namespace); err != nil {
+		return err
+	} else if ns == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "namespace %q does not exist", namespace)
+	}
+
+	for _, reservation := range args.Reservations {
+		if reservation.Namespace == "" {
+			reservation.Namespace = namespace
+		} else if reservation.Namespace != namespace {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest,
+				"reservation namespace %q does not match request namespace %q", reservation.Namespace, namespace)
+		}
+
+		// Only existing reservations may be referred to by ID, new ones get
+		// a generated ID.
+		if reservation.ID != "" {
+			existing, err := snap.ReservationByID(nil, namespace, reservation.ID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return structs.NewErrRPCCodedf(http.StatusNotFound, "reservation %s not found", reservation.ID)
+			}
+			reservation.CreateTime = existing.CreateTime
+		}
+
+		reservation.Canonicalize()
+		if err := reservation.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid reservation: %v", err)
+		}
+
+		if pool, err := snap.NodePoolByName(nil, reservation.NodePool); err != nil {
+			return err
+		} else if pool == nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "node pool %q does not exist", reservation.NodePool)
+		}
+	}
+
+	_, index, err := r.srv.raftApply(structs.ReservationUpsertRequestType, args)
+	if err != nil {
+		r.logger.Error("raft apply failed", "error", err, "method", "upsert")
+		return err
+	}
+
+	reply.Reservations = args.Reservations
+	reply.Index = index
+	return nil
+}
+
+// Delete removes reservations of the request namespace.
+func (r *Reservation) Delete(args *structs.ReservationDeleteRequest, reply *structs.GenericResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.Delete", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "delete"}, time.Now())
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.IDs) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one reservation to delete")
+	}
+
+	snap, err := r.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, id := range args.IDs {
+		existing, err := snap.ReservationByID(nil, args.RequestNamespace(), id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return structs.NewErrRPCCodedf(http.StatusNotFound, "reservation %s not found", id)
+		}
+	}
+
+	_, index, err := r.srv.raftApply(structs.ReservationDeleteRequestType, args)
+	if err != nil {
+		r.logger.Error("raft apply failed", "error", err, "method", "delete")
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
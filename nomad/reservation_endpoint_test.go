@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservationEndpoint_CRUD(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanup := TestServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+
+	upsertReq := &structs.ReservationUpsertRequest{
+		Reservations: []*structs.Reservation{{
+			Description: "batch headroom",
+			Resources:   &structs.ReservationResources{CPU: 1000, MemoryMB: 512},
+		}},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.ReservationUpsertResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.Upsert", upsertReq, &upsertResp))
+	must.Len(t, 1, upsertResp.Reservations)
+	must.NotEq(t, 0, upsertResp.Index)
+
+	created := upsertResp.Reservations[0]
+	must.UUIDv4(t, created.ID)
+	must.Eq(t, structs.DefaultNamespace, created.Namespace)
+	must.Eq(t, structs.NodePoolDefault, created.NodePool)
+	must.False(t, created.CreateTime.IsZero())
+
+	getReq := &structs.ReservationSpecificRequest{
+		ID:           created.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleReservationResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.GetReservation", getReq, &getResp))
+	must.NotNil(t, getResp.Reservation)
+	must.Eq(t, 1000, getResp.Reservation.Resources.CPU)
+	must.Eq(t, upsertResp.Index, getResp.Index)
+
+	// Updating the reservation keeps its create time.
+	update := getResp.Reservation.Copy()
+	update.Resources.CPU = 2000
+	update.ExpirationTTL = time.Hour
+	upsertReq.Reservations = []*structs.Reservation{update}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.Upsert", upsertReq, &upsertResp))
+	must.Eq(t, created.CreateTime, upsertResp.Reservations[0].CreateTime)
+	must.True(t, upsertResp.Reservations[0].HasExpirationTime())
+
+	// Unknown IDs, node pools and namespaces are rejected.
+	unknown := update.Copy()
+	unknown.ID = "00000000-0000-0000-0000-000000000000"
+	upsertReq.Reservations = []*structs.Reservation{unknown}
+	err := msgpackrpc.CallWithCodec(codec, "Reservation.Upsert", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "not found")
+
+	upsertReq.Reservations = []*structs.Reservation{{
+		NodePool:  "unknown",
+		Resources: &structs.ReservationResources{CPU: 1000},
+	}}
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.Upsert", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, `node pool "unknown" does not exist`)
+
+	upsertReq.Reservations = []*structs.Reservation{{
+		Resources: &structs.ReservationResources{CPU: 1000},
+	}}
+	upsertReq.Namespace = "unknown"
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.Upsert", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, `namespace "unknown" does not exist`)
+
+	listReq := &structs.ReservationListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.ReservationListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Reservations)
+
+	listReq.Prefix = created.ID[:4]
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Reservations)
+
+	deleteReq := &structs.ReservationDeleteRequest{
+		IDs:          []string{created.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var deleteResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.Delete", deleteReq, &deleteResp))
+
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.Delete", deleteReq, &deleteResp)
+	must.ErrorContains(t, err, "not found")
+
+	listReq.Prefix = ""
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.List", listReq, &listResp))
+	must.SliceEmpty(t, listResp.Reservations)
+}
+
+func TestReservationEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanup := TestACLServer(t, nil)
+	defer cleanup()
+	testutil.WaitForLeader(t, s.RPC)
+	codec := rpcClient(t, s)
+	store := s.fsm.State()
+
+	ns := mock.Namespace()
+	must.NoError(t, store.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	r1 := &structs.Reservation{
+		Namespace: structs.DefaultNamespace,
+		Resources: &structs.ReservationResources{CPU: 1000},
+	}
+	r2 := &structs.Reservation{
+		Namespace: ns.Name,
+		Resources: &structs.ReservationResources{CPU: 1000},
+	}
+	r1.Canonicalize()
+	r2.Canonicalize()
+	must.NoError(t, store.UpsertReservations(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Reservation{r1, r2}))
+
+	readToken := mock.CreatePolicyAndToken(t, store, 1002, "read-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	submitToken := mock.CreatePolicyAndToken(t, store, 1003, "submit-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	operatorToken := mock.CreatePolicyAndToken(t, store, 1004, "operator-write",
+		`operator { policy = "write" }`)
+
+	listReq := &structs.ReservationListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.ReservationListResponse
+
+	err := msgpackrpc.CallWithCodec(codec, "Reservation.List", listReq, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Listing all namespaces only returns the readable ones.
+	listReq.AuthToken = readToken.SecretID
+	listReq.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Reservations)
+	must.Eq(t, r1.ID, listResp.Reservations[0].ID)
+
+	listReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.List", listReq, &listResp))
+	must.Len(t, 2, listResp.Reservations)
+
+	getReq := &structs.ReservationSpecificRequest{
+		ID: r2.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: ns.Name,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var getResp structs.SingleReservationResponse
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.GetReservation", getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Reserving capacity requires the operator write capability, even in
+	// the namespaces where jobs can be submitted.
+	upsertReq := &structs.ReservationUpsertRequest{
+		Reservations: []*structs.Reservation{{
+			Resources: &structs.ReservationResources{MemoryMB: 512},
+		}},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: submitToken.SecretID,
+		},
+	}
+	var upsertResp structs.ReservationUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.Upsert", upsertReq, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = operatorToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.Upsert", upsertReq, &upsertResp))
+
+	deleteReq := &structs.ReservationDeleteRequest{
+		IDs: []string{r1.ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: submitToken.SecretID,
+		},
+	}
+	var deleteResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.Delete", deleteReq, &deleteResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	deleteReq.AuthToken = operatorToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.Delete", deleteReq, &deleteResp))
+}
@@ -263,6 +263,9 @@ type Server struct {
 	workerConfigLock sync.RWMutex
 	workersEventCh   chan interface{}
 
+	// placementPlugins are the placement plugins consulted by the schedulers
+	placementPlugins []*placementPlugin
+
 	// oidcProviderCache maintains a cache of OIDC providers. This is useful as
 	// the provider performs background HTTP requests. When the Nomad server is
 	// shutting down, the oidcProviderCache.Shutdown() function must be called.
@@ -466,6 +469,13 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 		return nil, fmt.Errorf("Failed to start serf: %v", err)
 	}
 
+	// Load the placement plugins before the workers start scheduling
+	if err := s.setupPlacementPlugins(); err != nil {
+		s.Shutdown()
+		s.logger.Error("failed to load placement plugins", "error", err)
+		return nil, fmt.Errorf("Failed to load placement plugins: %v", err)
+	}
+
 	// Initialize the scheduling workers
 	if err := s.setupWorkers(s.shutdownCtx); err != nil {
 		s.Shutdown()
@@ -757,6 +767,9 @@ func (s *Server) Shutdown() error {
 		s.oidcProviderCache.Shutdown()
 	}
 
+	// Stop the placement plugin processes
+	s.shutdownPlacementPlugins()
+
 	return nil
 }
 
@@ -1304,6 +1317,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewCSIVolumeEndpoint(s, ctx))
 	_ = server.Register(NewCSIPluginEndpoint(s, ctx))
 	_ = server.Register(NewIngressPluginEndpoint(s, ctx))
+	_ = server.Register(NewIngressRouteEndpoint(s, ctx))
 	_ = server.Register(NewDeploymentEndpoint(s, ctx))
 	_ = server.Register(NewEvalEndpoint(s, ctx))
 	_ = server.Register(NewJobEndpoints(s, ctx))
@@ -1314,6 +1328,8 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewPeriodicEndpoint(s, ctx))
 	_ = server.Register(NewPlanEndpoint(s, ctx))
 	_ = server.Register(NewRegionEndpoint(s, ctx))
+	_ = server.Register(NewReservationEndpoint(s, ctx))
+	_ = server.Register(NewMeshEndpoint(s, ctx))
 	_ = server.Register(NewScalingEndpoint(s, ctx))
 	_ = server.Register(NewSearchEndpoint(s, ctx))
 	_ = server.Register(NewServiceRegistrationEndpoint(s, ctx))
@@ -200,6 +200,9 @@ func (s *ServiceRegistration) List(
 
 			for raw := iter.Next(); raw != nil; raw = iter.Next() {
 				serviceReg := raw.(*structs.ServiceRegistration)
+				if args.Healthy && !serviceReg.Healthy() {
+					continue
+				}
 				tagSet.add(serviceReg.ServiceName, serviceReg.Tags)
 			}
 
@@ -291,6 +294,9 @@ func (s *ServiceRegistration) listAllServiceRegistrations(
 				if allowedNSes != nil && !allowedNSes[reg.Namespace] {
 					continue
 				}
+				if args.Healthy && !reg.Healthy() {
+					continue
+				}
 
 				// Accumulate the set of tags associated with a particular service name in a particular namespace
 				nsSvcTagSet.add(reg.Namespace, reg.ServiceName, reg.Tags)
@@ -343,8 +349,21 @@ func (s *ServiceRegistration) GetService(
 	if err != nil {
 		return structs.ErrPermissionDenied
 	}
-	if !aclObj.AllowServiceRegistrationReadList(args.RequestNamespace(),
-		args.GetIdentity().Claims != nil) {
+
+	allowed := aclObj.AllowServiceRegistrationReadList(args.RequestNamespace(),
+		args.GetIdentity().Claims != nil)
+
+	// Clients look up the upstream services of the Nomad service mesh
+	// proxies they run, so they may only read the services which are
+	// upstreams of the allocations placed on them.
+	if clientID := args.GetIdentity().ClientID; clientID != "" && s.srv.config.ACLEnabled {
+		allowed, err = nodeHasMeshUpstream(s.srv.State(),
+			clientID, args.RequestNamespace(), args.ServiceName)
+		if err != nil {
+			return err
+		}
+	}
+	if !allowed {
 		return structs.ErrPermissionDenied
 	}
 
@@ -372,9 +391,20 @@ func (s *ServiceRegistration) GetService(
 			// Set up our output after we have checked the error.
 			var services []*structs.ServiceRegistration
 
+			// Filter out the registrations with failing or pending checks if
+			// the caller only wants healthy registrations.
+			var filters []paginator.Filter
+			if args.Healthy {
+				filters = append(filters, paginator.GenericFilter{
+					Allow: func(raw interface{}) (bool, error) {
+						return raw.(*structs.ServiceRegistration).Healthy(), nil
+					},
+				})
+			}
+
 			// Build the paginator. This includes the function that is
 			// responsible for appending a registration to the services array.
-			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, nil, args.QueryOptions,
+			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
 				func(raw interface{}) error {
 					services = append(services, raw.(*structs.ServiceRegistration))
 					return nil
@@ -476,3 +506,32 @@ func (*ServiceRegistration) choose(services []*structs.ServiceRegistration, para
 
 	return chosen, nil
 }
+
+// nodeHasMeshUpstream returns true if the node runs a non-terminal allocation
+// of the namespace with a mesh service that has the service as upstream.
+func nodeHasMeshUpstream(store *state.StateStore, nodeID, namespace, service string) (bool, error) {
+	allocs, err := store.AllocsByNodeTerminal(nil, nodeID, false)
+	if err != nil {
+		return false, err
+	}
+	for _, alloc := range allocs {
+		if alloc.Namespace != namespace || alloc.Job == nil {
+			continue
+		}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil {
+			continue
+		}
+		for _, s := range tg.Services {
+			if s.Mesh == nil {
+				continue
+			}
+			for _, upstream := range s.Mesh.Upstreams {
+				if upstream.DestinationName == service {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
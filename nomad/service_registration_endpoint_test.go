@@ -1161,6 +1161,62 @@ func TestServiceRegistration_GetService(t *testing.T) {
 				codec := rpcClient(t, s)
 				testutil.WaitForKeyring(t, s.RPC, "global")
 
+				services := mock.ServiceRegistrations()
+				require.NoError(t, s.fsm.State().UpsertServiceRegistrations(
+					structs.MsgTypeTestSetup, 10, services))
+
+				node := mock.Node()
+				require.NoError(t, s.State().UpsertNode(structs.MsgTypeTestSetup, 20, node))
+
+				// Clients look up the upstreams of the mesh proxies they run
+				// using their node secret, but not other services.
+				serviceRegReq := &structs.ServiceRegistrationByNameRequest{
+					ServiceName: services[1].ServiceName,
+					QueryOptions: structs.QueryOptions{
+						Namespace: services[1].Namespace,
+						Region:    s.Region(),
+						AuthToken: node.SecretID,
+					},
+				}
+				var serviceRegResp structs.ServiceRegistrationByNameResponse
+				err := msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+				alloc := mock.Alloc()
+				alloc.NodeID = node.ID
+				alloc.Namespace = services[1].Namespace
+				alloc.Job.Namespace = services[1].Namespace
+				alloc.Job.TaskGroups[0].Services = []*structs.Service{{
+					Name:     "web",
+					Provider: structs.ServiceProviderNomad,
+					Mesh: &structs.ServiceMesh{
+						LocalServicePort: 8080,
+						Upstreams: []*structs.MeshUpstream{
+							{DestinationName: services[1].ServiceName, LocalBindPort: 9090},
+						},
+					},
+				}}
+				require.NoError(t, s.State().UpsertAllocs(structs.MsgTypeTestSetup, 30, []*structs.Allocation{alloc}))
+
+				err = msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				require.NoError(t, err)
+				require.Len(t, serviceRegResp.Services, 1)
+
+				// The upstreams of allocations on other nodes are not readable.
+				serviceRegReq.ServiceName = services[0].ServiceName
+				err = msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+			},
+			name: "ACLs enabled get service using node secret",
+		},
+		{
+			serverFn: func(t *testing.T) (*Server, *structs.ACLToken, func()) {
+				return TestACLServer(t, nil)
+			},
+			testFn: func(t *testing.T, s *Server, token *structs.ACLToken) {
+				codec := rpcClient(t, s)
+				testutil.WaitForKeyring(t, s.RPC, "global")
+
 				// Generate mock services then upsert them individually using different indexes.
 				services := mock.ServiceRegistrations()
 
@@ -1450,6 +1506,73 @@ func TestServiceRegistration_GetService(t *testing.T) {
 	}
 }
 
+func TestServiceRegistration_Healthy(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanup := TestServer(t, nil)
+	defer cleanup()
+	codec := rpcClient(t, s)
+	testutil.WaitForKeyring(t, s.RPC, "global")
+
+	// Register three instances of the same service, one of which has no
+	// checks, one with passing checks, and one with failing checks.
+	services := make([]*structs.ServiceRegistration, 3)
+	for i, status := range []structs.CheckStatus{"", structs.CheckSuccess, structs.CheckFailure} {
+		services[i] = mock.ServiceRegistrations()[0]
+		services[i].ID = fmt.Sprintf("%s-%d", services[i].ID, i)
+		services[i].CheckStatus = status
+	}
+	must.NoError(t, s.fsm.State().UpsertServiceRegistrations(
+		structs.MsgTypeTestSetup, 10, services))
+
+	getReq := &structs.ServiceRegistrationByNameRequest{
+		ServiceName: services[0].ServiceName,
+		QueryOptions: structs.QueryOptions{
+			Namespace: services[0].Namespace,
+			Region:    s.Region(),
+		},
+	}
+	var getResp structs.ServiceRegistrationByNameResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(
+		codec, structs.ServiceRegistrationGetServiceRPCMethod, getReq, &getResp))
+	must.Len(t, 3, getResp.Services)
+
+	getReq.Healthy = true
+	getResp = structs.ServiceRegistrationByNameResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(
+		codec, structs.ServiceRegistrationGetServiceRPCMethod, getReq, &getResp))
+	must.Len(t, 2, getResp.Services)
+	for _, service := range getResp.Services {
+		must.True(t, service.Healthy())
+	}
+
+	// Once the remaining healthy instances fail, the service is no longer
+	// listed when only healthy services are requested.
+	unhealthy := []*structs.ServiceRegistration{services[0].Copy(), services[1].Copy()}
+	for _, service := range unhealthy {
+		service.CheckStatus = structs.CheckPending
+	}
+	must.NoError(t, s.fsm.State().UpsertServiceRegistrations(
+		structs.MsgTypeTestSetup, 20, unhealthy))
+
+	listReq := &structs.ServiceRegistrationListRequest{
+		QueryOptions: structs.QueryOptions{
+			Namespace: services[0].Namespace,
+			Region:    s.Region(),
+		},
+	}
+	var listResp structs.ServiceRegistrationListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(
+		codec, structs.ServiceRegistrationListRPCMethod, listReq, &listResp))
+	must.Len(t, 1, listResp.Services)
+
+	listReq.Healthy = true
+	listResp = structs.ServiceRegistrationListResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(
+		codec, structs.ServiceRegistrationListRPCMethod, listReq, &listResp))
+	must.Len(t, 0, listResp.Services)
+}
+
 func TestServiceRegistration_chooseErr(t *testing.T) {
 	ci.Parallel(t)
 
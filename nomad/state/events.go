@@ -41,23 +41,34 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.ServiceRegistrationUpsertRequestType:         structs.TypeServiceRegistration,
 	structs.ServiceRegistrationDeleteByIDRequestType:     structs.TypeServiceDeregistration,
 	structs.ServiceRegistrationDeleteByNodeIDRequestType: structs.TypeServiceDeregistration,
+	structs.IngressRouteAckRequestType:                   structs.TypeDeploymentAllocHealth,
 }
 
 func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 	eventType, ok := MsgTypeEvents[changes.MsgType]
-	if !ok {
-		return nil
-	}
 
 	var events []structs.Event
 	for _, change := range changes.Changes {
-		if event, ok := eventFromChange(change); ok {
+		event, eventOk := eventFromChange(change)
+		if !eventOk {
+			continue
+		}
+
+		// Some tables are derived from others and are updated by many
+		// message types, so their events carry their own type.
+		if event.Type == "" {
+			if !ok {
+				continue
+			}
 			event.Type = eventType
-			event.Index = changes.Index
-			events = append(events, event)
 		}
+		event.Index = changes.Index
+		events = append(events, event)
 	}
 
+	if !ok && len(events) == 0 {
+		return nil
+	}
 	return &structs.Events{Index: changes.Index, Events: events}
 }
 
@@ -168,6 +179,24 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					Service: before,
 				},
 			}, true
+		case TableIngressRoutes:
+			before, ok := change.Before.(*structs.IngressRoute)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic: structs.TopicIngress,
+				Type:  structs.TypeIngressRouteDeleted,
+				Key:   before.ID,
+				FilterKeys: []string{
+					before.JobID,
+					before.Service,
+				},
+				Namespace: before.Namespace,
+				Payload: &structs.IngressRouteEvent{
+					Route: before,
+				},
+			}, true
 		}
 		return structs.Event{}, false
 	}
@@ -345,6 +374,24 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				Service: after,
 			},
 		}, true
+	case TableIngressRoutes:
+		after, ok := change.After.(*structs.IngressRoute)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic: structs.TopicIngress,
+			Type:  structs.TypeIngressRouteUpserted,
+			Key:   after.ID,
+			FilterKeys: []string{
+				after.JobID,
+				after.Service,
+			},
+			Namespace: after.Namespace,
+			Payload: &structs.IngressRouteEvent{
+				Route: after,
+			},
+		}, true
 	}
 
 	return structs.Event{}, false
@@ -1272,3 +1272,47 @@ func testNodeID() string {
 func testNodeIDTwo() string {
 	return "694ff31d-8c59-4030-ac83-e15692560c8d"
 }
+
+func Test_eventsFromChanges_IngressRoute(t *testing.T) {
+	ci.Parallel(t)
+	testState := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer testState.StopEventBroker()
+
+	job, _ := ingressRouteTestJob()
+
+	// Registering the job materializes its ingress route.
+	writeTxn := testState.db.WriteTxnMsgT(structs.JobRegisterRequestType, 10)
+	must.NoError(t, testState.upsertJobImpl(10, nil, job, false, writeTxn))
+	writeTxn.Txn.Commit()
+
+	registerChange := Changes{Changes: writeTxn.Changes(), Index: 10, MsgType: structs.JobRegisterRequestType}
+	received := eventsFromChanges(writeTxn, registerChange)
+
+	var routeEvents []structs.Event
+	for _, e := range received.Events {
+		if e.Topic == structs.TopicIngress {
+			routeEvents = append(routeEvents, e)
+		}
+	}
+	must.Len(t, 1, routeEvents)
+	must.Eq(t, structs.TypeIngressRouteUpserted, routeEvents[0].Type)
+	must.Eq(t, job.Namespace, routeEvents[0].Namespace)
+	must.Eq(t, []string{job.ID, "web"}, routeEvents[0].FilterKeys)
+	must.Eq(t, 10, routeEvents[0].Index)
+
+	payload := routeEvents[0].Payload.(*structs.IngressRouteEvent)
+	must.Eq(t, routeEvents[0].Key, payload.Route.ID)
+
+	// Route changes are published for message types without events of their
+	// own.
+	deleteTxn := testState.db.WriteTxn(20)
+	must.NoError(t, testState.DeleteJobTxn(20, job.Namespace, job.ID, deleteTxn))
+	deleteTxn.Txn.Commit()
+
+	deleteChange := Changes{Changes: deleteTxn.Changes(), Index: 20, MsgType: structs.IgnoreUnknownTypeFlag}
+	received = eventsFromChanges(deleteTxn, deleteChange)
+	must.NotNil(t, received)
+	must.Len(t, 1, received.Events)
+	must.Eq(t, structs.TopicIngress, received.Events[0].Topic)
+	must.Eq(t, structs.TypeIngressRouteDeleted, received.Events[0].Type)
+}
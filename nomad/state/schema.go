@@ -25,6 +25,9 @@ const (
 	TableACLAuthMethods       = "acl_auth_methods"
 	TableACLBindingRules      = "acl_binding_rules"
 	TableAllocs               = "allocs"
+	TableIngressRoutes        = "ingress_routes"
+	TableReservations         = "reservations"
+	TableServiceIntentions    = "service_intentions"
 )
 
 const (
@@ -40,6 +43,7 @@ const (
 	indexName          = "name"
 	indexSigningKey    = "signing_key"
 	indexAuthMethod    = "auth_method"
+	indexNodePool      = "node_pool"
 )
 
 var (
@@ -87,6 +91,9 @@ func init() {
 		csiVolumeTableSchema,
 		csiPluginTableSchema,
 		ingressPluginTableSchema,
+		ingressRoutesTableSchema,
+		reservationsTableSchema,
+		serviceIntentionsTableSchema,
 		scalingPolicyTableSchema,
 		scalingEventTableSchema,
 		namespaceTableSchema,
@@ -254,6 +261,14 @@ func jobTableSchema() *memdb.TableSchema {
 					Field: "NodePool",
 				},
 			},
+			"meta": {
+				Name:         "meta",
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: &memdb.StringMapFieldIndex{
+					Field: "Meta",
+				},
+			},
 		},
 	}
 }
@@ -1195,6 +1210,120 @@ func ingressPluginTableSchema() *memdb.TableSchema {
 	}
 }
 
+// ingressRoutesTableSchema returns the MemDB schema for the ingress routes
+// table. This table is used to store the routes materialized from the ingress
+// blocks of job services and the addresses of their healthy allocations.
+func ingressRoutesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableIngressRoutes,
+		Indexes: map[string]*memdb.IndexSchema{
+			// The routeID in combination with namespace forms a unique
+			// identifier for an ingress route.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+			// The job index allows the routes of a job to be recomputed and
+			// deleted together.
+			indexJob: {
+				Name:         indexJob,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// reservationsTableSchema returns the MemDB schema for the reservations
+// table. This table is used to store the capacity reserved for namespaces on
+// the nodes of node pools.
+func reservationsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableReservations,
+		Indexes: map[string]*memdb.IndexSchema{
+			// The ID in combination with namespace forms a unique identifier
+			// for a reservation.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+			// The node pool index allows the scheduler to look up the
+			// reservations held by the nodes it ranks.
+			indexNodePool: {
+				Name:         indexNodePool,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "NodePool",
+				},
+			},
+		},
+	}
+}
+
+// serviceIntentionsTableSchema returns the MemDB schema for the service
+// intentions table. This table is used to store the intentions authorizing
+// the traffic between the services of the Nomad service mesh.
+func serviceIntentionsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableServiceIntentions,
+		Indexes: map[string]*memdb.IndexSchema{
+			// The namespace in combination with the destination and source
+			// services forms a unique identifier for an intention.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "DestinationService",
+						},
+						&memdb.StringFieldIndex{
+							Field: "SourceService",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // ScalingPolicyTargetFieldIndex is used to extract a field from an object
 // using reflection and builds an index on that field.
 type ScalingPolicyTargetFieldIndex struct {
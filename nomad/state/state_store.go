@@ -1109,6 +1109,9 @@ func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, update
 	if err := txn.Insert("index", &IndexEntry{"nodes", txn.Index}); err != nil {
 		return fmt.Errorf("index update failed: %v", err)
 	}
+	if err := updateIngressPluginsForNodeStatus(txn, copyNode, txn.Index); err != nil {
+		return fmt.Errorf("ingress plugin update failed: %v", err)
+	}
 	return nil
 }
 
@@ -1463,6 +1466,11 @@ func upsertCSIPluginsForNode(txn *txn, node *structs.Node, index uint64) error {
 
 func upsertIngressPluginsForNode(txn *txn, node *structs.Node, index uint64) error {
 	upsertFn := func(info *structs.IngressInfo) error {
+		info = info.Copy()
+		if node.StatusUpdatedAt != 0 {
+			info.NodeStatusUpdateTime = time.Unix(node.StatusUpdatedAt, 0)
+		}
+
 		raw, err := txn.First("ingress_plugins", "id", info.PluginID)
 		if err != nil {
 			return fmt.Errorf("ingress_plugins lookup error: %s %v", info.PluginID, err)
@@ -1639,6 +1647,148 @@ func deleteNodeIngressPlugins(txn *txn, node *structs.Node, index uint64) error
 	return nil
 }
 
+// updateIngressPluginsForNodeStatus updates the controllers running on a node
+// after its status changed. Controllers on down or disconnected nodes are
+// marked unhealthy, and restored from the fingerprints of the node once it is
+// ready again.
+func updateIngressPluginsForNodeStatus(txn *txn, node *structs.Node, index uint64) error {
+	switch node.Status {
+	case structs.NodeStatusReady:
+		return upsertIngressPluginsForNode(txn, node, index)
+	case structs.NodeStatusDown, structs.NodeStatusDisconnected:
+	default:
+		return nil
+	}
+
+	var updated bool
+	for _, info := range node.IngressPlugins {
+		raw, err := txn.First("ingress_plugins", "id", info.PluginID)
+		if err != nil {
+			return fmt.Errorf("ingress_plugins lookup error %s: %v", info.PluginID, err)
+		}
+		if raw == nil {
+			continue
+		}
+
+		plug := raw.(*structs.IngressPlugin).Copy()
+		changed, err := reconcileIngressController(txn, plug, node.ID)
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := updateOrGCIngressPlugin(index, txn, plug); err != nil {
+				return err
+			}
+			updated = true
+		}
+	}
+
+	if updated {
+		if err := txn.Insert("index", &IndexEntry{"ingress_plugins", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// SweepIngressPlugins reconciles the controllers of every ingress plugin with
+// the nodes and allocations running them, so the health of plugins reflects
+// nodes lost or restarted while their updates were missed.
+func (s *StateStore) SweepIngressPlugins(msgType structs.MessageType, index uint64) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	iter, err := txn.Get("ingress_plugins", "id")
+	if err != nil {
+		return fmt.Errorf("ingress_plugins lookup failed: %v", err)
+	}
+	var plugs []*structs.IngressPlugin
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		plugs = append(plugs, raw.(*structs.IngressPlugin))
+	}
+
+	var updated bool
+	for _, plug := range plugs {
+		plug = plug.Copy()
+
+		var changed bool
+		for nodeID := range plug.Controllers {
+			c, err := reconcileIngressController(txn, plug, nodeID)
+			if err != nil {
+				return err
+			}
+			changed = changed || c
+		}
+		if changed {
+			if err := updateOrGCIngressPlugin(index, txn, plug); err != nil {
+				return err
+			}
+			updated = true
+		}
+	}
+
+	if updated {
+		if err := txn.Insert("index", &IndexEntry{"ingress_plugins", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	// Plugins may also have been removed along with their node, so check
+	// every route still waiting to be programmed.
+	if err := s.updateUnservedIngressPendingTxn(index, txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// reconcileIngressController updates the controller of the plugin running on
+// the node from the state of the node and of the controller allocation. It
+// returns true if the plugin was changed.
+func reconcileIngressController(txn *txn, plug *structs.IngressPlugin, nodeID string) (bool, error) {
+	info := plug.Controllers[nodeID]
+	if info == nil {
+		return false, nil
+	}
+
+	if info.AllocID != "" {
+		raw, err := txn.First("allocs", "id", info.AllocID)
+		if err != nil {
+			return false, fmt.Errorf("alloc lookup failed: %v", err)
+		}
+		if raw == nil || raw.(*structs.Allocation).TerminalStatus() {
+			return true, plug.DeleteAlloc(info.AllocID, nodeID)
+		}
+	}
+
+	raw, err := txn.First("nodes", "id", nodeID)
+	if err != nil {
+		return false, fmt.Errorf("node lookup failed: %v", err)
+	}
+	if raw == nil {
+		return true, plug.DeleteNodeForType(nodeID)
+	}
+	node := raw.(*structs.Node)
+
+	switch node.Status {
+	case structs.NodeStatusDown:
+		return plug.MarkStale(nodeID, structs.IngressControllerNodeDown), nil
+	case structs.NodeStatusDisconnected:
+		return plug.MarkStale(nodeID, structs.IngressControllerNodeDisconnected), nil
+	case structs.NodeStatusReady:
+		reported, ok := node.IngressPlugins[plug.ID]
+		if !ok || reported.AllocID != info.AllocID {
+			return true, plug.DeleteNodeForType(nodeID)
+		}
+		if reported.Equal(info) {
+			return false, nil
+		}
+		restored := reported.Copy()
+		restored.NodeStatusUpdateTime = info.NodeStatusUpdateTime
+		return true, plug.AddPlugin(nodeID, restored)
+	}
+	return false, nil
+}
+
 // updateOrGCPlugin updates a plugin but will delete it if the plugin is empty
 func updateOrGCPlugin(index uint64, txn Txn, plug *structs.CSIPlugin) error {
 	if plug.IsEmpty() {
@@ -2021,6 +2171,10 @@ func (s *StateStore) upsertJobImpl(index uint64, sub *structs.JobSubmission, job
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	if err := s.updateIngressRoutesTxn(index, txn, job.Namespace, job.ID); err != nil {
+		return fmt.Errorf("unable to update job ingress routes: %v", err)
+	}
+
 	return nil
 }
 
@@ -2144,6 +2298,11 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// Delete the ingress routes of the job
+	if err := s.updateIngressRoutesTxn(index, txn, namespace, jobID); err != nil {
+		return fmt.Errorf("deleting job ingress routes failed: %v", err)
+	}
+
 	return nil
 }
 
@@ -2568,6 +2727,21 @@ func (s *StateStore) JobsByPool(ws memdb.WatchSet, pool string) (memdb.ResultIte
 	return iter, nil
 }
 
+// JobsByMeta returns an iterator over the jobs of all namespaces with the
+// meta key set to the value.
+func (s *StateStore) JobsByMeta(ws memdb.WatchSet, key, value string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("jobs", "meta", key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // JobSummaryByID returns a job summary object which matches a specific id.
 func (s *StateStore) JobSummaryByID(ws memdb.WatchSet, namespace, jobID string) (*structs.JobSummary, error) {
 	txn := s.db.ReadTxn()
@@ -3169,6 +3343,20 @@ func (s *StateStore) CSIPluginsByIDPrefix(ws memdb.WatchSet, pluginID string) (m
 	return iter, nil
 }
 
+// IngressPluginsByIDPrefix supports search
+func (s *StateStore) IngressPluginsByIDPrefix(ws memdb.WatchSet, pluginID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("ingress_plugins", "id_prefix", pluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // CSIPluginByID returns a named CSIPlugin. This method creates a new
 // transaction so you should not call it from within another transaction.
 func (s *StateStore) CSIPluginByID(ws memdb.WatchSet, id string) (*structs.CSIPlugin, error) {
@@ -3346,6 +3534,9 @@ func (s *StateStore) DeleteCSIPlugin(index uint64, id string) error {
 	return txn.Commit()
 }
 
+// DeleteIngressPlugin deletes the plugin if it's not in use. Controllers
+// whose allocation is gone or terminal don't keep the plugin in use, as the
+// node fingerprint may outlive the controller job.
 func (s *StateStore) DeleteIngressPlugin(index uint64, id string) error {
 	txn := s.db.WriteTxn(index)
 	defer txn.Abort()
@@ -3359,14 +3550,29 @@ func (s *StateStore) DeleteIngressPlugin(index uint64, id string) error {
 		return nil
 	}
 
-	if !plug.IsEmpty() {
+	if plug.ControllerJobs.Count() > 0 {
 		return fmt.Errorf("plugin in use")
 	}
+	for _, info := range plug.Controllers {
+		alloc, err := s.allocByIDImpl(txn, nil, info.AllocID)
+		if err != nil {
+			return err
+		}
+		if alloc != nil && !alloc.TerminalStatus() {
+			return fmt.Errorf("plugin in use")
+		}
+	}
 
 	err = txn.Delete("ingress_plugins", plug)
 	if err != nil {
 		return fmt.Errorf("ingress_plugins delete error: %v", err)
 	}
+	if err := txn.Insert("index", &IndexEntry{"ingress_plugins", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	if err := s.updateUnservedIngressPendingTxn(index, txn); err != nil {
+		return err
+	}
 	return txn.Commit()
 }
 
@@ -4066,12 +4272,25 @@ func (s *StateStore) UpdateAllocsFromClient(msgType structs.MessageType, index u
 	// so this request may include allocs from several nodes.
 	nodeIDs := set.New[string](1)
 
+	// Capture all jobs being affected so their ingress routes are only
+	// recomputed once.
+	jobs := set.New[structs.NamespacedID](1)
+
 	// Handle each of the updated allocations
 	for _, alloc := range allocs {
 		nodeIDs.Insert(alloc.NodeID)
 		if err := s.nestedUpdateAllocFromClient(txn, index, alloc); err != nil {
 			return err
 		}
+
+		// Client updates do not carry the job of the allocation.
+		existing, err := txn.First("allocs", "id", alloc.ID)
+		if err != nil {
+			return fmt.Errorf("alloc lookup failed: %v", err)
+		}
+		if exist, ok := existing.(*structs.Allocation); ok {
+			jobs.Insert(structs.NamespacedID{ID: exist.JobID, Namespace: exist.Namespace})
+		}
 	}
 
 	// Update the indexes
@@ -4086,6 +4305,12 @@ func (s *StateStore) UpdateAllocsFromClient(msgType structs.MessageType, index u
 		}
 	}
 
+	for _, tuple := range jobs.Slice() {
+		if err := s.updateIngressRoutesTxn(index, txn, tuple.Namespace, tuple.ID); err != nil {
+			return fmt.Errorf("updating ingress routes failed: %v", err)
+		}
+	}
+
 	return txn.Commit()
 }
 
@@ -4266,6 +4491,13 @@ func (s *StateStore) upsertAllocsImpl(index uint64, allocs []*structs.Allocation
 			if alloc.Job == nil {
 				alloc.Job = exist.Job
 			}
+
+			// Only the state store knows if the ingress routes of a healthy
+			// allocation have been programmed
+			if alloc.DeploymentStatus != nil && exist.DeploymentStatus != nil &&
+				alloc.DeploymentID == exist.DeploymentID {
+				alloc.DeploymentStatus.IngressPending = exist.DeploymentStatus.IngressPending
+			}
 		}
 
 		// OPTIMIZATION:
@@ -4334,6 +4566,13 @@ func (s *StateStore) upsertAllocsImpl(index uint64, allocs []*structs.Allocation
 		return fmt.Errorf("setting job status failed: %v", err)
 	}
 
+	// Update the ingress routes of the jobs
+	for tuple := range jobs {
+		if err := s.updateIngressRoutesTxn(index, txn, tuple.Namespace, tuple.ID); err != nil {
+			return fmt.Errorf("updating ingress routes failed: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -5001,6 +5240,14 @@ func (s *StateStore) updateDeploymentStatusImpl(index uint64, u *structs.Deploym
 		}
 	}
 
+	// Canaries of a terminal deployment are no longer weighted separately
+	// in the ingress routes
+	if !copy.Active() {
+		if err := s.updateIngressRoutesTxn(index, txn, copy.Namespace, copy.JobID); err != nil {
+			return fmt.Errorf("updating ingress routes failed: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -5183,6 +5430,11 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// Promoted canaries receive the full weight of their ingress routes
+	if err := s.updateIngressRoutesTxn(index, txn, deployment.Namespace, deployment.JobID); err != nil {
+		return fmt.Errorf("updating ingress routes failed: %v", err)
+	}
+
 	return txn.Commit()
 }
 
@@ -5255,6 +5507,10 @@ func (s *StateStore) UpdateDeploymentAllocHealth(msgType structs.MessageType, in
 		if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
 			return fmt.Errorf("index update failed: %v", err)
 		}
+
+		if err := s.updateIngressRoutesTxn(index, txn, deployment.Namespace, deployment.JobID); err != nil {
+			return fmt.Errorf("updating ingress routes failed: %v", err)
+		}
 	}
 
 	// Update the deployment status as needed.
@@ -6035,16 +6291,27 @@ func (s *StateStore) updateDeploymentWithAlloc(index uint64, alloc, existing *st
 	if existing == nil || existing.DeploymentID != alloc.DeploymentID {
 		placed++
 	} else if !existingHealthSet && allocHealthSet {
-		if *alloc.DeploymentStatus.Healthy {
-			healthy++
-		} else {
+		if !*alloc.DeploymentStatus.Healthy {
 			unhealthy++
+		} else if allocHasIngress(alloc) {
+			// The allocation is only healthy once its ingress routes are
+			// programmed, see updateIngressPendingAllocsTxn
+			alloc.DeploymentStatus.IngressPending = true
+		} else {
+			healthy++
 		}
 	} else if existingHealthSet && allocHealthSet {
 		// See if it has gone from healthy to unhealthy
 		if *existing.DeploymentStatus.Healthy && !*alloc.DeploymentStatus.Healthy {
-			healthy--
+			if !existing.DeploymentStatus.IngressPending {
+				healthy--
+			}
+			alloc.DeploymentStatus.IngressPending = false
 			unhealthy++
+		} else if *alloc.DeploymentStatus.Healthy &&
+			existing.DeploymentStatus.IngressPending && !alloc.DeploymentStatus.IngressPending {
+			// The ingress routes of the allocation have been programmed
+			healthy++
 		}
 	}
 
@@ -0,0 +1,617 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// updateIngressRoutesTxn recomputes the ingress routes of a single job using
+// the provided write transaction. It must be called whenever the job, its
+// allocations or its service registrations change. Routes are only written
+// when they differ from the existing entries, so calling this repeatedly
+// within the same transaction is safe.
+func (s *StateStore) updateIngressRoutesTxn(index uint64, txn *txn, namespace, jobID string) error {
+
+	existingRoutes, err := ingressRoutesByJobTxn(txn, namespace, jobID)
+	if err != nil {
+		return err
+	}
+
+	rawJob, err := txn.First("jobs", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+
+	var job *structs.Job
+	if rawJob != nil {
+		job = rawJob.(*structs.Job)
+	}
+
+	// Exit early for the vast majority of jobs which do not use ingress so we
+	// avoid walking their allocations.
+	if len(existingRoutes) == 0 && !jobHasIngress(job) {
+		return nil
+	}
+
+	desired := map[string]*structs.IngressRoute{}
+	if job != nil && !job.Stopped() {
+		desired, err = s.desiredIngressRoutesTxn(txn, job)
+		if err != nil {
+			return err
+		}
+	}
+
+	var updated bool
+
+	for id, route := range desired {
+		existing := existingRoutes[id]
+
+		// Keep track of what ingress plugins acknowledged so the backends
+		// already programmed aren't waited on again.
+		existingBackends := map[string]*structs.IngressBackend{}
+		if existing != nil {
+			route.ProgrammedIndex = existing.ProgrammedIndex
+			for _, b := range existing.Backends {
+				existingBackends[ingressBackendKey(b)] = b
+			}
+		}
+		for _, b := range route.Backends {
+			b.CreateIndex = index
+			if eb, ok := existingBackends[ingressBackendKey(b)]; ok {
+				b.CreateIndex = eb.CreateIndex
+			}
+		}
+
+		if existing.Equal(route) {
+			continue
+		}
+		if existing != nil {
+			route.CreateIndex = existing.CreateIndex
+		} else {
+			route.CreateIndex = index
+		}
+		route.ModifyIndex = index
+
+		if err := txn.Insert(TableIngressRoutes, route); err != nil {
+			return fmt.Errorf("ingress route insert failed: %v", err)
+		}
+		updated = true
+	}
+
+	for id, existing := range existingRoutes {
+		if _, ok := desired[id]; ok {
+			continue
+		}
+		if err := txn.Delete(TableIngressRoutes, existing); err != nil {
+			return fmt.Errorf("ingress route deletion failed: %v", err)
+		}
+		updated = true
+	}
+
+	if updated {
+		if err := txn.Insert(tableIndex, &IndexEntry{TableIngressRoutes, index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	return s.updateIngressPendingAllocsTxn(index, txn, namespace, jobID, desired)
+}
+
+// AckIngressRoutes records that ingress plugins programmed routes in their
+// load balancer, and marks the allocations that were waiting for it healthy.
+func (s *StateStore) AckIngressRoutes(msgType structs.MessageType, index uint64, acks []*structs.IngressRouteAck) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	jobs := map[structs.NamespacedID]struct{}{}
+	var updated bool
+
+	for _, ack := range acks {
+		raw, err := txn.First(TableIngressRoutes, indexID, ack.Namespace, ack.RouteID)
+		if err != nil {
+			return fmt.Errorf("ingress route lookup failed: %v", err)
+		}
+		if raw == nil {
+			// The route was deleted since the plugin programmed it
+			continue
+		}
+		existing := raw.(*structs.IngressRoute)
+
+		// Plugins can't have programmed a version of the route which
+		// doesn't exist yet.
+		programmed := min(ack.Index, existing.ModifyIndex)
+		if programmed <= existing.ProgrammedIndex {
+			continue
+		}
+
+		route := existing.Copy()
+		route.ProgrammedIndex = programmed
+		if err := txn.Insert(TableIngressRoutes, route); err != nil {
+			return fmt.Errorf("ingress route insert failed: %v", err)
+		}
+		updated = true
+		jobs[structs.NamespacedID{Namespace: route.Namespace, ID: route.JobID}] = struct{}{}
+	}
+
+	if !updated {
+		return nil
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableIngressRoutes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	for job := range jobs {
+		routes, err := ingressRoutesByJobTxn(txn, job.Namespace, job.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.updateIngressPendingAllocsTxn(index, txn, job.Namespace, job.ID, routes); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+// updateIngressPendingAllocsTxn marks the healthy allocations of a job which
+// were waiting for their ingress routes to be programmed healthy, once every
+// route they are a backend of was acknowledged by an ingress plugin. Routes
+// whose class no ingress plugin serves are never acknowledged, so they are
+// not waited on.
+func (s *StateStore) updateIngressPendingAllocsTxn(index uint64, txn *txn,
+	namespace, jobID string, routes map[string]*structs.IngressRoute) error {
+
+	served := make(map[string]*structs.IngressRoute, len(routes))
+	for id, route := range routes {
+		ok, err := ingressClassServedTxn(txn, route.Ingress)
+		if err != nil {
+			return err
+		}
+		if ok {
+			served[id] = route
+		}
+	}
+	routes = served
+
+	iter, err := txn.Get("allocs", "job", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("alloc lookup failed: %v", err)
+	}
+
+	// Collect the allocations first, as updating them invalidates the
+	// iterator.
+	var programmed []*structs.Allocation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		if alloc.DeploymentStatus == nil || !alloc.DeploymentStatus.IngressPending {
+			continue
+		}
+		if alloc.TerminalStatus() || !ingressRoutesProgrammed(alloc, routes) {
+			continue
+		}
+		programmed = append(programmed, alloc)
+	}
+
+	if len(programmed) == 0 {
+		return nil
+	}
+
+	for _, existing := range programmed {
+		alloc := existing.Copy()
+		alloc.DeploymentStatus.IngressPending = false
+		alloc.DeploymentStatus.ModifyIndex = index
+		alloc.ModifyIndex = index
+
+		if err := s.updateDeploymentWithAlloc(index, alloc, existing, txn); err != nil {
+			return fmt.Errorf("error updating deployment: %v", err)
+		}
+		if err := txn.Insert("allocs", alloc); err != nil {
+			return fmt.Errorf("alloc insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{"allocs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// updateUnservedIngressPendingTxn marks the allocations waiting for routes
+// whose class is no longer served by any ingress plugin healthy, so their
+// deployment doesn't wait on acknowledgements that never come. It must be
+// called whenever ingress plugins are removed.
+func (s *StateStore) updateUnservedIngressPendingTxn(index uint64, txn *txn) error {
+	iter, err := txn.Get(TableIngressRoutes, indexID)
+	if err != nil {
+		return fmt.Errorf("ingress route lookup failed: %v", err)
+	}
+
+	jobs := map[structs.NamespacedID]struct{}{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		route := raw.(*structs.IngressRoute)
+		if route.ProgrammedIndex >= route.ModifyIndex {
+			continue
+		}
+		served, err := ingressClassServedTxn(txn, route.Ingress)
+		if err != nil {
+			return err
+		}
+		if !served {
+			jobs[structs.NamespacedID{Namespace: route.Namespace, ID: route.JobID}] = struct{}{}
+		}
+	}
+
+	for job := range jobs {
+		routes, err := ingressRoutesByJobTxn(txn, job.Namespace, job.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.updateIngressPendingAllocsTxn(index, txn, job.Namespace, job.ID, routes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ingressClassServedTxn returns true if an ingress plugin with at least one
+// controller is registered for the class of the ingress block.
+func ingressClassServedTxn(txn *txn, ingress *structs.ServiceIngress) (bool, error) {
+	if ingress == nil || ingress.Class == "" {
+		return false, nil
+	}
+	raw, err := txn.First("ingress_plugins", "id", ingress.Class)
+	if err != nil {
+		return false, fmt.Errorf("ingress_plugins lookup error %s: %v", ingress.Class, err)
+	}
+	return raw != nil && len(raw.(*structs.IngressPlugin).Controllers) > 0, nil
+}
+
+// ingressRoutesProgrammed returns true if every route the allocation is a
+// backend of was programmed since the allocation was added to it.
+func ingressRoutesProgrammed(alloc *structs.Allocation, routes map[string]*structs.IngressRoute) bool {
+	for _, route := range routes {
+		if route.TaskGroup != alloc.TaskGroup {
+			continue
+		}
+		for _, b := range route.Backends {
+			if b.AllocID == alloc.ID && !route.Programmed(b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func ingressBackendKey(b *structs.IngressBackend) string {
+	return fmt.Sprintf("%s/%s:%d", b.AllocID, b.Address, b.Port)
+}
+
+// desiredIngressRoutesTxn builds the routes of every service with an ingress
+// block in the job, keyed by route ID.
+func (s *StateStore) desiredIngressRoutesTxn(txn *txn, job *structs.Job) (map[string]*structs.IngressRoute, error) {
+
+	routes := map[string]*structs.IngressRoute{}
+	ports := map[string]string{}
+
+	for _, tg := range job.TaskGroups {
+		services := append([]*structs.Service{}, tg.Services...)
+		for _, task := range tg.Tasks {
+			services = append(services, task.Services...)
+		}
+
+		for _, service := range services {
+			if service.Ingress == nil {
+				continue
+			}
+			id := structs.IngressRouteID(job.Namespace, job.ID, tg.Name, service.Name)
+			if _, ok := routes[id]; ok {
+				continue
+			}
+			routes[id] = &structs.IngressRoute{
+				ID:        id,
+				Namespace: job.Namespace,
+				JobID:     job.ID,
+				TaskGroup: tg.Name,
+				Service:   service.Name,
+				Ingress:   service.Ingress.Copy(),
+			}
+			ports[id] = service.PortLabel
+		}
+	}
+
+	if len(routes) == 0 {
+		return routes, nil
+	}
+
+	// Index the service registrations of the job by allocation and service
+	// name. Registrations hold the address advertised by the client and are
+	// preferred over the allocated ports.
+	regIter, err := txn.Get(TableServiceRegistrations, indexJob, job.Namespace, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service registration lookup failed: %v", err)
+	}
+	registrations := map[string]*structs.ServiceRegistration{}
+	for raw := regIter.Next(); raw != nil; raw = regIter.Next() {
+		reg := raw.(*structs.ServiceRegistration)
+		registrations[reg.AllocID+"/"+reg.ServiceName] = reg
+	}
+
+	// Canaries are weighted separately until their deployment is promoted.
+	deployments := map[string]*structs.Deployment{}
+	isCanary := func(alloc *structs.Allocation) (bool, error) {
+		if !alloc.DeploymentStatus.IsCanary() {
+			return false, nil
+		}
+		d, ok := deployments[alloc.DeploymentID]
+		if !ok {
+			raw, err := txn.First("deployment", "id", alloc.DeploymentID)
+			if err != nil {
+				return false, fmt.Errorf("deployment lookup failed: %v", err)
+			}
+			if raw != nil {
+				d = raw.(*structs.Deployment)
+			}
+			deployments[alloc.DeploymentID] = d
+		}
+		if d == nil || !d.Active() {
+			return false, nil
+		}
+		dstate, ok := d.TaskGroups[alloc.TaskGroup]
+		return ok && !dstate.Promoted, nil
+	}
+
+	allocIter, err := txn.Get("allocs", "job", job.Namespace, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("alloc lookup failed: %v", err)
+	}
+	for raw := allocIter.Next(); raw != nil; raw = allocIter.Next() {
+		alloc := raw.(*structs.Allocation)
+		if !allocServesIngress(alloc, job) {
+			continue
+		}
+		canary, err := isCanary(alloc)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, route := range routes {
+			if route.TaskGroup != alloc.TaskGroup {
+				continue
+			}
+
+			var backend *structs.IngressBackend
+			if reg, ok := registrations[alloc.ID+"/"+route.Service]; ok {
+				backend = &structs.IngressBackend{
+					AllocID: alloc.ID,
+					NodeID:  alloc.NodeID,
+					Address: reg.Address,
+					Port:    reg.Port,
+				}
+			} else {
+				backend = allocIngressBackend(alloc, ports[id])
+			}
+			if backend != nil {
+				backend.Canary = canary
+				route.Backends = append(route.Backends, backend)
+			}
+		}
+	}
+
+	for _, route := range routes {
+		sort.Slice(route.Backends, func(i, j int) bool {
+			return route.Backends[i].AllocID < route.Backends[j].AllocID
+		})
+		setIngressBackendWeights(route)
+	}
+
+	return routes, nil
+}
+
+// setIngressBackendWeights weights the backends of a route. The canaries of a
+// deployment receive the canary weight of the route as a share of its
+// traffic, split evenly between them, and the other allocations the rest.
+func setIngressBackendWeights(route *structs.IngressRoute) {
+	var canaries, others int
+	for _, b := range route.Backends {
+		b.Weight = structs.ServiceIngressMaxWeight
+		if b.Canary {
+			canaries++
+		} else {
+			others++
+		}
+	}
+
+	canaryWeight := route.Ingress.CanaryWeight
+	if canaryWeight == 0 || canaries == 0 || others == 0 {
+		return
+	}
+
+	share := func(total, n int) int {
+		if total == 0 {
+			return 0
+		}
+		return max(1, (total+n/2)/n)
+	}
+	for _, b := range route.Backends {
+		if b.Canary {
+			b.Weight = share(canaryWeight, canaries)
+		} else {
+			b.Weight = share(structs.ServiceIngressMaxWeight-canaryWeight, others)
+		}
+	}
+}
+
+// allocServesIngress returns true if the allocation should receive traffic
+// from ingress routes: it must be running, not be stopping, and be healthy
+// when it is part of a deployment. Healthy allocations waiting for their
+// routes to be programmed are backends, so ingress plugins can program them.
+func allocServesIngress(alloc *structs.Allocation, job *structs.Job) bool {
+	if alloc.Job != nil && alloc.Job.CreateIndex != job.CreateIndex {
+		return false
+	}
+	if alloc.TerminalStatus() || alloc.ClientStatus != structs.AllocClientStatusRunning {
+		return false
+	}
+	if alloc.DeploymentID != "" &&
+		!(alloc.DeploymentStatus.HasHealth() && *alloc.DeploymentStatus.Healthy) {
+		return false
+	}
+	return !alloc.DeploymentStatus.IsUnhealthy()
+}
+
+// allocIngressBackend builds a backend from the ports allocated to the
+// allocation. It returns nil if the port label could not be resolved.
+func allocIngressBackend(alloc *structs.Allocation, portLabel string) *structs.IngressBackend {
+	if alloc.AllocatedResources == nil || portLabel == "" {
+		return nil
+	}
+
+	port, ok := alloc.AllocatedResources.Shared.Ports.Get(portLabel)
+	if !ok {
+		return nil
+	}
+
+	if port.HostIP == "" {
+		return nil
+	}
+
+	return &structs.IngressBackend{
+		AllocID: alloc.ID,
+		NodeID:  alloc.NodeID,
+		Address: port.HostIP,
+		Port:    port.Value,
+	}
+}
+
+// allocHasIngress returns true if any service of the task group of the
+// allocation has an ingress block.
+func allocHasIngress(alloc *structs.Allocation) bool {
+	if alloc.Job == nil {
+		return false
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return false
+	}
+	for _, service := range tg.Services {
+		if service.Ingress != nil {
+			return true
+		}
+	}
+	for _, task := range tg.Tasks {
+		for _, service := range task.Services {
+			if service.Ingress != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jobHasIngress returns true if any service of the job has an ingress block.
+func jobHasIngress(job *structs.Job) bool {
+	if job == nil {
+		return false
+	}
+	for _, tg := range job.TaskGroups {
+		for _, service := range tg.Services {
+			if service.Ingress != nil {
+				return true
+			}
+		}
+		for _, task := range tg.Tasks {
+			for _, service := range task.Services {
+				if service.Ingress != nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ingressRoutesByJobTxn returns the routes of a job keyed by route ID.
+func ingressRoutesByJobTxn(txn ReadTxn, namespace, jobID string) (map[string]*structs.IngressRoute, error) {
+	iter, err := txn.Get(TableIngressRoutes, indexJob, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("ingress route lookup failed: %v", err)
+	}
+
+	routes := map[string]*structs.IngressRoute{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		route := raw.(*structs.IngressRoute)
+		routes[route.ID] = route
+	}
+	return routes, nil
+}
+
+// IngressRoutes returns an iterator that contains all ingress routes stored
+// within state. The caller is responsible for ensuring ACL access is
+// confirmed, or filtering is performed before responding.
+func (s *StateStore) IngressRoutes(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableIngressRoutes, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("ingress route lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// IngressRoutesByNamespace returns an iterator that contains all ingress
+// routes belonging to the provided namespace.
+func (s *StateStore) IngressRoutesByNamespace(
+	ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableIngressRoutes, indexID+"_prefix", namespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("ingress route lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// IngressRouteByID returns a single ingress route. The route will be nil, if
+// no matching entry was found; it is the responsibility of the caller to
+// check for this.
+func (s *StateStore) IngressRouteByID(
+	ws memdb.WatchSet, namespace, id string) (*structs.IngressRoute, error) {
+
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableIngressRoutes, indexID, namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("ingress route lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.IngressRoute), nil
+	}
+	return nil, nil
+}
+
+// IngressRoutesByJobID returns an iterator containing all the ingress routes
+// corresponding to a single job.
+func (s *StateStore) IngressRoutesByJobID(
+	ws memdb.WatchSet, namespace, jobID string) (memdb.ResultIterator, error) {
+
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableIngressRoutes, indexJob, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("ingress route lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
@@ -0,0 +1,377 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// ingressRouteTestJob returns a job with a single group service exposed
+// through an ingress block, and a pending allocation of it with the
+// service port allocated.
+func ingressRouteTestJob() (*structs.Job, *structs.Allocation) {
+	job := mock.Job()
+	job.TaskGroups[0].Services = []*structs.Service{{
+		Name:      "web",
+		PortLabel: "http",
+		Provider:  structs.ServiceProviderNomad,
+		Ingress: &structs.ServiceIngress{
+			Hosts:  []string{"example.com"},
+			Class:  "haproxy",
+			Weight: 100,
+		},
+	}}
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.ClientStatus = structs.AllocClientStatusPending
+	alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 8080, HostIP: "10.0.0.1"},
+	}
+	return job, alloc
+}
+
+// testIngressController registers a controller of the haproxy ingress plugin
+// serving the routes of ingressRouteTestJob, and returns its allocation.
+func testIngressController(t *testing.T, s *StateStore, index uint64) *structs.Allocation {
+	t.Helper()
+
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+
+	node := mock.Node()
+	node.ID = alloc.NodeID
+	info := &structs.IngressInfo{PluginID: "haproxy", AllocID: alloc.ID}
+	info.SetHealthy(true)
+	node.IngressPlugins = map[string]*structs.IngressInfo{"haproxy": info}
+
+	must.NoError(t, s.UpsertAllocs(structs.MsgTypeTestSetup, index, []*structs.Allocation{alloc}))
+	must.NoError(t, s.UpsertNode(structs.MsgTypeTestSetup, index+1, node))
+	return alloc
+}
+
+func testIngressRoute(t *testing.T, s *StateStore, job *structs.Job) *structs.IngressRoute {
+	t.Helper()
+	id := structs.IngressRouteID(job.Namespace, job.ID, job.TaskGroups[0].Name, "web")
+	route, err := s.IngressRouteByID(memdb.NewWatchSet(), job.Namespace, id)
+	must.NoError(t, err)
+	return route
+}
+
+func TestStateStore_IngressRoutes_Lifecycle(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	job, alloc := ingressRouteTestJob()
+
+	// Registering the job creates the route without any backend.
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	route := testIngressRoute(t, testState, job)
+	must.NotNil(t, route)
+	must.Eq(t, job.TaskGroups[0].Services[0].Ingress, route.Ingress)
+	must.SliceEmpty(t, route.Backends)
+	must.Eq(t, 10, route.CreateIndex)
+
+	index, err := testState.Index(TableIngressRoutes)
+	must.NoError(t, err)
+	must.Eq(t, 10, index)
+
+	// A pending allocation does not receive traffic.
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 20, []*structs.Allocation{alloc}))
+	must.SliceEmpty(t, testIngressRoute(t, testState, job).Backends)
+
+	index, err = testState.Index(TableIngressRoutes)
+	must.NoError(t, err)
+	must.Eq(t, 10, index)
+
+	// Once running, the allocated port is used as backend.
+	running := alloc.Copy()
+	running.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 30, []*structs.Allocation{running}))
+
+	route = testIngressRoute(t, testState, job)
+	must.Eq(t, []*structs.IngressBackend{{
+		AllocID:     alloc.ID,
+		NodeID:      alloc.NodeID,
+		Address:     "10.0.0.1",
+		Port:        8080,
+		Weight:      100,
+		CreateIndex: 30,
+	}}, route.Backends)
+	must.Eq(t, 10, route.CreateIndex)
+	must.Eq(t, 30, route.ModifyIndex)
+
+	// The address of a service registration takes precedence.
+	reg := &structs.ServiceRegistration{
+		ID:          "_nomad-task-" + alloc.ID + "-group-web-web-http",
+		ServiceName: "web",
+		Namespace:   job.Namespace,
+		NodeID:      alloc.NodeID,
+		JobID:       job.ID,
+		AllocID:     alloc.ID,
+		Address:     "192.168.0.1",
+		Port:        9999,
+	}
+	must.NoError(t, testState.UpsertServiceRegistrations(
+		structs.ServiceRegistrationUpsertRequestType, 40, []*structs.ServiceRegistration{reg}))
+
+	route = testIngressRoute(t, testState, job)
+	must.Len(t, 1, route.Backends)
+	must.Eq(t, "192.168.0.1", route.Backends[0].Address)
+	must.Eq(t, 9999, route.Backends[0].Port)
+
+	must.NoError(t, testState.DeleteServiceRegistrationByNodeID(
+		structs.ServiceRegistrationDeleteByNodeIDRequestType, 50, alloc.NodeID))
+
+	route = testIngressRoute(t, testState, job)
+	must.Len(t, 1, route.Backends)
+	must.Eq(t, "10.0.0.1", route.Backends[0].Address)
+
+	// Terminal allocations are removed from the backends.
+	complete := alloc.Copy()
+	complete.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 60, []*structs.Allocation{complete}))
+	must.SliceEmpty(t, testIngressRoute(t, testState, job).Backends)
+
+	// Removing the ingress block from the job deletes the route.
+	job2 := job.Copy()
+	job2.TaskGroups[0].Services[0].Ingress = nil
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 70, nil, job2))
+	must.Nil(t, testIngressRoute(t, testState, job))
+
+	index, err = testState.Index(TableIngressRoutes)
+	must.NoError(t, err)
+	must.Eq(t, 70, index)
+}
+
+func TestStateStore_IngressRoutes_DeleteJob(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	job, _ := ingressRouteTestJob()
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	iter, err := testState.IngressRoutesByJobID(memdb.NewWatchSet(), job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, iter.Next())
+
+	must.NoError(t, testState.DeleteJob(20, job.Namespace, job.ID))
+
+	iter, err = testState.IngressRoutes(memdb.NewWatchSet())
+	must.NoError(t, err)
+	must.Nil(t, iter.Next())
+}
+
+func TestStateStore_IngressRoutes_DeploymentHealth(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	job, alloc := ingressRouteTestJob()
+	testIngressController(t, testState, 5)
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	deployment := mock.Deployment()
+	deployment.JobID = job.ID
+	must.NoError(t, testState.UpsertDeployment(20, deployment))
+
+	alloc.DeploymentID = deployment.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 30, []*structs.Allocation{alloc}))
+
+	// Running allocations of a deployment are only routed to once healthy.
+	running := alloc.Copy()
+	must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 40, []*structs.Allocation{running}))
+	must.SliceEmpty(t, testIngressRoute(t, testState, job).Backends)
+
+	must.NoError(t, testState.UpdateDeploymentAllocHealth(structs.MsgTypeTestSetup, 50,
+		&structs.ApplyDeploymentAllocHealthRequest{
+			DeploymentAllocHealthRequest: structs.DeploymentAllocHealthRequest{
+				DeploymentID:         deployment.ID,
+				HealthyAllocationIDs: []string{alloc.ID},
+			},
+			Timestamp: time.Now(),
+		}))
+
+	route := testIngressRoute(t, testState, job)
+	must.Len(t, 1, route.Backends)
+	must.Eq(t, alloc.ID, route.Backends[0].AllocID)
+	must.Eq(t, 50, route.ModifyIndex)
+	must.False(t, route.Programmed(route.Backends[0]))
+
+	// The allocation doesn't count as healthy until the route is programmed.
+	out, err := testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.True(t, out.DeploymentStatus.IngressPending)
+	must.False(t, out.DeploymentStatus.IsHealthy())
+
+	d, err := testState.DeploymentByID(nil, deployment.ID)
+	must.NoError(t, err)
+	must.Eq(t, 0, d.TaskGroups[job.TaskGroups[0].Name].HealthyAllocs)
+
+	// Acknowledging an older version of the route doesn't program it.
+	must.NoError(t, testState.AckIngressRoutes(structs.MsgTypeTestSetup, 60, []*structs.IngressRouteAck{
+		{Namespace: route.Namespace, RouteID: route.ID, Index: 40},
+	}))
+	out, err = testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.True(t, out.DeploymentStatus.IngressPending)
+
+	must.NoError(t, testState.AckIngressRoutes(structs.MsgTypeTestSetup, 70, []*structs.IngressRouteAck{
+		{Namespace: route.Namespace, RouteID: route.ID, Index: route.ModifyIndex},
+	}))
+
+	route = testIngressRoute(t, testState, job)
+	must.Eq(t, 50, route.ProgrammedIndex)
+	must.Eq(t, 50, route.ModifyIndex)
+
+	out, err = testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.False(t, out.DeploymentStatus.IngressPending)
+	must.True(t, out.DeploymentStatus.IsHealthy())
+	must.Eq(t, 70, out.ModifyIndex)
+
+	d, err = testState.DeploymentByID(nil, deployment.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1, d.TaskGroups[job.TaskGroups[0].Name].HealthyAllocs)
+
+	// Acknowledging the route again is a no-op.
+	must.NoError(t, testState.AckIngressRoutes(structs.MsgTypeTestSetup, 80, []*structs.IngressRouteAck{
+		{Namespace: route.Namespace, RouteID: route.ID, Index: route.ModifyIndex},
+	}))
+	index, err := testState.Index(TableIngressRoutes)
+	must.NoError(t, err)
+	must.Eq(t, 70, index)
+}
+
+func TestStateStore_IngressRoutes_DeploymentHealth_Unserved(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	job, alloc := ingressRouteTestJob()
+	controller := testIngressController(t, testState, 5)
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	deployment := mock.Deployment()
+	deployment.JobID = job.ID
+	must.NoError(t, testState.UpsertDeployment(20, deployment))
+
+	alloc.DeploymentID = deployment.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 30, []*structs.Allocation{alloc}))
+
+	healthy := func(index uint64, allocID string) {
+		must.NoError(t, testState.UpdateDeploymentAllocHealth(structs.MsgTypeTestSetup, index,
+			&structs.ApplyDeploymentAllocHealthRequest{
+				DeploymentAllocHealthRequest: structs.DeploymentAllocHealthRequest{
+					DeploymentID:         deployment.ID,
+					HealthyAllocationIDs: []string{allocID},
+				},
+				Timestamp: time.Now(),
+			}))
+	}
+	healthy(40, alloc.ID)
+
+	out, err := testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.True(t, out.DeploymentStatus.IngressPending)
+
+	// Once the controller stops, the sweep of the plugins removes it and
+	// the allocation no longer waits for the route to be programmed.
+	stopped := controller.Copy()
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 50, []*structs.Allocation{stopped}))
+	must.NoError(t, testState.SweepIngressPlugins(structs.MsgTypeTestSetup, 60))
+
+	plug, err := testState.IngressPluginByID(nil, "haproxy")
+	must.NoError(t, err)
+	must.Nil(t, plug)
+
+	out, err = testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.False(t, out.DeploymentStatus.IngressPending)
+	must.True(t, out.DeploymentStatus.IsHealthy())
+
+	d, err := testState.DeploymentByID(nil, deployment.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1, d.TaskGroups[job.TaskGroups[0].Name].HealthyAllocs)
+
+	// New allocations don't wait on routes no plugin serves.
+	other := mock.Alloc()
+	other.Job = job
+	other.JobID = job.ID
+	other.DeploymentID = deployment.ID
+	other.ClientStatus = structs.AllocClientStatusRunning
+	other.AllocatedResources.Shared.Ports = alloc.AllocatedResources.Shared.Ports
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 70, []*structs.Allocation{other}))
+	healthy(80, other.ID)
+
+	out, err = testState.AllocByID(nil, other.ID)
+	must.NoError(t, err)
+	must.False(t, out.DeploymentStatus.IngressPending)
+	must.True(t, out.DeploymentStatus.IsHealthy())
+}
+
+func TestStateStore_IngressRoutes_CanaryWeight(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	job, alloc := ingressRouteTestJob()
+	job.TaskGroups[0].Services[0].Ingress.CanaryWeight = 10
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	// An allocation of the previous deployment and a canary of the current
+	// one.
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+
+	deployment := mock.Deployment()
+	deployment.JobID = job.ID
+	deployment.TaskGroups[job.TaskGroups[0].Name].DesiredCanaries = 1
+	must.NoError(t, testState.UpsertDeployment(20, deployment))
+
+	canary := alloc.Copy()
+	canary.ID = uuid.Generate()
+	canary.DeploymentID = deployment.ID
+	canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: pointer.Of(true),
+		Canary:  true,
+	}
+	canary.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 8081, HostIP: "10.0.0.2"},
+	}
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 30, []*structs.Allocation{alloc, canary}))
+
+	weights := func() map[string]int {
+		out := map[string]int{}
+		for _, b := range testIngressRoute(t, testState, job).Backends {
+			out[b.AllocID] = b.Weight
+		}
+		return out
+	}
+
+	// Canaries receive their share of traffic until promoted.
+	must.Eq(t, map[string]int{alloc.ID: 90, canary.ID: 10}, weights())
+	for _, b := range testIngressRoute(t, testState, job).Backends {
+		must.Eq(t, b.AllocID == canary.ID, b.Canary)
+	}
+
+	must.NoError(t, testState.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 40,
+		&structs.ApplyDeploymentPromoteRequest{
+			DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+				DeploymentID: deployment.ID,
+				All:          true,
+			},
+		}))
+	must.Eq(t, map[string]int{alloc.ID: 100, canary.ID: 100}, weights())
+}
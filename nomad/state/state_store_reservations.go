@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertReservations inserts or updates the given set of reservations.
+func (s *StateStore) UpsertReservations(msgType structs.MessageType, index uint64, reservations []*structs.Reservation) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, reservation := range reservations {
+		if err := s.upsertReservationTxn(txn, index, reservation); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableReservations, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+func (s *StateStore) upsertReservationTxn(txn *txn, index uint64, reservation *structs.Reservation) error {
+	if reservation == nil {
+		return nil
+	}
+
+	existing, err := txn.First(TableReservations, indexID, reservation.Namespace, reservation.ID)
+	if err != nil {
+		return fmt.Errorf("reservation lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		exist := existing.(*structs.Reservation)
+		reservation.CreateIndex = exist.CreateIndex
+		reservation.CreateTime = exist.CreateTime
+	} else {
+		reservation.CreateIndex = index
+	}
+	reservation.ModifyIndex = index
+
+	if err := txn.Insert(TableReservations, reservation); err != nil {
+		return fmt.Errorf("reservation insert failed: %v", err)
+	}
+	return nil
+}
+
+// DeleteReservations removes the given reservations of a namespace.
+func (s *StateStore) DeleteReservations(msgType structs.MessageType, index uint64, namespace string, ids []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		existing, err := txn.First(TableReservations, indexID, namespace, id)
+		if err != nil {
+			return fmt.Errorf("reservation lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("reservation %s not found", id)
+		}
+		if err := txn.Delete(TableReservations, existing); err != nil {
+			return fmt.Errorf("reservation deletion failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableReservations, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// Reservations returns an iterator over all the reservations. The caller is
+// responsible for ensuring ACL access is confirmed, or filtering is performed
+// before responding.
+func (s *StateStore) Reservations(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableReservations, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ReservationsByNamespace returns an iterator over the reservations of the
+// provided namespace.
+func (s *StateStore) ReservationsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableReservations, indexID+"_prefix", namespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("reservation lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ReservationsByNodePool returns an iterator over the reservations held by
+// the nodes of the provided node pool.
+func (s *StateStore) ReservationsByNodePool(ws memdb.WatchSet, pool string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableReservations, indexNodePool, pool)
+	if err != nil {
+		return nil, fmt.Errorf("reservation lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ReservationByID returns a single reservation, or nil if no matching
+// reservation was found.
+func (s *StateStore) ReservationByID(ws memdb.WatchSet, namespace, id string) (*structs.Reservation, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableReservations, indexID, namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("reservation lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.Reservation), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func testReservation(namespace, pool string) *structs.Reservation {
+	return &structs.Reservation{
+		ID:        uuid.Generate(),
+		Namespace: namespace,
+		NodePool:  pool,
+		Resources: &structs.ReservationResources{CPU: 500, MemoryMB: 256},
+	}
+}
+
+func TestStateStore_Reservations(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	r1 := testReservation(structs.DefaultNamespace, structs.NodePoolDefault)
+	r2 := testReservation(structs.DefaultNamespace, "gpu")
+	r3 := testReservation("team", structs.NodePoolDefault)
+
+	ws := memdb.NewWatchSet()
+	_, err := testState.ReservationsByNodePool(ws, structs.NodePoolDefault)
+	must.NoError(t, err)
+
+	must.NoError(t, testState.UpsertReservations(structs.MsgTypeTestSetup, 10,
+		[]*structs.Reservation{r1, r2, r3}))
+	must.True(t, watchFired(ws))
+
+	collect := func(iter memdb.ResultIterator, err error) []string {
+		t.Helper()
+		must.NoError(t, err)
+		var ids []string
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			ids = append(ids, raw.(*structs.Reservation).ID)
+		}
+		return ids
+	}
+
+	must.Len(t, 3, collect(testState.Reservations(nil)))
+	must.SliceContainsAll(t, []string{r1.ID, r2.ID},
+		collect(testState.ReservationsByNamespace(nil, structs.DefaultNamespace)))
+	must.SliceContainsAll(t, []string{r1.ID, r3.ID},
+		collect(testState.ReservationsByNodePool(nil, structs.NodePoolDefault)))
+
+	out, err := testState.ReservationByID(nil, "team", r3.ID)
+	must.NoError(t, err)
+	must.Eq(t, 10, out.CreateIndex)
+	must.Eq(t, 10, out.ModifyIndex)
+
+	out, err = testState.ReservationByID(nil, structs.DefaultNamespace, r3.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// Updating a reservation keeps its create index.
+	r3 = r3.Copy()
+	r3.Resources.CPU = 1000
+	must.NoError(t, testState.UpsertReservations(structs.MsgTypeTestSetup, 20,
+		[]*structs.Reservation{r3}))
+
+	out, err = testState.ReservationByID(nil, "team", r3.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1000, out.Resources.CPU)
+	must.Eq(t, 10, out.CreateIndex)
+	must.Eq(t, 20, out.ModifyIndex)
+
+	index, err := testState.Index(TableReservations)
+	must.NoError(t, err)
+	must.Eq(t, 20, index)
+
+	// Deleting reservations of the wrong namespace fails.
+	must.Error(t, testState.DeleteReservations(structs.MsgTypeTestSetup, 30,
+		structs.DefaultNamespace, []string{r3.ID}))
+
+	must.NoError(t, testState.DeleteReservations(structs.MsgTypeTestSetup, 30,
+		structs.DefaultNamespace, []string{r1.ID, r2.ID}))
+	must.Eq(t, []string{r3.ID}, collect(testState.Reservations(nil)))
+
+	index, err = testState.Index(TableReservations)
+	must.NoError(t, err)
+	must.Eq(t, 30, index)
+}
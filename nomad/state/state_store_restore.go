@@ -189,7 +189,7 @@ func (r *StateRestore) CSIPluginRestore(plugin *structs.CSIPlugin) error {
 
 func (r *StateRestore) IngressPluginRestore(plugin *structs.IngressPlugin) error {
 	if err := r.txn.Insert("ingress_plugins", plugin); err != nil {
-		return fmt.Errorf("csi plugin insert failed: %v", err)
+		return fmt.Errorf("ingress plugin insert failed: %v", err)
 	}
 	return nil
 }
@@ -229,6 +229,33 @@ func (r *StateRestore) ServiceRegistrationRestore(service *structs.ServiceRegist
 	return nil
 }
 
+// IngressRouteRestore is used to restore a single ingress route into the
+// ingress_routes table.
+func (r *StateRestore) IngressRouteRestore(route *structs.IngressRoute) error {
+	if err := r.txn.Insert(TableIngressRoutes, route); err != nil {
+		return fmt.Errorf("ingress route insert failed: %v", err)
+	}
+	return nil
+}
+
+// ServiceIntentionRestore is used to restore a single service intention into
+// the service_intentions table.
+func (r *StateRestore) ServiceIntentionRestore(intention *structs.ServiceIntention) error {
+	if err := r.txn.Insert(TableServiceIntentions, intention); err != nil {
+		return fmt.Errorf("service intention insert failed: %v", err)
+	}
+	return nil
+}
+
+// ReservationRestore is used to restore a single reservation into the
+// reservations table.
+func (r *StateRestore) ReservationRestore(reservation *structs.Reservation) error {
+	if err := r.txn.Insert(TableReservations, reservation); err != nil {
+		return fmt.Errorf("reservation insert failed: %v", err)
+	}
+	return nil
+}
+
 // VariablesRestore is used to restore a single variable into the variables
 // table.
 func (r *StateRestore) VariablesRestore(variable *structs.VariableEncrypted) error {
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertServiceIntentions inserts or updates the given set of service
+// intentions.
+func (s *StateStore) UpsertServiceIntentions(msgType structs.MessageType, index uint64, intentions []*structs.ServiceIntention) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, intention := range intentions {
+		if err := s.upsertServiceIntentionTxn(txn, index, intention); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableServiceIntentions, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+func (s *StateStore) upsertServiceIntentionTxn(txn *txn, index uint64, intention *structs.ServiceIntention) error {
+	if intention == nil {
+		return nil
+	}
+
+	existing, err := txn.First(TableServiceIntentions, indexID,
+		intention.Namespace, intention.DestinationService, intention.SourceService)
+	if err != nil {
+		return fmt.Errorf("service intention lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		exist := existing.(*structs.ServiceIntention)
+		if exist.Equal(intention) {
+			return nil
+		}
+		intention.CreateIndex = exist.CreateIndex
+	} else {
+		intention.CreateIndex = index
+	}
+	intention.ModifyIndex = index
+
+	if err := txn.Insert(TableServiceIntentions, intention); err != nil {
+		return fmt.Errorf("service intention insert failed: %v", err)
+	}
+	return nil
+}
+
+// DeleteServiceIntention removes the service intention of the namespace
+// between the source and destination services.
+func (s *StateStore) DeleteServiceIntention(msgType structs.MessageType, index uint64, namespace, source, destination string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableServiceIntentions, indexID, namespace, destination, source)
+	if err != nil {
+		return fmt.Errorf("service intention lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("service intention from %s to %s not found", source, destination)
+	}
+	if err := txn.Delete(TableServiceIntentions, existing); err != nil {
+		return fmt.Errorf("service intention deletion failed: %v", err)
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableServiceIntentions, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// ServiceIntentions returns an iterator over all the service intentions. The
+// caller is responsible for ensuring ACL access is confirmed, or filtering is
+// performed before responding.
+func (s *StateStore) ServiceIntentions(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableServiceIntentions, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("service intention lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ServiceIntentionsByNamespace returns an iterator over the service
+// intentions of the provided namespace.
+func (s *StateStore) ServiceIntentionsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableServiceIntentions, indexID+"_prefix", namespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("service intention lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ServiceIntentionByName returns the service intention of the namespace
+// between the source and destination services, or nil if no matching
+// intention was found.
+func (s *StateStore) ServiceIntentionByName(ws memdb.WatchSet, namespace, source, destination string) (*structs.ServiceIntention, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableServiceIntentions, indexID, namespace, destination, source)
+	if err != nil {
+		return nil, fmt.Errorf("service intention lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ServiceIntention), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func testServiceIntention(namespace, source, destination string) *structs.ServiceIntention {
+	return &structs.ServiceIntention{
+		Namespace:          namespace,
+		SourceService:      source,
+		DestinationService: destination,
+		Action:             structs.ServiceIntentionActionAllow,
+	}
+}
+
+func TestStateStore_ServiceIntentions(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	i1 := testServiceIntention(structs.DefaultNamespace, "web", "api")
+	i2 := testServiceIntention(structs.DefaultNamespace, "*", "db")
+	i3 := testServiceIntention("team", "web", "api")
+
+	ws := memdb.NewWatchSet()
+	_, err := testState.ServiceIntentionsByNamespace(ws, structs.DefaultNamespace)
+	must.NoError(t, err)
+
+	must.NoError(t, testState.UpsertServiceIntentions(structs.MsgTypeTestSetup, 10,
+		[]*structs.ServiceIntention{i1, i2, i3}))
+	must.True(t, watchFired(ws))
+
+	collect := func(iter memdb.ResultIterator, err error) []*structs.ServiceIntention {
+		t.Helper()
+		must.NoError(t, err)
+		var intentions []*structs.ServiceIntention
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			intentions = append(intentions, raw.(*structs.ServiceIntention))
+		}
+		return intentions
+	}
+
+	must.Len(t, 3, collect(testState.ServiceIntentions(nil)))
+	must.SliceContainsAll(t, []*structs.ServiceIntention{i1, i2},
+		collect(testState.ServiceIntentionsByNamespace(nil, structs.DefaultNamespace)))
+
+	// Writing an intention for an existing pair of services replaces it and
+	// keeps its create index.
+	update := testServiceIntention(structs.DefaultNamespace, "web", "api")
+	update.Action = structs.ServiceIntentionActionDeny
+	must.NoError(t, testState.UpsertServiceIntentions(structs.MsgTypeTestSetup, 20,
+		[]*structs.ServiceIntention{update}))
+
+	out, err := testState.ServiceIntentionByName(nil, structs.DefaultNamespace, "web", "api")
+	must.NoError(t, err)
+	must.Eq(t, structs.ServiceIntentionActionDeny, out.Action)
+	must.Eq(t, 10, out.CreateIndex)
+	must.Eq(t, 20, out.ModifyIndex)
+
+	ws = memdb.NewWatchSet()
+	_, err = testState.ServiceIntentionByName(ws, structs.DefaultNamespace, "web", "api")
+	must.NoError(t, err)
+	must.NoError(t, testState.DeleteServiceIntention(structs.MsgTypeTestSetup, 30,
+		structs.DefaultNamespace, "web", "api"))
+	must.True(t, watchFired(ws))
+
+	out, err = testState.ServiceIntentionByName(nil, structs.DefaultNamespace, "web", "api")
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	err = testState.DeleteServiceIntention(structs.MsgTypeTestSetup, 40,
+		structs.DefaultNamespace, "web", "api")
+	must.ErrorContains(t, err, "not found")
+
+	index, err := testState.Index(TableServiceIntentions)
+	must.NoError(t, err)
+	must.Eq(t, 30, index)
+}
@@ -46,6 +46,11 @@ func (s *StateStore) UpsertServiceRegistrations(
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// The registrations provide the addresses of ingress route backends.
+	if err := s.updateIngressRoutesForServicesTxn(index, txn, services); err != nil {
+		return err
+	}
+
 	return txn.Commit()
 }
 
@@ -117,7 +122,9 @@ func (s *StateStore) deleteServiceRegistrationByIDTxn(
 	if err := txn.Insert(tableIndex, &IndexEntry{TableServiceRegistrations, index}); err != nil {
 		return fmt.Errorf("index update failed: %v", err)
 	}
-	return nil
+
+	service := existing.(*structs.ServiceRegistration)
+	return s.updateIngressRoutesTxn(index, txn, service.Namespace, service.JobID)
 }
 
 // DeleteServiceRegistrationByNodeID deletes all service registrations that
@@ -129,6 +136,17 @@ func (s *StateStore) DeleteServiceRegistrationByNodeID(
 	txn := s.db.WriteTxnMsgT(msgType, index)
 	defer txn.Abort()
 
+	// Capture the registrations before deleting them so the ingress routes
+	// of their jobs can be updated.
+	iter, err := txn.Get(TableServiceRegistrations, indexNodeID, nodeID)
+	if err != nil {
+		return fmt.Errorf("service registration lookup failed: %v", err)
+	}
+	var services []*structs.ServiceRegistration
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		services = append(services, raw.(*structs.ServiceRegistration))
+	}
+
 	num, err := txn.DeleteAll(TableServiceRegistrations, indexNodeID, nodeID)
 	if err != nil {
 		return fmt.Errorf("deleting service registrations failed: %v", err)
@@ -145,9 +163,31 @@ func (s *StateStore) DeleteServiceRegistrationByNodeID(
 		}
 	}
 
+	if err := s.updateIngressRoutesForServicesTxn(index, txn, services); err != nil {
+		return err
+	}
+
 	return txn.Commit()
 }
 
+// updateIngressRoutesForServicesTxn updates the ingress routes of every job
+// referenced by the service registrations.
+func (s *StateStore) updateIngressRoutesForServicesTxn(
+	index uint64, txn *txn, services []*structs.ServiceRegistration) error {
+
+	jobs := make(map[structs.NamespacedID]struct{})
+	for _, service := range services {
+		jobs[structs.NamespacedID{ID: service.JobID, Namespace: service.Namespace}] = struct{}{}
+	}
+
+	for tuple := range jobs {
+		if err := s.updateIngressRoutesTxn(index, txn, tuple.Namespace, tuple.ID); err != nil {
+			return fmt.Errorf("updating ingress routes failed: %v", err)
+		}
+	}
+	return nil
+}
+
 // GetServiceRegistrations returns an iterator that contains all service
 // registrations stored within state. This is primarily useful when performing
 // listings which use the namespace wildcard operator. The caller is
@@ -3171,6 +3171,37 @@ func TestStateStore_JobsByIDPrefix_Namespaces(t *testing.T) {
 	require.False(t, watchFired(ws))
 }
 
+func TestStateStore_JobsByMeta(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job1 := mock.Job()
+	job1.Meta = map[string]string{"platform": "web"}
+	job2 := mock.Job()
+	job2.Meta = map[string]string{"platform": "batch"}
+	job3 := mock.Job()
+	job3.Meta = nil
+
+	ws := memdb.NewWatchSet()
+	_, err := state.JobsByMeta(ws, "platform", "web")
+	must.NoError(t, err)
+
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, job1))
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job2))
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, job3))
+	must.True(t, watchFired(ws))
+
+	iter, err := state.JobsByMeta(nil, "platform", "web")
+	must.NoError(t, err)
+
+	var out []*structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.Job))
+	}
+	must.Len(t, 1, out)
+	must.Eq(t, job1.ID, out[0].ID)
+}
+
 func TestStateStore_JobsByNamespace(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4285,6 +4316,78 @@ func TestStateStore_CSIPlugin_Lifecycle(t *testing.T) {
 	})
 }
 
+func TestStateStore_IngressPlugin_ControllerHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	store := testStateStore(t)
+
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+
+	node := mock.Node()
+	node.ID = alloc.NodeID
+	node.StatusUpdatedAt = 1000
+	info := &structs.IngressInfo{PluginID: "haproxy", AllocID: alloc.ID}
+	info.SetHealthy(true)
+	node.IngressPlugins = map[string]*structs.IngressInfo{"haproxy": info}
+
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 10, []*structs.Allocation{alloc}))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 20, node))
+
+	plug, err := store.IngressPluginByID(nil, "haproxy")
+	must.NoError(t, err)
+	must.Eq(t, 1, plug.ControllersHealthy)
+	must.Eq(t, time.Unix(1000, 0), plug.Controllers[node.ID].NodeStatusUpdateTime)
+
+	// Controllers of down nodes are stale.
+	must.NoError(t, store.UpdateNodeStatus(structs.MsgTypeTestSetup, 30, node.ID,
+		structs.NodeStatusDown, 2000, nil))
+
+	plug, err = store.IngressPluginByID(nil, "haproxy")
+	must.NoError(t, err)
+	must.Eq(t, 0, plug.ControllersHealthy)
+	must.False(t, plug.Controllers[node.ID].Healthy)
+	must.Eq(t, structs.IngressControllerNodeDown, plug.Controllers[node.ID].HealthDescription)
+	must.Eq(t, time.Unix(1000, 0), plug.Controllers[node.ID].NodeStatusUpdateTime)
+	must.Eq(t, 30, plug.ModifyIndex)
+
+	// They are restored from the fingerprints of the node once it's back.
+	must.NoError(t, store.UpdateNodeStatus(structs.MsgTypeTestSetup, 40, node.ID,
+		structs.NodeStatusReady, 3000, nil))
+
+	plug, err = store.IngressPluginByID(nil, "haproxy")
+	must.NoError(t, err)
+	must.Eq(t, 1, plug.ControllersHealthy)
+	must.Eq(t, "healthy", plug.Controllers[node.ID].HealthDescription)
+	must.Eq(t, time.Unix(3000, 0), plug.Controllers[node.ID].NodeStatusUpdateTime)
+
+	// Sweeping doesn't change controllers matching their node.
+	must.NoError(t, store.SweepIngressPlugins(structs.MsgTypeTestSetup, 50))
+	index, err := store.Index("ingress_plugins")
+	must.NoError(t, err)
+	must.Eq(t, 40, index)
+
+	// Sweeping catches up with nodes going down without an update.
+	down := node.Copy()
+	down.Status = structs.NodeStatusDown
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 60, down))
+	must.NoError(t, store.SweepIngressPlugins(structs.MsgTypeTestSetup, 70))
+
+	plug, err = store.IngressPluginByID(nil, "haproxy")
+	must.NoError(t, err)
+	must.Eq(t, 0, plug.ControllersHealthy)
+	must.Eq(t, 70, plug.ModifyIndex)
+
+	// Controllers whose allocation is gone are removed, and the plugin with
+	// them.
+	must.NoError(t, store.DeleteEval(80, nil, []string{alloc.ID}, false))
+	must.NoError(t, store.SweepIngressPlugins(structs.MsgTypeTestSetup, 90))
+
+	plug, err = store.IngressPluginByID(nil, "haproxy")
+	must.NoError(t, err)
+	must.Nil(t, plug)
+}
+
 func TestStateStore_Indexes(t *testing.T) {
 	ci.Parallel(t)
 
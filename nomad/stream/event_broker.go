@@ -359,7 +359,8 @@ func aclAllowsSubscription(aclObj *acl.ACL, subReq *SubscribeRequest) bool {
 			structs.TopicEvaluation,
 			structs.TopicAllocation,
 			structs.TopicJob,
-			structs.TopicService:
+			structs.TopicService,
+			structs.TopicIngress:
 			if ok := aclObj.AllowNsOp(subReq.Namespace, acl.NamespaceCapabilityReadJob); !ok {
 				return false
 			}
@@ -44,6 +44,7 @@ type CheckID string
 type CheckQueryResult struct {
 	ID         CheckID
 	Mode       CheckMode
+	Role       string `json:",omitempty"`
 	Status     CheckStatus
 	StatusCode int `json:",omitempty"`
 	Output     string
@@ -90,6 +91,19 @@ func NomadCheckID(allocID, group string, c *ServiceCheck) CheckID {
 	hashString(sum, c.Protocol)
 	hashString(sum, c.Path)
 	hashString(sum, c.Method)
+
+	// Only include the fields of grpc checks if set, to maintain ID stability
+	// of the http and tcp checks of existing allocations. The command of
+	// script checks is not included, as it is interpolated with the
+	// environment of the task running it.
+	hashStringIfNonEmpty(sum, c.GRPCService)
+	hashBool(sum, c.GRPCUseTLS, "grpc_use_tls")
+	hashStringIfNonEmpty(sum, c.TLSServerName)
+	hashBool(sum, c.TLSSkipVerify, "tls_skip_verify")
+
+	// Only include the role if set, to maintain ID stability of checks
+	// without a role.
+	hashStringIfNonEmpty(sum, c.Role)
 	h := sum.Sum(nil)
 	return CheckID(fmt.Sprintf("%x", h))
 }
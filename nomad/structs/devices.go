@@ -58,6 +58,20 @@ func NewDeviceAccounter(n *Node) *DeviceAccounter {
 	return d
 }
 
+// Usage returns the number of device instances in use and the number of
+// healthy device instances.
+func (d *DeviceAccounter) Usage() (used, total int) {
+	for _, devInst := range d.Devices {
+		for _, v := range devInst.Instances {
+			if v != 0 {
+				used++
+			}
+			total++
+		}
+	}
+	return used, total
+}
+
 // AddAllocs takes a set of allocations and internally marks which devices are
 // used. If a device is used more than once by the set of passed allocations,
 // the collision will be returned as true.
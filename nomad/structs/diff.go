@@ -309,6 +309,17 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Job affinities diff
+	jobAffinitiesDiff := primitiveObjectSetDiff(
+		interfaceSlice(tg.JobAffinities),
+		interfaceSlice(other.JobAffinities),
+		nil,
+		"JobAffinity",
+		contextual)
+	if jobAffinitiesDiff != nil {
+		diff.Objects = append(diff.Objects, jobAffinitiesDiff...)
+	}
+
 	// Restart policy diff
 	rDiff := primitiveObjectDiff(tg.RestartPolicy, other.RestartPolicy, nil, "RestartPolicy", contextual)
 	if rDiff != nil {
@@ -813,6 +824,107 @@ func serviceDiff(old, new *Service, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, wiDiffs)
 	}
 
+	// Ingress diffs
+	if iDiff := serviceIngressDiff(old.Ingress, new.Ingress, contextual); iDiff != nil {
+		diff.Objects = append(diff.Objects, iDiff)
+	}
+
+	// Mesh diffs
+	if mDiff := serviceMeshDiff(old.Mesh, new.Mesh, contextual); mDiff != nil {
+		diff.Objects = append(diff.Objects, mDiff)
+	}
+
+	return diff
+}
+
+// serviceMeshDiff returns the diff of two service mesh objects. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func serviceMeshDiff(old, new *ServiceMesh, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Mesh"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &ServiceMesh{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &ServiceMesh{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Diff the upstreams, keyed by destination name.
+	oldUpstreams := make(map[string]*MeshUpstream, len(old.Upstreams))
+	newUpstreams := make(map[string]*MeshUpstream, len(new.Upstreams))
+	for _, o := range old.Upstreams {
+		oldUpstreams[o.DestinationName] = o
+	}
+	for _, n := range new.Upstreams {
+		newUpstreams[n.DestinationName] = n
+	}
+
+	var upstreamDiffs []*ObjectDiff
+	for name, oldUpstream := range oldUpstreams {
+		if uDiff := primitiveObjectDiff(oldUpstream, newUpstreams[name], nil, "Upstreams", contextual); uDiff != nil {
+			upstreamDiffs = append(upstreamDiffs, uDiff)
+		}
+	}
+	for name, newUpstream := range newUpstreams {
+		if _, ok := oldUpstreams[name]; !ok {
+			if uDiff := primitiveObjectDiff(nil, newUpstream, nil, "Upstreams", contextual); uDiff != nil {
+				upstreamDiffs = append(upstreamDiffs, uDiff)
+			}
+		}
+	}
+	sort.Sort(ObjectDiffs(upstreamDiffs))
+	diff.Objects = append(diff.Objects, upstreamDiffs...)
+
+	return diff
+}
+
+// serviceIngressDiff returns the diff of two service ingress objects. If
+// contextual diff is enabled, all fields will be returned, even if no diff
+// occurred.
+func serviceIngressDiff(old, new *ServiceIngress, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Ingress"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &ServiceIngress{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &ServiceIngress{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	if setDiff := stringSetDiff(old.Hosts, new.Hosts, "Hosts", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
+	if setDiff := stringSetDiff(old.Paths, new.Paths, "Paths", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	return diff
 }
 
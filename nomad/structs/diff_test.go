@@ -1295,6 +1295,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Gang",
+								Old:  "",
+								New:  "false",
+							},
 						},
 					},
 					{
@@ -1323,6 +1329,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Gang",
+								Old:  "false",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -2057,6 +2069,79 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "Job affinities edited",
+			Old: &TaskGroup{
+				JobAffinities: []*JobAffinity{
+					{
+						JobID:  "cache",
+						Weight: 50,
+					},
+				},
+			},
+			New: &TaskGroup{
+				JobAffinities: []*JobAffinity{
+					{
+						JobID:    "cache",
+						Weight:   -50,
+						Required: true,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "JobAffinity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "JobID",
+								Old:  "",
+								New:  "cache",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Required",
+								Old:  "",
+								New:  "true",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Weight",
+								Old:  "",
+								New:  "-50",
+							},
+						},
+					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "JobAffinity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "JobID",
+								Old:  "cache",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Required",
+								Old:  "false",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Weight",
+								Old:  "50",
+								New:  "",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			TestCase: "Consul added",
 			Old:      &TaskGroup{},
@@ -3434,6 +3519,12 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "http",
 										New:  "tcp",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Role",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeEdited,
 										Name: "SuccessBeforePassing",
@@ -7220,6 +7311,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "http",
 										New:  "http",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Role",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "SuccessBeforePassing",
@@ -9803,6 +9900,71 @@ func TestServicesDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Service with ingress edited",
+			Old: []*Service{
+				{
+					Name: "webapp",
+					Ingress: &ServiceIngress{
+						Hosts:  []string{"example.com"},
+						Paths:  []string{"/"},
+						Class:  "haproxy",
+						Weight: 100,
+					},
+				},
+			},
+			New: []*Service{
+				{
+					Name: "webapp",
+					Ingress: &ServiceIngress{
+						Hosts:     []string{"example.com", "www.example.com"},
+						Paths:     []string{"/"},
+						Class:     "haproxy",
+						TLSSecret: "certs/example",
+						Weight:    50,
+					},
+				},
+			},
+			Expected: []*ObjectDiff{
+				{
+					Type: DiffTypeEdited,
+					Name: "Service",
+					Objects: []*ObjectDiff{
+						{
+							Type: DiffTypeEdited,
+							Name: "Ingress",
+							Fields: []*FieldDiff{
+								{
+									Type: DiffTypeAdded,
+									Name: "TLSSecret",
+									Old:  "",
+									New:  "certs/example",
+								},
+								{
+									Type: DiffTypeEdited,
+									Name: "Weight",
+									Old:  "100",
+									New:  "50",
+								},
+							},
+							Objects: []*ObjectDiff{
+								{
+									Type: DiffTypeAdded,
+									Name: "Hosts",
+									Fields: []*FieldDiff{
+										{
+											Type: DiffTypeAdded,
+											Name: "Hosts",
+											New:  "www.example.com",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -31,6 +31,7 @@ const (
 	TopicACLAuthMethod  Topic = "ACLAuthMethod"
 	TopicACLBindingRule Topic = "ACLBindingRule"
 	TopicService        Topic = "Service"
+	TopicIngress        Topic = "Ingress"
 	TopicAll            Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
@@ -63,6 +64,8 @@ const (
 	TypeACLBindingRuleDeleted         = "ACLBindingRuleDeleted"
 	TypeServiceRegistration           = "ServiceRegistration"
 	TypeServiceDeregistration         = "ServiceDeregistration"
+	TypeIngressRouteUpserted          = "IngressRouteUpserted"
+	TypeIngressRouteDeleted           = "IngressRouteDeleted"
 )
 
 // Event represents a change in Nomads state.
@@ -152,6 +155,11 @@ type ServiceRegistrationStreamEvent struct {
 	Service *ServiceRegistration
 }
 
+// IngressRouteEvent holds a newly updated or deleted ingress route.
+type IngressRouteEvent struct {
+	Route *IngressRoute
+}
+
 // NewACLTokenEvent takes a token and creates a new ACLTokenEvent.  It creates
 // a copy of the passed in ACLToken and empties out the copied tokens SecretID
 func NewACLTokenEvent(token *ACLToken) *ACLTokenEvent {
@@ -273,6 +273,74 @@ func ScoreFitSpread(node *Node, util *ComparableResources) float64 {
 	return score
 }
 
+// ScoreFitWeighted computes a fit score to achieve binpacking behavior across
+// every resource of the node: CPU, memory, disk, network bandwidth, reserved
+// cores and devices, weighted by the resource weights. Resources the node
+// does not have are ignored. Nodes with resources that allocations leave
+// unused score lower, so that they are kept for allocations using them. Unlike
+// a dominant share, every resource contributes to the score.
+// Score is in [0, 18]
+//
+// With the CPU and memory weighted equally and the other resources ignored,
+// this is equivalent to ScoreFitBinPack.
+func ScoreFitWeighted(node *Node, util *ComparableResources, devices *DeviceAccounter, weights ResourceWeights) float64 {
+	available := node.NodeResources.Comparable()
+	available.Subtract(node.ReservedResources.Comparable())
+
+	var nodeMBits, usedMBits int
+	for _, n := range node.NodeResources.Networks {
+		nodeMBits += n.MBits
+	}
+	for _, n := range util.Flattened.Networks {
+		usedMBits += n.MBits
+	}
+
+	var usedDevices, nodeDevices int
+	if devices != nil {
+		usedDevices, nodeDevices = devices.Usage()
+	}
+
+	resources := []struct {
+		weight   int
+		capacity float64
+		used     float64
+	}{
+		{weights.CPU, float64(available.Flattened.Cpu.CpuShares), float64(util.Flattened.Cpu.CpuShares)},
+		{weights.Memory, float64(available.Flattened.Memory.MemoryMB), float64(util.Flattened.Memory.MemoryMB)},
+		{weights.Disk, float64(available.Shared.DiskMB), float64(util.Shared.DiskMB)},
+		{weights.Network, float64(nodeMBits), float64(usedMBits)},
+		{weights.Cores, float64(len(available.Flattened.Cpu.ReservedCores)), float64(len(util.Flattened.Cpu.ReservedCores))},
+		{weights.Devices, float64(nodeDevices), float64(usedDevices)},
+	}
+
+	// The total is the weighted mean of 10^freePct over the resources, so
+	// that it is 1 at 100% utilization and 10 at 0% utilization.
+	var total, totalWeight float64
+	for _, r := range resources {
+		if r.weight <= 0 || r.capacity <= 0 {
+			continue
+		}
+		freePct := 1 - r.used/r.capacity
+		total += float64(r.weight) * math.Pow(10, freePct)
+		totalWeight += float64(r.weight)
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+
+	// Scale to the range of ScoreFitBinPack, which sums the terms of two
+	// resources, and invert so that a perfect fit scores 18.
+	score := 20.0 - 2*total/totalWeight
+
+	// Bound the score, just in case
+	if score > 18.0 {
+		score = 18.0
+	} else if score < 0 {
+		score = 0
+	}
+	return score
+}
+
 func CopySliceConstraints(s []*Constraint) []*Constraint {
 	l := len(s)
 	if l == 0 {
@@ -594,6 +594,127 @@ func TestScoreFitBinPack(t *testing.T) {
 	}
 }
 
+func TestScoreFitWeighted(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &Node{}
+	node.NodeResources = &NodeResources{
+		Processors: NodeProcessorResources{
+			Topology: &numalib.Topology{
+				NodeIDs:   idset.From[hw.NodeID]([]hw.NodeID{0}),
+				Distances: numalib.SLIT{[]numalib.Cost{10}},
+				Cores: []numalib.Core{{
+					ID:        0,
+					Grade:     numalib.Performance,
+					BaseSpeed: 4096,
+				}},
+			},
+		},
+		Memory: NodeMemoryResources{
+			MemoryMB: 8192,
+		},
+		Disk: NodeDiskResources{
+			DiskMB: 10000,
+		},
+		Networks: []*NetworkResource{{
+			Device: "eth0",
+			MBits:  1000,
+		}},
+		Devices: []*NodeDeviceResource{{
+			Vendor: "nvidia",
+			Type:   "gpu",
+			Name:   "1080ti",
+			Instances: []*NodeDevice{
+				{ID: "gpu-0", Healthy: true},
+				{ID: "gpu-1", Healthy: true},
+			},
+		}},
+	}
+	node.NodeResources.Compatibility()
+	node.ReservedResources = &NodeReservedResources{
+		Cpu: NodeReservedCpuResources{
+			CpuShares: 2048,
+		},
+		Memory: NodeReservedMemoryResources{
+			MemoryMB: 4096,
+		},
+	}
+
+	cpuAndMemory := AllocatedTaskResources{
+		Cpu:    AllocatedCpuResources{CpuShares: 2048},
+		Memory: AllocatedMemoryResources{MemoryMB: 4096},
+	}
+	everything := AllocatedTaskResources{
+		Cpu:      AllocatedCpuResources{CpuShares: 2048, ReservedCores: []uint16{0}},
+		Memory:   AllocatedMemoryResources{MemoryMB: 4096},
+		Networks: []*NetworkResource{{Device: "eth0", MBits: 1000}},
+	}
+
+	cases := []struct {
+		name        string
+		flattened   AllocatedTaskResources
+		diskMB      int64
+		usedDevices []string
+		weights     ResourceWeights
+		score       float64
+	}{
+		{
+			name: "cpu and memory weights match binpack",
+			flattened: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 2048},
+			},
+			weights: ResourceWeights{CPU: 1, Memory: 1},
+			score:   13.675,
+		},
+		{
+			name:      "unused resources lower the score",
+			flattened: cpuAndMemory,
+			weights:   ResourceWeights{}.Effective(),
+			score:     6,
+		},
+		{
+			name:      "weighted unused devices lower the score more",
+			flattened: cpuAndMemory,
+			weights:   ResourceWeights{CPU: 1, Memory: 1, Devices: 2},
+			score:     9,
+		},
+		{
+			name:        "filled node",
+			flattened:   everything,
+			diskMB:      10000,
+			usedDevices: []string{"gpu-0", "gpu-1"},
+			weights:     ResourceWeights{}.Effective(),
+			score:       18,
+		},
+		{
+			name:      "zero weights ignore resources",
+			flattened: cpuAndMemory,
+			weights:   ResourceWeights{Memory: 1},
+			score:     18,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			util := &ComparableResources{
+				Flattened: c.flattened,
+				Shared:    AllocatedSharedResources{DiskMB: c.diskMB},
+			}
+
+			devices := NewDeviceAccounter(node)
+			for _, instance := range devices.Devices {
+				for _, id := range c.usedDevices {
+					instance.Instances[id] = 1
+				}
+			}
+
+			score := ScoreFitWeighted(node, util, devices, c.weights)
+			require.InDelta(t, c.score, score, 0.001)
+		})
+	}
+}
+
 func TestACLPolicyListHash(t *testing.T) {
 	ci.Parallel(t)
 
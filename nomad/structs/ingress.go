@@ -1,6 +1,16 @@
 package structs
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
 
 // IngressClass is an enum string that encapsulates the valid options for a
 // Ingress Plugin block's Type. These modes will allow the plugin to be used in
@@ -16,6 +26,29 @@ const (
 	IngressSocketName                 = "ingress.sock"
 )
 
+const (
+	// IngressTemplateHAProxy renders the load balancer configuration of an
+	// internal ingress class plugin as an haproxy.cfg file.
+	IngressTemplateHAProxy = "haproxy"
+
+	// IngressTemplateNginx renders the load balancer configuration of an
+	// internal ingress class plugin as an nginx.conf file.
+	IngressTemplateNginx = "nginx"
+
+	// IngressTemplateEnvoy renders the load balancer configuration of an
+	// internal ingress class plugin as Envoy filesystem based cds.yaml and
+	// lds.yaml files.
+	IngressTemplateEnvoy = "envoy"
+)
+
+// IngressTemplates are the templates Nomad can render the load balancer
+// configuration of an internal ingress class plugin with.
+var IngressTemplates = []string{
+	IngressTemplateEnvoy,
+	IngressTemplateHAProxy,
+	IngressTemplateNginx,
+}
+
 // TaskIngressPluginConfig contains the data that is required to setup a task as a
 // Ingress plugin. This will be used by the ingress_plugin_supervisor_hook to initiate the connection to the plugin catalog.
 type TaskIngressPluginConfig struct {
@@ -30,12 +63,36 @@ type TaskIngressPluginConfig struct {
 // InternalIngressClassConfig contains parameters for ingress controller
 // which manages load balancer inside nomad cluster
 type InternalIngressClassConfig struct {
+	// LoadBalancerConfigurationPath is the directory inside the task the
+	// load balancer configuration is written to.
 	LoadBalancerConfigurationPath string `mapstructure:"lb_conf_path" hcl:"lb_conf_path"`
+
+	// Template is the name of the built-in template Nomad renders the load
+	// balancer configuration with from the ingress routes. When empty the
+	// plugin is responsible for writing the configuration.
+	Template string `mapstructure:"template" hcl:"template,optional"`
+
+	// PortLabel is the label of the port the rendered load balancer listens
+	// on.
+	PortLabel string `mapstructure:"port" hcl:"port,optional"`
+
+	// ReloadSignal is the signal sent to the task when the rendered
+	// configuration changes. No signal is sent when empty.
+	ReloadSignal string `mapstructure:"reload_signal" hcl:"reload_signal,optional"`
 }
 
 // ExternalIngressClassConfig contains parameters for ingress controller
 // which manages load balancer outside nomad cluster
 type ExternalIngressClassConfig struct {
+	// TargetGroup is the identity of the target group or pool of the
+	// external load balancer the addresses of the allocations are
+	// registered in.
+	TargetGroup string `mapstructure:"target_group" hcl:"target_group"`
+
+	// DrainTimeout is how long the load balancer keeps serving in-flight
+	// connections to an allocation before it is removed from the target
+	// group.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout" hcl:"drain_timeout,optional"`
 }
 
 func (t *TaskIngressPluginConfig) Copy() *TaskIngressPluginConfig {
@@ -45,9 +102,47 @@ func (t *TaskIngressPluginConfig) Copy() *TaskIngressPluginConfig {
 
 	nt := new(TaskIngressPluginConfig)
 	*nt = *t
+	nt.Internal = t.Internal.Copy()
+	nt.External = t.External.Copy()
 	return nt
 }
 
+// Validate checks if the ingress plugin configuration of a task is valid.
+func (t *TaskIngressPluginConfig) Validate() error {
+	if t == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+
+	if t.ID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("IngressPluginConfig must have a non-empty PluginID"))
+	}
+
+	switch t.Class {
+	case InternalIngressClass:
+		if t.External != nil {
+			mErr.Errors = append(mErr.Errors, errors.New("IngressPluginConfig of class \"internal\" can't have an external block"))
+		}
+		if err := t.Internal.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	case ExternalIngressClass:
+		if t.External == nil {
+			mErr.Errors = append(mErr.Errors, errors.New("IngressPluginConfig of class \"external\" requires an external block"))
+		} else if err := t.External.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		if t.Internal != nil {
+			mErr.Errors = append(mErr.Errors, errors.New("IngressPluginConfig of class \"external\" can't have an internal block"))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("IngressPluginConfig class must be one of 'internal' or 'external', got: %q", t.Class))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
 func (t *TaskIngressPluginConfig) Equal(o *TaskIngressPluginConfig) bool {
 	if t == nil || o == nil {
 		return t == o
@@ -59,7 +154,7 @@ func (t *TaskIngressPluginConfig) Equal(o *TaskIngressPluginConfig) bool {
 		return false
 	case t.NomadToken != o.NomadToken:
 		return false
-	case t.Class != t.Class:
+	case t.Class != o.Class:
 		return false
 	}
 
@@ -73,18 +168,94 @@ func (i *InternalIngressClassConfig) Equal(o *InternalIngressClassConfig) bool {
 	switch {
 	case i.LoadBalancerConfigurationPath != o.LoadBalancerConfigurationPath:
 		return false
+	case i.Template != o.Template:
+		return false
+	case i.PortLabel != o.PortLabel:
+		return false
+	case i.ReloadSignal != o.ReloadSignal:
+		return false
 	}
 	return true
 }
 
-// TODO add checks after making external
+func (i *InternalIngressClassConfig) Copy() *InternalIngressClassConfig {
+	if i == nil {
+		return nil
+	}
+	ni := *i
+	return &ni
+}
+
+// Validate checks if the internal class configuration is valid.
+func (i *InternalIngressClassConfig) Validate() error {
+	if i == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if i.Template == "" {
+		if i.PortLabel != "" || i.ReloadSignal != "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Internal ingress class port and reload_signal require a template"))
+		}
+		return mErr.ErrorOrNil()
+	}
+
+	if !slices.Contains(IngressTemplates, i.Template) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Internal ingress class template must be one of %s; got %q",
+			strings.Join(IngressTemplates, ", "), i.Template))
+	}
+	if i.PortLabel == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Internal ingress class with a template must specify a port"))
+	}
+	if i.LoadBalancerConfigurationPath == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Internal ingress class with a template must specify a lb_conf_path"))
+	}
+	return mErr.ErrorOrNil()
+}
+
 func (e *ExternalIngressClassConfig) Equal(o *ExternalIngressClassConfig) bool {
 	if e == nil || o == nil {
 		return e == o
 	}
+	switch {
+	case e.TargetGroup != o.TargetGroup:
+		return false
+	case e.DrainTimeout != o.DrainTimeout:
+		return false
+	}
 	return true
 }
 
+func (e *ExternalIngressClassConfig) Copy() *ExternalIngressClassConfig {
+	if e == nil {
+		return nil
+	}
+	ne := *e
+	return &ne
+}
+
+// Validate checks if the external class configuration is valid.
+func (e *ExternalIngressClassConfig) Validate() error {
+	var mErr multierror.Error
+	if e.TargetGroup == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("External ingress class must specify a target_group"))
+	}
+	if e.DrainTimeout < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("External ingress class drain_timeout must not be negative; got %v", e.DrainTimeout))
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// IngressControllerNodeDown is the health description of controllers
+	// running on a down node.
+	IngressControllerNodeDown = "node is down"
+
+	// IngressControllerNodeDisconnected is the health description of
+	// controllers running on a disconnected node.
+	IngressControllerNodeDisconnected = "node is disconnected"
+)
+
 type IngressPlugin struct {
 	ID       string
 	Provider string
@@ -138,6 +309,9 @@ func (i *IngressPlugin) Copy() *IngressPlugin {
 	return out
 }
 
+// AddPlugin records the fingerprint of the controller running on the node,
+// healthy or not, so its health description is visible until it recovers or
+// its allocation stops.
 func (i *IngressPlugin) AddPlugin(nodeID string, info *IngressInfo) error {
 	prev, ok := i.Controllers[nodeID]
 	if ok {
@@ -148,15 +322,31 @@ func (i *IngressPlugin) AddPlugin(nodeID string, info *IngressInfo) error {
 			i.ControllersHealthy -= 1
 		}
 	}
-	if prev != nil || info.Healthy {
-		i.Controllers[nodeID] = info
-	}
+	i.Controllers[nodeID] = info
 	if info.Healthy {
 		i.ControllersHealthy += 1
 	}
 	return nil
 }
 
+// MarkStale marks the controller running on the node unhealthy because the
+// servers lost track of it, keeping its last fingerprint otherwise. It
+// returns true if the controller was changed.
+func (i *IngressPlugin) MarkStale(nodeID, description string) bool {
+	prev, ok := i.Controllers[nodeID]
+	if !ok || prev == nil || !prev.Healthy && prev.HealthDescription == description {
+		return false
+	}
+	if prev.Healthy {
+		i.ControllersHealthy -= 1
+	}
+	info := prev.Copy()
+	info.Healthy = false
+	info.HealthDescription = description
+	i.Controllers[nodeID] = info
+	return true
+}
+
 func (i *IngressPlugin) IsEmpty() bool {
 	return i == nil ||
 		len(i.Controllers) == 0 &&
@@ -246,6 +436,12 @@ type IngressPluginDeleteResponse struct {
 	QueryMeta
 }
 
+// IngressPluginSweepRequest is sent by the core scheduler to reconcile the
+// controllers of ingress plugins with the nodes and allocations running them.
+type IngressPluginSweepRequest struct {
+	WriteRequest
+}
+
 type IngressPluginListRequest struct {
 	QueryOptions
 }
@@ -284,3 +480,277 @@ type IngressPluginGetResponse struct {
 	Plugin *IngressPlugin
 	QueryMeta
 }
+
+const (
+	// ServiceIngressDefaultWeight is the weight given to a service ingress
+	// when none is set in the jobspec.
+	ServiceIngressDefaultWeight = 100
+
+	// ServiceIngressMaxWeight is the maximum weight a service ingress can
+	// have.
+	ServiceIngressMaxWeight = 100
+)
+
+var (
+	// validIngressHost matches a DNS host name with an optional leading
+	// wildcard label, such as "*.example.com".
+	validIngressHost = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?$`)
+)
+
+// ServiceIngress is the ingress block of a service. It describes how traffic
+// from outside the cluster is routed to the instances of the service by an
+// ingress plugin.
+type ServiceIngress struct {
+	// Hosts is the set of host names matched by the route.
+	Hosts []string
+
+	// Paths is the set of path prefixes matched by the route.
+	Paths []string
+
+	// TLSSecret is a reference to the secret holding the TLS certificate
+	// used to terminate TLS for the hosts, such as a Variable path.
+	TLSSecret string
+
+	// Class is the ID of the ingress plugin responsible for programming the
+	// route.
+	Class string
+
+	// Weight is the relative weight of the traffic sent to this service
+	// when several services match the same host and path.
+	Weight int
+
+	// CanaryWeight is the percentage of the traffic of the route sent to
+	// the canaries of a deployment until they are promoted. When 0 the
+	// canaries are weighted like the other allocations.
+	CanaryWeight int
+}
+
+// Copy the block recursively. Returns nil if nil.
+func (i *ServiceIngress) Copy() *ServiceIngress {
+	if i == nil {
+		return nil
+	}
+	ni := new(ServiceIngress)
+	*ni = *i
+	ni.Hosts = slices.Clone(i.Hosts)
+	ni.Paths = slices.Clone(i.Paths)
+	return ni
+}
+
+// Equal returns true if the structs are recursively equal.
+func (i *ServiceIngress) Equal(o *ServiceIngress) bool {
+	if i == nil || o == nil {
+		return i == o
+	}
+	switch {
+	case !helper.SliceSetEq(i.Hosts, o.Hosts):
+		return false
+	case !helper.SliceSetEq(i.Paths, o.Paths):
+		return false
+	case i.TLSSecret != o.TLSSecret:
+		return false
+	case i.Class != o.Class:
+		return false
+	case i.Weight != o.Weight:
+		return false
+	case i.CanaryWeight != o.CanaryWeight:
+		return false
+	}
+	return true
+}
+
+// Canonicalize ensures empty lists are treated as null to avoid scheduler
+// issues when using DeepEquals.
+func (i *ServiceIngress) Canonicalize() {
+	if i == nil {
+		return
+	}
+	if len(i.Hosts) == 0 {
+		i.Hosts = nil
+	}
+	if len(i.Paths) == 0 {
+		i.Paths = nil
+	}
+}
+
+// Validate checks if the ingress block is valid.
+func (i *ServiceIngress) Validate() error {
+	if i == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+
+	if len(i.Hosts) == 0 && len(i.Paths) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Ingress must specify at least one host or path"))
+	}
+
+	if i.Class == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Ingress must specify a class"))
+	}
+
+	for _, host := range i.Hosts {
+		if !validIngressHost.MatchString(host) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Ingress host %q is not a valid host name", host))
+		}
+	}
+
+	for _, path := range i.Paths {
+		if !strings.HasPrefix(path, "/") {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Ingress path %q must begin with a '/'", path))
+		}
+	}
+
+	if i.Weight < 0 || i.Weight > ServiceIngressMaxWeight {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Ingress weight must be between 0 and %d; not %d", ServiceIngressMaxWeight, i.Weight))
+	}
+
+	if i.CanaryWeight < 0 || i.CanaryWeight > ServiceIngressMaxWeight {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Ingress canary_weight must be between 0 and %d; not %d", ServiceIngressMaxWeight, i.CanaryWeight))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// IngressRoute is the server side representation of a route programmed by
+// ingress plugins. It is materialized by the state store from a service
+// ingress block and the addresses of the healthy allocations running the
+// service.
+type IngressRoute struct {
+	// ID is the unique identifier of the route. It is built from the
+	// namespace, job, group and service name so it can be handed to ingress
+	// plugins as is.
+	ID string
+
+	// Namespace, JobID, TaskGroup and Service identify the service block
+	// the route was built from.
+	Namespace string
+	JobID     string
+	TaskGroup string
+	Service   string
+
+	// Ingress is a copy of the ingress block of the service.
+	Ingress *ServiceIngress
+
+	// Backends are the addresses of the healthy allocations of the service,
+	// sorted by allocation ID.
+	Backends []*IngressBackend
+
+	// ProgrammedIndex is the highest ModifyIndex of the route an ingress
+	// plugin acknowledged having programmed in its load balancer.
+	ProgrammedIndex uint64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// IngressBackend is the address of a single allocation behind an ingress
+// route.
+type IngressBackend struct {
+	AllocID string
+	NodeID  string
+	Address string
+	Port    int
+
+	// Weight is the relative weight of the traffic of the route sent to the
+	// backend, between 0 and 100.
+	Weight int
+
+	// Canary is true if the allocation is an unpromoted canary.
+	Canary bool
+
+	// CreateIndex is the index at which the backend was added to the route.
+	CreateIndex uint64
+}
+
+// IngressRouteID returns the ID of the route built from the given service.
+func IngressRouteID(namespace, jobID, group, service string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", namespace, jobID, group, service)
+}
+
+// Copy the route recursively. Returns nil if nil.
+func (r *IngressRoute) Copy() *IngressRoute {
+	if r == nil {
+		return nil
+	}
+	nr := new(IngressRoute)
+	*nr = *r
+	nr.Ingress = r.Ingress.Copy()
+	nr.Backends = helper.CopySlice(r.Backends)
+	return nr
+}
+
+// Equal returns true if the routes are recursively equal, ignoring the raft
+// indexes.
+func (r *IngressRoute) Equal(o *IngressRoute) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	switch {
+	case r.ID != o.ID:
+		return false
+	case r.Namespace != o.Namespace:
+		return false
+	case r.JobID != o.JobID:
+		return false
+	case r.TaskGroup != o.TaskGroup:
+		return false
+	case r.Service != o.Service:
+		return false
+	case !r.Ingress.Equal(o.Ingress):
+		return false
+	}
+	return helper.ElementsEqual(r.Backends, o.Backends)
+}
+
+// Programmed returns true if an ingress plugin acknowledged having programmed
+// the route since the backend was added.
+func (r *IngressRoute) Programmed(b *IngressBackend) bool {
+	return r.ProgrammedIndex >= b.CreateIndex
+}
+
+// Copy the backend. Returns nil if nil.
+func (b *IngressBackend) Copy() *IngressBackend {
+	if b == nil {
+		return nil
+	}
+	nb := new(IngressBackend)
+	*nb = *b
+	return nb
+}
+
+// Equal returns true if the backends are equal.
+func (b *IngressBackend) Equal(o *IngressBackend) bool {
+	if b == nil || o == nil {
+		return b == o
+	}
+	return *b == *o
+}
+
+// IngressRouteListRequest is the request object when performing a listing of
+// ingress routes.
+type IngressRouteListRequest struct {
+	QueryOptions
+}
+
+// IngressRouteListResponse is the response object when performing a listing
+// of ingress routes.
+type IngressRouteListResponse struct {
+	Routes []*IngressRoute
+	QueryMeta
+}
+
+// IngressRouteAck is the acknowledgement by an ingress plugin that it
+// programmed a route as of the given ModifyIndex.
+type IngressRouteAck struct {
+	Namespace string
+	RouteID   string
+	Index     uint64
+}
+
+// IngressRouteAckRequest is used by ingress plugins to acknowledge having
+// programmed routes.
+type IngressRouteAckRequest struct {
+	Acks []*IngressRouteAck
+	WriteRequest
+}
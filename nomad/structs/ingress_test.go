@@ -1,8 +1,10 @@
 package structs
 
 import (
-	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestExternalIngressClassConfig_Equal(t *testing.T) {
@@ -124,6 +126,7 @@ func TestTaskIngressPluginConfig_Equal(t1 *testing.T) {
 		ID            string
 		NomadEndpoint string
 		NomadToken    string
+		Class         IngressClass
 		Internal      *InternalIngressClassConfig
 		External      *ExternalIngressClassConfig
 	}
@@ -224,6 +227,20 @@ func TestTaskIngressPluginConfig_Equal(t1 *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "test not equal class",
+			fields: fields{
+				ID:    "123",
+				Class: InternalIngressClass,
+			},
+			args: args{
+				o: &TaskIngressPluginConfig{
+					ID:    "123",
+					Class: ExternalIngressClass,
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t1.Run(tt.name, func(t1 *testing.T) {
@@ -231,6 +248,7 @@ func TestTaskIngressPluginConfig_Equal(t1 *testing.T) {
 				ID:            tt.fields.ID,
 				NomadEndpoint: tt.fields.NomadEndpoint,
 				NomadToken:    tt.fields.NomadToken,
+				Class:         tt.fields.Class,
 				Internal:      tt.fields.Internal,
 				External:      tt.fields.External,
 			}
@@ -238,3 +256,109 @@ func TestTaskIngressPluginConfig_Equal(t1 *testing.T) {
 		})
 	}
 }
+
+func TestInternalIngressClassConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *InternalIngressClassConfig
+		errMsg string
+	}{
+		{
+			name:   "plugin writes the configuration",
+			config: &InternalIngressClassConfig{LoadBalancerConfigurationPath: "/lb"},
+		},
+		{
+			name: "template",
+			config: &InternalIngressClassConfig{
+				LoadBalancerConfigurationPath: "/lb",
+				Template:                      IngressTemplateHAProxy,
+				PortLabel:                     "http",
+				ReloadSignal:                  "SIGUSR2",
+			},
+		},
+		{
+			name: "unknown template",
+			config: &InternalIngressClassConfig{
+				LoadBalancerConfigurationPath: "/lb",
+				Template:                      "traefik",
+				PortLabel:                     "http",
+			},
+			errMsg: "template must be one of envoy, haproxy, nginx",
+		},
+		{
+			name: "template without port",
+			config: &InternalIngressClassConfig{
+				LoadBalancerConfigurationPath: "/lb",
+				Template:                      IngressTemplateNginx,
+			},
+			errMsg: "must specify a port",
+		},
+		{
+			name: "reload signal without template",
+			config: &InternalIngressClassConfig{
+				LoadBalancerConfigurationPath: "/lb",
+				ReloadSignal:                  "SIGHUP",
+			},
+			errMsg: "require a template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestIngressPlugin_AddPlugin(t *testing.T) {
+	plug := NewIngressPlugin("haproxy", 10)
+
+	// Unhealthy controllers are recorded with their health description.
+	assert.NoError(t, plug.AddPlugin("node1", &IngressInfo{
+		PluginID:          "haproxy",
+		AllocID:           "alloc1",
+		HealthDescription: "initial fingerprint not completed",
+	}))
+	assert.Len(t, plug.Controllers, 1)
+	assert.Equal(t, 0, plug.ControllersHealthy)
+
+	healthy := &IngressInfo{PluginID: "haproxy", AllocID: "alloc1"}
+	healthy.SetHealthy(true)
+	assert.NoError(t, plug.AddPlugin("node1", healthy))
+	assert.NoError(t, plug.AddPlugin("node1", healthy.Copy()))
+	assert.Equal(t, 1, plug.ControllersHealthy)
+
+	// Stale controllers keep their fingerprint but are no longer healthy.
+	assert.True(t, plug.MarkStale("node1", IngressControllerNodeDown))
+	assert.False(t, plug.MarkStale("node1", IngressControllerNodeDown))
+	assert.False(t, plug.MarkStale("node2", IngressControllerNodeDown))
+	assert.Equal(t, 0, plug.ControllersHealthy)
+	assert.Equal(t, "alloc1", plug.Controllers["node1"].AllocID)
+	assert.Equal(t, IngressControllerNodeDown, plug.Controllers["node1"].HealthDescription)
+	assert.True(t, healthy.Healthy)
+
+	assert.NoError(t, plug.DeleteNodeForType("node1"))
+	assert.True(t, plug.IsEmpty())
+	assert.Equal(t, 0, plug.ControllersHealthy)
+}
+
+func TestIngressInfo_Equal(t *testing.T) {
+	info := &IngressInfo{PluginID: "haproxy", Healthy: true}
+
+	assert.True(t, (*IngressInfo)(nil).Equal(nil))
+	assert.False(t, info.Equal(nil))
+	assert.False(t, (*IngressInfo)(nil).Equal(info))
+
+	// Timestamps are ignored.
+	other := info.Copy()
+	other.UpdateTime = time.Now()
+	other.NodeStatusUpdateTime = time.Now()
+	assert.True(t, info.Equal(other))
+
+	other.Healthy = false
+	assert.False(t, info.Equal(other))
+}
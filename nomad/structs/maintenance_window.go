@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// DefaultMaintenanceWindowLead is the default duration before a
+	// maintenance window opens during which the scheduler avoids placing new
+	// allocations of service jobs on the nodes it applies to.
+	DefaultMaintenanceWindowLead = time.Hour
+
+	// DefaultMaintenanceWindowMaxParallel is the default number of nodes of
+	// a node pool drained for maintenance windows at once.
+	DefaultMaintenanceWindowMaxParallel = 1
+
+	// maxMaintenanceWindowStarts bounds the number of window starts walked
+	// when looking for an open window, so dense cron expressions with long
+	// durations stay cheap to evaluate.
+	maxMaintenanceWindowStarts = 1000
+)
+
+// MaintenanceWindow is a recurring period during which nodes are expected to
+// be unavailable. The nodes a window applies to are drained when it opens,
+// and the scheduler avoids placing new allocations of service jobs on them
+// shortly before it does.
+type MaintenanceWindow struct {
+	// Name identifies the window among the windows of a node or node pool.
+	Name string
+
+	// Cron is the cron expression of the times the window opens.
+	Cron string
+
+	// TimeZone is the IANA time zone the cron expression is evaluated in.
+	// Defaults to UTC.
+	TimeZone string
+
+	// Duration is how long the window stays open.
+	Duration time.Duration
+
+	// Lead is how long before the window opens the scheduler stops placing
+	// new allocations of service jobs on the nodes. A zero value uses
+	// DefaultMaintenanceWindowLead.
+	Lead time.Duration
+
+	// MaxParallel is the maximum number of nodes of the node pool drained
+	// for maintenance windows at once. The other nodes are drained as the
+	// drains complete while the window is open. A zero value uses
+	// DefaultMaintenanceWindowMaxParallel.
+	MaxParallel int
+}
+
+// Validate returns an error if the maintenance window is invalid.
+func (w *MaintenanceWindow) Validate() error {
+	if w == nil {
+		return errors.New("missing maintenance window")
+	}
+
+	var mErr *multierror.Error
+	if w.Name == "" {
+		mErr = multierror.Append(mErr, errors.New("missing name"))
+	}
+	if w.Cron == "" {
+		mErr = multierror.Append(mErr, errors.New("missing cron expression"))
+	} else if _, err := cronexpr.Parse(w.Cron); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid cron expression %q: %v", w.Cron, err))
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid time zone %q: %v", w.TimeZone, err))
+		}
+	}
+	if w.Duration <= 0 {
+		mErr = multierror.Append(mErr, errors.New("duration must be greater than zero"))
+	}
+	if w.Lead < 0 {
+		mErr = multierror.Append(mErr, errors.New("lead cannot be negative"))
+	}
+	if w.MaxParallel < 0 {
+		mErr = multierror.Append(mErr, errors.New("max_parallel cannot be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a copy of the maintenance window.
+func (w *MaintenanceWindow) Copy() *MaintenanceWindow {
+	if w == nil {
+		return nil
+	}
+	nw := *w
+	return &nw
+}
+
+// Equal returns whether the maintenance windows are equal.
+func (w *MaintenanceWindow) Equal(o *MaintenanceWindow) bool {
+	if w == nil || o == nil {
+		return w == o
+	}
+	return *w == *o
+}
+
+// EffectiveLead returns the lead of the window, or the default lead if it's
+// unset.
+func (w *MaintenanceWindow) EffectiveLead() time.Duration {
+	if w.Lead == 0 {
+		return DefaultMaintenanceWindowLead
+	}
+	return w.Lead
+}
+
+// EffectiveMaxParallel returns the maximum number of nodes drained at once
+// for the window, or the default if it's unset.
+func (w *MaintenanceWindow) EffectiveMaxParallel() int {
+	if w.MaxParallel == 0 {
+		return DefaultMaintenanceWindowMaxParallel
+	}
+	return w.MaxParallel
+}
+
+// Next returns the first time the window opens after t. The zero time is
+// returned if the window never opens again.
+func (w *MaintenanceWindow) Next(t time.Time) (time.Time, error) {
+	next, err := CronParseNext(t.In(w.location()), w.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return next, nil
+}
+
+// Open returns whether the window is open at t, along with the time it
+// opened and the time it closes. When overlapping occurrences are open, the
+// window closes with the last of them.
+func (w *MaintenanceWindow) Open(t time.Time) (start, end time.Time, open bool, err error) {
+	// The window is open if it opened within its duration before t, the
+	// latest start giving the time it closes.
+	from := t.Add(-w.Duration)
+	for i := 0; i < maxMaintenanceWindowStarts; i++ {
+		next, err := w.Next(from)
+		if err != nil {
+			return time.Time{}, time.Time{}, false, err
+		}
+		if next.IsZero() || next.After(t) {
+			break
+		}
+		if !open {
+			start = next
+		}
+		end = next.Add(w.Duration)
+		open = true
+		from = next
+	}
+	return start, end, open, nil
+}
+
+// location returns the time zone the cron expression is evaluated in.
+func (w *MaintenanceWindow) location() *time.Location {
+	if w.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// MarshalJSON implements the json.Marshaler interface and allows
+// MaintenanceWindow.Duration and MaintenanceWindow.Lead to be marshaled as
+// duration strings.
+func (w *MaintenanceWindow) MarshalJSON() ([]byte, error) {
+	type Alias MaintenanceWindow
+	exported := &struct {
+		Duration string
+		Lead     string
+		*Alias
+	}{
+		Duration: w.Duration.String(),
+		Lead:     w.Lead.String(),
+		Alias:    (*Alias)(w),
+	}
+
+	if w.Lead == 0 {
+		exported.Lead = ""
+	}
+	return json.Marshal(exported)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface and allows
+// MaintenanceWindow.Duration and MaintenanceWindow.Lead to be unmarshaled from
+// duration strings or nanoseconds.
+func (w *MaintenanceWindow) UnmarshalJSON(data []byte) error {
+	type Alias MaintenanceWindow
+	aux := &struct {
+		Duration interface{}
+		Lead     interface{}
+		*Alias
+	}{
+		Alias: (*Alias)(w),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if w.Duration, err = parseJSONDuration(aux.Duration); err != nil {
+		return fmt.Errorf("invalid duration: %v", err)
+	}
+	if w.Lead, err = parseJSONDuration(aux.Lead); err != nil {
+		return fmt.Errorf("invalid lead: %v", err)
+	}
+	return nil
+}
+
+// parseJSONDuration parses a duration decoded from JSON either as a duration
+// string or as nanoseconds.
+func parseJSONDuration(v interface{}) (time.Duration, error) {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		return time.ParseDuration(v)
+	case float64:
+		return time.Duration(v), nil
+	default:
+		return 0, nil
+	}
+}
+
+// CopySliceMaintenanceWindows returns a deep copy of the maintenance windows.
+func CopySliceMaintenanceWindows(s []*MaintenanceWindow) []*MaintenanceWindow {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*MaintenanceWindow, l)
+	for i, w := range s {
+		c[i] = w.Copy()
+	}
+	return c
+}
+
+// OpenMaintenanceWindow returns the first of the windows open at t, along
+// with the time the earliest of the open windows opened and the time the last
+// of them closes. A nil window is returned if none is open.
+func OpenMaintenanceWindow(windows []*MaintenanceWindow, t time.Time) (window *MaintenanceWindow, start, end time.Time, err error) {
+	for _, w := range windows {
+		wStart, wEnd, wOpen, err := w.Open(t)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, err
+		}
+		if !wOpen {
+			continue
+		}
+		if window == nil || wStart.Before(start) {
+			start = wStart
+		}
+		if window == nil || wEnd.After(end) {
+			end = wEnd
+		}
+		if window == nil {
+			window = w
+		}
+	}
+	return window, start, end, nil
+}
+
+// MaintenanceWindowSoon returns whether any of the windows is open at t or
+// opens within its lead after t.
+func MaintenanceWindowSoon(windows []*MaintenanceWindow, t time.Time) (bool, error) {
+	for _, w := range windows {
+		_, _, open, err := w.Open(t)
+		if err != nil {
+			return false, err
+		}
+		if open {
+			return true, nil
+		}
+
+		next, err := w.Next(t)
+		if err != nil {
+			return false, err
+		}
+		if !next.IsZero() && !next.After(t.Add(w.EffectiveLead())) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		window      *MaintenanceWindow
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			window: &MaintenanceWindow{
+				Name:     "weekly",
+				Cron:     "0 2 * * SUN",
+				TimeZone: "Europe/Berlin",
+				Duration: 4 * time.Hour,
+				Lead:     30 * time.Minute,
+			},
+		},
+		{
+			name:        "missing name",
+			window:      &MaintenanceWindow{Cron: "0 2 * * SUN", Duration: time.Hour},
+			expectedErr: "missing name",
+		},
+		{
+			name:        "invalid cron",
+			window:      &MaintenanceWindow{Name: "weekly", Cron: "every sunday", Duration: time.Hour},
+			expectedErr: "invalid cron expression",
+		},
+		{
+			name:        "invalid time zone",
+			window:      &MaintenanceWindow{Name: "weekly", Cron: "0 2 * * SUN", TimeZone: "Mars/Olympus", Duration: time.Hour},
+			expectedErr: "invalid time zone",
+		},
+		{
+			name:        "missing duration",
+			window:      &MaintenanceWindow{Name: "weekly", Cron: "0 2 * * SUN"},
+			expectedErr: "duration must be greater than zero",
+		},
+		{
+			name:        "negative lead",
+			window:      &MaintenanceWindow{Name: "weekly", Cron: "0 2 * * SUN", Duration: time.Hour, Lead: -time.Minute},
+			expectedErr: "lead cannot be negative",
+		},
+		{
+			name:        "negative max_parallel",
+			window:      &MaintenanceWindow{Name: "weekly", Cron: "0 2 * * SUN", Duration: time.Hour, MaxParallel: -1},
+			expectedErr: "max_parallel cannot be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.window.Validate()
+
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindow_Open(t *testing.T) {
+	ci.Parallel(t)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	must.NoError(t, err)
+
+	window := &MaintenanceWindow{
+		Name:     "weekly",
+		Cron:     "0 2 * * SUN",
+		TimeZone: "Europe/Berlin",
+		Duration: 4 * time.Hour,
+	}
+
+	// Sunday 2024-05-05 02:00 in Berlin is 00:00 UTC
+	start := time.Date(2024, 5, 5, 2, 0, 0, 0, berlin)
+
+	_, _, open, err := window.Open(start.Add(-time.Minute))
+	must.NoError(t, err)
+	must.False(t, open)
+
+	gotStart, gotEnd, open, err := window.Open(start.Add(time.Hour))
+	must.NoError(t, err)
+	must.True(t, open)
+	must.True(t, start.Equal(gotStart))
+	must.True(t, start.Add(4*time.Hour).Equal(gotEnd))
+
+	_, _, open, err = window.Open(start.Add(4 * time.Hour))
+	must.NoError(t, err)
+	must.False(t, open)
+}
+
+func TestMaintenanceWindowSoon(t *testing.T) {
+	ci.Parallel(t)
+
+	windows := []*MaintenanceWindow{{
+		Name:     "nightly",
+		Cron:     "0 2 * * *",
+		Duration: time.Hour,
+		Lead:     30 * time.Minute,
+	}}
+
+	testCases := []struct {
+		name   string
+		now    time.Time
+		expect bool
+	}{
+		{name: "before lead", now: time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC)},
+		{name: "within lead", now: time.Date(2024, 5, 1, 1, 45, 0, 0, time.UTC), expect: true},
+		{name: "open", now: time.Date(2024, 5, 1, 2, 30, 0, 0, time.UTC), expect: true},
+		{name: "closed", now: time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			soon, err := MaintenanceWindowSoon(windows, tc.now)
+			must.NoError(t, err)
+			must.Eq(t, tc.expect, soon)
+		})
+	}
+}
+
+func TestMaintenanceWindow_JSON(t *testing.T) {
+	ci.Parallel(t)
+
+	window := &MaintenanceWindow{
+		Name:     "weekly",
+		Cron:     "0 2 * * SUN",
+		Duration: 4 * time.Hour,
+		Lead:     30 * time.Minute,
+	}
+
+	out, err := json.Marshal(window)
+	must.NoError(t, err)
+	must.StrContains(t, string(out), `"Duration":"4h0m0s"`)
+
+	var decoded MaintenanceWindow
+	must.NoError(t, json.Unmarshal(out, &decoded))
+	must.Eq(t, window, &decoded)
+
+	// Durations are also accepted as nanoseconds
+	must.NoError(t, json.Unmarshal([]byte(`{"Name":"weekly","Cron":"0 2 * * SUN","Duration":3600000000000}`), &decoded))
+	must.Eq(t, time.Hour, decoded.Duration)
+	must.Zero(t, decoded.Lead)
+}
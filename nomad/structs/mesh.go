@@ -0,0 +1,463 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// ServiceIntentionActionAllow is the action of intentions authorizing the
+	// traffic from their source to their destination service.
+	ServiceIntentionActionAllow = "allow"
+
+	// ServiceIntentionActionDeny is the action of intentions denying the
+	// traffic from their source to their destination service.
+	ServiceIntentionActionDeny = "deny"
+
+	// ServiceIntentionWildcard matches any service when used as the source or
+	// destination of an intention.
+	ServiceIntentionWildcard = "*"
+
+	// maxServiceIntentionDescriptionLength is the maximum length allowed for
+	// an intention description.
+	maxServiceIntentionDescriptionLength = 256
+
+	// MeshTrustDomain is the SPIFFE trust domain of the identities of the
+	// services of the Nomad service mesh.
+	MeshTrustDomain = "nomad"
+
+	// MeshCertificateTTL is the lifetime of the certificates issued to the
+	// services of the Nomad service mesh.
+	MeshCertificateTTL = 72 * time.Hour
+
+	// MeshUpsertIntentionsRPCMethod is the RPC method for creating or
+	// updating service intentions.
+	//
+	// Args: MeshUpsertIntentionsRequest
+	// Reply: GenericResponse
+	MeshUpsertIntentionsRPCMethod = "Mesh.UpsertIntentions"
+
+	// MeshDeleteIntentionRPCMethod is the RPC method for deleting a service
+	// intention.
+	//
+	// Args: MeshDeleteIntentionRequest
+	// Reply: GenericResponse
+	MeshDeleteIntentionRPCMethod = "Mesh.DeleteIntention"
+
+	// MeshListIntentionsRPCMethod is the RPC method for listing service
+	// intentions.
+	//
+	// Args: MeshListIntentionsRequest
+	// Reply: MeshListIntentionsResponse
+	MeshListIntentionsRPCMethod = "Mesh.ListIntentions"
+
+	// MeshSignCertificateRPCMethod is the RPC method used by Nomad clients to
+	// get the certificates of the mesh services of their allocations signed.
+	//
+	// Args: MeshSignCertificateRequest
+	// Reply: MeshSignCertificateResponse
+	MeshSignCertificateRPCMethod = "Mesh.SignCertificate"
+)
+
+// ServiceMesh is the mesh block of a group service using the Nomad provider.
+// The Nomad client runs a proxy for the service, which accepts mutual TLS
+// connections from the other services of the mesh on the port of the service
+// and forwards the connections authorized by the service intentions to the
+// local service port. The proxy also listens on the local bind port of each
+// upstream, and forwards the connections to the upstream service over mutual
+// TLS.
+type ServiceMesh struct {
+	// LocalServicePort is the port the service listens on in the allocation
+	// network.
+	LocalServicePort int
+
+	// Upstreams are the services of the mesh the service connects to.
+	Upstreams []*MeshUpstream
+}
+
+// MeshUpstream is a service of the Nomad service mesh a service connects to.
+type MeshUpstream struct {
+	// DestinationName is the name of the upstream service, in the namespace
+	// of the job.
+	DestinationName string
+
+	// LocalBindPort is the port the proxy listens on for connections to the
+	// upstream service.
+	LocalBindPort int
+}
+
+// Copy the block recursively. Returns nil if nil.
+func (m *ServiceMesh) Copy() *ServiceMesh {
+	if m == nil {
+		return nil
+	}
+	nm := new(ServiceMesh)
+	*nm = *m
+	if m.Upstreams != nil {
+		nm.Upstreams = make([]*MeshUpstream, len(m.Upstreams))
+		for i, upstream := range m.Upstreams {
+			nm.Upstreams[i] = upstream.Copy()
+		}
+	}
+	return nm
+}
+
+// Equal returns true if the structs are recursively equal.
+func (m *ServiceMesh) Equal(o *ServiceMesh) bool {
+	if m == nil || o == nil {
+		return m == o
+	}
+	if m.LocalServicePort != o.LocalServicePort {
+		return false
+	}
+	return slices.EqualFunc(m.Upstreams, o.Upstreams, func(a, b *MeshUpstream) bool {
+		return a.Equal(b)
+	})
+}
+
+// Canonicalize ensures empty lists are treated as null to avoid scheduler
+// issues when using DeepEquals.
+func (m *ServiceMesh) Canonicalize() {
+	if m == nil {
+		return
+	}
+	if len(m.Upstreams) == 0 {
+		m.Upstreams = nil
+	}
+}
+
+// Validate checks if the mesh block is valid.
+func (m *ServiceMesh) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+
+	if m.LocalServicePort <= 0 || m.LocalServicePort > 65535 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Mesh local_service_port must be between 1 and 65535; not %d", m.LocalServicePort))
+	}
+
+	bindPorts := make(map[int]struct{}, len(m.Upstreams))
+	for _, upstream := range m.Upstreams {
+		if err := upstream.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+			continue
+		}
+		if _, exists := bindPorts[upstream.LocalBindPort]; exists {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Mesh upstreams cannot share local_bind_port %d", upstream.LocalBindPort))
+		}
+		bindPorts[upstream.LocalBindPort] = struct{}{}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// validateMesh checks that the mesh services of the group run in a bridge
+// network, as their proxies listen in the network namespace of the group, and
+// that the ports the proxies and the tasks listen on in the namespace don't
+// collide.
+func (tg *TaskGroup) validateMesh() error {
+	var services []*Service
+	for _, service := range tg.Services {
+		if service.Mesh != nil {
+			services = append(services, service)
+		}
+	}
+	if len(services) == 0 {
+		return nil
+	}
+
+	if n := len(tg.Networks); n != 1 {
+		return fmt.Errorf("Mesh services require exactly 1 network, found %d", n)
+	}
+	network := tg.Networks[0]
+	if network.Mode != "bridge" {
+		return fmt.Errorf("Mesh services require bridge network, found %q", network.Mode)
+	}
+
+	var mErr multierror.Error
+
+	// owners tracks what listens on each port of the network namespace.
+	// Ports of the group network without a known port in the namespace are
+	// checked when the proxies start listening.
+	owners := make(map[int]string)
+	for _, ports := range [][]Port{network.ReservedPorts, network.DynamicPorts} {
+		for _, port := range ports {
+			to := port.To
+			if to < 1 {
+				to = port.Value
+			}
+			if _, exists := owners[to]; to > 0 && !exists {
+				owners[to] = fmt.Sprintf("network port %q", port.Label)
+			}
+		}
+	}
+	claim := func(port int, owner string) {
+		if other, exists := owners[port]; exists {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Mesh %s cannot use port %d already used by %s", owner, port, other))
+			return
+		}
+		owners[port] = owner
+	}
+
+	for _, service := range services {
+		if service.Mesh.LocalServicePort > 0 {
+			claim(service.Mesh.LocalServicePort, fmt.Sprintf("service %q local_service_port", service.Name))
+		}
+	}
+	for _, service := range services {
+		for _, upstream := range service.Mesh.Upstreams {
+			if upstream != nil && upstream.LocalBindPort > 0 {
+				claim(upstream.LocalBindPort, fmt.Sprintf("service %q upstream %q local_bind_port",
+					service.Name, upstream.DestinationName))
+			}
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy the block. Returns nil if nil.
+func (u *MeshUpstream) Copy() *MeshUpstream {
+	if u == nil {
+		return nil
+	}
+	nu := new(MeshUpstream)
+	*nu = *u
+	return nu
+}
+
+// Equal returns true if the structs are equal.
+func (u *MeshUpstream) Equal(o *MeshUpstream) bool {
+	if u == nil || o == nil {
+		return u == o
+	}
+	return *u == *o
+}
+
+// Validate checks if the upstream is valid.
+func (u *MeshUpstream) Validate() error {
+	if u == nil {
+		return errors.New("Mesh upstream cannot be nil")
+	}
+	if u.DestinationName == "" {
+		return errors.New("Mesh upstream requires a destination_name")
+	}
+	if u.LocalBindPort <= 0 || u.LocalBindPort > 65535 {
+		return fmt.Errorf("Mesh upstream %q local_bind_port must be between 1 and 65535; not %d", u.DestinationName, u.LocalBindPort)
+	}
+	return nil
+}
+
+// ServiceIntention authorizes or denies the traffic of the Nomad service mesh
+// from a source service to a destination service. Both services belong to the
+// namespace of the intention.
+type ServiceIntention struct {
+	// Namespace is the namespace of the source and destination services.
+	Namespace string
+
+	// SourceService is the name of the service initiating connections, or
+	// the wildcard to match any service.
+	SourceService string
+
+	// DestinationService is the name of the service accepting connections,
+	// or the wildcard to match any service.
+	DestinationService string
+
+	// Action is either allow or deny.
+	Action string
+
+	// Description is the human-friendly description of the intention.
+	Description string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Validate returns an error if the intention is invalid.
+func (i *ServiceIntention) Validate() error {
+	var mErr *multierror.Error
+
+	if i.Namespace == "" {
+		mErr = multierror.Append(mErr, errors.New("missing namespace"))
+	}
+	if i.SourceService == "" {
+		mErr = multierror.Append(mErr, errors.New("missing source service"))
+	}
+	if i.DestinationService == "" {
+		mErr = multierror.Append(mErr, errors.New("missing destination service"))
+	}
+
+	switch i.Action {
+	case ServiceIntentionActionAllow, ServiceIntentionActionDeny:
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("action must be %q or %q; got %q",
+			ServiceIntentionActionAllow, ServiceIntentionActionDeny, i.Action))
+	}
+
+	if len(i.Description) > maxServiceIntentionDescriptionLength {
+		mErr = multierror.Append(mErr, fmt.Errorf("description longer than %d", maxServiceIntentionDescriptionLength))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a copy of the intention.
+func (i *ServiceIntention) Copy() *ServiceIntention {
+	if i == nil {
+		return nil
+	}
+	ni := new(ServiceIntention)
+	*ni = *i
+	return ni
+}
+
+// Equal returns whether the intentions are equal, ignoring their indexes.
+func (i *ServiceIntention) Equal(o *ServiceIntention) bool {
+	if i == nil || o == nil {
+		return i == o
+	}
+	return i.Namespace == o.Namespace &&
+		i.SourceService == o.SourceService &&
+		i.DestinationService == o.DestinationService &&
+		i.Action == o.Action &&
+		i.Description == o.Description
+}
+
+// precedence returns the precedence of the intention, where intentions
+// matching exact service names take precedence over the ones using the
+// wildcard, and the destination is more significant than the source.
+func (i *ServiceIntention) precedence() int {
+	p := 0
+	if i.DestinationService != ServiceIntentionWildcard {
+		p += 2
+	}
+	if i.SourceService != ServiceIntentionWildcard {
+		p++
+	}
+	return p
+}
+
+// matches returns whether the intention applies to the traffic from source to
+// destination.
+func (i *ServiceIntention) matches(source, destination string) bool {
+	return (i.SourceService == source || i.SourceService == ServiceIntentionWildcard) &&
+		(i.DestinationService == destination || i.DestinationService == ServiceIntentionWildcard)
+}
+
+// MatchServiceIntention returns the intention with the highest precedence
+// applying to the traffic from the source to the destination service, or nil
+// if no intention applies.
+func MatchServiceIntention(intentions []*ServiceIntention, source, destination string) *ServiceIntention {
+	var match *ServiceIntention
+	for _, intention := range intentions {
+		if !intention.matches(source, destination) {
+			continue
+		}
+		if match == nil || intention.precedence() > match.precedence() {
+			match = intention
+		}
+	}
+	return match
+}
+
+// ServiceIntentionAllows returns whether the intentions allow the traffic
+// from the source to the destination service. Traffic not matched by any
+// intention is denied.
+func ServiceIntentionAllows(intentions []*ServiceIntention, source, destination string) bool {
+	match := MatchServiceIntention(intentions, source, destination)
+	return match != nil && match.Action == ServiceIntentionActionAllow
+}
+
+// MeshServiceURI returns the SPIFFE ID identifying the service of the
+// namespace in the certificates of the Nomad service mesh.
+func MeshServiceURI(namespace, service string) *url.URL {
+	return &url.URL{
+		Scheme: "spiffe",
+		Host:   MeshTrustDomain,
+		Path:   fmt.Sprintf("/ns/%s/svc/%s", namespace, service),
+	}
+}
+
+// ParseMeshServiceURI returns the namespace and name of the service
+// identified by the SPIFFE ID of a certificate of the Nomad service mesh.
+func ParseMeshServiceURI(u *url.URL) (string, string, error) {
+	if u == nil || u.Scheme != "spiffe" || u.Host != MeshTrustDomain {
+		return "", "", fmt.Errorf("invalid mesh service identity %q", u)
+	}
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "ns" || parts[2] != "svc" || parts[1] == "" || parts[3] == "" {
+		return "", "", fmt.Errorf("invalid mesh service identity %q", u)
+	}
+	return parts[1], parts[3], nil
+}
+
+// MeshUpsertIntentionsRequest is used to create or update service
+// intentions.
+type MeshUpsertIntentionsRequest struct {
+	Intentions []*ServiceIntention
+	WriteRequest
+}
+
+// MeshDeleteIntentionRequest is used to delete the service intention of the
+// request namespace between the source and destination services.
+type MeshDeleteIntentionRequest struct {
+	SourceService      string
+	DestinationService string
+	WriteRequest
+}
+
+// MeshListIntentionsRequest is used to list the service intentions of a
+// namespace.
+type MeshListIntentionsRequest struct {
+	QueryOptions
+}
+
+// MeshListIntentionsResponse is the response to a service intentions list
+// request.
+type MeshListIntentionsResponse struct {
+	Intentions []*ServiceIntention
+	QueryMeta
+}
+
+// MeshSignCertificateRequest is used by Nomad clients to get the certificate
+// of a mesh service of an allocation signed by the servers.
+type MeshSignCertificateRequest struct {
+	// AllocID is the ID of the allocation running the service.
+	AllocID string
+
+	// Service is the name of the service, which is set as the SPIFFE ID of
+	// the certificate.
+	Service string
+
+	// CSR is the PEM encoded certificate signing request.
+	CSR string
+
+	WriteRequest
+}
+
+// MeshSignCertificateResponse is the response to a certificate signing
+// request.
+type MeshSignCertificateResponse struct {
+	// Certificate is the PEM encoded signed certificate.
+	Certificate string
+
+	// CACertificates are the PEM encoded certificates of the mesh
+	// certificate authorities, one for each key of the keyring.
+	CACertificates []string
+
+	// Expiration is the time at which the certificate expires.
+	Expiration time.Time
+
+	WriteMeta
+}
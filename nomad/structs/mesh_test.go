@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceMesh_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		mesh   *ServiceMesh
+		expErr string
+	}{
+		{
+			name: "valid",
+			mesh: &ServiceMesh{
+				LocalServicePort: 8080,
+				Upstreams: []*MeshUpstream{
+					{DestinationName: "api", LocalBindPort: 9090},
+					{DestinationName: "db", LocalBindPort: 9091},
+				},
+			},
+		},
+		{
+			name:   "missing local service port",
+			mesh:   &ServiceMesh{},
+			expErr: "local_service_port must be between 1 and 65535",
+		},
+		{
+			name: "missing destination name",
+			mesh: &ServiceMesh{
+				LocalServicePort: 8080,
+				Upstreams:        []*MeshUpstream{{LocalBindPort: 9090}},
+			},
+			expErr: "requires a destination_name",
+		},
+		{
+			name: "invalid bind port",
+			mesh: &ServiceMesh{
+				LocalServicePort: 8080,
+				Upstreams:        []*MeshUpstream{{DestinationName: "api", LocalBindPort: 70000}},
+			},
+			expErr: `upstream "api" local_bind_port must be between 1 and 65535`,
+		},
+		{
+			name: "shared bind port",
+			mesh: &ServiceMesh{
+				LocalServicePort: 8080,
+				Upstreams: []*MeshUpstream{
+					{DestinationName: "api", LocalBindPort: 9090},
+					{DestinationName: "db", LocalBindPort: 9090},
+				},
+			},
+			expErr: "cannot share local_bind_port 9090",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.mesh.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestTaskGroup_validateMesh(t *testing.T) {
+	ci.Parallel(t)
+
+	meshService := func(name string, servicePort int, bindPorts ...int) *Service {
+		s := &Service{
+			Name:      name,
+			PortLabel: "mesh",
+			Provider:  ServiceProviderNomad,
+			Mesh:      &ServiceMesh{LocalServicePort: servicePort},
+		}
+		for i, port := range bindPorts {
+			s.Mesh.Upstreams = append(s.Mesh.Upstreams, &MeshUpstream{
+				DestinationName: fmt.Sprintf("upstream-%d", i),
+				LocalBindPort:   port,
+			})
+		}
+		return s
+	}
+	bridge := func(ports ...Port) Networks {
+		return Networks{{Mode: "bridge", DynamicPorts: ports}}
+	}
+
+	testCases := []struct {
+		name     string
+		networks Networks
+		services []*Service
+		expErr   string
+	}{
+		{
+			name:     "no mesh services",
+			services: []*Service{{Name: "web", Provider: ServiceProviderNomad}},
+		},
+		{
+			name:     "valid",
+			networks: bridge(Port{Label: "mesh", To: 20000}),
+			services: []*Service{
+				meshService("web", 8080, 9090, 9091),
+				meshService("admin", 8081, 9092),
+			},
+		},
+		{
+			name:     "no network",
+			services: []*Service{meshService("web", 8080)},
+			expErr:   "Mesh services require exactly 1 network, found 0",
+		},
+		{
+			name:     "host network",
+			networks: Networks{{Mode: "host"}},
+			services: []*Service{meshService("web", 8080)},
+			expErr:   `Mesh services require bridge network, found "host"`,
+		},
+		{
+			name:     "bind port shared across services",
+			networks: bridge(),
+			services: []*Service{
+				meshService("web", 8080, 9090),
+				meshService("admin", 8081, 9090),
+			},
+			expErr: `Mesh service "admin" upstream "upstream-0" local_bind_port cannot use port 9090 already used by service "web" upstream "upstream-0" local_bind_port`,
+		},
+		{
+			name:     "bind port used by local service port",
+			networks: bridge(),
+			services: []*Service{
+				meshService("web", 8080),
+				meshService("admin", 8081, 8080),
+			},
+			expErr: `local_bind_port cannot use port 8080 already used by service "web" local_service_port`,
+		},
+		{
+			name:     "local service port used by network port",
+			networks: bridge(Port{Label: "mesh", To: 8080}),
+			services: []*Service{meshService("web", 8080)},
+			expErr:   `Mesh service "web" local_service_port cannot use port 8080 already used by network port "mesh"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tg := &TaskGroup{
+				Name:     "group",
+				Networks: tc.networks,
+				Services: tc.services,
+			}
+			err := tg.validateMesh()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestServiceMesh_CopyEqual(t *testing.T) {
+	ci.Parallel(t)
+
+	m := &ServiceMesh{
+		LocalServicePort: 8080,
+		Upstreams:        []*MeshUpstream{{DestinationName: "api", LocalBindPort: 9090}},
+	}
+	c := m.Copy()
+	must.Equal(t, m, c)
+
+	c.Upstreams[0].LocalBindPort = 9091
+	must.NotEqual(t, m, c)
+	must.Eq(t, 9090, m.Upstreams[0].LocalBindPort)
+}
+
+func TestServiceIntention_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	intention := &ServiceIntention{
+		Namespace:          DefaultNamespace,
+		SourceService:      "web",
+		DestinationService: "*",
+		Action:             ServiceIntentionActionAllow,
+	}
+	must.NoError(t, intention.Validate())
+
+	intention.Action = "permit"
+	intention.SourceService = ""
+	err := intention.Validate()
+	must.ErrorContains(t, err, "missing source service")
+	must.ErrorContains(t, err, `action must be "allow" or "deny"; got "permit"`)
+}
+
+func TestMatchServiceIntention(t *testing.T) {
+	ci.Parallel(t)
+
+	newIntention := func(source, destination, action string) *ServiceIntention {
+		return &ServiceIntention{
+			Namespace:          DefaultNamespace,
+			SourceService:      source,
+			DestinationService: destination,
+			Action:             action,
+		}
+	}
+	intentions := []*ServiceIntention{
+		newIntention("*", "*", ServiceIntentionActionDeny),
+		newIntention("web", "*", ServiceIntentionActionAllow),
+		newIntention("*", "api", ServiceIntentionActionAllow),
+		newIntention("web", "db", ServiceIntentionActionDeny),
+		newIntention("batch", "api", ServiceIntentionActionDeny),
+	}
+
+	testCases := []struct {
+		source      string
+		destination string
+		expMatch    *ServiceIntention
+	}{
+		{"web", "db", intentions[3]},
+		{"batch", "api", intentions[4]},
+		{"web", "api", intentions[2]},
+		{"web", "cache", intentions[1]},
+		{"batch", "cache", intentions[0]},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.source+"->"+tc.destination, func(t *testing.T) {
+			must.Eq(t, tc.expMatch, MatchServiceIntention(intentions, tc.source, tc.destination))
+		})
+	}
+
+	// Traffic is denied when no intention applies.
+	must.Nil(t, MatchServiceIntention(intentions[1:], "batch", "cache"))
+	must.False(t, ServiceIntentionAllows(intentions[1:], "batch", "cache"))
+	must.True(t, ServiceIntentionAllows(intentions, "web", "cache"))
+}
+
+func TestMeshServiceURI(t *testing.T) {
+	ci.Parallel(t)
+
+	u := MeshServiceURI("prod", "api")
+	must.Eq(t, "spiffe://nomad/ns/prod/svc/api", u.String())
+
+	namespace, service, err := ParseMeshServiceURI(u)
+	must.NoError(t, err)
+	must.Eq(t, "prod", namespace)
+	must.Eq(t, "api", service)
+
+	u.Path = "/ns/prod/api"
+	_, _, err = ParseMeshServiceURI(u)
+	must.ErrorContains(t, err, "invalid mesh service identity")
+
+	u = MeshServiceURI("prod", "api")
+	u.Host = "consul"
+	_, _, err = ParseMeshServiceURI(u)
+	must.ErrorContains(t, err, "invalid mesh service identity")
+}
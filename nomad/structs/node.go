@@ -310,6 +310,11 @@ type IngressInfo struct {
 	HealthDescription string
 	UpdateTime        time.Time
 
+	// NodeStatusUpdateTime is the time of the last status update of the node
+	// of the controller when the controller was reported. Heartbeats don't
+	// update it. It is set by the servers.
+	NodeStatusUpdateTime time.Time
+
 	Provider        string
 	ProviderVersion string
 }
@@ -333,15 +338,18 @@ func (i *IngressInfo) SetHealthy(hs bool) {
 	}
 }
 
+// Equal compares the fingerprints, ignoring when they were taken.
 func (i *IngressInfo) Equal(o *IngressInfo) bool {
-	if i == nil && o == nil {
+	if i == nil || o == nil {
 		return i == o
 	}
 
 	nc := *i
 	nc.UpdateTime = time.Time{}
+	nc.NodeStatusUpdateTime = time.Time{}
 	no := *o
 	no.UpdateTime = time.Time{}
+	no.NodeStatusUpdateTime = time.Time{}
 
 	return reflect.DeepEqual(nc, no)
 }
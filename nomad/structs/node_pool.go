@@ -58,6 +58,10 @@ type NodePool struct {
 	// node pool.
 	SchedulerConfiguration *NodePoolSchedulerConfiguration
 
+	// MaintenanceWindows are the maintenance windows of the nodes in the node
+	// pool.
+	MaintenanceWindows []*MaintenanceWindow
+
 	// Hash is the hash of the node pool which is used to efficiently diff when
 	// we replicate pools across regions.
 	Hash []byte
@@ -84,6 +88,18 @@ func (n *NodePool) Validate() error {
 
 	mErr = multierror.Append(mErr, n.SchedulerConfiguration.Validate())
 
+	names := make(map[string]struct{}, len(n.MaintenanceWindows))
+	for i, w := range n.MaintenanceWindows {
+		if err := w.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("maintenance window %d: %v", i+1, err))
+			continue
+		}
+		if _, ok := names[w.Name]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("duplicate maintenance window %q", w.Name))
+		}
+		names[w.Name] = struct{}{}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -97,6 +113,7 @@ func (n *NodePool) Copy() *NodePool {
 	*nc = *n
 	nc.Meta = maps.Clone(nc.Meta)
 	nc.SchedulerConfiguration = nc.SchedulerConfiguration.Copy()
+	nc.MaintenanceWindows = CopySliceMaintenanceWindows(n.MaintenanceWindows)
 
 	nc.Hash = make([]byte, len(n.Hash))
 	copy(nc.Hash, n.Hash)
@@ -159,6 +176,14 @@ func (n *NodePool) SetHash() []byte {
 		}
 	}
 
+	for _, w := range n.MaintenanceWindows {
+		_, _ = hash.Write([]byte(w.Name))
+		_, _ = hash.Write([]byte(w.Cron))
+		_, _ = hash.Write([]byte(w.TimeZone))
+		_, _ = hash.Write([]byte(w.Duration.String()))
+		_, _ = hash.Write([]byte(w.Lead.String()))
+	}
+
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
 	for k := range n.Meta {
@@ -6,6 +6,7 @@ package structs
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -74,6 +75,27 @@ func TestNodePool_Validate(t *testing.T) {
 			},
 			expectedErr: "description longer",
 		},
+		{
+			name: "invalid maintenance window",
+			pool: &NodePool{
+				Name: "valid",
+				MaintenanceWindows: []*MaintenanceWindow{
+					{Name: "weekly", Cron: "not a cron", Duration: time.Hour},
+				},
+			},
+			expectedErr: "invalid cron expression",
+		},
+		{
+			name: "duplicate maintenance window",
+			pool: &NodePool{
+				Name: "valid",
+				MaintenanceWindows: []*MaintenanceWindow{
+					{Name: "weekly", Cron: "0 2 * * SUN", Duration: time.Hour},
+					{Name: "weekly", Cron: "0 2 * * SAT", Duration: time.Hour},
+				},
+			},
+			expectedErr: `duplicate maintenance window "weekly"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -6,6 +6,7 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/netip"
 	"time"
 
@@ -209,6 +210,12 @@ const (
 	// SchedulerAlgorithmSpread indicates that the scheduler should spread
 	// allocations as evenly as possible over the available hardware.
 	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
+
+	// SchedulerAlgorithmWeighted indicates that the scheduler should binpack
+	// allocations across every resource of the nodes, weighted by the
+	// ResourceWeights of the scheduler configuration, so that nodes are
+	// filled along the resources their allocations use.
+	SchedulerAlgorithmWeighted SchedulerAlgorithm = "weighted"
 )
 
 // SchedulerConfiguration is the config for controlling scheduler behavior
@@ -233,6 +240,18 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// RebalanceConfig controls the rebalancer, which periodically migrates
+	// allocations to rebalance the nodes of each node pool.
+	RebalanceConfig RebalanceConfig `hcl:"rebalance_config"`
+
+	// FairShareConfig controls how the eval broker shares the schedulers
+	// between namespaces.
+	FairShareConfig FairShareConfig `hcl:"fair_share_config"`
+
+	// ResourceWeights are the weights of the resources of nodes when
+	// scoring with the weighted scheduler algorithm.
+	ResourceWeights ResourceWeights `hcl:"resource_weights"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -244,6 +263,7 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	ns.FairShareConfig = s.FairShareConfig.Copy()
 	return &ns
 }
 
@@ -287,11 +307,25 @@ func (s *SchedulerConfiguration) Validate() error {
 	}
 
 	switch s.SchedulerAlgorithm {
-	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread, SchedulerAlgorithmWeighted:
 	default:
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	if s.RebalanceConfig.MaxMoves < 0 {
+		return fmt.Errorf("rebalance max moves cannot be negative: %d", s.RebalanceConfig.MaxMoves)
+	}
+
+	if err := s.ResourceWeights.Validate(); err != nil {
+		return err
+	}
+
+	for ns, weight := range s.FairShareConfig.NamespaceWeights {
+		if weight <= 0 {
+			return fmt.Errorf("fair share weight of namespace %q must be positive: %d", ns, weight)
+		}
+	}
+
 	return nil
 }
 
@@ -328,6 +362,135 @@ type PreemptionConfig struct {
 	ServiceSchedulerEnabled bool `hcl:"service_scheduler_enabled"`
 }
 
+// RebalanceConfig specifies whether and how much the rebalancer migrates
+// allocations.
+type RebalanceConfig struct {
+	// Enabled specifies if the rebalancer runs.
+	Enabled bool `hcl:"enabled"`
+
+	// MaxMoves is the maximum number of allocations migrated by each run of
+	// the rebalancer. Zero means no limit other than the migrate blocks of
+	// the task groups.
+	MaxMoves int `hcl:"max_moves"`
+}
+
+// ResourceWeights are the relative weights of the resources of nodes when
+// scoring with the weighted scheduler algorithm. A resource with a weight of zero
+// is ignored, unless all the weights are zero, in which case every resource
+// has a weight of DefaultResourceWeight.
+type ResourceWeights struct {
+	CPU     int `hcl:"cpu"`
+	Memory  int `hcl:"memory"`
+	Disk    int `hcl:"disk"`
+	Network int `hcl:"network"`
+	Cores   int `hcl:"cores"`
+	Devices int `hcl:"devices"`
+}
+
+// DefaultResourceWeight is the weight of every resource when no resource
+// weights are set.
+const DefaultResourceWeight = 1
+
+// Effective returns the resource weights applied by the weighted scheduler
+// algorithm.
+func (w ResourceWeights) Effective() ResourceWeights {
+	if w == (ResourceWeights{}) {
+		return ResourceWeights{
+			CPU:     DefaultResourceWeight,
+			Memory:  DefaultResourceWeight,
+			Disk:    DefaultResourceWeight,
+			Network: DefaultResourceWeight,
+			Cores:   DefaultResourceWeight,
+			Devices: DefaultResourceWeight,
+		}
+	}
+	return w
+}
+
+func (w ResourceWeights) Validate() error {
+	for _, weight := range []struct {
+		name  string
+		value int
+	}{
+		{"cpu", w.CPU},
+		{"memory", w.Memory},
+		{"disk", w.Disk},
+		{"network", w.Network},
+		{"cores", w.Cores},
+		{"devices", w.Devices},
+	} {
+		if weight.value < 0 {
+			return fmt.Errorf("%s resource weight cannot be negative: %d", weight.name, weight.value)
+		}
+	}
+	return nil
+}
+
+// FairShareConfig specifies whether and how the eval broker shares the
+// schedulers between namespaces.
+type FairShareConfig struct {
+	// Enabled specifies if the eval broker dequeues evaluations of equal
+	// priority by weighted fair queueing across namespaces, rather than in
+	// the order they were created.
+	Enabled bool `hcl:"enabled"`
+
+	// NamespaceWeights are the relative shares of the schedulers given to
+	// each namespace. Namespaces without a weight have a weight of
+	// DefaultFairShareWeight.
+	NamespaceWeights map[string]int `hcl:"namespace_weights"`
+}
+
+// DefaultFairShareWeight is the fair share weight of namespaces without a
+// configured weight.
+const DefaultFairShareWeight = 1
+
+func (f FairShareConfig) Copy() FairShareConfig {
+	f.NamespaceWeights = maps.Clone(f.NamespaceWeights)
+	return f
+}
+
+// Weight returns the fair share weight of the namespace.
+func (f *FairShareConfig) Weight(namespace string) int {
+	if weight, ok := f.NamespaceWeights[namespace]; ok && weight > 0 {
+		return weight
+	}
+	return DefaultFairShareWeight
+}
+
+const (
+	// RebalanceReasonSpread is the reason of moves off nodes more utilized
+	// than the other nodes of their pool, when the pool spreads allocations.
+	RebalanceReasonSpread = "node utilization above pool average"
+
+	// RebalanceReasonBinpack is the reason of moves emptying nodes whose
+	// allocations fit on the other nodes of their pool, when the pool
+	// binpacks allocations.
+	RebalanceReasonBinpack = "node can be emptied"
+)
+
+// RebalanceMove is an allocation the rebalancer migrates off its node.
+type RebalanceMove struct {
+	AllocID   string
+	AllocName string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+	NodePool  string
+
+	// Reason describes why the allocation is moved.
+	Reason string
+}
+
+// SchedulerRebalancePlanResponse is the response of the Operator endpoint
+// previewing the allocations the rebalancer would migrate.
+type SchedulerRebalancePlanResponse struct {
+	// Moves are the allocations the rebalancer would migrate.
+	Moves []*RebalanceMove
+
+	QueryMeta
+}
+
 // SchedulerSetConfigRequest is used by the Operator endpoint to update the
 // current Scheduler configuration of the cluster.
 type SchedulerSetConfigRequest struct {
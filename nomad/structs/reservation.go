@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+)
+
+const (
+	// maxReservationDescriptionLength is the maximum length allowed for a
+	// reservation description.
+	maxReservationDescriptionLength = 256
+)
+
+// Reservation holds capacity on the nodes of a node pool for a namespace.
+// The reserved resources are held on every node of the node pool matching
+// the constraints of the reservation. Allocations of the namespace consume
+// the reserved resources first, while the scheduler treats the resources the
+// namespace doesn't use as used when placing allocations of other
+// namespaces.
+type Reservation struct {
+	// ID is the UUID of the reservation, generated on creation.
+	ID string
+
+	// Namespace is the namespace the capacity is reserved for.
+	Namespace string
+
+	// NodePool is the node pool of the nodes holding the reservation.
+	NodePool string
+
+	// Description is the human-friendly description of the reservation.
+	Description string
+
+	// Resources are the resources reserved on each node.
+	Resources *ReservationResources
+
+	// Constraints restrict the nodes of the node pool holding the
+	// reservation. Without constraints every node of the node pool holds it.
+	Constraints []*Constraint
+
+	// ExpirationTime is the time at which the reservation expires. Expired
+	// reservations are ignored by the scheduler and garbage collected. A nil
+	// or zero value means the reservation never expires.
+	ExpirationTime *time.Time
+
+	// ExpirationTTL is a convenience field for setting the expiration time of
+	// the reservation relative to the time it is written.
+	ExpirationTTL time.Duration
+
+	// CreateTime is the time at which the reservation was created.
+	CreateTime time.Time
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ReservationResources are the resources held by a reservation on each node.
+type ReservationResources struct {
+	CPU      int
+	MemoryMB int
+	DiskMB   int
+}
+
+// Comparable returns the reserved resources as comparable resources.
+func (r *ReservationResources) Comparable() *ComparableResources {
+	if r == nil {
+		return new(ComparableResources)
+	}
+
+	return &ComparableResources{
+		Flattened: AllocatedTaskResources{
+			Cpu: AllocatedCpuResources{
+				CpuShares: int64(r.CPU),
+			},
+			Memory: AllocatedMemoryResources{
+				MemoryMB: int64(r.MemoryMB),
+			},
+		},
+		Shared: AllocatedSharedResources{
+			DiskMB: int64(r.DiskMB),
+		},
+	}
+}
+
+// Canonicalize generates the ID and create time of new reservations, and
+// computes the expiration time from the TTL. Callers should take a copy
+// first if needed.
+func (r *Reservation) Canonicalize() {
+	now := time.Now().UTC()
+
+	if r.ID == "" {
+		r.ID = uuid.Generate()
+		r.CreateTime = now
+	}
+	if r.NodePool == "" {
+		r.NodePool = NodePoolDefault
+	}
+	if r.ExpirationTime == nil && r.ExpirationTTL != 0 {
+		r.ExpirationTime = pointer.Of(now.Add(r.ExpirationTTL))
+	}
+}
+
+// Validate returns an error if the reservation is invalid.
+func (r *Reservation) Validate() error {
+	var mErr *multierror.Error
+
+	if r.Namespace == "" {
+		mErr = multierror.Append(mErr, errors.New("missing namespace"))
+	}
+	mErr = multierror.Append(mErr, ValidateNodePoolName(r.NodePool))
+
+	if len(r.Description) > maxReservationDescriptionLength {
+		mErr = multierror.Append(mErr, fmt.Errorf("description longer than %d", maxReservationDescriptionLength))
+	}
+
+	switch res := r.Resources; {
+	case res == nil:
+		mErr = multierror.Append(mErr, errors.New("missing resources"))
+	case res.CPU < 0 || res.MemoryMB < 0 || res.DiskMB < 0:
+		mErr = multierror.Append(mErr, errors.New("resources cannot be negative"))
+	case res.CPU == 0 && res.MemoryMB == 0 && res.DiskMB == 0:
+		mErr = multierror.Append(mErr, errors.New("must reserve cpu, memory or disk"))
+	}
+
+	for idx, constr := range r.Constraints {
+		if err := constr.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("constraint %d validation failed: %v", idx+1, err))
+		}
+	}
+
+	if r.ExpirationTTL < 0 {
+		mErr = multierror.Append(mErr,
+			fmt.Errorf("expiration TTL '%s' should not be negative", r.ExpirationTTL))
+	}
+	if r.HasExpirationTime() && r.ExpirationTime.Before(r.CreateTime) {
+		mErr = multierror.Append(mErr, errors.New("expiration time cannot be before create time"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the reservation.
+func (r *Reservation) Copy() *Reservation {
+	if r == nil {
+		return nil
+	}
+
+	nr := new(Reservation)
+	*nr = *r
+	if r.Resources != nil {
+		nr.Resources = pointer.Of(*r.Resources)
+	}
+	nr.Constraints = CopySliceConstraints(r.Constraints)
+	if r.ExpirationTime != nil {
+		nr.ExpirationTime = pointer.Of(*r.ExpirationTime)
+	}
+	return nr
+}
+
+// HasExpirationTime returns whether the reservation expires.
+func (r *Reservation) HasExpirationTime() bool {
+	if r == nil || r.ExpirationTime == nil {
+		return false
+	}
+	return !r.ExpirationTime.IsZero()
+}
+
+// IsExpired returns whether the reservation is expired at time t.
+func (r *Reservation) IsExpired(t time.Time) bool {
+	if !r.HasExpirationTime() {
+		return false
+	}
+	return r.ExpirationTime.Before(t.UTC())
+}
+
+// ReservationListRequest is used to list the reservations of a namespace.
+type ReservationListRequest struct {
+	QueryOptions
+}
+
+// ReservationListResponse is the response to a reservations list request.
+type ReservationListResponse struct {
+	Reservations []*Reservation
+	QueryMeta
+}
+
+// ReservationSpecificRequest is used to make a request for a specific
+// reservation.
+type ReservationSpecificRequest struct {
+	ID string
+	QueryOptions
+}
+
+// SingleReservationResponse is the response to a specific reservation
+// request.
+type SingleReservationResponse struct {
+	Reservation *Reservation
+	QueryMeta
+}
+
+// ReservationUpsertRequest is used to create or update reservations.
+type ReservationUpsertRequest struct {
+	Reservations []*Reservation
+	WriteRequest
+}
+
+// ReservationUpsertResponse is the response to a reservation upsert request.
+// It contains the reservations as written, including their generated IDs.
+type ReservationUpsertResponse struct {
+	Reservations []*Reservation
+	WriteMeta
+}
+
+// ReservationDeleteRequest is used to delete reservations of a namespace.
+type ReservationDeleteRequest struct {
+	IDs []string
+	WriteRequest
+}
@@ -91,6 +91,12 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// CheckStatus is the aggregate status of the checks of the service, as
+	// reported by the client running them. It is failure if any check is
+	// failing, pending if any check has not yet passed, and success once all
+	// checks are passing. It is empty when the service has no checks.
+	CheckStatus CheckStatus
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -142,12 +148,21 @@ func (s *ServiceRegistration) Equal(o *ServiceRegistration) bool {
 	if s.Port != o.Port {
 		return false
 	}
+	if s.CheckStatus != o.CheckStatus {
+		return false
+	}
 	if !helper.SliceSetEq(s.Tags, o.Tags) {
 		return false
 	}
 	return true
 }
 
+// Healthy returns whether the service registration should receive traffic,
+// which is the case when it has no checks or all its checks are passing.
+func (s *ServiceRegistration) Healthy() bool {
+	return s.CheckStatus == "" || s.CheckStatus == CheckSuccess
+}
+
 // Validate ensures the upserted service registration contains valid
 // information and routing capabilities. Objects should never fail here as
 // Nomad controls the entire registration process; but it's possible
@@ -239,6 +254,9 @@ type ServiceRegistrationDeleteByNodeIDResponse struct {
 // ServiceRegistrationListRequest is the request object when performing service
 // registration listings.
 type ServiceRegistrationListRequest struct {
+	// Healthy restricts the listing to services with at least one healthy
+	// registration.
+	Healthy bool
 	QueryOptions
 }
 
@@ -271,6 +289,10 @@ type ServiceRegistrationStub struct {
 type ServiceRegistrationByNameRequest struct {
 	ServiceName string
 	Choose      string // stable selection of n services
+
+	// Healthy restricts the lookup to registrations whose checks are all
+	// passing.
+	Healthy bool
 	QueryOptions
 }
 
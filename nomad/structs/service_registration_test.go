@@ -475,3 +475,23 @@ func TestServiceRegistration_HashWith(t *testing.T) {
 	// different service, different key -> different hash
 	must.NotEq(t, a.HashWith("aaa"), b.HashWith("bbb"))
 }
+
+func TestServiceRegistration_Healthy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		checkStatus CheckStatus
+		expected    bool
+	}{
+		{name: "no checks", checkStatus: "", expected: true},
+		{name: "success", checkStatus: CheckSuccess, expected: true},
+		{name: "pending", checkStatus: CheckPending, expected: false},
+		{name: "failure", checkStatus: CheckFailure, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reg := &ServiceRegistration{CheckStatus: tc.checkStatus}
+			must.Eq(t, tc.expected, reg.Healthy())
+		})
+	}
+}
@@ -41,6 +41,21 @@ const (
 	OnUpdateIgnoreWarn     = "ignore_warnings"
 	OnUpdateIgnore         = "ignore"
 
+	// CheckRoleStartup is the role of a Nomad check reporting whether a task
+	// has finished starting. The liveness checks of a task do not trigger
+	// restarts until every startup check of the task has passed once.
+	CheckRoleStartup = "startup"
+
+	// CheckRoleReadiness is the role of a Nomad check reporting whether a
+	// service is ready to receive traffic. The readiness checks of a service
+	// determine the check status of its service registration.
+	CheckRoleReadiness = "readiness"
+
+	// CheckRoleLiveness is the role of a Nomad check reporting whether a task
+	// is alive. Liveness checks restart their task according to check_restart,
+	// and do not affect the check status of the service registration.
+	CheckRoleLiveness = "liveness"
+
 	// minCheckInterval is the minimum check interval permitted.  Consul
 	// currently has its MinInterval set to 1s.  Mirror that here for
 	// consistency.
@@ -80,6 +95,7 @@ type ServiceCheck struct {
 	FailuresBeforeCritical int                 // Number of consecutive failures required before considered unhealthy
 	Body                   string              // Body to use in HTTP check
 	OnUpdate               string
+	Role                   string // Role of the check - startup, readiness or liveness (Nomad checks only)
 }
 
 // IsReadiness returns whether the configuration of the ServiceCheck is effectively
@@ -204,6 +220,10 @@ func (sc *ServiceCheck) Equal(o *ServiceCheck) bool {
 		return false
 	}
 
+	if sc.Role != o.Role {
+		return false
+	}
+
 	return true
 }
 
@@ -311,6 +331,14 @@ func (sc *ServiceCheck) validateCommon(allowableTypes []string) error {
 		return fmt.Errorf("on_update must be %q, %q, or %q; got %q", OnUpdateRequireHealthy, OnUpdateIgnoreWarn, OnUpdateIgnore, sc.OnUpdate)
 	}
 
+	// validate role
+	switch sc.Role {
+	case "", CheckRoleStartup, CheckRoleReadiness, CheckRoleLiveness:
+		// OK
+	default:
+		return fmt.Errorf("role must be %q, %q, or %q; got %q", CheckRoleStartup, CheckRoleReadiness, CheckRoleLiveness, sc.Role)
+	}
+
 	// validate check_restart and on_update do not conflict
 	if sc.CheckRestart != nil {
 		// CheckRestart and OnUpdate Ignore are incompatible If OnUpdate treats
@@ -337,7 +365,7 @@ func (sc *ServiceCheck) validateCommon(allowableTypes []string) error {
 
 // validate a Service's ServiceCheck in the context of the Nomad provider.
 func (sc *ServiceCheck) validateNomad() error {
-	allowable := []string{ServiceCheckTCP, ServiceCheckHTTP}
+	allowable := []string{ServiceCheckGRPC, ServiceCheckTCP, ServiceCheckHTTP, ServiceCheckScript}
 	if err := sc.validateCommon(allowable); err != nil {
 		return err
 	}
@@ -360,6 +388,12 @@ func (sc *ServiceCheck) validateNomad() error {
 		if sc.CheckRestart.IgnoreWarnings {
 			return errors.New("ignore_warnings on check_restart only supported for Consul service checks")
 		}
+
+		// only liveness checks (or checks without a role) drive restarts
+		switch sc.Role {
+		case CheckRoleStartup, CheckRoleReadiness:
+			return fmt.Errorf("check_restart may not be set for %s checks", sc.Role)
+		}
 	}
 
 	// address_mode="driver" not yet supported on nomad
@@ -383,14 +417,14 @@ func (sc *ServiceCheck) validateNomad() error {
 		return errors.New("failures_before_critical may only be set for Consul service checks")
 	}
 
-	// tls_server_name is consul only
-	if sc.TLSServerName != "" {
-		return errors.New("tls_server_name may only be set for Consul service checks")
-	}
-
-	// tls_skip_verify is consul only
-	if sc.TLSSkipVerify {
-		return errors.New("tls_skip_verify may only be set for Consul service checks")
+	// tls_server_name and tls_skip_verify only apply to grpc checks in nomad
+	if sc.Type != ServiceCheckGRPC {
+		if sc.TLSServerName != "" {
+			return errors.New("tls_server_name may only be set for grpc checks of Nomad services")
+		}
+		if sc.TLSSkipVerify {
+			return errors.New("tls_skip_verify may only be set for grpc checks of Nomad services")
+		}
 	}
 
 	return nil
@@ -405,6 +439,11 @@ func (sc *ServiceCheck) validateConsul() error {
 
 	checkType := strings.ToLower(sc.Type)
 
+	// check roles are Nomad only
+	if sc.Role != "" {
+		return errors.New("role may only be set for Nomad service checks")
+	}
+
 	// Note that we cannot completely validate the Expose field yet - we do not
 	// know whether this ServiceCheck belongs to a connect-enabled group-service.
 	// Instead, such validation will happen in a job admission controller.
@@ -622,6 +661,14 @@ type Service struct {
 	// Its name will be `consul-service/${service_name}`, and its contents will
 	// match the server's `consul.service_identity` configuration block.
 	Identity *WorkloadIdentity
+
+	// Ingress describes how traffic from outside the cluster is routed to
+	// the service by ingress plugins.
+	Ingress *ServiceIngress
+
+	// Mesh adds the service to the Nomad service mesh. It is only valid for
+	// group services using the Nomad provider.
+	Mesh *ServiceMesh
 }
 
 // Copy the block recursively. Returns nil if nil.
@@ -650,6 +697,10 @@ func (s *Service) Copy() *Service {
 
 	ns.Identity = s.Identity.Copy()
 
+	ns.Ingress = s.Ingress.Copy()
+
+	ns.Mesh = s.Mesh.Copy()
+
 	return ns
 }
 
@@ -671,6 +722,9 @@ func (s *Service) Canonicalize(job, taskGroup, task, jobNamespace string) {
 		s.TaggedAddresses = nil
 	}
 
+	s.Ingress.Canonicalize()
+	s.Mesh.Canonicalize()
+
 	// Set the task name if not already set
 	if s.TaskName == "" && task != "group" {
 		s.TaskName = task
@@ -769,6 +823,15 @@ func (s *Service) Validate() error {
 		mErr.Errors = append(mErr.Errors, err)
 	}
 
+	if s.Ingress != nil {
+		if s.PortLabel == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Service %s with ingress requires a port", s.Name))
+		}
+		if err := s.Ingress.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -830,6 +893,12 @@ func (s *Service) validateConsulService(mErr *multierror.Error) {
 		}
 	}
 
+	// The Nomad service mesh is only available to services using the nomad
+	// provider.
+	if s.Mesh != nil {
+		mErr.Errors = append(mErr.Errors, errors.New("Service with provider consul cannot include mesh blocks"))
+	}
+
 	// check connect
 	if s.Connect != nil {
 		if err := s.Connect.Validate(); err != nil {
@@ -865,6 +934,15 @@ func (s *Service) validateNomadService(mErr *multierror.Error) {
 	if s.Connect != nil {
 		mErr.Errors = append(mErr.Errors, errors.New("Service with provider nomad cannot include Connect blocks"))
 	}
+
+	if s.Mesh != nil {
+		if s.PortLabel == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Service %s with mesh requires a port", s.Name))
+		}
+		if err := s.Mesh.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
 }
 
 // validateIdentity performs validation on workload identity field populated by
@@ -1071,6 +1149,14 @@ func (s *Service) Equal(o *Service) bool {
 		return false
 	}
 
+	if !s.Ingress.Equal(o.Ingress) {
+		return false
+	}
+
+	if !s.Mesh.Equal(o.Mesh) {
+		return false
+	}
+
 	return true
 }
 
@@ -227,6 +227,19 @@ func TestServiceCheck_validate_OnUpdate_CheckRestart_Conflict(t *testing.T) {
 	})
 }
 
+func TestServiceCheck_validateConsul_Role(t *testing.T) {
+	ci.Parallel(t)
+
+	err := (&ServiceCheck{
+		Name:     "check",
+		Type:     "tcp",
+		Interval: 1 * time.Second,
+		Timeout:  2 * time.Second,
+		Role:     CheckRoleLiveness,
+	}).validateConsul()
+	must.EqError(t, err, `role may only be set for Nomad service checks`)
+}
+
 func TestServiceCheck_validateNomad(t *testing.T) {
 	ci.Parallel(t)
 
@@ -235,8 +248,45 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 		sc   *ServiceCheck
 		exp  string
 	}{
-		{name: "grpc", sc: &ServiceCheck{Type: ServiceCheckGRPC}, exp: `invalid check type ("grpc"), must be one of tcp, http`},
-		{name: "script", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `invalid check type ("script"), must be one of tcp, http`},
+		{name: "unknown", sc: &ServiceCheck{Type: "docker"}, exp: `invalid check type ("docker"), must be one of grpc, tcp, http, script`},
+		{
+			name: "grpc",
+			sc: &ServiceCheck{
+				Type:        ServiceCheckGRPC,
+				Interval:    3 * time.Second,
+				Timeout:     1 * time.Second,
+				GRPCService: "foo.Bar",
+			},
+		},
+		{
+			name: "grpc with tls",
+			sc: &ServiceCheck{
+				Type:          ServiceCheckGRPC,
+				Interval:      3 * time.Second,
+				Timeout:       1 * time.Second,
+				GRPCUseTLS:    true,
+				TLSServerName: "foo",
+				TLSSkipVerify: true,
+			},
+		},
+		{
+			name: "script",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckScript,
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+				Command:  "/bin/true",
+			},
+		},
+		{
+			name: "script without command",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckScript,
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			exp: `script type must have a valid script path`,
+		},
 		{
 			name: "expose",
 			sc: &ServiceCheck{
@@ -362,7 +412,59 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 				Path:          "/health",
 				TLSServerName: "foo",
 			},
-			exp: `tls_server_name may only be set for Consul service checks`,
+			exp: `tls_server_name may only be set for grpc checks of Nomad services`,
+		},
+		{
+			name: "tcp with tls_skip_verify",
+			sc: &ServiceCheck{
+				Type:          ServiceCheckTCP,
+				Interval:      3 * time.Second,
+				Timeout:       1 * time.Second,
+				TLSSkipVerify: true,
+			},
+			exp: `tls_skip_verify may only be set for grpc checks of Nomad services`,
+		},
+		{
+			name: "startup role",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckTCP,
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+				Role:     CheckRoleStartup,
+			},
+			exp: ``,
+		},
+		{
+			name: "unknown role",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckTCP,
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+				Role:     "ready",
+			},
+			exp: `role must be "startup", "readiness", or "liveness"; got "ready"`,
+		},
+		{
+			name: "liveness role with check_restart",
+			sc: &ServiceCheck{
+				Type:         ServiceCheckTCP,
+				Interval:     3 * time.Second,
+				Timeout:      1 * time.Second,
+				Role:         CheckRoleLiveness,
+				CheckRestart: &CheckRestart{Limit: 3},
+			},
+			exp: ``,
+		},
+		{
+			name: "readiness role with check_restart",
+			sc: &ServiceCheck{
+				Type:         ServiceCheckTCP,
+				Interval:     3 * time.Second,
+				Timeout:      1 * time.Second,
+				Role:         CheckRoleReadiness,
+				CheckRestart: &CheckRestart{Limit: 3},
+			},
+			exp: `check_restart may not be set for readiness checks`,
 		},
 	}
 
@@ -1994,6 +2096,137 @@ func TestService_Equal(t *testing.T) {
 
 	o.TaggedAddresses = map[string]string{"foo": "bar"}
 	assertDiff()
+
+	o.Ingress = &ServiceIngress{Hosts: []string{"example.com"}}
+	assertDiff()
+
+	o.Mesh = &ServiceMesh{LocalServicePort: 8080}
+	assertDiff()
+}
+
+func TestService_Validate_Ingress(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name    string
+		port    string
+		ingress *ServiceIngress
+		expErr  string
+	}{
+		{
+			name: "valid",
+			port: "http",
+			ingress: &ServiceIngress{
+				Hosts:  []string{"example.com", "*.example.com"},
+				Paths:  []string{"/", "/api"},
+				Class:  "haproxy",
+				Weight: 100,
+			},
+		},
+		{
+			name:    "missing port",
+			ingress: &ServiceIngress{Hosts: []string{"example.com"}},
+			expErr:  "with ingress requires a port",
+		},
+		{
+			name:    "no host or path",
+			port:    "http",
+			ingress: &ServiceIngress{Class: "haproxy"},
+			expErr:  "at least one host or path",
+		},
+		{
+			name:    "missing class",
+			port:    "http",
+			ingress: &ServiceIngress{Hosts: []string{"example.com"}},
+			expErr:  "must specify a class",
+		},
+		{
+			name:    "invalid host",
+			port:    "http",
+			ingress: &ServiceIngress{Hosts: []string{"exa mple.com"}},
+			expErr:  `host "exa mple.com" is not a valid host name`,
+		},
+		{
+			name:    "invalid path",
+			port:    "http",
+			ingress: &ServiceIngress{Paths: []string{"api"}},
+			expErr:  `path "api" must begin with a '/'`,
+		},
+		{
+			name:    "invalid weight",
+			port:    "http",
+			ingress: &ServiceIngress{Paths: []string{"/"}, Weight: 101},
+			expErr:  "weight must be between 0 and 100",
+		},
+		{
+			name:    "invalid canary weight",
+			port:    "http",
+			ingress: &ServiceIngress{Paths: []string{"/"}, CanaryWeight: -1},
+			expErr:  "canary_weight must be between 0 and 100",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Service{
+				Name:      "web",
+				PortLabel: tc.port,
+				Provider:  ServiceProviderNomad,
+				Ingress:   tc.ingress,
+			}
+			err := s.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestService_Validate_Mesh(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		port     string
+		provider string
+		expErr   string
+	}{
+		{
+			name:     "valid",
+			port:     "http",
+			provider: ServiceProviderNomad,
+		},
+		{
+			name:     "missing port",
+			provider: ServiceProviderNomad,
+			expErr:   "with mesh requires a port",
+		},
+		{
+			name:     "consul provider",
+			port:     "http",
+			provider: ServiceProviderConsul,
+			expErr:   "Service with provider consul cannot include mesh blocks",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Service{
+				Name:      "web",
+				PortLabel: tc.port,
+				Provider:  tc.provider,
+				Mesh:      &ServiceMesh{LocalServicePort: 8080},
+			}
+			err := s.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
 }
 
 func TestService_validateNomadService(t *testing.T) {
@@ -2061,7 +2294,7 @@ func TestService_validateNomadService(t *testing.T) {
 			},
 			inputErr: &multierror.Error{},
 			expectedOutputErrors: []error{
-				errors.New(`invalid check type (""), must be one of tcp, http`),
+				errors.New(`invalid check type (""), must be one of grpc, tcp, http, script`),
 			},
 			name: "bad nomad check",
 		},
@@ -129,9 +129,17 @@ const (
 	NodePoolUpsertRequestType                    MessageType = 59
 	NodePoolDeleteRequestType                    MessageType = 60
 	IngressPluginDeleteRequestType               MessageType = 61
+	IngressRouteAckRequestType                   MessageType = 62
+	IngressPluginSweepRequestType                MessageType = 63
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
 	NamespaceDeleteRequestType MessageType = 65
+
+	ReservationUpsertRequestType MessageType = 66
+	ReservationDeleteRequestType MessageType = 67
+
+	ServiceIntentionUpsertRequestType MessageType = 68
+	ServiceIntentionDeleteRequestType MessageType = 69
 )
 
 const (
@@ -2138,6 +2146,10 @@ type Node struct {
 	// NodePool is the node pool the node belongs to.
 	NodePool string
 
+	// MaintenanceWindows are the maintenance windows of the node, in addition
+	// to the ones of its node pool.
+	MaintenanceWindows []*MaintenanceWindow
+
 	// ComputedClass is a unique id that identifies nodes with a common set of
 	// attributes and capabilities.
 	ComputedClass string
@@ -2289,9 +2301,11 @@ func (n *Node) Copy() *Node {
 	nn.Drivers = helper.DeepCopyMap(n.Drivers)
 	nn.CSIControllerPlugins = helper.DeepCopyMap(nn.CSIControllerPlugins)
 	nn.CSINodePlugins = helper.DeepCopyMap(nn.CSINodePlugins)
+	nn.IngressPlugins = helper.DeepCopyMap(nn.IngressPlugins)
 	nn.HostVolumes = helper.DeepCopyMap(n.HostVolumes)
 	nn.HostNetworks = helper.DeepCopyMap(n.HostNetworks)
 	nn.LastDrain = nn.LastDrain.Copy()
+	nn.MaintenanceWindows = CopySliceMaintenanceWindows(n.MaintenanceWindows)
 	return &nn
 }
 
@@ -4949,6 +4963,12 @@ func (j *Job) RequiredSignals() map[string]map[string][]string {
 				taskSignals[t.ChangeSignal] = struct{}{}
 			}
 
+			// Check if the rendered ingress load balancer is reloaded with
+			// a signal
+			if cfg := task.IngressPluginConfig; cfg != nil && cfg.Internal != nil && cfg.Internal.ReloadSignal != "" {
+				taskSignals[cfg.Internal.ReloadSignal] = struct{}{}
+			}
+
 			// Flatten and sort the signals
 			l := len(taskSignals)
 			if l == 0 {
@@ -6612,6 +6632,10 @@ type TaskGroup struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// JobAffinities are used to place the allocations of the group close to,
+	// or away from, the allocations of other jobs.
+	JobAffinities []*JobAffinity
+
 	// Networks are the network configuration for the task group. This can be
 	// overridden in the task.
 	Networks Networks
@@ -6636,6 +6660,11 @@ type TaskGroup struct {
 	// MaxClientDisconnect, if set, configures the client to allow placed
 	// allocations for tasks in this group to attempt to resume running without a restart.
 	MaxClientDisconnect *time.Duration
+
+	// Gang, if set, requires the allocations of the group placed by an
+	// evaluation to be placed all at once. If any of them can't be placed,
+	// none are and the evaluation is blocked until they all fit.
+	Gang bool
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6650,6 +6679,7 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.ReschedulePolicy = ntg.ReschedulePolicy.Copy()
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
+	ntg.JobAffinities = helper.CopySlice(ntg.JobAffinities)
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
 	ntg.Consul = ntg.Consul.Copy()
@@ -6802,6 +6832,10 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect cannot be negative"))
 	}
 
+	if tg.Gang && j.Type != JobTypeBatch {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Gang scheduling is only supported by batch jobs, not %q", j.Type))
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -6821,6 +6855,19 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
+		if tg.JobAffinities != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a job_affinity block"))
+		}
+	} else {
+		for idx, affinity := range tg.JobAffinities {
+			if err := affinity.Validate(); err != nil {
+				outer := fmt.Errorf("Job affinity %d validation failed: %s", idx+1, err)
+				mErr.Errors = append(mErr.Errors, outer)
+			}
+		}
+	}
+
 	if tg.RestartPolicy != nil {
 		if err := tg.RestartPolicy.Validate(); err != nil {
 			mErr.Errors = append(mErr.Errors, err)
@@ -6935,6 +6982,12 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, outer)
 	}
 
+	// Validate group services of the Nomad service mesh
+	if err := tg.validateMesh(); err != nil {
+		outer := fmt.Errorf("Task group mesh validation failed: %v", err)
+		mErr.Errors = append(mErr.Errors, outer)
+	}
+
 	// Validate group service script-checks
 	if err := tg.validateScriptChecksInGroupServices(); err != nil {
 		outer := fmt.Errorf("Task group service check validation failed: %v", err)
@@ -7635,6 +7688,7 @@ func (t *Task) Copy() *Task {
 	nt.Affinities = CopySliceAffinities(nt.Affinities)
 	nt.VolumeMounts = CopySliceVolumeMount(nt.VolumeMounts)
 	nt.CSIPluginConfig = nt.CSIPluginConfig.Copy()
+	nt.IngressPluginConfig = nt.IngressPluginConfig.Copy()
 
 	nt.Vault = nt.Vault.Copy()
 	nt.Consul = nt.Consul.Copy()
@@ -7932,6 +7986,11 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 		// TODO: Investigate validation of the PluginMountDir. Not much we can do apart from check IsAbs until after we understand its execution environment though :(
 	}
 
+	// Validate Ingress Plugin Config
+	if err := t.IngressPluginConfig.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
 	// Validate Identity/Identities
 	for _, wid := range t.Identities {
 		// Task.Canonicalize should move the default identity out of the Identities
@@ -7998,6 +8057,11 @@ func validateServices(t *Task, tgNetworks Networks) error {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("service %q cannot have \"connect\" block, only services defined in a \"group\" block can", service.Name))
 		}
 
+		// mesh block is only allowed on group level
+		if service.Mesh != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("service %q cannot have \"mesh\" block, only services defined in a \"group\" block can", service.Name))
+		}
+
 		// Ensure that check names are unique and have valid ports
 		knownChecks := make(map[string]struct{})
 		for _, check := range service.Checks {
@@ -9698,6 +9762,107 @@ func (a *Affinity) Validate() error {
 	return mErr.ErrorOrNil()
 }
 
+// JobAffinity is used to place allocations close to, or away from, the
+// allocations of other jobs. A node is affine if it, or any node sharing the
+// value of Attribute with it, runs an allocation of a targeted job.
+type JobAffinity struct {
+	// Namespace is the namespace of the targeted jobs. It defaults to the
+	// namespace of the job.
+	Namespace string
+
+	// JobID targets the job with this ID.
+	JobID string
+
+	// Meta targets the jobs with all these meta values.
+	Meta map[string]string
+
+	// Attribute is the node attribute scoping the affinity. It defaults to
+	// the node itself.
+	Attribute string
+
+	// Weight is applied to affine nodes. It is negative for anti-affinity.
+	Weight int8
+
+	// Required makes the affinity a hard constraint: nodes which are not
+	// affine, or affine for a negative weight, are not feasible.
+	Required bool
+}
+
+// Equal checks if two job affinities are equal.
+func (a *JobAffinity) Equal(o *JobAffinity) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	switch {
+	case a.Namespace != o.Namespace:
+		return false
+	case a.JobID != o.JobID:
+		return false
+	case !maps.Equal(a.Meta, o.Meta):
+		return false
+	case a.Attribute != o.Attribute:
+		return false
+	case a.Weight != o.Weight:
+		return false
+	case a.Required != o.Required:
+		return false
+	}
+	return true
+}
+
+func (a *JobAffinity) Copy() *JobAffinity {
+	if a == nil {
+		return nil
+	}
+	na := new(JobAffinity)
+	*na = *a
+	na.Meta = maps.Clone(a.Meta)
+	return na
+}
+
+// String returns a description of the job affinity, used in placement
+// metrics.
+func (a *JobAffinity) String() string {
+	target := a.JobID
+	if len(a.Meta) > 0 {
+		meta := make([]string, 0, len(a.Meta))
+		for k, v := range a.Meta {
+			meta = append(meta, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(meta)
+		if target != "" {
+			target += " "
+		}
+		target += "meta[" + strings.Join(meta, ",") + "]"
+	}
+	if a.Namespace != "" {
+		target = a.Namespace + "/" + target
+	}
+
+	scope := a.Attribute
+	if scope == "" {
+		scope = "node"
+	}
+	return fmt.Sprintf("%s by %s %v", target, scope, a.Weight)
+}
+
+func (a *JobAffinity) Validate() error {
+	var mErr multierror.Error
+	if a.JobID == "" && len(a.Meta) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Job affinity must target a job ID or meta"))
+	}
+
+	// Ensure that weight is between -100 and 100, and not zero
+	if a.Weight == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Job affinity weight cannot be zero"))
+	}
+	if a.Weight > 100 || a.Weight < -100 {
+		mErr.Errors = append(mErr.Errors, errors.New("Job affinity weight must be within the range [-100,100]"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
 // Spread is used to specify desired distribution of allocations according to weight
 type Spread struct {
 	// Attribute is the node attribute used as the spread criteria
@@ -9710,6 +9875,12 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// MaxSkew, if set, makes the spread a hard constraint: a node is not
+	// feasible if placing on it would make the difference between the number
+	// of allocations with its attribute value and the least used value
+	// greater than MaxSkew.
+	MaxSkew int
+
 	// Memoized string representation
 	str string
 }
@@ -9723,6 +9894,8 @@ func (s *Spread) Equal(o *Spread) bool {
 		return false
 	case s.Weight != o.Weight:
 		return false
+	case s.MaxSkew != o.MaxSkew:
+		return false
 	case !slices.EqualFunc(s.SpreadTarget, o.SpreadTarget, func(a, b *SpreadTarget) bool { return a.Equal(b) }):
 		return false
 	}
@@ -9770,6 +9943,9 @@ func (s *Spread) String() string {
 		return s.str
 	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
+	if s.MaxSkew > 0 {
+		s.str += fmt.Sprintf(" max_skew=%d", s.MaxSkew)
+	}
 	return s.str
 }
 
@@ -9781,6 +9957,12 @@ func (s *Spread) Validate() error {
 	if s.Weight <= 0 || s.Weight > 100 {
 		mErr.Errors = append(mErr.Errors, errors.New("Spread block must have a positive weight from 0 to 100"))
 	}
+	if s.MaxSkew < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew cannot be negative"))
+	}
+	if s.MaxSkew > 0 && len(s.SpreadTarget) > 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew cannot be used with spread targets"))
+	}
 	seen := make(map[string]struct{})
 	sumPercent := uint32(0)
 
@@ -10442,6 +10624,11 @@ type DesiredTransition struct {
 	// task shutdown_delay configuration and ignore the delay for any
 	// allocations stopped as a result of this Deregister call.
 	NoShutdownDelay *bool
+
+	// Rebalance is used to indicate that the migration of this allocation
+	// was requested by the rebalancer, so it must not be placed back on the
+	// same node.
+	Rebalance *bool
 }
 
 // Merge merges the two desired transitions, preferring the values from the
@@ -10462,6 +10649,10 @@ func (d *DesiredTransition) Merge(o *DesiredTransition) {
 	if o.NoShutdownDelay != nil {
 		d.NoShutdownDelay = o.NoShutdownDelay
 	}
+
+	if o.Rebalance != nil {
+		d.Rebalance = o.Rebalance
+	}
 }
 
 // ShouldMigrate returns whether the transition object dictates a migration.
@@ -10469,6 +10660,12 @@ func (d *DesiredTransition) ShouldMigrate() bool {
 	return d.Migrate != nil && *d.Migrate
 }
 
+// ShouldRebalance returns whether the transition object dictates a migration
+// requested by the rebalancer.
+func (d *DesiredTransition) ShouldRebalance() bool {
+	return d.ShouldMigrate() && d.Rebalance != nil && *d.Rebalance
+}
+
 // ShouldReschedule returns whether the transition object dictates a
 // rescheduling.
 func (d *DesiredTransition) ShouldReschedule() bool {
@@ -11468,6 +11665,10 @@ type AllocMetric struct {
 	// DimensionExhausted provides the count by dimension or reason
 	DimensionExhausted map[string]int
 
+	// SkewFiltered is the number of nodes filtered because placing on them
+	// would exceed the max_skew of a spread, by the skew they would cause
+	SkewFiltered map[string]int
+
 	// QuotaExhausted provides the exhausted dimensions
 	QuotaExhausted []string
 
@@ -11513,6 +11714,7 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.ConstraintFiltered = maps.Clone(na.ConstraintFiltered)
 	na.ClassExhausted = maps.Clone(na.ClassExhausted)
 	na.DimensionExhausted = maps.Clone(na.DimensionExhausted)
+	na.SkewFiltered = maps.Clone(na.SkewFiltered)
 	na.QuotaExhausted = slices.Clone(na.QuotaExhausted)
 	na.Scores = maps.Clone(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
@@ -11539,6 +11741,22 @@ func (a *AllocMetric) FilterNode(node *Node, constraint string) {
 	}
 }
 
+// FilterNodeSkew records a node filtered because placing on it would make the
+// spread of allocations across the values of attribute exceed the max skew.
+func (a *AllocMetric) FilterNodeSkew(node *Node, attribute, value string, skew, maxSkew int) {
+	a.NodesFiltered += 1
+	if node != nil && node.NodeClass != "" {
+		if a.ClassFiltered == nil {
+			a.ClassFiltered = make(map[string]int)
+		}
+		a.ClassFiltered[node.NodeClass] += 1
+	}
+	if a.SkewFiltered == nil {
+		a.SkewFiltered = make(map[string]int)
+	}
+	a.SkewFiltered[fmt.Sprintf("%s = %s: skew %d > max_skew %d", attribute, value, skew, maxSkew)] += 1
+}
+
 func (a *AllocMetric) ExhaustedNode(node *Node, dimension string) {
 	a.NodesExhausted += 1
 	if node != nil && node.NodeClass != "" {
@@ -11751,6 +11969,11 @@ type AllocDeploymentStatus struct {
 	// been promoted will have this field set to false.
 	Canary bool
 
+	// IngressPending marks a healthy allocation whose ingress routes were
+	// not yet programmed by an ingress plugin. The allocation is only
+	// considered healthy by the deployment once they are.
+	IngressPending bool
+
 	// ModifyIndex is the raft index in which the deployment status was last
 	// changed.
 	ModifyIndex uint64
@@ -11762,13 +11985,13 @@ func (a *AllocDeploymentStatus) HasHealth() bool {
 }
 
 // IsHealthy returns if the allocation is marked as healthy as part of a
-// deployment
+// deployment and its ingress routes are programmed
 func (a *AllocDeploymentStatus) IsHealthy() bool {
 	if a == nil {
 		return false
 	}
 
-	return a.Healthy != nil && *a.Healthy
+	return a.Healthy != nil && *a.Healthy && !a.IngressPending
 }
 
 // IsUnhealthy returns if the allocation is marked as unhealthy as part of a
@@ -11849,6 +12072,7 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerRebalance            = "rebalance"
 )
 
 const (
@@ -11884,6 +12108,15 @@ const (
 	// or allocs running them. If so, we delete the plugin.
 	CoreJobCSIPluginGC = "csi-plugin-gc"
 
+	// CoreJobIngressPluginGC is used for the garbage collection of ingress
+	// plugins. We periodically scan plugins to see if they have no
+	// controllers or controller jobs left. If so, we delete the plugin.
+	CoreJobIngressPluginGC = "ingress-plugin-gc"
+
+	// CoreJobReservationGC is used for the garbage collection of expired
+	// reservations.
+	CoreJobReservationGC = "reservation-gc"
+
 	// CoreJobOneTimeTokenGC is use for the garbage collection of one-time
 	// tokens. We periodically scan for expired tokens and delete them.
 	CoreJobOneTimeTokenGC = "one-time-token-gc"
@@ -11907,6 +12140,12 @@ const (
 	// active key
 	CoreJobVariablesRekey = "variables-rekey"
 
+	// CoreJobRebalance is used to rebalance the allocations of node pools
+	// when enabled in the scheduler configuration. We periodically scan the
+	// nodes of each pool and migrate allocations off the nodes imbalancing
+	// the pool.
+	CoreJobRebalance = "rebalance"
+
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
 )
@@ -12515,6 +12754,39 @@ func (p *Plan) PopUpdate(alloc *Allocation) {
 	}
 }
 
+// RemoveAlloc removes a placement from the plan, along with the preemptions
+// made for it.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	if allocs := RemoveAllocs(p.NodeAllocation[alloc.NodeID], []*Allocation{alloc}); len(allocs) > 0 {
+		p.NodeAllocation[alloc.NodeID] = allocs
+	} else {
+		delete(p.NodeAllocation, alloc.NodeID)
+	}
+
+	for nodeID, preempted := range p.NodePreemptions {
+		var filtered []*Allocation
+		for _, a := range preempted {
+			if a.PreemptedByAllocation != alloc.ID {
+				filtered = append(filtered, a)
+			}
+		}
+		if len(filtered) > 0 {
+			p.NodePreemptions[nodeID] = filtered
+		} else {
+			delete(p.NodePreemptions, nodeID)
+		}
+	}
+}
+
+// RemoveUpdate removes the updates of the allocation from the plan.
+func (p *Plan) RemoveUpdate(alloc *Allocation) {
+	if allocs := RemoveAllocs(p.NodeUpdate[alloc.NodeID], []*Allocation{alloc}); len(allocs) > 0 {
+		p.NodeUpdate[alloc.NodeID] = allocs
+	} else {
+		delete(p.NodeUpdate, alloc.NodeID)
+	}
+}
+
 // AppendAlloc appends the alloc to the plan allocations.
 // Uses the passed job if explicitly passed, otherwise
 // it is assumed the alloc will use the plan Job version.
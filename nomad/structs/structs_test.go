@@ -1695,6 +1695,44 @@ func TestTaskGroup_Validate(t *testing.T) {
 			},
 			jobType: JobTypeService,
 		},
+		{
+			name: "job affinity in system job",
+			tg: &TaskGroup{
+				Name: "group-a",
+				JobAffinities: []*JobAffinity{
+					{JobID: "app", Weight: 50},
+				},
+			},
+			expErr: []string{
+				"System jobs may not have a job_affinity block",
+			},
+			jobType: JobTypeSystem,
+		},
+		{
+			name: "invalid job affinity",
+			tg: &TaskGroup{
+				Name: "group-a",
+				JobAffinities: []*JobAffinity{
+					{Weight: 50},
+				},
+			},
+			expErr: []string{
+				"Job affinity 1 validation failed",
+				"Job affinity must target a job ID or meta",
+			},
+			jobType: JobTypeService,
+		},
+		{
+			name: "gang scheduling in service job",
+			tg: &TaskGroup{
+				Name: "group-a",
+				Gang: true,
+			},
+			expErr: []string{
+				`Gang scheduling is only supported by batch jobs, not "service"`,
+			},
+			jobType: JobTypeService,
+		},
 	}
 
 	for _, tc := range tests {
@@ -7061,6 +7099,39 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   -1,
+			},
+			err:  fmt.Errorf("Spread max_skew cannot be negative"),
+			name: "Invalid max skew",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "dc1",
+						Percent: 50,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread max_skew cannot be used with spread targets"),
+			name: "Max skew with spread targets",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+			},
+			err:  nil,
+			name: "Valid max skew",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7076,6 +7147,68 @@ func TestSpread_Validate(t *testing.T) {
 	}
 }
 
+func TestJobAffinity_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		affinity *JobAffinity
+		err      string
+	}{
+		{
+			name:     "missing target",
+			affinity: &JobAffinity{Weight: 50},
+			err:      "Job affinity must target a job ID or meta",
+		},
+		{
+			name:     "zero weight",
+			affinity: &JobAffinity{JobID: "app"},
+			err:      "Job affinity weight cannot be zero",
+		},
+		{
+			name:     "invalid weight",
+			affinity: &JobAffinity{JobID: "app", Weight: -110},
+			err:      "Job affinity weight must be within the range [-100,100]",
+		},
+		{
+			name:     "valid job ID",
+			affinity: &JobAffinity{JobID: "app", Weight: 50},
+		},
+		{
+			name: "valid meta",
+			affinity: &JobAffinity{
+				Meta:      map[string]string{"role": "db"},
+				Attribute: "${meta.rack}",
+				Weight:    -100,
+				Required:  true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.affinity.Validate()
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJobAffinity_String(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, "app by node 50", (&JobAffinity{JobID: "app", Weight: 50}).String())
+	must.Eq(t, "databases/meta[role=db,tier=1] by ${meta.rack} -100", (&JobAffinity{
+		Namespace: "databases",
+		Meta:      map[string]string{"tier": "1", "role": "db"},
+		Attribute: "${meta.rack}",
+		Weight:    -100,
+	}).String())
+}
+
 func TestNodeReservedNetworkResources_ParseReserved(t *testing.T) {
 	ci.Parallel(t)
 
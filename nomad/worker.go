@@ -640,6 +640,12 @@ func (w *Worker) ServersMeetMinimumVersion(minVersion *version.Version, checkFai
 	return ServersMeetMinimumVersion(w.srv.Members(), w.srv.Region(), minVersion, checkFailedServers)
 }
 
+// PlacementExtensions returns the placement plugins of the server. This
+// implements the scheduler's PlacementExtender interface.
+func (w *Worker) PlacementExtensions() []scheduler.PlacementExtension {
+	return w.srv.placementExtensions()
+}
+
 // SubmitPlan is used to submit a plan for consideration. This allows
 // the worker to act as the planner for the scheduler.
 func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.State, error) {
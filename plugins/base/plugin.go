@@ -23,6 +23,9 @@ const (
 
 	// PluginTypeDevice implements the device plugin interface
 	PluginTypeDevice = "device"
+
+	// PluginTypePlacement implements the placement plugin interface
+	PluginTypePlacement = "placement"
 )
 
 var (
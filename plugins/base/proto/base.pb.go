@@ -29,21 +29,24 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type PluginType int32
 
 const (
-	PluginType_UNKNOWN PluginType = 0
-	PluginType_DRIVER  PluginType = 2
-	PluginType_DEVICE  PluginType = 3
+	PluginType_UNKNOWN   PluginType = 0
+	PluginType_DRIVER    PluginType = 2
+	PluginType_DEVICE    PluginType = 3
+	PluginType_PLACEMENT PluginType = 4
 )
 
 var PluginType_name = map[int32]string{
 	0: "UNKNOWN",
 	2: "DRIVER",
 	3: "DEVICE",
+	4: "PLACEMENT",
 }
 
 var PluginType_value = map[string]int32{
-	"UNKNOWN": 0,
-	"DRIVER":  2,
-	"DEVICE":  3,
+	"UNKNOWN":   0,
+	"DRIVER":    2,
+	"DEVICE":    3,
+	"PLACEMENT": 4,
 }
 
 func (x PluginType) String() string {
@@ -691,61 +694,62 @@ func init() {
 }
 
 var fileDescriptor_19edef855873449e = []byte{
-	// 861 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x4d, 0x6f, 0x23, 0x45,
-	0x10, 0xcd, 0xd8, 0x8e, 0x3f, 0xca, 0xb1, 0x71, 0x2a, 0x0b, 0x0c, 0x86, 0x15, 0xd6, 0x88, 0x95,
-	0xa2, 0x55, 0x98, 0x08, 0xb3, 0x59, 0xf6, 0x08, 0xf1, 0x46, 0xc8, 0x22, 0x6b, 0xa2, 0xb6, 0xc9,
-	0x22, 0x84, 0x64, 0x75, 0x66, 0xda, 0x76, 0x6b, 0x3d, 0xd3, 0xc3, 0xf4, 0x38, 0x24, 0x48, 0x9c,
-	0x38, 0xf3, 0x3f, 0xb8, 0xf1, 0x03, 0x38, 0x70, 0xe0, 0x8f, 0xa1, 0xfe, 0x18, 0xdb, 0x49, 0x84,
-	0xb0, 0xf7, 0xe4, 0xee, 0x7a, 0xaf, 0x5e, 0x75, 0xbd, 0x1e, 0x57, 0xc3, 0xe3, 0x64, 0xbe, 0x98,
-	0xf2, 0x58, 0x1e, 0x5f, 0x51, 0xc9, 0x8e, 0x93, 0x54, 0x64, 0x42, 0x2f, 0x7d, 0xbd, 0x44, 0x6f,
-	0x46, 0xe5, 0x8c, 0x07, 0x22, 0x4d, 0xfc, 0x58, 0x44, 0x34, 0xf4, 0x2d, 0xdd, 0x5f, 0x71, 0xda,
-	0x4f, 0x72, 0x09, 0x39, 0xa3, 0x29, 0x0b, 0x8f, 0x67, 0xc1, 0x5c, 0x26, 0x2c, 0x50, 0xbf, 0x63,
-	0xb5, 0x30, 0x34, 0xef, 0x00, 0xf6, 0x2f, 0x34, 0xb1, 0x1f, 0x4f, 0x04, 0x61, 0x3f, 0x2d, 0x98,
-	0xcc, 0xbc, 0x7f, 0x1c, 0xc0, 0xf5, 0xa8, 0x4c, 0x44, 0x2c, 0x19, 0x9e, 0x42, 0x29, 0xbb, 0x4d,
-	0x98, 0xeb, 0x74, 0x9c, 0xc3, 0x66, 0xd7, 0xf7, 0xff, 0xff, 0x14, 0xbe, 0x51, 0x19, 0xdd, 0x26,
-	0x8c, 0xe8, 0x5c, 0xf4, 0xe1, 0xc0, 0xd0, 0xc6, 0x34, 0xe1, 0xe3, 0x6b, 0x96, 0x4a, 0x2e, 0x62,
-	0xe9, 0x16, 0x3a, 0xc5, 0xc3, 0x1a, 0xd9, 0x37, 0xd0, 0x57, 0x09, 0xbf, 0xb4, 0x00, 0x3e, 0x81,
-	0xa6, 0xe5, 0x5b, 0xae, 0x5b, 0xec, 0x38, 0x87, 0x35, 0xd2, 0x30, 0x51, 0xcb, 0x43, 0x84, 0x52,
-	0x4c, 0x23, 0xe6, 0x96, 0x34, 0xa8, 0xd7, 0xde, 0xbb, 0x70, 0xd0, 0x13, 0xf1, 0x84, 0x4f, 0x87,
-	0xc1, 0x8c, 0x45, 0x34, 0x6f, 0xee, 0x7b, 0x78, 0x74, 0x37, 0x6c, 0xbb, 0xfb, 0x12, 0x4a, 0xca,
-	0x17, 0xdd, 0x5d, 0xbd, 0x7b, 0xf4, 0x9f, 0xdd, 0x19, 0x3f, 0x7d, 0xeb, 0xa7, 0x3f, 0x4c, 0x58,
-	0x40, 0x74, 0xa6, 0xf7, 0x97, 0x03, 0xad, 0x21, 0xcb, 0x8c, 0xba, 0x2d, 0xa7, 0x1a, 0x88, 0xe4,
-	0x34, 0xa1, 0xc1, 0x9b, 0x71, 0xa0, 0x01, 0x5d, 0x60, 0x8f, 0x34, 0x6c, 0xd4, 0xb0, 0x91, 0xc0,
-	0x9e, 0x2e, 0x93, 0x93, 0x0a, 0xfa, 0x14, 0xc7, 0x9b, 0x78, 0x3c, 0x50, 0x80, 0x2d, 0x5a, 0x8f,
-	0x57, 0x1b, 0x3c, 0x02, 0x7c, 0xe8, 0xb5, 0xf5, 0xaf, 0x75, 0xdf, 0x6a, 0xef, 0x47, 0xa8, 0xaf,
-	0x29, 0xe1, 0x2b, 0x28, 0x87, 0x29, 0xbf, 0x66, 0xa9, 0x35, 0xe4, 0x64, 0xe3, 0xa3, 0xbc, 0xd4,
-	0x69, 0xf6, 0x40, 0x56, 0xc4, 0xfb, 0xd3, 0x81, 0xfd, 0x07, 0x28, 0x7e, 0x02, 0x8d, 0xde, 0x9c,
-	0xb3, 0x38, 0x7b, 0x45, 0x6f, 0x2e, 0x44, 0x9a, 0xe9, 0x5a, 0x0d, 0x72, 0x37, 0xb8, 0xc6, 0xe2,
-	0xb1, 0x66, 0x15, 0xee, 0xb0, 0x4c, 0x10, 0x07, 0x50, 0x1d, 0x89, 0x44, 0xcc, 0xc5, 0xf4, 0x56,
-	0xf7, 0x58, 0xef, 0x76, 0x37, 0x39, 0xb2, 0x11, 0xc9, 0x33, 0xc9, 0x52, 0xc3, 0xfb, 0xbb, 0x00,
-	0xcd, 0xbb, 0x20, 0x7e, 0x00, 0xd5, 0x58, 0x84, 0x6c, 0xcc, 0x43, 0xe9, 0x3a, 0x9d, 0xe2, 0x61,
-	0x83, 0x54, 0xd4, 0xbe, 0x1f, 0x4a, 0x1c, 0x41, 0x2d, 0xe4, 0x32, 0xa3, 0x71, 0xc0, 0xa4, 0xbd,
-	0xbc, 0xe7, 0xdb, 0x97, 0x1f, 0x9e, 0xf7, 0x47, 0x64, 0x25, 0x84, 0xe7, 0xb0, 0x1b, 0x88, 0x94,
-	0x49, 0xb7, 0xd8, 0x29, 0xbe, 0x9d, 0x62, 0x4f, 0xa4, 0x8c, 0x18, 0x11, 0x7c, 0x06, 0xef, 0x89,
-	0x6b, 0x96, 0xa6, 0x3c, 0x64, 0xe3, 0x4c, 0x64, 0x74, 0x3e, 0x0e, 0x44, 0x94, 0x2c, 0x32, 0xf3,
-	0xb7, 0x29, 0x91, 0x47, 0x39, 0x3a, 0x52, 0x60, 0xcf, 0x60, 0xf8, 0x02, 0xdc, 0x65, 0xd6, 0xcf,
-	0x3c, 0x9b, 0x89, 0x79, 0xb8, 0xcc, 0xdb, 0xd5, 0x79, 0x4b, 0xd5, 0xd7, 0x06, 0xb6, 0x99, 0xde,
-	0x00, 0xf0, 0x61, 0x7b, 0xf8, 0x91, 0x72, 0x2a, 0x62, 0xb1, 0xfe, 0x18, 0xcd, 0x7d, 0xaf, 0x02,
-	0xd8, 0x86, 0xf2, 0x35, 0x9d, 0x2f, 0x98, 0x19, 0x09, 0x8d, 0xd3, 0x42, 0xcb, 0x21, 0x36, 0xe2,
-	0xfd, 0x51, 0xb8, 0x2f, 0xa8, 0xba, 0xc3, 0x0f, 0xa1, 0x26, 0x45, 0xf0, 0x86, 0x65, 0x63, 0x1e,
-	0x5a, 0xc1, 0xaa, 0x09, 0xf4, 0x43, 0x7c, 0x1f, 0x2a, 0xf6, 0xca, 0xec, 0x57, 0x53, 0x36, 0x37,
-	0xa6, 0x00, 0xe5, 0x8a, 0x02, 0x8a, 0x06, 0x50, 0xdb, 0x7e, 0x88, 0xe7, 0x00, 0x1a, 0x98, 0xa6,
-	0x34, 0x34, 0xce, 0x34, 0xbb, 0x9f, 0x6e, 0x64, 0xbc, 0x48, 0xd9, 0xd7, 0x2a, 0x89, 0xd4, 0x82,
-	0x7c, 0x89, 0x2e, 0x54, 0x42, 0x2e, 0xe9, 0xd5, 0xdc, 0x98, 0x55, 0x25, 0xf9, 0x16, 0x1f, 0x03,
-	0xa8, 0x64, 0x35, 0x8c, 0x59, 0xe8, 0x96, 0xb5, 0x93, 0x35, 0x15, 0x19, 0xaa, 0x80, 0xea, 0x2a,
-	0xa2, 0x37, 0x16, 0xad, 0x68, 0xb4, 0x1a, 0xd1, 0x1b, 0x03, 0x7e, 0x0c, 0xf5, 0xe9, 0x82, 0x49,
-	0x69, 0xe1, 0xaa, 0x86, 0x41, 0x87, 0x34, 0x41, 0x8d, 0xf5, 0xb5, 0x49, 0x64, 0x26, 0xdc, 0xd3,
-	0xcf, 0x00, 0x56, 0xf3, 0x18, 0xeb, 0x50, 0xf9, 0x6e, 0xf0, 0xcd, 0xe0, 0xdb, 0xd7, 0x83, 0xd6,
-	0x0e, 0x02, 0x94, 0x5f, 0x92, 0xfe, 0xe5, 0x19, 0x69, 0x15, 0xf4, 0xfa, 0xec, 0xb2, 0xdf, 0x3b,
-	0x6b, 0x15, 0x9f, 0x1e, 0x41, 0x6d, 0xd9, 0x16, 0xbe, 0x03, 0xf5, 0x0b, 0x96, 0x4e, 0x44, 0x1a,
-	0xa9, 0xaf, 0xb3, 0xb5, 0x83, 0x4d, 0x80, 0xb3, 0xc9, 0x84, 0x07, 0x9c, 0xc5, 0xc1, 0x6d, 0xcb,
-	0xe9, 0xfe, 0x5e, 0x04, 0x38, 0xa5, 0x92, 0x99, 0x2a, 0xf8, 0x6b, 0x5e, 0x4f, 0xbd, 0x22, 0x78,
-	0xb2, 0xf9, 0x7b, 0xb1, 0xf6, 0x16, 0xb5, 0x9f, 0x6f, 0x9b, 0x66, 0x9a, 0xf5, 0x76, 0xf0, 0x37,
-	0x07, 0xf6, 0xd6, 0x27, 0x3d, 0x7e, 0xb1, 0xd9, 0x2d, 0x3e, 0x78, 0x32, 0xda, 0x2f, 0xb6, 0x4f,
-	0x5c, 0x9e, 0xe2, 0x17, 0xa8, 0x2d, 0x6f, 0x02, 0x9f, 0x6d, 0x22, 0x74, 0xff, 0x09, 0x69, 0x9f,
-	0x6c, 0x99, 0x95, 0xd7, 0x3e, 0xad, 0xfc, 0xb0, 0xab, 0xc1, 0xab, 0xb2, 0xfe, 0xf9, 0xfc, 0xdf,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xe1, 0xe9, 0x67, 0x7b, 0x58, 0x08, 0x00, 0x00,
+	// 869 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5d, 0x6f, 0xe3, 0x44,
+	0x14, 0xad, 0x93, 0x34, 0x89, 0x6f, 0x9a, 0xe0, 0xde, 0x2e, 0x60, 0x02, 0x2b, 0x22, 0x8b, 0x95,
+	0xaa, 0x55, 0x71, 0xa5, 0xb0, 0x5d, 0xf6, 0x71, 0xb7, 0xd9, 0x08, 0x45, 0xb4, 0xa1, 0x9a, 0x84,
+	0x2e, 0x42, 0x48, 0x91, 0x6b, 0x4f, 0x92, 0xd1, 0xc6, 0x1e, 0xe3, 0x71, 0x4a, 0x8b, 0xc4, 0x13,
+	0xcf, 0xfc, 0x0f, 0xde, 0xf8, 0x01, 0x3c, 0xf0, 0xc0, 0x1f, 0x43, 0xf3, 0x91, 0x8f, 0x6e, 0x84,
+	0x48, 0x78, 0xca, 0xcc, 0x3d, 0xe7, 0x9e, 0x3b, 0xf7, 0x8c, 0x73, 0x07, 0x1e, 0xa7, 0xb3, 0xf9,
+	0x84, 0x25, 0xe2, 0xf4, 0x26, 0x10, 0xf4, 0x34, 0xcd, 0x78, 0xce, 0xd5, 0xd2, 0x57, 0x4b, 0xf4,
+	0xa6, 0x81, 0x98, 0xb2, 0x90, 0x67, 0xa9, 0x9f, 0xf0, 0x38, 0x88, 0x7c, 0x43, 0xf7, 0x57, 0x9c,
+	0xe6, 0x93, 0x85, 0x84, 0x98, 0x06, 0x19, 0x8d, 0x4e, 0xa7, 0xe1, 0x4c, 0xa4, 0x34, 0x94, 0xbf,
+	0x23, 0xb9, 0xd0, 0x34, 0xef, 0x08, 0x0e, 0xaf, 0x14, 0xb1, 0x97, 0x8c, 0x39, 0xa1, 0x3f, 0xce,
+	0xa9, 0xc8, 0xbd, 0xbf, 0x2d, 0xc0, 0xf5, 0xa8, 0x48, 0x79, 0x22, 0x28, 0x9e, 0x43, 0x29, 0xbf,
+	0x4f, 0xa9, 0x6b, 0xb5, 0xac, 0xe3, 0x46, 0xdb, 0xf7, 0xff, 0xfb, 0x14, 0xbe, 0x56, 0x19, 0xde,
+	0xa7, 0x94, 0xa8, 0x5c, 0xf4, 0xe1, 0x48, 0xd3, 0x46, 0x41, 0xca, 0x46, 0xb7, 0x34, 0x13, 0x8c,
+	0x27, 0xc2, 0x2d, 0xb4, 0x8a, 0xc7, 0x36, 0x39, 0xd4, 0xd0, 0xab, 0x94, 0x5d, 0x1b, 0x00, 0x9f,
+	0x40, 0xc3, 0xf0, 0x0d, 0xd7, 0x2d, 0xb6, 0xac, 0x63, 0x9b, 0xd4, 0x75, 0xd4, 0xf0, 0x10, 0xa1,
+	0x94, 0x04, 0x31, 0x75, 0x4b, 0x0a, 0x54, 0x6b, 0xef, 0x7d, 0x38, 0xea, 0xf0, 0x64, 0xcc, 0x26,
+	0x83, 0x70, 0x4a, 0xe3, 0x60, 0xd1, 0xdc, 0x77, 0xf0, 0xe8, 0x61, 0xd8, 0x74, 0xf7, 0x12, 0x4a,
+	0xd2, 0x17, 0xd5, 0x5d, 0xad, 0x7d, 0xf2, 0xaf, 0xdd, 0x69, 0x3f, 0x7d, 0xe3, 0xa7, 0x3f, 0x48,
+	0x69, 0x48, 0x54, 0xa6, 0xf7, 0xa7, 0x05, 0xce, 0x80, 0xe6, 0x5a, 0xdd, 0x94, 0x93, 0x0d, 0xc4,
+	0x62, 0x92, 0x06, 0xe1, 0xdb, 0x51, 0xa8, 0x00, 0x55, 0xe0, 0x80, 0xd4, 0x4d, 0x54, 0xb3, 0x91,
+	0xc0, 0x81, 0x2a, 0xb3, 0x20, 0x15, 0xd4, 0x29, 0x4e, 0xb7, 0xf1, 0xb8, 0x2f, 0x01, 0x53, 0xb4,
+	0x96, 0xac, 0x36, 0x78, 0x02, 0xb8, 0xe9, 0xb5, 0xf1, 0xcf, 0x79, 0xd7, 0x6a, 0xef, 0x07, 0xa8,
+	0xad, 0x29, 0xe1, 0x25, 0x94, 0xa3, 0x8c, 0xdd, 0xd2, 0xcc, 0x18, 0x72, 0xb6, 0xf5, 0x51, 0x5e,
+	0xab, 0x34, 0x73, 0x20, 0x23, 0xe2, 0xfd, 0x61, 0xc1, 0xe1, 0x06, 0x8a, 0x9f, 0x41, 0xbd, 0x33,
+	0x63, 0x34, 0xc9, 0x2f, 0x83, 0xbb, 0x2b, 0x9e, 0xe5, 0xaa, 0x56, 0x9d, 0x3c, 0x0c, 0xae, 0xb1,
+	0x58, 0xa2, 0x58, 0x85, 0x07, 0x2c, 0x1d, 0xc4, 0x3e, 0x54, 0x87, 0x3c, 0xe5, 0x33, 0x3e, 0xb9,
+	0x57, 0x3d, 0xd6, 0xda, 0xed, 0x6d, 0x8e, 0xac, 0x45, 0x16, 0x99, 0x64, 0xa9, 0xe1, 0xfd, 0x55,
+	0x80, 0xc6, 0x43, 0x10, 0x3f, 0x82, 0x6a, 0xc2, 0x23, 0x3a, 0x62, 0x91, 0x70, 0xad, 0x56, 0xf1,
+	0xb8, 0x4e, 0x2a, 0x72, 0xdf, 0x8b, 0x04, 0x0e, 0xc1, 0x8e, 0x98, 0xc8, 0x83, 0x24, 0xa4, 0xc2,
+	0x5c, 0xde, 0xf3, 0xdd, 0xcb, 0x0f, 0x2e, 0x7a, 0x43, 0xb2, 0x12, 0xc2, 0x0b, 0xd8, 0x0f, 0x79,
+	0x46, 0x85, 0x5b, 0x6c, 0x15, 0xff, 0x9f, 0x62, 0x87, 0x67, 0x94, 0x68, 0x11, 0x7c, 0x06, 0x1f,
+	0xf0, 0x5b, 0x9a, 0x65, 0x2c, 0xa2, 0xa3, 0x9c, 0xe7, 0xc1, 0x6c, 0x14, 0xf2, 0x38, 0x9d, 0xe7,
+	0xfa, 0x6f, 0x53, 0x22, 0x8f, 0x16, 0xe8, 0x50, 0x82, 0x1d, 0x8d, 0xe1, 0x0b, 0x70, 0x97, 0x59,
+	0x3f, 0xb1, 0x7c, 0xca, 0x67, 0xd1, 0x32, 0x6f, 0x5f, 0xe5, 0x2d, 0x55, 0xdf, 0x68, 0xd8, 0x64,
+	0x7a, 0x7d, 0xc0, 0xcd, 0xf6, 0xf0, 0x13, 0xe9, 0x54, 0x4c, 0x13, 0xf5, 0x31, 0xea, 0xfb, 0x5e,
+	0x05, 0xb0, 0x09, 0xe5, 0xdb, 0x60, 0x36, 0xa7, 0x7a, 0x24, 0xd4, 0xcf, 0x0b, 0x8e, 0x45, 0x4c,
+	0xc4, 0xfb, 0xbd, 0x00, 0xb8, 0xd9, 0x1d, 0x7e, 0x0c, 0xb6, 0xe0, 0xe1, 0x5b, 0x9a, 0x8f, 0x58,
+	0x64, 0x04, 0xab, 0x3a, 0xd0, 0x8b, 0xf0, 0x43, 0xa8, 0x98, 0x2b, 0x33, 0x5f, 0x4d, 0x59, 0xdf,
+	0x98, 0x04, 0xa4, 0x2b, 0x12, 0x28, 0x6a, 0x40, 0x6e, 0x7b, 0x11, 0x5e, 0x00, 0x28, 0x60, 0x92,
+	0x05, 0x91, 0x76, 0xa6, 0xd1, 0xfe, 0x7c, 0x2b, 0xe3, 0x79, 0x46, 0xbf, 0x92, 0x49, 0xc4, 0x0e,
+	0x17, 0x4b, 0x74, 0xa1, 0x12, 0x31, 0x11, 0xdc, 0xcc, 0xb4, 0x59, 0x55, 0xb2, 0xd8, 0xe2, 0x63,
+	0x00, 0x99, 0x2c, 0x87, 0x31, 0x8d, 0xdc, 0xb2, 0x72, 0xd2, 0x96, 0x91, 0x81, 0x0c, 0xc8, 0xae,
+	0xe2, 0xe0, 0xce, 0xa0, 0x15, 0x85, 0x56, 0xe3, 0xe0, 0x4e, 0x83, 0x9f, 0x42, 0x6d, 0x32, 0xa7,
+	0x42, 0x18, 0xb8, 0xaa, 0x60, 0x50, 0x21, 0x45, 0x90, 0x63, 0x7d, 0x6d, 0x12, 0xe9, 0x09, 0xf7,
+	0xf4, 0x25, 0xc0, 0x6a, 0x1e, 0x63, 0x0d, 0x2a, 0xdf, 0xf6, 0xbf, 0xee, 0x7f, 0xf3, 0xa6, 0xef,
+	0xec, 0x21, 0x40, 0xf9, 0x35, 0xe9, 0x5d, 0x77, 0x89, 0x53, 0x50, 0xeb, 0xee, 0x75, 0xaf, 0xd3,
+	0x75, 0x8a, 0x58, 0x07, 0xfb, 0xea, 0xe2, 0x55, 0xa7, 0x7b, 0xd9, 0xed, 0x0f, 0x9d, 0xd2, 0xd3,
+	0x13, 0xb0, 0x97, 0x5d, 0xe2, 0x7b, 0x50, 0xbb, 0xa2, 0xd9, 0x98, 0x67, 0xb1, 0xfc, 0x58, 0x9d,
+	0x3d, 0x6c, 0x00, 0x74, 0xc7, 0x63, 0x16, 0x32, 0x9a, 0x84, 0xf7, 0x8e, 0xd5, 0xfe, 0xad, 0x08,
+	0x70, 0x1e, 0x08, 0xaa, 0x8b, 0xe2, 0x2f, 0x00, 0xab, 0x47, 0x05, 0xcf, 0xb6, 0x7f, 0x3e, 0xd6,
+	0x9e, 0xa6, 0xe6, 0xf3, 0x5d, 0xd3, 0x74, 0xef, 0xde, 0x1e, 0xfe, 0x6a, 0xc1, 0xc1, 0xfa, 0xe0,
+	0xc7, 0x2f, 0xb7, 0xbb, 0xd4, 0x8d, 0x17, 0xa4, 0xf9, 0x62, 0xf7, 0xc4, 0xe5, 0x29, 0x7e, 0x06,
+	0x7b, 0x79, 0x31, 0xf8, 0x6c, 0x1b, 0xa1, 0x77, 0x5f, 0x94, 0xe6, 0xd9, 0x8e, 0x59, 0x8b, 0xda,
+	0xe7, 0x95, 0xef, 0xf7, 0x15, 0x78, 0x53, 0x56, 0x3f, 0x5f, 0xfc, 0x33, 0x00, 0xfa, 0x47, 0xa3,
+	0xc3, 0x67, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/nomad/helper/grpc-middleware/logging"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/ingress/proto"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+)
+
+type client struct {
+	addr   string
+	conn   *grpc.ClientConn
+	client proto.IngressPluginClient
+	logger hclog.Logger
+}
+
+func NewClient(addr string, logger hclog.Logger) IngressPlugin {
+	return &client{
+		addr:   addr,
+		logger: logger,
+	}
+}
+
+func (c *client) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+func (c *client) ensureConnected(ctx context.Context) error {
+	if c == nil {
+		return fmt.Errorf("client not initialized")
+	}
+	if c.conn != nil {
+		return nil
+	}
+	if c.addr == "" {
+		return fmt.Errorf("address is empty")
+	}
+	var conn *grpc.ClientConn
+	var err error
+	t := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while connecting to gRPC socket: %v", err)
+		case <-t.C:
+			_, err = os.Stat(c.addr)
+			if err != nil {
+				err = fmt.Errorf("failed to stat socket: %v", err)
+				t.Reset(5 * time.Second)
+				continue
+			}
+			conn, err = newGrpcConn(c.addr, c.logger)
+			if err != nil {
+				err = fmt.Errorf("failed to create gRPC connection: %v", err)
+				t.Reset(time.Second * 5)
+				continue
+			}
+			c.conn = conn
+			c.client = proto.NewIngressPluginClient(conn)
+			return nil
+		}
+	}
+}
+
+func newGrpcConn(addr string, logger hclog.Logger) (*grpc.ClientConn, error) {
+	// after DialContext returns w/ initial connection, closing this
+	// context is a no-op
+	connectCtx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	conn, err := grpc.DialContext(
+		connectCtx,
+		addr,
+		grpc.WithBlock(),
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor(logger)),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor(logger)),
+		grpc.WithAuthority("localhost"),
+		grpc.WithDialer(func(target string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", target, timeout)
+		}),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open grpc connection to addr: %s, err: %v", addr, err)
+	}
+
+	return conn, nil
+}
+
+// PluginInfo describes the type and version of a plugin as required by the nomad
+// base.BasePlugin interface.
+func (c *client) PluginInfo() (*base.PluginInfoResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+
+	// note: no grpc retries needed here, as this is called in
+	// fingerprinting and will get retried by the caller.
+	resp, err := c.client.GetInfo(ctx, &proto.GetInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetName() == "" {
+		return nil, fmt.Errorf("PluginInfo: plugin returned empty name field")
+	}
+
+	apiVersions := resp.GetApiVersions()
+	if len(apiVersions) == 0 {
+		// plugins that don't report their API versions predate versioning
+		apiVersions = []string{ApiVersion010}
+	}
+
+	return &base.PluginInfoResponse{
+		Type:              IngressPluginType,
+		PluginApiVersions: apiVersions,
+		PluginVersion:     resp.GetVendorVersion(),
+		Name:              resp.GetName(),
+	}, nil
+}
+
+// ConfigSchema returns the schema for parsing the plugins configuration as
+// required by the base.BasePlugin interface. It will always return nil, the
+// configuration is defined by the ingress_plugin block of the task.
+func (c *client) ConfigSchema() (*hclspec.Spec, error) {
+	return nil, nil
+}
+
+// SetConfig is used to set the configuration by passing a MessagePack
+// encoding of it.
+func (c *client) SetConfig(config *base.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+
+	req := &proto.SetConfigRequest{
+		MsgpackConfig:    config.PluginConfig,
+		PluginApiVersion: config.ApiVersion,
+	}
+	_, err := c.client.SetConfig(ctx, req)
+	return err
+}
+
+func (c *client) PluginProbe(ctx context.Context) (bool, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return false, err
+	}
+
+	// note: no grpc retries should be done here
+	resp, err := c.client.Probe(ctx, &proto.ProbeRequest{})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetReady(), nil
+}
+
+func (c *client) PluginGetInfo(ctx context.Context) (string, string, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return "", "", err
+	}
+
+	resp, err := c.client.GetInfo(ctx, &proto.GetInfoRequest{})
+	if err != nil {
+		return "", "", err
+	}
+
+	name := resp.GetName()
+	if name == "" {
+		return "", "", fmt.Errorf("PluginGetInfo: plugin returned empty name field")
+	}
+	version := resp.GetVendorVersion()
+
+	return name, version, nil
+}
+
+func (c *client) UpsertRoutes(ctx context.Context, routes []*Route) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+
+	for _, r := range routes {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+
+	req := &proto.UpsertRoutesRequest{
+		Routes: convertStructRoutes(routes),
+	}
+	_, err := c.client.UpsertRoutes(ctx, req)
+	return err
+}
+
+func (c *client) DeleteRoutes(ctx context.Context, routeIDs []string) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+
+	if len(routeIDs) == 0 {
+		return nil
+	}
+
+	req := &proto.DeleteRoutesRequest{
+		RouteIds: routeIDs,
+	}
+	_, err := c.client.DeleteRoutes(ctx, req)
+	return err
+}
+
+func (c *client) RegisterTargets(ctx context.Context, targetGroup string, targets []*Backend) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+	for _, t := range targets {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+
+	req := &proto.RegisterTargetsRequest{
+		TargetGroup: targetGroup,
+		Targets:     convertStructBackends(targets),
+	}
+	_, err := c.client.RegisterTargets(ctx, req)
+	return err
+}
+
+func (c *client) DeregisterTargets(ctx context.Context, targetGroup string, targets []*Backend, drainTimeout time.Duration) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	req := &proto.DeregisterTargetsRequest{
+		TargetGroup:    targetGroup,
+		Targets:        convertStructBackends(targets),
+		DrainTimeoutMs: drainTimeout.Milliseconds(),
+	}
+	_, err := c.client.DeregisterTargets(ctx, req)
+	return err
+}
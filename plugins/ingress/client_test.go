@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ingress_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/ingress"
+	"github.com/hashicorp/nomad/plugins/ingress/fake"
+)
+
+func newTestClient(t *testing.T) (*fake.Controller, ingress.IngressPlugin) {
+	controller := fake.NewController()
+
+	socket := filepath.Join(t.TempDir(), "ingress.sock")
+	stop, err := controller.Serve(socket)
+	must.NoError(t, err)
+
+	client := ingress.NewClient(socket, hclog.NewNullLogger())
+	t.Cleanup(func() {
+		_ = client.Close()
+		stop()
+	})
+
+	return controller, client
+}
+
+func testCtx(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestClient_RPC_PluginProbe(t *testing.T) {
+	ci.Parallel(t)
+
+	controller, client := newTestClient(t)
+
+	ready, err := client.PluginProbe(testCtx(t))
+	must.NoError(t, err)
+	must.True(t, ready)
+
+	controller.Mu.Lock()
+	controller.Ready = false
+	controller.Mu.Unlock()
+
+	ready, err = client.PluginProbe(testCtx(t))
+	must.NoError(t, err)
+	must.False(t, ready)
+
+	controller.Mu.Lock()
+	controller.NextErr = errors.New("some grpc error")
+	controller.Mu.Unlock()
+
+	_, err = client.PluginProbe(testCtx(t))
+	must.ErrorContains(t, err, "some grpc error")
+}
+
+func TestClient_RPC_PluginInfo(t *testing.T) {
+	ci.Parallel(t)
+
+	controller, client := newTestClient(t)
+
+	info, err := client.PluginInfo()
+	must.NoError(t, err)
+	must.Eq(t, ingress.IngressPluginType, info.Type)
+	must.Eq(t, controller.Name, info.Name)
+	must.Eq(t, controller.Version, info.PluginVersion)
+	must.Eq(t, []string{ingress.ApiVersion010}, info.PluginApiVersions)
+
+	controller.Mu.Lock()
+	controller.Name = ""
+	controller.Mu.Unlock()
+
+	_, _, err = client.PluginGetInfo(testCtx(t))
+	must.ErrorContains(t, err, "empty name")
+}
+
+func TestClient_RPC_SetConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	controller, client := newTestClient(t)
+
+	err := client.SetConfig(&base.Config{
+		ApiVersion:   ingress.ApiVersion010,
+		PluginConfig: []byte("config"),
+	})
+	must.NoError(t, err)
+
+	controller.Mu.RLock()
+	defer controller.Mu.RUnlock()
+	must.Eq(t, ingress.ApiVersion010, controller.Config.ApiVersion)
+	must.Eq(t, []byte("config"), controller.Config.PluginConfig)
+}
+
+func TestClient_RPC_Routes(t *testing.T) {
+	ci.Parallel(t)
+
+	controller, client := newTestClient(t)
+
+	routes := []*ingress.Route{
+		{
+			ID:        "default/web/group/http",
+			Namespace: "default",
+			JobID:     "web",
+			TaskGroup: "group",
+			Service:   "http",
+			Hosts:     []string{"example.com"},
+			Paths:     []string{"/"},
+			TLSSecret: "certs/example",
+			Weight:    100,
+			Backends: []*ingress.Backend{
+				{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080},
+			},
+		},
+		{ID: "default/api/group/grpc", Weight: 50},
+	}
+
+	must.NoError(t, client.UpsertRoutes(testCtx(t), routes))
+	must.Eq(t, []string{"default/api/group/grpc", "default/web/group/http"}, controller.RouteIDs())
+	must.Eq(t, routes[0], controller.Route("default/web/group/http"))
+
+	must.NoError(t, client.DeleteRoutes(testCtx(t), []string{"default/web/group/http", "unknown"}))
+	must.Eq(t, []string{"default/api/group/grpc"}, controller.RouteIDs())
+
+	err := client.UpsertRoutes(testCtx(t), []*ingress.Route{{Weight: 1}})
+	must.ErrorContains(t, err, "missing route ID")
+
+	err = client.UpsertRoutes(testCtx(t), []*ingress.Route{{ID: "bad", Weight: -1}})
+	must.ErrorContains(t, err, "negative weight")
+
+	err = client.UpsertRoutes(testCtx(t), []*ingress.Route{{ID: "bad", Backends: []*ingress.Backend{nil}}})
+	must.ErrorContains(t, err, "missing backend")
+}
+
+func TestClient_RPC_Targets(t *testing.T) {
+	ci.Parallel(t)
+
+	controller, client := newTestClient(t)
+
+	targets := []*ingress.Backend{
+		{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080},
+		{AllocID: "alloc2", Address: "10.0.0.2", Port: 8080},
+	}
+
+	must.NoError(t, client.RegisterTargets(testCtx(t), "tg-web", targets))
+	must.Eq(t, []string{"alloc1", "alloc2"}, controller.Targets("tg-web"))
+
+	err := client.DeregisterTargets(testCtx(t), "tg-web", targets[:1], 30*time.Second)
+	must.NoError(t, err)
+	must.Eq(t, []string{"alloc2"}, controller.Targets("tg-web"))
+
+	controller.Mu.RLock()
+	must.Eq(t, 30*time.Second, controller.Drained["alloc1"])
+	controller.Mu.RUnlock()
+
+	err = client.RegisterTargets(testCtx(t), "tg-web", []*ingress.Backend{{AllocID: "alloc3", Port: 8080}})
+	must.ErrorContains(t, err, "has no address")
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// fake is a package that includes fake implementations of public interfaces
+// from the ingress package for testing.
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/ingress"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+)
+
+var _ ingress.IngressPlugin = &Client{}
+
+// Client is a mock implementation of the ingress.IngressPlugin interface for
+// use in testing external components
+type Client struct {
+	Mu sync.RWMutex
+
+	NextPluginInfoResponse *base.PluginInfoResponse
+	NextPluginInfoErr      error
+	PluginInfoCallCount    int64
+
+	NextSetConfigErr   error
+	PrevConfig         *base.Config
+	SetConfigCallCount int64
+
+	NextPluginProbeResponse bool
+	NextPluginProbeErr      error
+	PluginProbeCallCount    int64
+
+	NextPluginGetInfoNameResponse    string
+	NextPluginGetInfoVersionResponse string
+	NextPluginGetInfoErr             error
+	PluginGetInfoCallCount           int64
+
+	PrevUpsertRoutes      []*ingress.Route
+	NextUpsertRoutesErr   error
+	UpsertRoutesCallCount int64
+
+	PrevDeleteRouteIDs    []string
+	NextDeleteRoutesErr   error
+	DeleteRoutesCallCount int64
+
+	PrevRegisterTargetGroup  string
+	PrevRegisterTargets      []*ingress.Backend
+	NextRegisterTargetsErr   error
+	RegisterTargetsCallCount int64
+
+	PrevDeregisterTargetGroup  string
+	PrevDeregisterTargets      []*ingress.Backend
+	PrevDrainTimeout           time.Duration
+	NextDeregisterTargetsErr   error
+	DeregisterTargetsCallCount int64
+}
+
+// PluginInfo describes the type and version of a plugin.
+func (c *Client) PluginInfo() (*base.PluginInfoResponse, error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.PluginInfoCallCount++
+
+	return c.NextPluginInfoResponse, c.NextPluginInfoErr
+}
+
+// ConfigSchema returns the schema for parsing the plugins configuration.
+func (c *Client) ConfigSchema() (*hclspec.Spec, error) {
+	return nil, errors.New("Unsupported")
+}
+
+// SetConfig is used to set the configuration by passing a MessagePack
+// encoding of it.
+func (c *Client) SetConfig(config *base.Config) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.SetConfigCallCount++
+	c.PrevConfig = config
+
+	return c.NextSetConfigErr
+}
+
+// PluginProbe is used to verify that the plugin is in a healthy state
+func (c *Client) PluginProbe(ctx context.Context) (bool, error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.PluginProbeCallCount++
+
+	return c.NextPluginProbeResponse, c.NextPluginProbeErr
+}
+
+// PluginGetInfo is used to return semantic data about the plugin.
+// Response:
+//   - string: name, the name of the plugin in domain notation format.
+func (c *Client) PluginGetInfo(ctx context.Context) (string, string, error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.PluginGetInfoCallCount++
+
+	return c.NextPluginGetInfoNameResponse, c.NextPluginGetInfoVersionResponse, c.NextPluginGetInfoErr
+}
+
+// UpsertRoutes records the routes it was called with.
+func (c *Client) UpsertRoutes(ctx context.Context, routes []*ingress.Route) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.UpsertRoutesCallCount++
+	c.PrevUpsertRoutes = routes
+
+	return c.NextUpsertRoutesErr
+}
+
+// DeleteRoutes records the route IDs it was called with.
+func (c *Client) DeleteRoutes(ctx context.Context, routeIDs []string) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.DeleteRoutesCallCount++
+	c.PrevDeleteRouteIDs = routeIDs
+
+	return c.NextDeleteRoutesErr
+}
+
+// RegisterTargets records the target group and targets it was called with.
+func (c *Client) RegisterTargets(ctx context.Context, targetGroup string, targets []*ingress.Backend) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.RegisterTargetsCallCount++
+	c.PrevRegisterTargetGroup = targetGroup
+	c.PrevRegisterTargets = targets
+
+	return c.NextRegisterTargetsErr
+}
+
+// DeregisterTargets records the target group, targets and drain timeout it
+// was called with.
+func (c *Client) DeregisterTargets(ctx context.Context, targetGroup string, targets []*ingress.Backend, drainTimeout time.Duration) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.DeregisterTargetsCallCount++
+	c.PrevDeregisterTargetGroup = targetGroup
+	c.PrevDeregisterTargets = targets
+	c.PrevDrainTimeout = drainTimeout
+
+	return c.NextDeregisterTargetsErr
+}
+
+// Close the client and ensure any connections are cleaned up.
+func (c *Client) Close() error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.NextPluginInfoResponse = nil
+	c.NextPluginInfoErr = fmt.Errorf("closed client")
+
+	c.NextSetConfigErr = fmt.Errorf("closed client")
+
+	c.NextPluginProbeResponse = false
+	c.NextPluginProbeErr = fmt.Errorf("closed client")
+
+	c.NextPluginGetInfoNameResponse = ""
+	c.NextPluginGetInfoVersionResponse = ""
+	c.NextPluginGetInfoErr = fmt.Errorf("closed client")
+
+	c.NextUpsertRoutesErr = fmt.Errorf("closed client")
+
+	c.NextDeleteRoutesErr = fmt.Errorf("closed client")
+
+	c.NextRegisterTargetsErr = fmt.Errorf("closed client")
+
+	c.NextDeregisterTargetsErr = fmt.Errorf("closed client")
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fake
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/ingress"
+)
+
+var _ ingress.Controller = &Controller{}
+
+// Controller is an in-memory implementation of the ingress.Controller
+// interface. It keeps the programmed routes and the target groups of a fake
+// external load balancer in maps so tests can assert on what Nomad sent to
+// the controller.
+type Controller struct {
+	Mu sync.RWMutex
+
+	Name    string
+	Version string
+
+	// Ready is returned by Probe
+	Ready bool
+
+	// NextErr is returned by every call when set
+	NextErr error
+
+	Config *base.Config
+	Routes map[string]*ingress.Route
+
+	// TargetGroups maps a target group to its registered targets, keyed by
+	// allocation ID.
+	TargetGroups map[string]map[string]*ingress.Backend
+
+	// Drained maps the allocation ID of deregistered targets to the drain
+	// timeout they were deregistered with.
+	Drained map[string]time.Duration
+}
+
+// NewController returns a ready Controller with no routes.
+func NewController() *Controller {
+	return &Controller{
+		Name:    "fake.ingress.nomadproject.io",
+		Version: "0.0.1",
+		Ready:   true,
+		Routes:  map[string]*ingress.Route{},
+
+		TargetGroups: map[string]map[string]*ingress.Backend{},
+		Drained:      map[string]time.Duration{},
+	}
+}
+
+func (c *Controller) Probe(ctx context.Context) (bool, error) {
+	c.Mu.RLock()
+	defer c.Mu.RUnlock()
+	return c.Ready, c.NextErr
+}
+
+func (c *Controller) GetInfo(ctx context.Context) (string, string, error) {
+	c.Mu.RLock()
+	defer c.Mu.RUnlock()
+	return c.Name, c.Version, c.NextErr
+}
+
+func (c *Controller) SetConfig(ctx context.Context, config *base.Config) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	if c.NextErr != nil {
+		return c.NextErr
+	}
+	c.Config = config
+	return nil
+}
+
+func (c *Controller) UpsertRoutes(ctx context.Context, routes []*ingress.Route) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	if c.NextErr != nil {
+		return c.NextErr
+	}
+	for _, r := range routes {
+		c.Routes[r.ID] = r.Copy()
+	}
+	return nil
+}
+
+func (c *Controller) DeleteRoutes(ctx context.Context, routeIDs []string) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	if c.NextErr != nil {
+		return c.NextErr
+	}
+	for _, id := range routeIDs {
+		delete(c.Routes, id)
+	}
+	return nil
+}
+
+func (c *Controller) RegisterTargets(ctx context.Context, targetGroup string, targets []*ingress.Backend) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	if c.NextErr != nil {
+		return c.NextErr
+	}
+	group, ok := c.TargetGroups[targetGroup]
+	if !ok {
+		group = map[string]*ingress.Backend{}
+		c.TargetGroups[targetGroup] = group
+	}
+	for _, t := range targets {
+		nt := *t
+		group[t.AllocID] = &nt
+		delete(c.Drained, t.AllocID)
+	}
+	return nil
+}
+
+func (c *Controller) DeregisterTargets(ctx context.Context, targetGroup string, targets []*ingress.Backend, drainTimeout time.Duration) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	if c.NextErr != nil {
+		return c.NextErr
+	}
+	group := c.TargetGroups[targetGroup]
+	for _, t := range targets {
+		if _, ok := group[t.AllocID]; !ok {
+			continue
+		}
+		delete(group, t.AllocID)
+		c.Drained[t.AllocID] = drainTimeout
+	}
+	return nil
+}
+
+// Targets returns the allocation IDs registered in the target group, sorted.
+func (c *Controller) Targets(targetGroup string) []string {
+	c.Mu.RLock()
+	defer c.Mu.RUnlock()
+	ids := make([]string, 0, len(c.TargetGroups[targetGroup]))
+	for id := range c.TargetGroups[targetGroup] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// RouteIDs returns the sorted IDs of the programmed routes.
+func (c *Controller) RouteIDs() []string {
+	c.Mu.RLock()
+	defer c.Mu.RUnlock()
+	ids := make([]string, 0, len(c.Routes))
+	for id := range c.Routes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Route returns a copy of the programmed route with the given ID.
+func (c *Controller) Route(id string) *ingress.Route {
+	c.Mu.RLock()
+	defer c.Mu.RUnlock()
+	return c.Routes[id].Copy()
+}
+
+// Serve starts a gRPC server for the controller on the unix socket at the
+// given path. The returned function stops the server.
+func (c *Controller) Serve(socketPath string) (func(), error) {
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s := grpc.NewServer()
+	ingress.RegisterController(s, c)
+	go s.Serve(l)
+
+	return s.Stop, nil
+}
@@ -1,158 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
 package ingress
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/hashicorp/go-hclog"
-	"github.com/hashicorp/nomad/helper/grpc-middleware/logging"
-	"github.com/hashicorp/nomad/plugins/base"
-	"github.com/hashicorp/nomad/plugins/shared/hclspec"
-	"google.golang.org/grpc"
-	"net"
-	"os"
 	"time"
+
+	"github.com/hashicorp/nomad/plugins/base"
 )
 
+// IngressPluginType is the type of the ingress plugin. Note that this isn't a
+// Nomad go-plugin type, ingress plugins run as tasks and are reached over a
+// unix socket.
 const IngressPluginType = "ingress"
 
+const (
+	// ApiVersion010 is the initial API version for ingress plugins
+	ApiVersion010 = "v0.1.0"
+)
+
+// IngressPlugin implements the client side of the ingress plugin API and is
+// used by Nomad to talk to ingress controllers.
 type IngressPlugin interface {
 	base.BasePlugin
+
+	// PluginProbe is used to verify that the plugin is in a healthy state
 	PluginProbe(ctx context.Context) (bool, error)
-	CreateRoutes(allocID string) error
-	ChangeOrDeleteRoutes(allocID string) error
+
+	// PluginGetInfo is used to return semantic data about the plugin.
+	// Response:
+	//  - string: name, the name of the plugin in domain notation format.
+	//  - string: version, the vendor version of the plugin
 	PluginGetInfo(ctx context.Context) (string, string, error)
+
+	// UpsertRoutes creates or replaces the given routes on the load balancer
+	// managed by the plugin.
+	UpsertRoutes(ctx context.Context, routes []*Route) error
+
+	// DeleteRoutes removes the routes with the given IDs from the load
+	// balancer managed by the plugin.
+	DeleteRoutes(ctx context.Context, routeIDs []string) error
+
+	// RegisterTargets adds the given allocation addresses to a target group
+	// of the external load balancer managed by the plugin.
+	RegisterTargets(ctx context.Context, targetGroup string, targets []*Backend) error
+
+	// DeregisterTargets drains the given allocation addresses for at most
+	// drainTimeout and removes them from a target group of the external load
+	// balancer managed by the plugin.
+	DeregisterTargets(ctx context.Context, targetGroup string, targets []*Backend, drainTimeout time.Duration) error
+
+	// Close the connection to the plugin
 	Close() error
 }
 
-type client struct {
-	addr   string
-	conn   *grpc.ClientConn
-	logger hclog.Logger
-}
+// Route is a single routing rule programmed on a load balancer by an ingress
+// controller.
+type Route struct {
+	// ID uniquely identifies the route across the cluster.
+	ID string
 
-func NewClient(addr string, logger hclog.Logger) IngressPlugin {
-	return &client{
-		addr:   addr,
-		logger: logger,
-	}
-}
+	Namespace string
+	JobID     string
+	TaskGroup string
+	Service   string
 
-func (c *client) Close() error {
-	if c.conn != nil {
-		return c.conn.Close()
-	}
-	return nil
+	// Hosts and Paths are the host names and path prefixes matched by the
+	// route.
+	Hosts []string
+	Paths []string
+
+	// TLSSecret is a reference to the secret holding the TLS certificate.
+	TLSSecret string
+
+	// Weight is the relative weight of the traffic sent to this route.
+	Weight int
+
+	// Backends is the set of addresses traffic should be sent to.
+	Backends []*Backend
 }
 
-func (c *client) ensureConnected(ctx context.Context) error {
-	if c == nil {
-		return fmt.Errorf("client not initialized")
-	}
-	if c.conn != nil {
+// Copy returns a deep copy of the route.
+func (r *Route) Copy() *Route {
+	if r == nil {
 		return nil
 	}
-	if c.addr == "" {
-		return fmt.Errorf("address is empty")
-	}
-	var conn *grpc.ClientConn
-	var err error
-	t := time.NewTimer(0)
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timeout while connecting to gRPC socket: %v", err)
-		case <-t.C:
-			_, err = os.Stat(c.addr)
-			if err != nil {
-				err = fmt.Errorf("failed to stat socket: %v", err)
-				t.Reset(5 * time.Second)
+	nr := new(Route)
+	*nr = *r
+	nr.Hosts = append([]string(nil), r.Hosts...)
+	nr.Paths = append([]string(nil), r.Paths...)
+	if r.Backends != nil {
+		nr.Backends = make([]*Backend, len(r.Backends))
+		for i, b := range r.Backends {
+			if b == nil {
 				continue
 			}
-			conn, err = newGrpcConn(c.addr, c.logger)
-			if err != nil {
-				err = fmt.Errorf("failed to create gRPC connection: %v", err)
-				t.Reset(time.Second * 5)
-				continue
-			}
-			c.conn = conn
-			return nil
+			nb := *b
+			nr.Backends[i] = &nb
 		}
 	}
+	return nr
 }
 
-func newGrpcConn(addr string, logger hclog.Logger) (*grpc.ClientConn, error) {
-	// after DialContext returns w/ initial connection, closing this
-	// context is a no-op
-	connectCtx, cancel := context.WithTimeout(context.Background(), time.Second*1)
-	defer cancel()
-	conn, err := grpc.DialContext(
-		connectCtx,
-		addr,
-		grpc.WithBlock(),
-		grpc.WithInsecure(),
-		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor(logger)),
-		grpc.WithStreamInterceptor(logging.StreamClientInterceptor(logger)),
-		grpc.WithAuthority("localhost"),
-		grpc.WithDialer(func(target string, timeout time.Duration) (net.Conn, error) {
-			return net.DialTimeout("unix", target, timeout)
-		}),
-	)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to open grpc connection to addr: %s, err: %v", addr, err)
+// Validate returns an error if the route can't be sent to a plugin.
+func (r *Route) Validate() error {
+	if r == nil {
+		return errors.New("missing route")
 	}
-
-	return conn, nil
-}
-
-func (c *client) PluginProbe(ctx context.Context) (bool, error) {
-	return true, nil
-}
-
-func (c *client) PluginInfo() (*base.PluginInfoResponse, error) {
-	//ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	//defer cancel()
-	//if err := c.ensureConnected(ctx); err != nil {
-	//	return nil, err
-	//}
-
-	// note: no grpc retries needed here, as this is called in
-	// fingerprinting and will get retried by the caller.
-	name := "test"
-	version := "1.0.0"
-	var err error = nil
-	if err != nil {
-		return nil, err
+	if r.ID == "" {
+		return errors.New("missing route ID")
 	}
-
-	return &base.PluginInfoResponse{
-		Type:              IngressPluginType, // note: this isn't a Nomad go-plugin type
-		PluginApiVersions: []string{"1.0.0"}, // TODO(tgross): we want to fingerprint spec version, but this isn't included as a field from the plugins
-		PluginVersion:     version,
-		Name:              name,
-	}, nil
-}
-
-func (c *client) PluginGetInfo(ctx context.Context) (string, string, error) {
-	return "", "", nil
-}
-
-func (c *client) ConfigSchema() (*hclspec.Spec, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (c *client) SetConfig(config *base.Config) error {
-	//TODO implement me
-	panic("implement me")
+	if r.Weight < 0 {
+		return fmt.Errorf("route %q has negative weight %d", r.ID, r.Weight)
+	}
+	for _, b := range r.Backends {
+		if b == nil {
+			return fmt.Errorf("route %q has a missing backend", r.ID)
+		}
+	}
+	return nil
 }
 
-func (c *client) CreateRoutes(allocID string) error {
-	//TODO implement me
-	panic("implement me")
+// Backend is a single address receiving traffic for a route, or registered
+// in a target group of an external load balancer.
+type Backend struct {
+	AllocID string
+	Address string
+	Port    int
 }
 
-func (c *client) ChangeOrDeleteRoutes(allocID string) error {
-	//TODO implement me
-	panic("implement me")
+// Validate returns an error if the backend can't be registered in a target
+// group.
+func (b *Backend) Validate() error {
+	if b == nil {
+		return errors.New("missing target")
+	}
+	if b.Address == "" {
+		return fmt.Errorf("target of allocation %q has no address", b.AllocID)
+	}
+	if b.Port <= 0 {
+		return fmt.Errorf("target of allocation %q has invalid port %d", b.AllocID, b.Port)
+	}
+	return nil
 }
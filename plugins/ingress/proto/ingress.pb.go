@@ -0,0 +1,1175 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: plugins/ingress/proto/ingress.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ProbeRequest is used to probe the health of the controller.
+type ProbeRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProbeRequest) Reset()         { *m = ProbeRequest{} }
+func (m *ProbeRequest) String() string { return proto.CompactTextString(m) }
+func (*ProbeRequest) ProtoMessage()    {}
+func (*ProbeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{0}
+}
+
+func (m *ProbeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeRequest.Unmarshal(m, b)
+}
+func (m *ProbeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeRequest.Marshal(b, m, deterministic)
+}
+func (m *ProbeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeRequest.Merge(m, src)
+}
+func (m *ProbeRequest) XXX_Size() int {
+	return xxx_messageInfo_ProbeRequest.Size(m)
+}
+func (m *ProbeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeRequest proto.InternalMessageInfo
+
+// ProbeResponse returns the readiness of the controller.
+type ProbeResponse struct {
+	// ready is true when the controller is able to program routes.
+	Ready                bool     `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProbeResponse) Reset()         { *m = ProbeResponse{} }
+func (m *ProbeResponse) String() string { return proto.CompactTextString(m) }
+func (*ProbeResponse) ProtoMessage()    {}
+func (*ProbeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{1}
+}
+
+func (m *ProbeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeResponse.Unmarshal(m, b)
+}
+func (m *ProbeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeResponse.Marshal(b, m, deterministic)
+}
+func (m *ProbeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeResponse.Merge(m, src)
+}
+func (m *ProbeResponse) XXX_Size() int {
+	return xxx_messageInfo_ProbeResponse.Size(m)
+}
+func (m *ProbeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeResponse proto.InternalMessageInfo
+
+func (m *ProbeResponse) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+// GetInfoRequest is used to request the controller's information.
+type GetInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInfoRequest) Reset()         { *m = GetInfoRequest{} }
+func (m *GetInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetInfoRequest) ProtoMessage()    {}
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{2}
+}
+
+func (m *GetInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInfoRequest.Unmarshal(m, b)
+}
+func (m *GetInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInfoRequest.Merge(m, src)
+}
+func (m *GetInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetInfoRequest.Size(m)
+}
+func (m *GetInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInfoRequest proto.InternalMessageInfo
+
+// GetInfoResponse returns the controller's information.
+type GetInfoResponse struct {
+	// name is the name of the controller, usually the vendor name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// vendor_version is the version of the controller.
+	VendorVersion string `protobuf:"bytes,2,opt,name=vendor_version,json=vendorVersion,proto3" json:"vendor_version,omitempty"`
+	// api_versions is the set of plugin API versions the controller supports.
+	ApiVersions          []string `protobuf:"bytes,3,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInfoResponse) Reset()         { *m = GetInfoResponse{} }
+func (m *GetInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetInfoResponse) ProtoMessage()    {}
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{3}
+}
+
+func (m *GetInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInfoResponse.Unmarshal(m, b)
+}
+func (m *GetInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *GetInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInfoResponse.Merge(m, src)
+}
+func (m *GetInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_GetInfoResponse.Size(m)
+}
+func (m *GetInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInfoResponse proto.InternalMessageInfo
+
+func (m *GetInfoResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetVendorVersion() string {
+	if m != nil {
+		return m.VendorVersion
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetApiVersions() []string {
+	if m != nil {
+		return m.ApiVersions
+	}
+	return nil
+}
+
+// SetConfigRequest is used to configure the controller.
+type SetConfigRequest struct {
+	// msgpack_config is the configuration encoded as MessagePack.
+	MsgpackConfig []byte `protobuf:"bytes,1,opt,name=msgpack_config,json=msgpackConfig,proto3" json:"msgpack_config,omitempty"`
+	// plugin_api_version is the api version to use.
+	PluginApiVersion     string   `protobuf:"bytes,2,opt,name=plugin_api_version,json=pluginApiVersion,proto3" json:"plugin_api_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetConfigRequest) Reset()         { *m = SetConfigRequest{} }
+func (m *SetConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*SetConfigRequest) ProtoMessage()    {}
+func (*SetConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{4}
+}
+
+func (m *SetConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetConfigRequest.Unmarshal(m, b)
+}
+func (m *SetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *SetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetConfigRequest.Merge(m, src)
+}
+func (m *SetConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_SetConfigRequest.Size(m)
+}
+func (m *SetConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetConfigRequest proto.InternalMessageInfo
+
+func (m *SetConfigRequest) GetMsgpackConfig() []byte {
+	if m != nil {
+		return m.MsgpackConfig
+	}
+	return nil
+}
+
+func (m *SetConfigRequest) GetPluginApiVersion() string {
+	if m != nil {
+		return m.PluginApiVersion
+	}
+	return ""
+}
+
+// SetConfigResponse is used to return any error from setting the config.
+type SetConfigResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetConfigResponse) Reset()         { *m = SetConfigResponse{} }
+func (m *SetConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*SetConfigResponse) ProtoMessage()    {}
+func (*SetConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{5}
+}
+
+func (m *SetConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetConfigResponse.Unmarshal(m, b)
+}
+func (m *SetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *SetConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetConfigResponse.Merge(m, src)
+}
+func (m *SetConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_SetConfigResponse.Size(m)
+}
+func (m *SetConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetConfigResponse proto.InternalMessageInfo
+
+// Route is a single routing rule to be programmed on the load balancer.
+type Route struct {
+	// id uniquely identifies the route across the cluster.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// namespace is the namespace of the job that owns the route.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// job_id is the ID of the job that owns the route.
+	JobId string `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// task_group is the name of the task group that owns the route.
+	TaskGroup string `protobuf:"bytes,4,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	// service is the name of the service the route points at.
+	Service string `protobuf:"bytes,5,opt,name=service,proto3" json:"service,omitempty"`
+	// hosts is the set of host names matched by the route.
+	Hosts []string `protobuf:"bytes,6,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	// paths is the set of path prefixes matched by the route.
+	Paths []string `protobuf:"bytes,7,rep,name=paths,proto3" json:"paths,omitempty"`
+	// tls_secret is a reference to the secret holding the TLS certificate.
+	TlsSecret string `protobuf:"bytes,8,opt,name=tls_secret,json=tlsSecret,proto3" json:"tls_secret,omitempty"`
+	// weight is the relative weight of traffic sent to this route.
+	Weight int32 `protobuf:"varint,9,opt,name=weight,proto3" json:"weight,omitempty"`
+	// backends is the set of addresses traffic should be sent to.
+	Backends             []*Backend `protobuf:"bytes,10,rep,name=backends,proto3" json:"backends,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Route) Reset()         { *m = Route{} }
+func (m *Route) String() string { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()    {}
+func (*Route) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{6}
+}
+
+func (m *Route) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Route.Unmarshal(m, b)
+}
+func (m *Route) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Route.Marshal(b, m, deterministic)
+}
+func (m *Route) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Route.Merge(m, src)
+}
+func (m *Route) XXX_Size() int {
+	return xxx_messageInfo_Route.Size(m)
+}
+func (m *Route) XXX_DiscardUnknown() {
+	xxx_messageInfo_Route.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Route proto.InternalMessageInfo
+
+func (m *Route) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Route) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Route) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *Route) GetTaskGroup() string {
+	if m != nil {
+		return m.TaskGroup
+	}
+	return ""
+}
+
+func (m *Route) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Route) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+func (m *Route) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *Route) GetTlsSecret() string {
+	if m != nil {
+		return m.TlsSecret
+	}
+	return ""
+}
+
+func (m *Route) GetWeight() int32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *Route) GetBackends() []*Backend {
+	if m != nil {
+		return m.Backends
+	}
+	return nil
+}
+
+// Backend is a single address receiving traffic for a route.
+type Backend struct {
+	// alloc_id is the ID of the allocation serving the address.
+	AllocId string `protobuf:"bytes,1,opt,name=alloc_id,json=allocId,proto3" json:"alloc_id,omitempty"`
+	// address is the IP address or host name of the backend.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// port is the port of the backend.
+	Port                 int32    `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Backend) Reset()         { *m = Backend{} }
+func (m *Backend) String() string { return proto.CompactTextString(m) }
+func (*Backend) ProtoMessage()    {}
+func (*Backend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{7}
+}
+
+func (m *Backend) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backend.Unmarshal(m, b)
+}
+func (m *Backend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backend.Marshal(b, m, deterministic)
+}
+func (m *Backend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backend.Merge(m, src)
+}
+func (m *Backend) XXX_Size() int {
+	return xxx_messageInfo_Backend.Size(m)
+}
+func (m *Backend) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backend proto.InternalMessageInfo
+
+func (m *Backend) GetAllocId() string {
+	if m != nil {
+		return m.AllocId
+	}
+	return ""
+}
+
+func (m *Backend) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Backend) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+// UpsertRoutesRequest is used to create or replace routes.
+type UpsertRoutesRequest struct {
+	// routes is the set of routes to program.
+	Routes               []*Route `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpsertRoutesRequest) Reset()         { *m = UpsertRoutesRequest{} }
+func (m *UpsertRoutesRequest) String() string { return proto.CompactTextString(m) }
+func (*UpsertRoutesRequest) ProtoMessage()    {}
+func (*UpsertRoutesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{8}
+}
+
+func (m *UpsertRoutesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpsertRoutesRequest.Unmarshal(m, b)
+}
+func (m *UpsertRoutesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpsertRoutesRequest.Marshal(b, m, deterministic)
+}
+func (m *UpsertRoutesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpsertRoutesRequest.Merge(m, src)
+}
+func (m *UpsertRoutesRequest) XXX_Size() int {
+	return xxx_messageInfo_UpsertRoutesRequest.Size(m)
+}
+func (m *UpsertRoutesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpsertRoutesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpsertRoutesRequest proto.InternalMessageInfo
+
+func (m *UpsertRoutesRequest) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+// UpsertRoutesResponse is returned once the routes have been programmed.
+type UpsertRoutesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpsertRoutesResponse) Reset()         { *m = UpsertRoutesResponse{} }
+func (m *UpsertRoutesResponse) String() string { return proto.CompactTextString(m) }
+func (*UpsertRoutesResponse) ProtoMessage()    {}
+func (*UpsertRoutesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{9}
+}
+
+func (m *UpsertRoutesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpsertRoutesResponse.Unmarshal(m, b)
+}
+func (m *UpsertRoutesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpsertRoutesResponse.Marshal(b, m, deterministic)
+}
+func (m *UpsertRoutesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpsertRoutesResponse.Merge(m, src)
+}
+func (m *UpsertRoutesResponse) XXX_Size() int {
+	return xxx_messageInfo_UpsertRoutesResponse.Size(m)
+}
+func (m *UpsertRoutesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpsertRoutesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpsertRoutesResponse proto.InternalMessageInfo
+
+// DeleteRoutesRequest is used to remove routes.
+type DeleteRoutesRequest struct {
+	// route_ids is the set of route IDs to remove.
+	RouteIds             []string `protobuf:"bytes,1,rep,name=route_ids,json=routeIds,proto3" json:"route_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRoutesRequest) Reset()         { *m = DeleteRoutesRequest{} }
+func (m *DeleteRoutesRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRoutesRequest) ProtoMessage()    {}
+func (*DeleteRoutesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{10}
+}
+
+func (m *DeleteRoutesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRoutesRequest.Unmarshal(m, b)
+}
+func (m *DeleteRoutesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRoutesRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRoutesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRoutesRequest.Merge(m, src)
+}
+func (m *DeleteRoutesRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRoutesRequest.Size(m)
+}
+func (m *DeleteRoutesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRoutesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRoutesRequest proto.InternalMessageInfo
+
+func (m *DeleteRoutesRequest) GetRouteIds() []string {
+	if m != nil {
+		return m.RouteIds
+	}
+	return nil
+}
+
+// DeleteRoutesResponse is returned once the routes have been removed.
+type DeleteRoutesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRoutesResponse) Reset()         { *m = DeleteRoutesResponse{} }
+func (m *DeleteRoutesResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteRoutesResponse) ProtoMessage()    {}
+func (*DeleteRoutesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{11}
+}
+
+func (m *DeleteRoutesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRoutesResponse.Unmarshal(m, b)
+}
+func (m *DeleteRoutesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRoutesResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteRoutesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRoutesResponse.Merge(m, src)
+}
+func (m *DeleteRoutesResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteRoutesResponse.Size(m)
+}
+func (m *DeleteRoutesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRoutesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRoutesResponse proto.InternalMessageInfo
+
+// RegisterTargetsRequest is used to add targets to a target group.
+type RegisterTargetsRequest struct {
+	// target_group is the identity of the target group or pool on the
+	// external load balancer.
+	TargetGroup string `protobuf:"bytes,1,opt,name=target_group,json=targetGroup,proto3" json:"target_group,omitempty"`
+	// targets is the set of addresses to register.
+	Targets              []*Backend `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RegisterTargetsRequest) Reset()         { *m = RegisterTargetsRequest{} }
+func (m *RegisterTargetsRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterTargetsRequest) ProtoMessage()    {}
+func (*RegisterTargetsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{12}
+}
+
+func (m *RegisterTargetsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterTargetsRequest.Unmarshal(m, b)
+}
+func (m *RegisterTargetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterTargetsRequest.Marshal(b, m, deterministic)
+}
+func (m *RegisterTargetsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterTargetsRequest.Merge(m, src)
+}
+func (m *RegisterTargetsRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterTargetsRequest.Size(m)
+}
+func (m *RegisterTargetsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterTargetsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterTargetsRequest proto.InternalMessageInfo
+
+func (m *RegisterTargetsRequest) GetTargetGroup() string {
+	if m != nil {
+		return m.TargetGroup
+	}
+	return ""
+}
+
+func (m *RegisterTargetsRequest) GetTargets() []*Backend {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+// RegisterTargetsResponse is returned once the targets have been registered.
+type RegisterTargetsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterTargetsResponse) Reset()         { *m = RegisterTargetsResponse{} }
+func (m *RegisterTargetsResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterTargetsResponse) ProtoMessage()    {}
+func (*RegisterTargetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{13}
+}
+
+func (m *RegisterTargetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterTargetsResponse.Unmarshal(m, b)
+}
+func (m *RegisterTargetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterTargetsResponse.Marshal(b, m, deterministic)
+}
+func (m *RegisterTargetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterTargetsResponse.Merge(m, src)
+}
+func (m *RegisterTargetsResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterTargetsResponse.Size(m)
+}
+func (m *RegisterTargetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterTargetsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterTargetsResponse proto.InternalMessageInfo
+
+// DeregisterTargetsRequest is used to remove targets from a target group.
+type DeregisterTargetsRequest struct {
+	// target_group is the identity of the target group or pool on the
+	// external load balancer.
+	TargetGroup string `protobuf:"bytes,1,opt,name=target_group,json=targetGroup,proto3" json:"target_group,omitempty"`
+	// targets is the set of addresses to deregister.
+	Targets []*Backend `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	// drain_timeout_ms is how long the load balancer should keep serving
+	// in-flight connections to the targets before removing them.
+	DrainTimeoutMs       int64    `protobuf:"varint,3,opt,name=drain_timeout_ms,json=drainTimeoutMs,proto3" json:"drain_timeout_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeregisterTargetsRequest) Reset()         { *m = DeregisterTargetsRequest{} }
+func (m *DeregisterTargetsRequest) String() string { return proto.CompactTextString(m) }
+func (*DeregisterTargetsRequest) ProtoMessage()    {}
+func (*DeregisterTargetsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{14}
+}
+
+func (m *DeregisterTargetsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeregisterTargetsRequest.Unmarshal(m, b)
+}
+func (m *DeregisterTargetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeregisterTargetsRequest.Marshal(b, m, deterministic)
+}
+func (m *DeregisterTargetsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeregisterTargetsRequest.Merge(m, src)
+}
+func (m *DeregisterTargetsRequest) XXX_Size() int {
+	return xxx_messageInfo_DeregisterTargetsRequest.Size(m)
+}
+func (m *DeregisterTargetsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeregisterTargetsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeregisterTargetsRequest proto.InternalMessageInfo
+
+func (m *DeregisterTargetsRequest) GetTargetGroup() string {
+	if m != nil {
+		return m.TargetGroup
+	}
+	return ""
+}
+
+func (m *DeregisterTargetsRequest) GetTargets() []*Backend {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *DeregisterTargetsRequest) GetDrainTimeoutMs() int64 {
+	if m != nil {
+		return m.DrainTimeoutMs
+	}
+	return 0
+}
+
+// DeregisterTargetsResponse is returned once the targets have been
+// deregistered.
+type DeregisterTargetsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeregisterTargetsResponse) Reset()         { *m = DeregisterTargetsResponse{} }
+func (m *DeregisterTargetsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeregisterTargetsResponse) ProtoMessage()    {}
+func (*DeregisterTargetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93891853207ed251, []int{15}
+}
+
+func (m *DeregisterTargetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeregisterTargetsResponse.Unmarshal(m, b)
+}
+func (m *DeregisterTargetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeregisterTargetsResponse.Marshal(b, m, deterministic)
+}
+func (m *DeregisterTargetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeregisterTargetsResponse.Merge(m, src)
+}
+func (m *DeregisterTargetsResponse) XXX_Size() int {
+	return xxx_messageInfo_DeregisterTargetsResponse.Size(m)
+}
+func (m *DeregisterTargetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeregisterTargetsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeregisterTargetsResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*ProbeRequest)(nil), "hashicorp.nomad.plugins.ingress.v1.ProbeRequest")
+	proto.RegisterType((*ProbeResponse)(nil), "hashicorp.nomad.plugins.ingress.v1.ProbeResponse")
+	proto.RegisterType((*GetInfoRequest)(nil), "hashicorp.nomad.plugins.ingress.v1.GetInfoRequest")
+	proto.RegisterType((*GetInfoResponse)(nil), "hashicorp.nomad.plugins.ingress.v1.GetInfoResponse")
+	proto.RegisterType((*SetConfigRequest)(nil), "hashicorp.nomad.plugins.ingress.v1.SetConfigRequest")
+	proto.RegisterType((*SetConfigResponse)(nil), "hashicorp.nomad.plugins.ingress.v1.SetConfigResponse")
+	proto.RegisterType((*Route)(nil), "hashicorp.nomad.plugins.ingress.v1.Route")
+	proto.RegisterType((*Backend)(nil), "hashicorp.nomad.plugins.ingress.v1.Backend")
+	proto.RegisterType((*UpsertRoutesRequest)(nil), "hashicorp.nomad.plugins.ingress.v1.UpsertRoutesRequest")
+	proto.RegisterType((*UpsertRoutesResponse)(nil), "hashicorp.nomad.plugins.ingress.v1.UpsertRoutesResponse")
+	proto.RegisterType((*DeleteRoutesRequest)(nil), "hashicorp.nomad.plugins.ingress.v1.DeleteRoutesRequest")
+	proto.RegisterType((*DeleteRoutesResponse)(nil), "hashicorp.nomad.plugins.ingress.v1.DeleteRoutesResponse")
+	proto.RegisterType((*RegisterTargetsRequest)(nil), "hashicorp.nomad.plugins.ingress.v1.RegisterTargetsRequest")
+	proto.RegisterType((*RegisterTargetsResponse)(nil), "hashicorp.nomad.plugins.ingress.v1.RegisterTargetsResponse")
+	proto.RegisterType((*DeregisterTargetsRequest)(nil), "hashicorp.nomad.plugins.ingress.v1.DeregisterTargetsRequest")
+	proto.RegisterType((*DeregisterTargetsResponse)(nil), "hashicorp.nomad.plugins.ingress.v1.DeregisterTargetsResponse")
+}
+
+func init() {
+	proto.RegisterFile("plugins/ingress/proto/ingress.proto", fileDescriptor_93891853207ed251)
+}
+
+var fileDescriptor_93891853207ed251 = []byte{
+	// 775 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xcf, 0x6f, 0xdb, 0x36,
+	0x14, 0x9e, 0xec, 0xc8, 0xb2, 0x5f, 0x6c, 0xc7, 0x61, 0xb2, 0x4c, 0x71, 0x36, 0xc0, 0xd1, 0x10,
+	0xc0, 0xc3, 0x06, 0x67, 0x71, 0x36, 0x2c, 0xd8, 0x8f, 0x43, 0xb2, 0x0c, 0x81, 0x0f, 0x03, 0x02,
+	0x25, 0x1b, 0x8a, 0x5e, 0x04, 0x59, 0x62, 0x64, 0x26, 0xb6, 0xa8, 0x92, 0xb4, 0x8b, 0xf6, 0xd8,
+	0x5e, 0x7b, 0xea, 0x7f, 0xd0, 0x7f, 0xa0, 0x40, 0xff, 0xc3, 0x42, 0x24, 0xa5, 0xda, 0x89, 0x81,
+	0xca, 0xb9, 0xf4, 0x64, 0xbd, 0x8f, 0xfc, 0xde, 0xf7, 0xf1, 0x91, 0xef, 0xc1, 0xf0, 0x7d, 0x32,
+	0x9e, 0x46, 0x24, 0xe6, 0x87, 0x24, 0x8e, 0x18, 0xe6, 0xfc, 0x30, 0x61, 0x54, 0xd0, 0x2c, 0xea,
+	0xc9, 0x08, 0x39, 0x23, 0x9f, 0x8f, 0x48, 0x40, 0x59, 0xd2, 0x8b, 0xe9, 0xc4, 0x0f, 0x7b, 0x9a,
+	0xd4, 0xcb, 0xb6, 0xcd, 0x8e, 0x9c, 0x26, 0xd4, 0x2f, 0x19, 0x1d, 0x62, 0x17, 0x3f, 0x9b, 0x62,
+	0x2e, 0x9c, 0x03, 0x68, 0xe8, 0x98, 0x27, 0x34, 0xe6, 0x18, 0x6d, 0x83, 0xc9, 0xb0, 0x1f, 0xbe,
+	0xb0, 0x8d, 0x8e, 0xd1, 0xad, 0xba, 0x2a, 0x70, 0x5a, 0xd0, 0xbc, 0xc0, 0x62, 0x10, 0xdf, 0xd0,
+	0x8c, 0x48, 0x61, 0x23, 0x47, 0x34, 0x15, 0xc1, 0x5a, 0xec, 0x4f, 0xb0, 0x64, 0xd6, 0x5c, 0xf9,
+	0x8d, 0x0e, 0xa0, 0x39, 0xc3, 0x71, 0x48, 0x99, 0x37, 0xc3, 0x8c, 0x13, 0x1a, 0xdb, 0x25, 0xb9,
+	0xda, 0x50, 0xe8, 0xff, 0x0a, 0x44, 0xfb, 0x50, 0xf7, 0x13, 0x92, 0xed, 0xe1, 0x76, 0xb9, 0x53,
+	0xee, 0xd6, 0xdc, 0x75, 0x3f, 0x21, 0x7a, 0x07, 0x77, 0x22, 0x68, 0x5d, 0x61, 0xf1, 0x37, 0x8d,
+	0x6f, 0x48, 0xa4, 0x4d, 0xa4, 0xd9, 0x27, 0x3c, 0x4a, 0xfc, 0xe0, 0xce, 0x0b, 0xe4, 0x82, 0xd4,
+	0xae, 0xbb, 0x0d, 0x8d, 0xaa, 0xdd, 0xe8, 0x27, 0x40, 0xaa, 0x14, 0xde, 0x9c, 0x88, 0x36, 0xd2,
+	0x52, 0x2b, 0xa7, 0xb9, 0x92, 0xb3, 0x05, 0x9b, 0x73, 0x42, 0xea, 0x6c, 0xce, 0x87, 0x12, 0x98,
+	0x2e, 0x9d, 0x0a, 0x8c, 0x9a, 0x50, 0x22, 0xa1, 0x3e, 0x63, 0x89, 0x84, 0xe8, 0x5b, 0xa8, 0xa5,
+	0x27, 0xe5, 0x89, 0x1f, 0x60, 0x9d, 0xf3, 0x13, 0x80, 0xbe, 0x86, 0xca, 0x2d, 0x1d, 0x7a, 0x24,
+	0xb4, 0xcb, 0x72, 0xc9, 0xbc, 0xa5, 0xc3, 0x41, 0x88, 0xbe, 0x03, 0x10, 0x3e, 0xbf, 0xf3, 0x22,
+	0x46, 0xa7, 0x89, 0xbd, 0xa6, 0x58, 0x29, 0x72, 0x91, 0x02, 0xc8, 0x06, 0x8b, 0x63, 0x36, 0x23,
+	0x01, 0xb6, 0x4d, 0xb9, 0x96, 0x85, 0xe9, 0xf5, 0x8c, 0x28, 0x17, 0xdc, 0xae, 0xc8, 0x0a, 0xa9,
+	0x20, 0x45, 0x13, 0x5f, 0x8c, 0xb8, 0x6d, 0x29, 0x54, 0x06, 0x52, 0x64, 0xcc, 0x3d, 0x8e, 0x03,
+	0x86, 0x85, 0x5d, 0xd5, 0x22, 0x63, 0x7e, 0x25, 0x01, 0xb4, 0x03, 0x95, 0xe7, 0x98, 0x44, 0x23,
+	0x61, 0xd7, 0x3a, 0x46, 0xd7, 0x74, 0x75, 0x84, 0x2e, 0xa0, 0x3a, 0xf4, 0x83, 0x3b, 0x1c, 0x87,
+	0xdc, 0x86, 0x4e, 0xb9, 0xbb, 0xde, 0xff, 0xb1, 0xf7, 0xf9, 0x97, 0xd5, 0x3b, 0x53, 0x1c, 0x37,
+	0x27, 0x3b, 0x2e, 0x58, 0x1a, 0x44, 0xbb, 0x50, 0xf5, 0xc7, 0x63, 0x1a, 0x78, 0x79, 0xe9, 0x2c,
+	0x19, 0x0f, 0xc2, 0xf4, 0xac, 0x7e, 0x18, 0xa6, 0x59, 0x74, 0xf5, 0xb2, 0x30, 0x7d, 0x4f, 0x09,
+	0x65, 0x42, 0x56, 0xce, 0x74, 0xe5, 0xb7, 0xf3, 0x04, 0xb6, 0xfe, 0x4b, 0x38, 0x66, 0x42, 0x5e,
+	0x06, 0xcf, 0x1e, 0xc2, 0x29, 0x54, 0x98, 0x04, 0x6c, 0x43, 0x3a, 0xfe, 0xa1, 0x88, 0x63, 0x99,
+	0xc2, 0xd5, 0x44, 0x67, 0x07, 0xb6, 0x17, 0x33, 0xeb, 0x9b, 0xef, 0xc3, 0xd6, 0x39, 0x1e, 0x63,
+	0x81, 0x17, 0x15, 0xf7, 0xa0, 0x26, 0x89, 0x1e, 0x09, 0x95, 0x68, 0xcd, 0xad, 0x4a, 0x60, 0x10,
+	0xca, 0x5c, 0x8b, 0x1c, 0x9d, 0xeb, 0x95, 0x01, 0x3b, 0x2e, 0x8e, 0x08, 0x17, 0x98, 0x5d, 0xfb,
+	0x2c, 0xc2, 0x22, 0xcf, 0xb7, 0x0f, 0x75, 0x21, 0x11, 0xfd, 0x26, 0x54, 0x95, 0xd6, 0x15, 0xa6,
+	0x5e, 0xc5, 0x3f, 0x60, 0xa9, 0x30, 0xad, 0xd4, 0xca, 0xf7, 0x92, 0x71, 0x9d, 0x5d, 0xf8, 0xe6,
+	0x81, 0x07, 0xed, 0xef, 0xbd, 0x01, 0xf6, 0x39, 0x66, 0x5f, 0xd8, 0x21, 0xea, 0x42, 0x2b, 0x64,
+	0x3e, 0x89, 0x3d, 0x41, 0x26, 0x98, 0x4e, 0x85, 0x37, 0xe1, 0xf2, 0x11, 0x94, 0xdd, 0xa6, 0xc4,
+	0xaf, 0x15, 0xfc, 0x2f, 0x77, 0xf6, 0x60, 0x77, 0x89, 0x5f, 0x75, 0x9a, 0xfe, 0x3b, 0x0b, 0x1a,
+	0x03, 0x25, 0x73, 0x29, 0x55, 0x51, 0x0c, 0xa6, 0x9c, 0x76, 0xe8, 0xe7, 0x22, 0xbe, 0xe6, 0x07,
+	0x65, 0xfb, 0x68, 0x05, 0x86, 0xae, 0xe6, 0x57, 0x48, 0x80, 0xa5, 0x87, 0x24, 0xea, 0x17, 0xe1,
+	0x2f, 0xce, 0xd8, 0xf6, 0xf1, 0x4a, 0x9c, 0x5c, 0xf5, 0x25, 0xd4, 0xf2, 0x01, 0x86, 0x7e, 0x29,
+	0x92, 0xe3, 0xfe, 0x60, 0x6d, 0xff, 0xba, 0x22, 0x2b, 0xd7, 0x7e, 0x6d, 0x40, 0x7d, 0xbe, 0x8d,
+	0xd0, 0x6f, 0x45, 0x32, 0x2d, 0x69, 0xe9, 0xf6, 0xc9, 0xea, 0xc4, 0x05, 0x17, 0xf3, 0x0d, 0x58,
+	0xcc, 0xc5, 0x92, 0x36, 0x6f, 0x9f, 0xac, 0x4e, 0xcc, 0x5d, 0xbc, 0x31, 0x60, 0xe3, 0x5e, 0xa7,
+	0xa1, 0xdf, 0x0b, 0x0d, 0xa6, 0xa5, 0x0d, 0xd8, 0xfe, 0xe3, 0x51, 0xdc, 0xdc, 0xce, 0x5b, 0x03,
+	0x36, 0x1f, 0x34, 0x0b, 0xfa, 0xb3, 0xd8, 0x01, 0x97, 0xcf, 0x84, 0xf6, 0x5f, 0x8f, 0x64, 0x67,
+	0xa6, 0xce, 0xac, 0xa7, 0xa6, 0xfc, 0xf3, 0x32, 0xac, 0xc8, 0x9f, 0xe3, 0x8f, 0x03, 0x00, 0x65,
+	0x3f, 0xcf, 0x3d, 0xea, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// IngressPluginClient is the client API for IngressPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IngressPluginClient interface {
+	// Probe is used to determine if the controller is ready to accept
+	// requests.
+	Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error)
+	// GetInfo returns the name, version and supported API versions of the
+	// controller.
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// SetConfig is used to send the plugin configuration from the jobspec
+	// to the controller.
+	SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*SetConfigResponse, error)
+	// UpsertRoutes creates or replaces the given routes on the load balancer
+	// managed by the controller.
+	UpsertRoutes(ctx context.Context, in *UpsertRoutesRequest, opts ...grpc.CallOption) (*UpsertRoutesResponse, error)
+	// DeleteRoutes removes the given routes from the load balancer managed
+	// by the controller.
+	DeleteRoutes(ctx context.Context, in *DeleteRoutesRequest, opts ...grpc.CallOption) (*DeleteRoutesResponse, error)
+	// RegisterTargets adds allocation addresses to a target group of an
+	// external load balancer managed by the controller.
+	RegisterTargets(ctx context.Context, in *RegisterTargetsRequest, opts ...grpc.CallOption) (*RegisterTargetsResponse, error)
+	// DeregisterTargets drains and removes allocation addresses from a target
+	// group of an external load balancer managed by the controller.
+	DeregisterTargets(ctx context.Context, in *DeregisterTargetsRequest, opts ...grpc.CallOption) (*DeregisterTargetsResponse, error)
+}
+
+type ingressPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIngressPluginClient(cc grpc.ClientConnInterface) IngressPluginClient {
+	return &ingressPluginClient{cc}
+}
+
+func (c *ingressPluginClient) Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error) {
+	out := new(ProbeResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/Probe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingressPluginClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/GetInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingressPluginClient) SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*SetConfigResponse, error) {
+	out := new(SetConfigResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/SetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingressPluginClient) UpsertRoutes(ctx context.Context, in *UpsertRoutesRequest, opts ...grpc.CallOption) (*UpsertRoutesResponse, error) {
+	out := new(UpsertRoutesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/UpsertRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingressPluginClient) DeleteRoutes(ctx context.Context, in *DeleteRoutesRequest, opts ...grpc.CallOption) (*DeleteRoutesResponse, error) {
+	out := new(DeleteRoutesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/DeleteRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingressPluginClient) RegisterTargets(ctx context.Context, in *RegisterTargetsRequest, opts ...grpc.CallOption) (*RegisterTargetsResponse, error) {
+	out := new(RegisterTargetsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/RegisterTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingressPluginClient) DeregisterTargets(ctx context.Context, in *DeregisterTargetsRequest, opts ...grpc.CallOption) (*DeregisterTargetsResponse, error) {
+	out := new(DeregisterTargetsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/DeregisterTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IngressPluginServer is the server API for IngressPlugin service.
+type IngressPluginServer interface {
+	// Probe is used to determine if the controller is ready to accept
+	// requests.
+	Probe(context.Context, *ProbeRequest) (*ProbeResponse, error)
+	// GetInfo returns the name, version and supported API versions of the
+	// controller.
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// SetConfig is used to send the plugin configuration from the jobspec
+	// to the controller.
+	SetConfig(context.Context, *SetConfigRequest) (*SetConfigResponse, error)
+	// UpsertRoutes creates or replaces the given routes on the load balancer
+	// managed by the controller.
+	UpsertRoutes(context.Context, *UpsertRoutesRequest) (*UpsertRoutesResponse, error)
+	// DeleteRoutes removes the given routes from the load balancer managed
+	// by the controller.
+	DeleteRoutes(context.Context, *DeleteRoutesRequest) (*DeleteRoutesResponse, error)
+	// RegisterTargets adds allocation addresses to a target group of an
+	// external load balancer managed by the controller.
+	RegisterTargets(context.Context, *RegisterTargetsRequest) (*RegisterTargetsResponse, error)
+	// DeregisterTargets drains and removes allocation addresses from a target
+	// group of an external load balancer managed by the controller.
+	DeregisterTargets(context.Context, *DeregisterTargetsRequest) (*DeregisterTargetsResponse, error)
+}
+
+// UnimplementedIngressPluginServer can be embedded to have forward compatible implementations.
+type UnimplementedIngressPluginServer struct {
+}
+
+func (*UnimplementedIngressPluginServer) Probe(ctx context.Context, req *ProbeRequest) (*ProbeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Probe not implemented")
+}
+func (*UnimplementedIngressPluginServer) GetInfo(ctx context.Context, req *GetInfoRequest) (*GetInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (*UnimplementedIngressPluginServer) SetConfig(ctx context.Context, req *SetConfigRequest) (*SetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfig not implemented")
+}
+func (*UnimplementedIngressPluginServer) UpsertRoutes(ctx context.Context, req *UpsertRoutesRequest) (*UpsertRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertRoutes not implemented")
+}
+func (*UnimplementedIngressPluginServer) DeleteRoutes(ctx context.Context, req *DeleteRoutesRequest) (*DeleteRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoutes not implemented")
+}
+func (*UnimplementedIngressPluginServer) RegisterTargets(ctx context.Context, req *RegisterTargetsRequest) (*RegisterTargetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterTargets not implemented")
+}
+func (*UnimplementedIngressPluginServer) DeregisterTargets(ctx context.Context, req *DeregisterTargetsRequest) (*DeregisterTargetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterTargets not implemented")
+}
+
+func RegisterIngressPluginServer(s *grpc.Server, srv IngressPluginServer) {
+	s.RegisterService(&_IngressPlugin_serviceDesc, srv)
+}
+
+func _IngressPlugin_Probe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngressPluginServer).Probe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/Probe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngressPluginServer).Probe(ctx, req.(*ProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngressPlugin_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngressPluginServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngressPluginServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngressPlugin_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngressPluginServer).SetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/SetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngressPluginServer).SetConfig(ctx, req.(*SetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngressPlugin_UpsertRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngressPluginServer).UpsertRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/UpsertRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngressPluginServer).UpsertRoutes(ctx, req.(*UpsertRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngressPlugin_DeleteRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngressPluginServer).DeleteRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/DeleteRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngressPluginServer).DeleteRoutes(ctx, req.(*DeleteRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngressPlugin_RegisterTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngressPluginServer).RegisterTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/RegisterTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngressPluginServer).RegisterTargets(ctx, req.(*RegisterTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngressPlugin_DeregisterTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngressPluginServer).DeregisterTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.ingress.v1.IngressPlugin/DeregisterTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngressPluginServer).DeregisterTargets(ctx, req.(*DeregisterTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _IngressPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.nomad.plugins.ingress.v1.IngressPlugin",
+	HandlerType: (*IngressPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Probe",
+			Handler:    _IngressPlugin_Probe_Handler,
+		},
+		{
+			MethodName: "GetInfo",
+			Handler:    _IngressPlugin_GetInfo_Handler,
+		},
+		{
+			MethodName: "SetConfig",
+			Handler:    _IngressPlugin_SetConfig_Handler,
+		},
+		{
+			MethodName: "UpsertRoutes",
+			Handler:    _IngressPlugin_UpsertRoutes_Handler,
+		},
+		{
+			MethodName: "DeleteRoutes",
+			Handler:    _IngressPlugin_DeleteRoutes_Handler,
+		},
+		{
+			MethodName: "RegisterTargets",
+			Handler:    _IngressPlugin_RegisterTargets_Handler,
+		},
+		{
+			MethodName: "DeregisterTargets",
+			Handler:    _IngressPlugin_DeregisterTargets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugins/ingress/proto/ingress.proto",
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/ingress/proto"
+)
+
+// Controller is the interface implemented by ingress controllers. It is
+// exposed to Nomad over gRPC by Serve or RegisterController.
+type Controller interface {
+	// Probe returns true when the controller is able to program routes.
+	Probe(ctx context.Context) (bool, error)
+
+	// GetInfo returns the name and vendor version of the controller.
+	GetInfo(ctx context.Context) (string, string, error)
+
+	// SetConfig is called with the ingress_plugin configuration of the task.
+	SetConfig(ctx context.Context, config *base.Config) error
+
+	// UpsertRoutes creates or replaces the given routes.
+	UpsertRoutes(ctx context.Context, routes []*Route) error
+
+	// DeleteRoutes removes the routes with the given IDs. Unknown IDs must
+	// be ignored.
+	DeleteRoutes(ctx context.Context, routeIDs []string) error
+
+	// RegisterTargets adds the targets to the target group of an external
+	// load balancer. Registering a known target must be a no-op.
+	RegisterTargets(ctx context.Context, targetGroup string, targets []*Backend) error
+
+	// DeregisterTargets drains the targets for at most drainTimeout and
+	// removes them from the target group. Unknown targets must be ignored.
+	DeregisterTargets(ctx context.Context, targetGroup string, targets []*Backend, drainTimeout time.Duration) error
+}
+
+// ingressPluginServer wraps an ingress controller and exposes it via gRPC.
+type ingressPluginServer struct {
+	impl Controller
+}
+
+// RegisterController registers the controller on the given gRPC server.
+func RegisterController(s *grpc.Server, impl Controller) {
+	proto.RegisterIngressPluginServer(s, &ingressPluginServer{impl: impl})
+}
+
+// Serve is used to serve an ingress controller on the unix socket at the
+// given path. It blocks until the listener is closed.
+func Serve(impl Controller, socketPath string, logger hclog.Logger) error {
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket: %v", err)
+	}
+
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on socket %q: %v", socketPath, err)
+	}
+
+	s := grpc.NewServer()
+	RegisterController(s, impl)
+
+	logger.Info("serving ingress controller", "socket", socketPath)
+	return s.Serve(l)
+}
+
+func (i *ingressPluginServer) Probe(ctx context.Context, req *proto.ProbeRequest) (*proto.ProbeResponse, error) {
+	ready, err := i.impl.Probe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ProbeResponse{Ready: ready}, nil
+}
+
+func (i *ingressPluginServer) GetInfo(ctx context.Context, req *proto.GetInfoRequest) (*proto.GetInfoResponse, error) {
+	name, version, err := i.impl.GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetInfoResponse{
+		Name:          name,
+		VendorVersion: version,
+		ApiVersions:   []string{ApiVersion010},
+	}, nil
+}
+
+func (i *ingressPluginServer) SetConfig(ctx context.Context, req *proto.SetConfigRequest) (*proto.SetConfigResponse, error) {
+	cfg := &base.Config{
+		ApiVersion:   req.GetPluginApiVersion(),
+		PluginConfig: req.GetMsgpackConfig(),
+	}
+
+	if err := i.impl.SetConfig(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	return &proto.SetConfigResponse{}, nil
+}
+
+func (i *ingressPluginServer) UpsertRoutes(ctx context.Context, req *proto.UpsertRoutesRequest) (*proto.UpsertRoutesResponse, error) {
+	routes := convertProtoRoutes(req.GetRoutes())
+	for _, r := range routes {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.impl.UpsertRoutes(ctx, routes); err != nil {
+		return nil, err
+	}
+
+	return &proto.UpsertRoutesResponse{}, nil
+}
+
+func (i *ingressPluginServer) DeleteRoutes(ctx context.Context, req *proto.DeleteRoutesRequest) (*proto.DeleteRoutesResponse, error) {
+	if err := i.impl.DeleteRoutes(ctx, req.GetRouteIds()); err != nil {
+		return nil, err
+	}
+
+	return &proto.DeleteRoutesResponse{}, nil
+}
+
+func (i *ingressPluginServer) RegisterTargets(ctx context.Context, req *proto.RegisterTargetsRequest) (*proto.RegisterTargetsResponse, error) {
+	targets := convertProtoBackends(req.GetTargets())
+	for _, t := range targets {
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.impl.RegisterTargets(ctx, req.GetTargetGroup(), targets); err != nil {
+		return nil, err
+	}
+
+	return &proto.RegisterTargetsResponse{}, nil
+}
+
+func (i *ingressPluginServer) DeregisterTargets(ctx context.Context, req *proto.DeregisterTargetsRequest) (*proto.DeregisterTargetsResponse, error) {
+	targets := convertProtoBackends(req.GetTargets())
+	drainTimeout := time.Duration(req.GetDrainTimeoutMs()) * time.Millisecond
+
+	if err := i.impl.DeregisterTargets(ctx, req.GetTargetGroup(), targets, drainTimeout); err != nil {
+		return nil, err
+	}
+
+	return &proto.DeregisterTargetsResponse{}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ingress
+
+import (
+	"github.com/hashicorp/nomad/plugins/ingress/proto"
+)
+
+// convertStructRoutes converts between a list of struct and proto routes.
+func convertStructRoutes(in []*Route) []*proto.Route {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*proto.Route, 0, len(in))
+	for _, r := range in {
+		out = append(out, convertStructRoute(r))
+	}
+
+	return out
+}
+
+// convertStructRoute converts between a struct and proto route.
+func convertStructRoute(in *Route) *proto.Route {
+	if in == nil {
+		return nil
+	}
+
+	out := &proto.Route{
+		Id:        in.ID,
+		Namespace: in.Namespace,
+		JobId:     in.JobID,
+		TaskGroup: in.TaskGroup,
+		Service:   in.Service,
+		Hosts:     in.Hosts,
+		Paths:     in.Paths,
+		TlsSecret: in.TLSSecret,
+		Weight:    int32(in.Weight),
+	}
+
+	out.Backends = convertStructBackends(in.Backends)
+
+	return out
+}
+
+// convertStructBackends converts between a list of struct and proto
+// backends.
+func convertStructBackends(in []*Backend) []*proto.Backend {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*proto.Backend, 0, len(in))
+	for _, b := range in {
+		if b == nil {
+			continue
+		}
+		out = append(out, &proto.Backend{
+			AllocId: b.AllocID,
+			Address: b.Address,
+			Port:    int32(b.Port),
+		})
+	}
+
+	return out
+}
+
+// convertProtoRoutes converts between a list of proto and struct routes.
+func convertProtoRoutes(in []*proto.Route) []*Route {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*Route, 0, len(in))
+	for _, r := range in {
+		out = append(out, convertProtoRoute(r))
+	}
+
+	return out
+}
+
+// convertProtoRoute converts between a proto and struct route.
+func convertProtoRoute(in *proto.Route) *Route {
+	if in == nil {
+		return nil
+	}
+
+	out := &Route{
+		ID:        in.GetId(),
+		Namespace: in.GetNamespace(),
+		JobID:     in.GetJobId(),
+		TaskGroup: in.GetTaskGroup(),
+		Service:   in.GetService(),
+		Hosts:     in.GetHosts(),
+		Paths:     in.GetPaths(),
+		TLSSecret: in.GetTlsSecret(),
+		Weight:    int(in.GetWeight()),
+	}
+
+	out.Backends = convertProtoBackends(in.GetBackends())
+
+	return out
+}
+
+// convertProtoBackends converts between a list of proto and struct backends.
+func convertProtoBackends(in []*proto.Backend) []*Backend {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*Backend, 0, len(in))
+	for _, b := range in {
+		if b == nil {
+			continue
+		}
+		out = append(out, &Backend{
+			AllocID: b.GetAllocId(),
+			Address: b.GetAddress(),
+			Port:    int(b.GetPort()),
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ingress
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+)
+
+func TestRoute_NilBackend(t *testing.T) {
+	ci.Parallel(t)
+
+	route := &Route{
+		ID: "default/web/group/http",
+		Backends: []*Backend{
+			nil,
+			{AllocID: "alloc1", Address: "10.0.0.1", Port: 8080},
+		},
+	}
+
+	copied := route.Copy()
+	must.Len(t, 2, copied.Backends)
+	must.Nil(t, copied.Backends[0])
+	must.Eq(t, route.Backends[1], copied.Backends[1])
+	must.True(t, route.Backends[1] != copied.Backends[1])
+
+	out := convertStructRoute(route)
+	must.Len(t, 1, out.Backends)
+	must.Eq(t, "alloc1", out.Backends[0].AllocId)
+
+	back := convertProtoRoute(out)
+	must.Len(t, 1, back.Backends)
+	must.Eq(t, route.Backends[1], back.Backends[0])
+}
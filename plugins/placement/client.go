@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+import (
+	"context"
+
+	"github.com/LK4D4/joincontext"
+	"github.com/hashicorp/nomad/helper/pluginutils/grpcutils"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/placement/proto"
+)
+
+// placementPluginClient implements the client side of a remote placement
+// plugin, using gRPC to communicate to the remote plugin.
+type placementPluginClient struct {
+	// basePluginClient is embedded to give access to the base plugin methods.
+	*base.BasePluginClient
+
+	client proto.PlacementPluginClient
+
+	// doneCtx is closed when the plugin exits
+	doneCtx context.Context
+}
+
+// Capabilities returns the extension points implemented by the plugin.
+func (p *placementPluginClient) Capabilities(ctx context.Context) (*Capabilities, error) {
+	// Join the passed context and the shutdown context
+	joinedCtx, _ := joincontext.Join(ctx, p.doneCtx)
+
+	resp, err := p.client.Capabilities(joinedCtx, &proto.CapabilitiesRequest{})
+	if err != nil {
+		return nil, grpcutils.HandleReqCtxGrpcErr(err, ctx, p.doneCtx)
+	}
+
+	return &Capabilities{
+		Feasibility: resp.GetFeasibility(),
+		Scoring:     resp.GetScoring(),
+	}, nil
+}
+
+// Feasible returns whether the task group may be placed on the node.
+func (p *placementPluginClient) Feasible(ctx context.Context, req *PlacementRequest) (*FeasibleResponse, error) {
+	joinedCtx, _ := joincontext.Join(ctx, p.doneCtx)
+
+	resp, err := p.client.Feasible(joinedCtx, convertStructPlacementRequest(req))
+	if err != nil {
+		return nil, grpcutils.HandleReqCtxGrpcErr(err, ctx, p.doneCtx)
+	}
+
+	return &FeasibleResponse{
+		Feasible: resp.GetFeasible(),
+		Reason:   resp.GetReason(),
+	}, nil
+}
+
+// Score returns the preference of the plugin for placing the task group on
+// the node.
+func (p *placementPluginClient) Score(ctx context.Context, req *PlacementRequest) (*ScoreResponse, error) {
+	joinedCtx, _ := joincontext.Join(ctx, p.doneCtx)
+
+	resp, err := p.client.Score(joinedCtx, convertStructPlacementRequest(req))
+	if err != nil {
+		return nil, grpcutils.HandleReqCtxGrpcErr(err, ctx, p.doneCtx)
+	}
+
+	return &ScoreResponse{
+		Score: resp.GetScore(),
+	}, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+type CapabilitiesFn func(context.Context) (*Capabilities, error)
+type FeasibleFn func(context.Context, *PlacementRequest) (*FeasibleResponse, error)
+type ScoreFn func(context.Context, *PlacementRequest) (*ScoreResponse, error)
+
+// MockPlacementPlugin is used for testing.
+// Each function can be set as a closure to make assertions about how data
+// is passed through the base plugin layer.
+type MockPlacementPlugin struct {
+	*base.MockPlugin
+	CapabilitiesF CapabilitiesFn
+	FeasibleF     FeasibleFn
+	ScoreF        ScoreFn
+}
+
+func (p *MockPlacementPlugin) Capabilities(ctx context.Context) (*Capabilities, error) {
+	return p.CapabilitiesF(ctx)
+}
+
+func (p *MockPlacementPlugin) Feasible(ctx context.Context, req *PlacementRequest) (*FeasibleResponse, error) {
+	return p.FeasibleF(ctx, req)
+}
+
+func (p *MockPlacementPlugin) Score(ctx context.Context, req *PlacementRequest) (*ScoreResponse, error) {
+	return p.ScoreF(ctx, req)
+}
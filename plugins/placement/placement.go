@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// PlacementPlugin is the interface for a plugin that extends the scheduler
+// with site-specific placement logic. Servers call the plugin while scheduling
+// to filter out the nodes a task group can't be placed on, and to score the
+// remaining ones.
+type PlacementPlugin interface {
+	base.BasePlugin
+
+	// Capabilities returns the extension points the plugin implements.
+	Capabilities(ctx context.Context) (*Capabilities, error)
+
+	// Feasible returns whether the task group may be placed on the node.
+	Feasible(ctx context.Context, req *PlacementRequest) (*FeasibleResponse, error)
+
+	// Score returns the preference of the plugin for placing the task group
+	// on the node.
+	Score(ctx context.Context, req *PlacementRequest) (*ScoreResponse, error)
+}
+
+// Capabilities are the extension points implemented by a placement plugin.
+type Capabilities struct {
+	// Feasibility is set if the plugin filters nodes.
+	Feasibility bool
+
+	// Scoring is set if the plugin scores nodes.
+	Scoring bool
+}
+
+// PlacementRequest is the node and task group being evaluated.
+type PlacementRequest struct {
+	Node      *Node
+	TaskGroup *TaskGroup
+}
+
+// Node is the node being evaluated.
+type Node struct {
+	ID         string
+	Name       string
+	Datacenter string
+	NodePool   string
+	NodeClass  string
+	Attributes map[string]string
+	Meta       map[string]string
+}
+
+// TaskGroup is the task group being placed.
+type TaskGroup struct {
+	Namespace string
+	JobID     string
+	JobType   string
+	Name      string
+	Count     int
+
+	// Meta is the meta of the job merged with the meta of the group.
+	Meta map[string]string
+
+	// Drivers is the set of task drivers used by the group.
+	Drivers []string
+}
+
+// FeasibleResponse is the verdict of a plugin on a node.
+type FeasibleResponse struct {
+	Feasible bool
+
+	// Reason is why the node is not feasible, and is reported in the
+	// placement metrics of the allocation.
+	Reason string
+}
+
+// ScoreResponse is the score of a plugin for a node.
+type ScoreResponse struct {
+	// Score is between -1 and 1, negative scores expressing anti-affinity.
+	Score float64
+}
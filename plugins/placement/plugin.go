@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+import (
+	"context"
+
+	log "github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/plugins/base"
+	bproto "github.com/hashicorp/nomad/plugins/base/proto"
+	"github.com/hashicorp/nomad/plugins/placement/proto"
+	"google.golang.org/grpc"
+)
+
+// PluginPlacement wraps a PlacementPlugin and implements go-plugins
+// GRPCPlugin interface to expose the interface over gRPC.
+type PluginPlacement struct {
+	plugin.NetRPCUnsupportedPlugin
+	Impl PlacementPlugin
+}
+
+func (p *PluginPlacement) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterPlacementPluginServer(s, &placementPluginServer{
+		impl:   p.Impl,
+		broker: broker,
+	})
+	return nil
+}
+
+func (p *PluginPlacement) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &placementPluginClient{
+		doneCtx: ctx,
+		client:  proto.NewPlacementPluginClient(c),
+		BasePluginClient: &base.BasePluginClient{
+			Client:  bproto.NewBasePluginClient(c),
+			DoneCtx: ctx,
+		},
+	}, nil
+}
+
+// Serve is used to serve a placement plugin
+func Serve(p PlacementPlugin, logger log.Logger) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: base.Handshake,
+		Plugins: map[string]plugin.Plugin{
+			base.PluginTypeBase:      &base.PluginBase{Impl: p},
+			base.PluginTypePlacement: &PluginPlacement{Impl: p},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+		Logger:     logger,
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/shoenig/test/must"
+)
+
+// testPlacementPlugin dispenses the mock over a gRPC connection.
+func testPlacementPlugin(t *testing.T, mock *MockPlacementPlugin) PlacementPlugin {
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		base.PluginTypeBase:      &base.PluginBase{Impl: mock},
+		base.PluginTypePlacement: &PluginPlacement{Impl: mock},
+	})
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	raw, err := client.Dispense(base.PluginTypePlacement)
+	must.NoError(t, err)
+
+	impl, ok := raw.(PlacementPlugin)
+	must.True(t, ok)
+	return impl
+}
+
+func TestPlacementPlugin_PluginInfo(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := &MockPlacementPlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF: func() (*base.PluginInfoResponse, error) {
+				return &base.PluginInfoResponse{
+					Type:              base.PluginTypePlacement,
+					PluginApiVersions: []string{ApiVersion010},
+					PluginVersion:     "v0.1.0",
+					Name:              "mock_placement",
+				}, nil
+			},
+		},
+	}
+	impl := testPlacementPlugin(t, mock)
+
+	resp, err := impl.PluginInfo()
+	must.NoError(t, err)
+	must.Eq(t, base.PluginTypePlacement, resp.Type)
+	must.Eq(t, "mock_placement", resp.Name)
+	must.Eq(t, []string{ApiVersion010}, resp.PluginApiVersions)
+}
+
+func TestPlacementPlugin_Capabilities(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := &MockPlacementPlugin{
+		CapabilitiesF: func(context.Context) (*Capabilities, error) {
+			return &Capabilities{Scoring: true}, nil
+		},
+	}
+	impl := testPlacementPlugin(t, mock)
+
+	caps, err := impl.Capabilities(context.Background())
+	must.NoError(t, err)
+	must.Eq(t, &Capabilities{Scoring: true}, caps)
+}
+
+func TestPlacementPlugin_Feasible(t *testing.T) {
+	ci.Parallel(t)
+
+	req := &PlacementRequest{
+		Node: &Node{
+			ID:         "node-1",
+			Name:       "client-1",
+			Datacenter: "dc1",
+			NodePool:   "default",
+			NodeClass:  "large",
+			Attributes: map[string]string{"kernel.name": "linux"},
+			Meta:       map[string]string{"rack": "r1"},
+		},
+		TaskGroup: &TaskGroup{
+			Namespace: "default",
+			JobID:     "example",
+			JobType:   "service",
+			Name:      "web",
+			Count:     3,
+			Meta:      map[string]string{"owner": "team-a"},
+			Drivers:   []string{"docker"},
+		},
+	}
+
+	var got *PlacementRequest
+	mock := &MockPlacementPlugin{
+		FeasibleF: func(_ context.Context, req *PlacementRequest) (*FeasibleResponse, error) {
+			got = req
+			if req.Node.Meta["rack"] == "r1" {
+				return &FeasibleResponse{Reason: "rack r1 is reserved"}, nil
+			}
+			return &FeasibleResponse{Feasible: true}, nil
+		},
+	}
+	impl := testPlacementPlugin(t, mock)
+
+	resp, err := impl.Feasible(context.Background(), req)
+	must.NoError(t, err)
+	must.False(t, resp.Feasible)
+	must.Eq(t, "rack r1 is reserved", resp.Reason)
+	must.Eq(t, req, got)
+
+	// Errors are passed through.
+	mock.FeasibleF = func(context.Context, *PlacementRequest) (*FeasibleResponse, error) {
+		return nil, errors.New("inventory unavailable")
+	}
+	_, err = impl.Feasible(context.Background(), req)
+	must.ErrorContains(t, err, "inventory unavailable")
+}
+
+func TestPlacementPlugin_Score(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := &MockPlacementPlugin{
+		ScoreF: func(_ context.Context, req *PlacementRequest) (*ScoreResponse, error) {
+			if req.Node.ID == "node-1" {
+				return &ScoreResponse{Score: 0.5}, nil
+			}
+			return &ScoreResponse{Score: -1}, nil
+		},
+	}
+	impl := testPlacementPlugin(t, mock)
+
+	resp, err := impl.Score(context.Background(), &PlacementRequest{Node: &Node{ID: "node-1"}})
+	must.NoError(t, err)
+	must.Eq(t, 0.5, resp.Score)
+
+	resp, err = impl.Score(context.Background(), &PlacementRequest{Node: &Node{ID: "node-2"}})
+	must.NoError(t, err)
+	must.Eq(t, -1, resp.Score)
+}
@@ -0,0 +1,642 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: plugins/placement/proto/placement.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// CapabilitiesRequest is used to request the capabilities of the plugin.
+type CapabilitiesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CapabilitiesRequest) Reset()         { *m = CapabilitiesRequest{} }
+func (m *CapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesRequest) ProtoMessage()    {}
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_55ec5f01265cf75f, []int{0}
+}
+
+func (m *CapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapabilitiesRequest.Unmarshal(m, b)
+}
+func (m *CapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapabilitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *CapabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesRequest.Merge(m, src)
+}
+func (m *CapabilitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_CapabilitiesRequest.Size(m)
+}
+func (m *CapabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapabilitiesRequest proto.InternalMessageInfo
+
+// CapabilitiesResponse returns the capabilities of the plugin.
+type CapabilitiesResponse struct {
+	// feasibility is true if the plugin filters nodes.
+	Feasibility bool `protobuf:"varint,1,opt,name=feasibility,proto3" json:"feasibility,omitempty"`
+	// scoring is true if the plugin scores nodes.
+	Scoring              bool     `protobuf:"varint,2,opt,name=scoring,proto3" json:"scoring,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CapabilitiesResponse) Reset()         { *m = CapabilitiesResponse{} }
+func (m *CapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesResponse) ProtoMessage()    {}
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_55ec5f01265cf75f, []int{1}
+}
+
+func (m *CapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapabilitiesResponse.Unmarshal(m, b)
+}
+func (m *CapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapabilitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *CapabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesResponse.Merge(m, src)
+}
+func (m *CapabilitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_CapabilitiesResponse.Size(m)
+}
+func (m *CapabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapabilitiesResponse proto.InternalMessageInfo
+
+func (m *CapabilitiesResponse) GetFeasibility() bool {
+	if m != nil {
+		return m.Feasibility
+	}
+	return false
+}
+
+func (m *CapabilitiesResponse) GetScoring() bool {
+	if m != nil {
+		return m.Scoring
+	}
+	return false
+}
+
+// PlacementRequest is the node and task group being evaluated.
+type PlacementRequest struct {
+	Node                 *Node      `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	TaskGroup            *TaskGroup `protobuf:"bytes,2,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PlacementRequest) Reset()         { *m = PlacementRequest{} }
+func (m *PlacementRequest) String() string { return proto.CompactTextString(m) }
+func (*PlacementRequest) ProtoMessage()    {}
+func (*PlacementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_55ec5f01265cf75f, []int{2}
+}
+
+func (m *PlacementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlacementRequest.Unmarshal(m, b)
+}
+func (m *PlacementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlacementRequest.Marshal(b, m, deterministic)
+}
+func (m *PlacementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlacementRequest.Merge(m, src)
+}
+func (m *PlacementRequest) XXX_Size() int {
+	return xxx_messageInfo_PlacementRequest.Size(m)
+}
+func (m *PlacementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlacementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlacementRequest proto.InternalMessageInfo
+
+func (m *PlacementRequest) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *PlacementRequest) GetTaskGroup() *TaskGroup {
+	if m != nil {
+		return m.TaskGroup
+	}
+	return nil
+}
+
+// Node is the node being evaluated.
+type Node struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Datacenter           string            `protobuf:"bytes,3,opt,name=datacenter,proto3" json:"datacenter,omitempty"`
+	NodePool             string            `protobuf:"bytes,4,opt,name=node_pool,json=nodePool,proto3" json:"node_pool,omitempty"`
+	NodeClass            string            `protobuf:"bytes,5,opt,name=node_class,json=nodeClass,proto3" json:"node_class,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Meta                 map[string]string `protobuf:"bytes,7,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_55ec5f01265cf75f, []int{3}
+}
+
+func (m *Node) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node.Unmarshal(m, b)
+}
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+}
+func (m *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(m, src)
+}
+func (m *Node) XXX_Size() int {
+	return xxx_messageInfo_Node.Size(m)
+}
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node proto.InternalMessageInfo
+
+func (m *Node) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Node) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Node) GetDatacenter() string {
+	if m != nil {
+		return m.Datacenter
+	}
+	return ""
+}
+
+func (m *Node) GetNodePool() string {
+	if m != nil {
+		return m.NodePool
+	}
+	return ""
+}
+
+func (m *Node) GetNodeClass() string {
+	if m != nil {
+		return m.NodeClass
+	}
+	return ""
+}
+
+func (m *Node) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Node) GetMeta() map[string]string {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+// TaskGroup is the task group being placed.
+type TaskGroup struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	JobId     string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobType   string `protobuf:"bytes,3,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	Name      string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Count     int32  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// meta is the meta of the job merged with the meta of the group.
+	Meta map[string]string `protobuf:"bytes,6,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// drivers is the set of task drivers used by the group.
+	Drivers              []string `protobuf:"bytes,7,rep,name=drivers,proto3" json:"drivers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskGroup) Reset()         { *m = TaskGroup{} }
+func (m *TaskGroup) String() string { return proto.CompactTextString(m) }
+func (*TaskGroup) ProtoMessage()    {}
+func (*TaskGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_55ec5f01265cf75f, []int{4}
+}
+
+func (m *TaskGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskGroup.Unmarshal(m, b)
+}
+func (m *TaskGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskGroup.Marshal(b, m, deterministic)
+}
+func (m *TaskGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskGroup.Merge(m, src)
+}
+func (m *TaskGroup) XXX_Size() int {
+	return xxx_messageInfo_TaskGroup.Size(m)
+}
+func (m *TaskGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskGroup proto.InternalMessageInfo
+
+func (m *TaskGroup) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *TaskGroup) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *TaskGroup) GetJobType() string {
+	if m != nil {
+		return m.JobType
+	}
+	return ""
+}
+
+func (m *TaskGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TaskGroup) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *TaskGroup) GetMeta() map[string]string {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *TaskGroup) GetDrivers() []string {
+	if m != nil {
+		return m.Drivers
+	}
+	return nil
+}
+
+// FeasibleResponse returns the verdict of the plugin.
+type FeasibleResponse struct {
+	Feasible bool `protobuf:"varint,1,opt,name=feasible,proto3" json:"feasible,omitempty"`
+	// reason is why the node is not feasible, reported in placement metrics.
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeasibleResponse) Reset()         { *m = FeasibleResponse{} }
+func (m *FeasibleResponse) String() string { return proto.CompactTextString(m) }
+func (*FeasibleResponse) ProtoMessage()    {}
+func (*FeasibleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_55ec5f01265cf75f, []int{5}
+}
+
+func (m *FeasibleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeasibleResponse.Unmarshal(m, b)
+}
+func (m *FeasibleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeasibleResponse.Marshal(b, m, deterministic)
+}
+func (m *FeasibleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeasibleResponse.Merge(m, src)
+}
+func (m *FeasibleResponse) XXX_Size() int {
+	return xxx_messageInfo_FeasibleResponse.Size(m)
+}
+func (m *FeasibleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeasibleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeasibleResponse proto.InternalMessageInfo
+
+func (m *FeasibleResponse) GetFeasible() bool {
+	if m != nil {
+		return m.Feasible
+	}
+	return false
+}
+
+func (m *FeasibleResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// ScoreResponse returns the score of the node.
+type ScoreResponse struct {
+	// score is between -1 and 1, negative scores expressing anti-affinity.
+	Score                float64  `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScoreResponse) Reset()         { *m = ScoreResponse{} }
+func (m *ScoreResponse) String() string { return proto.CompactTextString(m) }
+func (*ScoreResponse) ProtoMessage()    {}
+func (*ScoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_55ec5f01265cf75f, []int{6}
+}
+
+func (m *ScoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScoreResponse.Unmarshal(m, b)
+}
+func (m *ScoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScoreResponse.Marshal(b, m, deterministic)
+}
+func (m *ScoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScoreResponse.Merge(m, src)
+}
+func (m *ScoreResponse) XXX_Size() int {
+	return xxx_messageInfo_ScoreResponse.Size(m)
+}
+func (m *ScoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScoreResponse proto.InternalMessageInfo
+
+func (m *ScoreResponse) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*CapabilitiesRequest)(nil), "hashicorp.nomad.plugins.placement.CapabilitiesRequest")
+	proto.RegisterType((*CapabilitiesResponse)(nil), "hashicorp.nomad.plugins.placement.CapabilitiesResponse")
+	proto.RegisterType((*PlacementRequest)(nil), "hashicorp.nomad.plugins.placement.PlacementRequest")
+	proto.RegisterType((*Node)(nil), "hashicorp.nomad.plugins.placement.Node")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.placement.Node.AttributesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.placement.Node.MetaEntry")
+	proto.RegisterType((*TaskGroup)(nil), "hashicorp.nomad.plugins.placement.TaskGroup")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.placement.TaskGroup.MetaEntry")
+	proto.RegisterType((*FeasibleResponse)(nil), "hashicorp.nomad.plugins.placement.FeasibleResponse")
+	proto.RegisterType((*ScoreResponse)(nil), "hashicorp.nomad.plugins.placement.ScoreResponse")
+}
+
+func init() {
+	proto.RegisterFile("plugins/placement/proto/placement.proto", fileDescriptor_55ec5f01265cf75f)
+}
+
+var fileDescriptor_55ec5f01265cf75f = []byte{
+	// 603 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x5d, 0x6b, 0xd4, 0x40,
+	0x14, 0x6d, 0xb2, 0xc9, 0xee, 0xe6, 0xae, 0xda, 0x72, 0xdd, 0x4a, 0x5c, 0x3f, 0xa8, 0x01, 0x69,
+	0x1f, 0x24, 0xd5, 0x2d, 0x58, 0x51, 0x7c, 0xd0, 0xd2, 0x8a, 0x8a, 0x52, 0x62, 0x41, 0xf0, 0xa5,
+	0x4c, 0x92, 0x69, 0x3b, 0x6d, 0x36, 0x33, 0x66, 0x26, 0x85, 0x7d, 0xf4, 0x7f, 0x88, 0x4f, 0xfe,
+	0x4f, 0x25, 0x93, 0x8f, 0xdd, 0x2e, 0x82, 0xd9, 0x3e, 0x75, 0xce, 0xbd, 0x39, 0xe7, 0x4e, 0xcf,
+	0xd9, 0x3b, 0xb0, 0x29, 0x92, 0xfc, 0x94, 0xa5, 0x72, 0x5b, 0x24, 0x24, 0xa2, 0x13, 0x9a, 0xaa,
+	0x6d, 0x91, 0x71, 0xc5, 0x67, 0xd8, 0xd7, 0x18, 0x1f, 0x9d, 0x11, 0x79, 0xc6, 0x22, 0x9e, 0x09,
+	0x3f, 0xe5, 0x13, 0x12, 0xfb, 0x15, 0xd1, 0x6f, 0x3e, 0xf4, 0xd6, 0xe1, 0xf6, 0x1e, 0x11, 0x24,
+	0x64, 0x09, 0x53, 0x8c, 0xca, 0x80, 0x7e, 0xcf, 0xa9, 0x54, 0x5e, 0x00, 0xc3, 0xab, 0x65, 0x29,
+	0x78, 0x2a, 0x29, 0x6e, 0xc0, 0xe0, 0x84, 0x12, 0xc9, 0x74, 0x63, 0xea, 0x1a, 0x1b, 0xc6, 0x56,
+	0x3f, 0x98, 0x2f, 0xa1, 0x0b, 0x3d, 0x19, 0xf1, 0x8c, 0xa5, 0xa7, 0xae, 0xa9, 0xbb, 0x35, 0xf4,
+	0x7e, 0x1a, 0xb0, 0x76, 0x58, 0x0f, 0xae, 0x06, 0xe1, 0x2b, 0xb0, 0x52, 0x1e, 0x53, 0xad, 0x34,
+	0x18, 0x6f, 0xfa, 0xff, 0xbd, 0xb1, 0xff, 0x99, 0xc7, 0x34, 0xd0, 0x24, 0xfc, 0x08, 0xa0, 0x88,
+	0xbc, 0x38, 0x3e, 0xcd, 0x78, 0x2e, 0xf4, 0xb8, 0xc1, 0xf8, 0x49, 0x0b, 0x89, 0x23, 0x22, 0x2f,
+	0xde, 0x15, 0x9c, 0xc0, 0x51, 0xf5, 0xd1, 0xfb, 0xd5, 0x01, 0xab, 0xd0, 0xc6, 0x5b, 0x60, 0xb2,
+	0x58, 0x5f, 0xc8, 0x09, 0x4c, 0x16, 0x23, 0x82, 0x95, 0x92, 0x09, 0xd5, 0xfa, 0x4e, 0xa0, 0xcf,
+	0xf8, 0x10, 0x20, 0x26, 0x8a, 0x44, 0x34, 0x55, 0x34, 0x73, 0x3b, 0xba, 0x33, 0x57, 0xc1, 0x7b,
+	0xe0, 0x14, 0x37, 0x3c, 0x16, 0x9c, 0x27, 0xae, 0xa5, 0xdb, 0xfd, 0xa2, 0x70, 0xc8, 0x79, 0x82,
+	0x0f, 0x00, 0x74, 0x33, 0x4a, 0x88, 0x94, 0xae, 0xad, 0xbb, 0xfa, 0xf3, 0xbd, 0xa2, 0x80, 0x5f,
+	0x01, 0x88, 0x52, 0x19, 0x0b, 0x73, 0x45, 0xa5, 0xdb, 0xdd, 0xe8, 0x6c, 0x0d, 0xc6, 0xbb, 0x2d,
+	0x8d, 0xf1, 0xdf, 0x34, 0xcc, 0xfd, 0x54, 0x65, 0xd3, 0x60, 0x4e, 0x0a, 0xf7, 0xc1, 0x9a, 0x50,
+	0x45, 0xdc, 0x9e, 0x96, 0x7c, 0xd6, 0x56, 0xf2, 0x13, 0x55, 0xa4, 0x14, 0xd3, 0xf4, 0xd1, 0x6b,
+	0x58, 0x5d, 0x98, 0x82, 0x6b, 0xd0, 0xb9, 0xa0, 0xd3, 0xca, 0xb3, 0xe2, 0x88, 0x43, 0xb0, 0x2f,
+	0x49, 0x92, 0xd7, 0xae, 0x95, 0xe0, 0xa5, 0xf9, 0xc2, 0x18, 0xed, 0x82, 0xd3, 0x28, 0x2e, 0x43,
+	0xf4, 0x7e, 0x9b, 0xe0, 0x34, 0xc9, 0xe1, 0x7d, 0x70, 0x8a, 0x24, 0xa4, 0x20, 0x11, 0xad, 0xf8,
+	0xb3, 0x02, 0xae, 0x43, 0xf7, 0x9c, 0x87, 0xc7, 0x2c, 0xae, 0x65, 0xce, 0x79, 0xf8, 0x3e, 0xc6,
+	0xbb, 0xd0, 0x2f, 0xca, 0x6a, 0x2a, 0x68, 0x15, 0x5a, 0xef, 0x9c, 0x87, 0x47, 0x53, 0x41, 0x9b,
+	0x94, 0xad, 0xb9, 0x94, 0x87, 0x60, 0x47, 0x3c, 0x4f, 0x95, 0xce, 0xc8, 0x0e, 0x4a, 0x80, 0x1f,
+	0x2a, 0x1b, 0xcb, 0x64, 0x9e, 0x2f, 0xf3, 0x7b, 0x5b, 0xf4, 0xb2, 0xd8, 0x96, 0x38, 0x63, 0x97,
+	0x34, 0x93, 0x3a, 0x15, 0x27, 0xa8, 0xe1, 0xf5, 0x6d, 0x3a, 0x80, 0xb5, 0x03, 0xbd, 0x8f, 0x09,
+	0x6d, 0xd6, 0x76, 0x04, 0xfd, 0x93, 0xaa, 0x56, 0xed, 0x6c, 0x83, 0xf1, 0x0e, 0x74, 0x33, 0x4a,
+	0x24, 0x4f, 0x2b, 0xa9, 0x0a, 0x79, 0x8f, 0xe1, 0xe6, 0x97, 0x88, 0x67, 0x33, 0x91, 0x21, 0xd8,
+	0xc5, 0x2a, 0x97, 0x0a, 0x46, 0x50, 0x82, 0xf1, 0x1f, 0x13, 0x56, 0x9b, 0xad, 0x3e, 0xd4, 0xff,
+	0x3a, 0xfe, 0x30, 0xe0, 0xc6, 0xfc, 0xf3, 0x81, 0x6d, 0x4c, 0xfa, 0xc7, 0x33, 0x34, 0xda, 0x5d,
+	0x9a, 0x57, 0xde, 0xd5, 0x5b, 0xc1, 0x4b, 0xe8, 0xd7, 0x36, 0xe0, 0x4e, 0x0b, 0x99, 0xc5, 0x97,
+	0x69, 0xd4, 0x86, 0xb4, 0x68, 0xb4, 0xb7, 0x82, 0x02, 0x6c, 0x6d, 0xdb, 0xf5, 0x86, 0x3e, 0x6d,
+	0x41, 0xba, 0x92, 0x8a, 0xb7, 0xf2, 0xb6, 0xf7, 0xcd, 0xd6, 0xcf, 0x7d, 0xd8, 0xd5, 0x7f, 0x76,
+	0xfe, 0x0e, 0x00, 0x29, 0x5f, 0x99, 0x4d, 0x20, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PlacementPluginClient is the client API for PlacementPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PlacementPluginClient interface {
+	// Capabilities returns the extension points implemented by the plugin.
+	Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+	// Feasible returns whether the task group may be placed on the node.
+	Feasible(ctx context.Context, in *PlacementRequest, opts ...grpc.CallOption) (*FeasibleResponse, error)
+	// Score returns the preference of the plugin for placing the task group
+	// on the node.
+	Score(ctx context.Context, in *PlacementRequest, opts ...grpc.CallOption) (*ScoreResponse, error)
+}
+
+type placementPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlacementPluginClient(cc grpc.ClientConnInterface) PlacementPluginClient {
+	return &placementPluginClient{cc}
+}
+
+func (c *placementPluginClient) Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.placement.PlacementPlugin/Capabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *placementPluginClient) Feasible(ctx context.Context, in *PlacementRequest, opts ...grpc.CallOption) (*FeasibleResponse, error) {
+	out := new(FeasibleResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.placement.PlacementPlugin/Feasible", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *placementPluginClient) Score(ctx context.Context, in *PlacementRequest, opts ...grpc.CallOption) (*ScoreResponse, error) {
+	out := new(ScoreResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.placement.PlacementPlugin/Score", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlacementPluginServer is the server API for PlacementPlugin service.
+type PlacementPluginServer interface {
+	// Capabilities returns the extension points implemented by the plugin.
+	Capabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+	// Feasible returns whether the task group may be placed on the node.
+	Feasible(context.Context, *PlacementRequest) (*FeasibleResponse, error)
+	// Score returns the preference of the plugin for placing the task group
+	// on the node.
+	Score(context.Context, *PlacementRequest) (*ScoreResponse, error)
+}
+
+// UnimplementedPlacementPluginServer can be embedded to have forward compatible implementations.
+type UnimplementedPlacementPluginServer struct {
+}
+
+func (*UnimplementedPlacementPluginServer) Capabilities(ctx context.Context, req *CapabilitiesRequest) (*CapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
+func (*UnimplementedPlacementPluginServer) Feasible(ctx context.Context, req *PlacementRequest) (*FeasibleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Feasible not implemented")
+}
+func (*UnimplementedPlacementPluginServer) Score(ctx context.Context, req *PlacementRequest) (*ScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Score not implemented")
+}
+
+func RegisterPlacementPluginServer(s *grpc.Server, srv PlacementPluginServer) {
+	s.RegisterService(&_PlacementPlugin_serviceDesc, srv)
+}
+
+func _PlacementPlugin_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementPluginServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.placement.PlacementPlugin/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementPluginServer).Capabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlacementPlugin_Feasible_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlacementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementPluginServer).Feasible(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.placement.PlacementPlugin/Feasible",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementPluginServer).Feasible(ctx, req.(*PlacementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlacementPlugin_Score_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlacementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementPluginServer).Score(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.placement.PlacementPlugin/Score",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementPluginServer).Score(ctx, req.(*PlacementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PlacementPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.nomad.plugins.placement.PlacementPlugin",
+	HandlerType: (*PlacementPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Capabilities",
+			Handler:    _PlacementPlugin_Capabilities_Handler,
+		},
+		{
+			MethodName: "Feasible",
+			Handler:    _PlacementPlugin_Feasible_Handler,
+		},
+		{
+			MethodName: "Score",
+			Handler:    _PlacementPlugin_Score_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugins/placement/proto/placement.proto",
+}
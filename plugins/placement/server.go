@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/hashicorp/nomad/plugins/placement/proto"
+)
+
+// placementPluginServer wraps a placement plugin and exposes it via gRPC.
+type placementPluginServer struct {
+	broker *plugin.GRPCBroker
+	impl   PlacementPlugin
+}
+
+func (p *placementPluginServer) Capabilities(ctx context.Context, req *proto.CapabilitiesRequest) (*proto.CapabilitiesResponse, error) {
+	caps, err := p.impl.Capabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.CapabilitiesResponse{
+		Feasibility: caps.Feasibility,
+		Scoring:     caps.Scoring,
+	}, nil
+}
+
+func (p *placementPluginServer) Feasible(ctx context.Context, req *proto.PlacementRequest) (*proto.FeasibleResponse, error) {
+	resp, err := p.impl.Feasible(ctx, convertProtoPlacementRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.FeasibleResponse{
+		Feasible: resp.Feasible,
+		Reason:   resp.Reason,
+	}, nil
+}
+
+func (p *placementPluginServer) Score(ctx context.Context, req *proto.PlacementRequest) (*proto.ScoreResponse, error) {
+	resp, err := p.impl.Score(ctx, convertProtoPlacementRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ScoreResponse{
+		Score: resp.Score,
+	}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+import (
+	"github.com/hashicorp/nomad/plugins/placement/proto"
+)
+
+// convertStructPlacementRequest converts a placement request to its protobuf
+// representation.
+func convertStructPlacementRequest(in *PlacementRequest) *proto.PlacementRequest {
+	if in == nil {
+		return nil
+	}
+
+	out := &proto.PlacementRequest{}
+	if n := in.Node; n != nil {
+		out.Node = &proto.Node{
+			Id:         n.ID,
+			Name:       n.Name,
+			Datacenter: n.Datacenter,
+			NodePool:   n.NodePool,
+			NodeClass:  n.NodeClass,
+			Attributes: n.Attributes,
+			Meta:       n.Meta,
+		}
+	}
+	if tg := in.TaskGroup; tg != nil {
+		out.TaskGroup = &proto.TaskGroup{
+			Namespace: tg.Namespace,
+			JobId:     tg.JobID,
+			JobType:   tg.JobType,
+			Name:      tg.Name,
+			Count:     int32(tg.Count),
+			Meta:      tg.Meta,
+			Drivers:   tg.Drivers,
+		}
+	}
+	return out
+}
+
+// convertProtoPlacementRequest converts a protobuf placement request to its
+// struct representation.
+func convertProtoPlacementRequest(in *proto.PlacementRequest) *PlacementRequest {
+	if in == nil {
+		return nil
+	}
+
+	out := &PlacementRequest{}
+	if n := in.GetNode(); n != nil {
+		out.Node = &Node{
+			ID:         n.GetId(),
+			Name:       n.GetName(),
+			Datacenter: n.GetDatacenter(),
+			NodePool:   n.GetNodePool(),
+			NodeClass:  n.GetNodeClass(),
+			Attributes: n.GetAttributes(),
+			Meta:       n.GetMeta(),
+		}
+	}
+	if tg := in.GetTaskGroup(); tg != nil {
+		out.TaskGroup = &TaskGroup{
+			Namespace: tg.GetNamespace(),
+			JobID:     tg.GetJobId(),
+			JobType:   tg.GetJobType(),
+			Name:      tg.GetName(),
+			Count:     int(tg.GetCount()),
+			Meta:      tg.GetMeta(),
+			Drivers:   tg.GetDrivers(),
+		}
+	}
+	return out
+}
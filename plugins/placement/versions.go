@@ -0,0 +1,9 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package placement
+
+const (
+	// ApiVersion010 is the initial API version for the placement plugins
+	ApiVersion010 = "v0.1.0"
+)
@@ -32,6 +32,7 @@ const (
 	FilterConstraintDrivers                        = "missing drivers"
 	FilterConstraintDevices                        = "missing devices"
 	FilterConstraintsCSIPluginTopology             = "did not meet topology requirement"
+	FilterConstraintMigratedNode                   = "allocation migrated away from node"
 )
 
 var (
@@ -748,6 +749,102 @@ func (iter *DistinctPropertyIterator) Reset() {
 	}
 }
 
+// JobAffinityConstraintIterator is a FeasibleIterator which returns nodes that
+// satisfy the required job affinities of the task group. Nodes not affine to a
+// required affinity, or affine to a required anti-affinity, are filtered.
+type JobAffinityConstraintIterator struct {
+	ctx    Context
+	source FeasibleIterator
+	tg     *structs.TaskGroup
+	job    *structs.Job
+
+	hasJobAffinities bool
+	groupSets        map[string][]*jobAffinitySet
+}
+
+// NewJobAffinityConstraintIterator creates a JobAffinityConstraintIterator
+// from a source.
+func NewJobAffinityConstraintIterator(ctx Context, source FeasibleIterator) *JobAffinityConstraintIterator {
+	return &JobAffinityConstraintIterator{
+		ctx:       ctx,
+		source:    source,
+		groupSets: make(map[string][]*jobAffinitySet),
+	}
+}
+
+func (iter *JobAffinityConstraintIterator) SetJob(job *structs.Job) {
+	iter.job = job
+	iter.groupSets = make(map[string][]*jobAffinitySet)
+}
+
+func (iter *JobAffinityConstraintIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+
+	// Build the sets of the required job affinities of the task group
+	if _, ok := iter.groupSets[tg.Name]; !ok {
+		sets := []*jobAffinitySet{}
+		for _, affinity := range tg.JobAffinities {
+			if affinity.Required {
+				sets = append(sets, newJobAffinitySet(iter.ctx, iter.job, affinity))
+			}
+		}
+		iter.groupSets[tg.Name] = sets
+	}
+
+	iter.hasJobAffinities = len(iter.groupSets[tg.Name]) != 0
+}
+
+func (iter *JobAffinityConstraintIterator) Next() *structs.Node {
+	for {
+		// Get the next option from the source
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || !iter.hasJobAffinities {
+			return option
+		}
+
+		if !iter.satisfiesJobAffinities(option) {
+			continue
+		}
+
+		return option
+	}
+}
+
+// satisfiesJobAffinities returns whether the option satisfies the required job
+// affinities. If not it will be filtered.
+func (iter *JobAffinityConstraintIterator) satisfiesJobAffinities(option *structs.Node) bool {
+	for _, set := range iter.groupSets[iter.tg.Name] {
+		affine, errorMsg := set.Affine(option)
+		if errorMsg != "" {
+			iter.ctx.Metrics().FilterNode(option, errorMsg)
+			return false
+		}
+
+		if set.affinity.Weight > 0 && !affine {
+			iter.ctx.Metrics().FilterNode(option, fmt.Sprintf("job_affinity: %s", set.affinity))
+			return false
+		}
+		if set.affinity.Weight < 0 && affine {
+			iter.ctx.Metrics().FilterNode(option, fmt.Sprintf("job_anti_affinity: %s", set.affinity))
+			return false
+		}
+	}
+
+	return true
+}
+
+func (iter *JobAffinityConstraintIterator) Reset() {
+	iter.source.Reset()
+
+	for _, sets := range iter.groupSets {
+		for _, set := range sets {
+			set.PopulateProposed()
+		}
+	}
+}
+
 // ConstraintChecker is a FeasibilityChecker which returns nodes that match a
 // given set of constraints. This is used to filter on job, task group, and task
 // constraints.
@@ -1585,3 +1682,60 @@ func newSemverConstraintParser(ctx Context) verConstraintParser {
 		return constraint
 	}
 }
+
+// PlacementExtensionIterator is a FeasibleIterator which filters the nodes
+// rejected by any of the placement extensions.
+type PlacementExtensionIterator struct {
+	ctx        Context
+	source     FeasibleIterator
+	extensions []PlacementExtension
+	job        *structs.Job
+	tg         *structs.TaskGroup
+}
+
+// NewPlacementExtensionIterator creates a PlacementExtensionIterator from a
+// source.
+func NewPlacementExtensionIterator(ctx Context, source FeasibleIterator) *PlacementExtensionIterator {
+	return &PlacementExtensionIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *PlacementExtensionIterator) SetExtensions(extensions []PlacementExtension) {
+	iter.extensions = extensions
+}
+
+func (iter *PlacementExtensionIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *PlacementExtensionIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+}
+
+func (iter *PlacementExtensionIterator) Next() *structs.Node {
+OUTER:
+	for {
+		option := iter.source.Next()
+		if option == nil || len(iter.extensions) == 0 {
+			return option
+		}
+
+		for _, extension := range iter.extensions {
+			if ok, reason := extension.Feasible(option, iter.job, iter.tg); !ok {
+				if reason == "" {
+					reason = fmt.Sprintf("placement extension %q", extension.Name())
+				}
+				iter.ctx.Metrics().FilterNode(option, reason)
+				continue OUTER
+			}
+		}
+
+		return option
+	}
+}
+
+func (iter *PlacementExtensionIterator) Reset() {
+	iter.source.Reset()
+}
@@ -2492,6 +2492,74 @@ func (c *mockFeasibilityChecker) Feasible(*structs.Node) bool {
 // calls returns how many times the checker was called.
 func (c *mockFeasibilityChecker) calls() int { return c.i }
 
+func TestJobAffinityConstraintIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	for i, n := range nodes {
+		n.Meta["rack"] = fmt.Sprintf("r%d", i/2)
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), n))
+	}
+
+	// Run an app on node 1 and a database on node 3
+	app := mock.Job()
+	app.ID = "app"
+	db := mock.Job()
+	db.Meta = map[string]string{"role": "db"}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 200, nil, app))
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 201, nil, db))
+
+	appAlloc := mock.AllocForNode(nodes[0])
+	appAlloc.Job = app
+	appAlloc.JobID = app.ID
+	dbAlloc := mock.AllocForNode(nodes[2])
+	dbAlloc.Job = db
+	dbAlloc.JobID = db.ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 202, []*structs.Allocation{appAlloc, dbAlloc}))
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	feasibleNodes := func() []*structs.Node {
+		static := NewStaticIterator(ctx, nodes)
+		iter := NewJobAffinityConstraintIterator(ctx, static)
+		iter.SetJob(job)
+		iter.SetTaskGroup(tg)
+		return collectFeasible(iter)
+	}
+
+	// Affinities which are not required are ignored
+	tg.JobAffinities = []*structs.JobAffinity{
+		{JobID: app.ID, Weight: 100},
+	}
+	must.Len(t, 4, feasibleNodes())
+
+	// A required affinity to the app only keeps its node
+	tg.JobAffinities[0].Required = true
+	must.Eq(t, []*structs.Node{nodes[0]}, feasibleNodes())
+
+	// A required anti-affinity to the database filters the nodes of its rack
+	tg.JobAffinities = []*structs.JobAffinity{{
+		Meta:      map[string]string{"role": "db"},
+		Attribute: "${meta.rack}",
+		Weight:    -100,
+		Required:  true,
+	}}
+	ctx.Reset()
+	must.Eq(t, []*structs.Node{nodes[0], nodes[1]}, feasibleNodes())
+	must.Eq(t, 2, ctx.Metrics().ConstraintFiltered["job_anti_affinity: meta[role=db] by ${meta.rack} -100"])
+
+	// Stopping the database frees its rack
+	ctx.Plan().NodeUpdate[nodes[2].ID] = []*structs.Allocation{dbAlloc}
+	must.Len(t, 4, feasibleNodes())
+}
+
 func TestFeasibilityWrapper_JobIneligible(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3128,3 +3196,78 @@ func TestCheckAttributeConstraint(t *testing.T) {
 		}
 	}
 }
+
+// testPlacementExtension is a PlacementExtension calling its functions, if
+// set.
+type testPlacementExtension struct {
+	name     string
+	feasible func(*structs.Node, *structs.Job, *structs.TaskGroup) (bool, string)
+	score    func(*structs.Node, *structs.Job, *structs.TaskGroup) (float64, bool)
+}
+
+func (e *testPlacementExtension) Name() string { return e.name }
+
+func (e *testPlacementExtension) Feasible(node *structs.Node, job *structs.Job, tg *structs.TaskGroup) (bool, string) {
+	if e.feasible == nil {
+		return true, ""
+	}
+	return e.feasible(node, job, tg)
+}
+
+func (e *testPlacementExtension) Score(node *structs.Node, job *structs.Job, tg *structs.TaskGroup) (float64, bool) {
+	if e.score == nil {
+		return 0, false
+	}
+	return e.score(node, job, tg)
+}
+
+func TestPlacementExtensionIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[1].Meta["rack"] = "reserved"
+	nodes[2].Meta["rack"] = "broken"
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	var calls int
+	rack := &testPlacementExtension{
+		name: "rack",
+		feasible: func(node *structs.Node, j *structs.Job, g *structs.TaskGroup) (bool, string) {
+			calls++
+			must.Eq(t, job, j)
+			must.Eq(t, tg, g)
+			switch node.Meta["rack"] {
+			case "reserved":
+				return false, "rack: reserved"
+			case "broken":
+				return false, ""
+			}
+			return true, ""
+		},
+	}
+
+	static := NewStaticIterator(ctx, nodes)
+	iter := NewPlacementExtensionIterator(ctx, static)
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+
+	// Without extensions all the nodes are feasible.
+	must.Len(t, 3, collectFeasible(iter))
+
+	iter.Reset()
+	iter.SetExtensions([]PlacementExtension{rack, &testPlacementExtension{name: "noop"}})
+	out := collectFeasible(iter)
+	must.Eq(t, []*structs.Node{nodes[0]}, out)
+	must.Eq(t, 3, calls)
+	must.Eq(t, map[string]int{
+		"rack: reserved":             1,
+		`placement extension "rack"`: 1,
+	}, ctx.metrics.ConstraintFiltered)
+}
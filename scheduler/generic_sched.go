@@ -170,7 +170,8 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerRebalance:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -281,6 +282,7 @@ func (s *GenericScheduler) process() (bool, error) {
 
 	// Construct the placement stack
 	s.stack = NewGenericStack(s.batch, s.ctx)
+	s.stack.SetPlacementExtensions(placementExtensions(s.planner))
 	if !s.job.Stopped() {
 		s.setJob(s.job)
 	}
@@ -524,6 +526,11 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements of gang task groups, and the allocations they
+	// replace, to roll them back if the group can't be placed entirely.
+	gangPlaced := map[string][]*structs.Allocation{}
+	gangStopped := map[string][]*structs.Allocation{}
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -707,6 +714,13 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				if tg.Gang {
+					gangPlaced[tg.Name] = append(gangPlaced[tg.Name], alloc)
+					if stopPrevAlloc {
+						gangStopped[tg.Name] = append(gangStopped[tg.Name], prevAllocation)
+					}
+				}
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -729,9 +743,35 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	s.rollbackGangPlacements(gangPlaced, gangStopped)
 	return nil
 }
 
+// rollbackGangPlacements removes from the plan the placements of the gang
+// task groups which couldn't be placed entirely, so none of their allocations
+// hold resources until all of them fit. The allocations they replace are kept
+// running.
+func (s *GenericScheduler) rollbackGangPlacements(placed, stopped map[string][]*structs.Allocation) {
+	for tgName, allocs := range placed {
+		metric, ok := s.failedTGAllocs[tgName]
+		if !ok {
+			continue
+		}
+
+		for _, alloc := range allocs {
+			s.plan.RemoveAlloc(alloc)
+		}
+		for _, prev := range stopped[tgName] {
+			s.plan.RemoveUpdate(prev)
+		}
+
+		// The placements rolled back count as failures of the group.
+		metric.CoalescedFailures += len(allocs)
+		s.logger.Debug("failed to place all allocations of gang task group",
+			"task_group", tgName, "placements_rolled_back", len(allocs))
+	}
+}
+
 // setJob updates the stack with the given job and job's node pool scheduler
 // configuration.
 func (s *GenericScheduler) setJob(job *structs.Job) error {
@@ -822,6 +862,14 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 		if prevAllocation.ClientStatus == structs.AllocClientStatusFailed {
 			penaltyNodes[prevAllocation.NodeID] = struct{}{}
 		}
+
+		// If alloc is migrated by the rebalancer, exclude the node it runs
+		// on, as the migration is meant to move the alloc off that node.
+		if prevAllocation.DesiredTransition.ShouldRebalance() {
+			selectOptions.ExcludedNodeIDs = map[string]struct{}{
+				prevAllocation.NodeID: {},
+			}
+		}
 		if prevAllocation.RescheduleTracker != nil {
 			for _, reschedEvent := range prevAllocation.RescheduleTracker.Events {
 				penaltyNodes[reschedEvent.PrevNodeID] = struct{}{}
@@ -1271,6 +1271,104 @@ func TestServiceSched_JobRegister_AllocFail(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_JobAffinity(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 5; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Run an app on one of the nodes
+	app := mock.Job()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, app))
+	appAlloc := mock.AllocForNode(nodes[3])
+	appAlloc.Job = app
+	appAlloc.JobID = app.ID
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{appAlloc}))
+
+	// Create a cache job required to run next to the app
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].JobAffinities = []*structs.JobAffinity{{
+		JobID:    app.ID,
+		Weight:   100,
+		Required: true,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure all the allocations were placed next to the app
+	must.Len(t, 1, h.Plans)
+	must.MapLen(t, 1, h.Plans[0].NodeAllocation)
+	must.Len(t, 3, h.Plans[0].NodeAllocation[nodes[3].ID])
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestServiceSched_JobRegister_PlacementExtensions(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Filter out the first node and prefer the last one
+	h.Extensions = []PlacementExtension{&testPlacementExtension{
+		name: "site",
+		feasible: func(node *structs.Node, _ *structs.Job, _ *structs.TaskGroup) (bool, string) {
+			return node.ID != nodes[0].ID, "site: node is reserved"
+		},
+		score: func(node *structs.Node, _ *structs.Job, _ *structs.TaskGroup) (float64, bool) {
+			if node.ID == nodes[2].ID {
+				return 1, true
+			}
+			return -1, true
+		},
+	}}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	must.MapLen(t, 1, h.Plans[0].NodeAllocation)
+	must.Len(t, 1, h.Plans[0].NodeAllocation[nodes[2].ID])
+
+	alloc := h.Plans[0].NodeAllocation[nodes[2].ID][0]
+	must.Eq(t, 1, alloc.Metrics.ConstraintFiltered["site: node is reserved"])
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 func TestServiceSched_JobRegister_CreateBlockedEval(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5097,6 +5195,75 @@ func TestBatchSched_Run_FailedAllocQueuedAllocations(t *testing.T) {
 	}
 }
 
+func TestBatchSched_Run_GangPlacement(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create 2 nodes fitting 2 allocations each
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a gang job with more allocations than fit
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 5
+	job.TaskGroups[0].Gang = true
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 3000
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+	// Ensure none of the allocations were placed
+	for _, plan := range h.Plans {
+		must.MapEmpty(t, plan.NodeAllocation)
+	}
+	ws := memdb.NewWatchSet()
+	out, err := h.State.AllocsByJob(ws, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.SliceEmpty(t, out)
+
+	// Ensure the evaluation is blocked and all the allocations are reported
+	// as failed
+	must.Len(t, 1, h.CreateEvals)
+	must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+	must.Len(t, 1, h.Evals)
+	outEval := h.Evals[0]
+	must.Eq(t, h.CreateEvals[0].ID, outEval.BlockedEval)
+	must.MapContainsKey(t, outEval.FailedTGAllocs, "web")
+	must.Eq(t, 4, outEval.FailedTGAllocs["web"].CoalescedFailures)
+	must.Eq(t, 5, outEval.QueuedAllocations["web"])
+
+	// Add a node and process the evaluation again
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	h1 := NewHarnessWithState(t, h.State)
+	must.NoError(t, h1.Process(NewBatchScheduler, eval))
+
+	// Ensure all the allocations were placed at once
+	must.Len(t, 1, h1.Plans)
+	var planned []*structs.Allocation
+	for _, allocList := range h1.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 5, planned)
+	must.SliceEmpty(t, h1.CreateEvals)
+	h1.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 func TestBatchSched_ReRun_SuccessfullyFinishedAlloc(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6475,6 +6642,59 @@ func TestServiceSched_Migrate_NonCanary(t *testing.T) {
 
 }
 
+// TestServiceSched_Migrate_Rebalance asserts that allocations migrated by the
+// rebalancer are never placed back on the node they are moved off.
+func TestServiceSched_Migrate_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node1 := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node1))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = node1.ID
+	alloc.Name = "my-job.web[0]"
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.DesiredTransition = structs.DesiredTransition{
+		Migrate:   pointer.Of(true),
+		Rebalance: pointer.Of(true),
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerRebalance,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The only node is excluded, so the allocation isn't placed
+	must.Len(t, 1, h.Plans)
+	must.MapNotContainsKey(t, h.Plans[0].NodeAllocation, node1.ID)
+	must.MapLen(t, 1, h.Evals[0].FailedTGAllocs)
+
+	// The allocation is placed once another node is available
+	node2 := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node2))
+
+	h = NewHarnessWithState(t, h.State)
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.Plans[0].NodeAllocation[node2.ID])
+	must.MapNotContainsKey(t, h.Plans[0].NodeAllocation, node1.ID)
+}
+
 // TestServiceSched_Migrate_CanaryStatus asserts that migrations/rescheduling
 // of allocations use the proper versions of allocs rather than latest:
 // Canaries should be replaced by canaries, and non-canaries should be replaced
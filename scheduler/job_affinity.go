@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobAffinitySet is used to track the nodes running the allocations targeted
+// by a job affinity, by the value of the attribute scoping the affinity.
+type jobAffinitySet struct {
+	// ctx is used to lookup the plan and state
+	ctx Context
+
+	// logger is the logger for the job affinity set
+	logger log.Logger
+
+	// affinity is the job affinity tracked
+	affinity *structs.JobAffinity
+
+	// namespace is the namespace of the targeted jobs
+	namespace string
+
+	// job is the job we are operating on. Its proposed allocations count if
+	// it is targeted by the affinity.
+	job *structs.Job
+
+	// targeted is the set of jobs targeted by the affinity
+	targeted map[string]struct{}
+
+	// errorBuilding marks whether there was an error when building the set
+	errorBuilding error
+
+	// existingValues is a mapping of the values of the attribute to the
+	// number of targeted allocations on nodes with that value.
+	existingValues map[string]uint64
+
+	// proposedValues is a mapping of the values of the attribute to the
+	// number of proposed allocations of the job on nodes with that value, if
+	// the job is targeted.
+	proposedValues map[string]uint64
+
+	// clearedValues is a mapping of the values of the attribute to the number
+	// of targeted allocations stopped or preempted by the plan.
+	clearedValues map[string]uint64
+}
+
+// newJobAffinitySet returns a new job affinity set for the affinity of a task
+// group of the job.
+func newJobAffinitySet(ctx Context, job *structs.Job, affinity *structs.JobAffinity) *jobAffinitySet {
+	s := &jobAffinitySet{
+		ctx:            ctx,
+		logger:         ctx.Logger().Named("job_affinity"),
+		affinity:       affinity,
+		namespace:      affinity.Namespace,
+		job:            job,
+		targeted:       make(map[string]struct{}),
+		existingValues: make(map[string]uint64),
+	}
+	if s.namespace == "" {
+		s.namespace = job.Namespace
+	}
+
+	s.populateExisting()
+	s.PopulateProposed()
+	return s
+}
+
+// populateExisting finds the targeted jobs and populates the existing values
+// from their allocations.
+func (s *jobAffinitySet) populateExisting() {
+	ws := memdb.NewWatchSet()
+
+	var jobs []*structs.Job
+	if s.affinity.JobID != "" {
+		job, err := s.ctx.State().JobByID(ws, s.namespace, s.affinity.JobID)
+		if err != nil {
+			s.setError(fmt.Errorf("failed to get targeted job: %v", err))
+			return
+		}
+		if job != nil {
+			jobs = append(jobs, job)
+		}
+	} else {
+		// Lookup the jobs with one of the meta values using the index, and
+		// check the others against each job.
+		var key string
+		for k := range s.affinity.Meta {
+			if key == "" || k < key {
+				key = k
+			}
+		}
+		iter, err := s.ctx.State().JobsByMeta(ws, key, s.affinity.Meta[key])
+		if err != nil {
+			s.setError(fmt.Errorf("failed to get targeted jobs: %v", err))
+			return
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			job := raw.(*structs.Job)
+			if job.Namespace == s.namespace {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+
+	var allocs []*structs.Allocation
+	for _, job := range jobs {
+		if !s.matchesMeta(job) {
+			continue
+		}
+		s.targeted[job.ID] = struct{}{}
+
+		jobAllocs, err := s.ctx.State().AllocsByJob(ws, s.namespace, job.ID, false)
+		if err != nil {
+			s.setError(fmt.Errorf("failed to get targeted job's allocations: %v", err))
+			return
+		}
+		for _, alloc := range jobAllocs {
+			if !alloc.TerminalStatus() {
+				allocs = append(allocs, alloc)
+			}
+		}
+	}
+
+	if err := s.populateValues(allocs, s.existingValues); err != nil {
+		s.setError(err)
+	}
+}
+
+// PopulateProposed populates the proposed and cleared values. It should be
+// called whenever the plan is updated to ensure correct results when checking
+// an option.
+func (s *jobAffinitySet) PopulateProposed() {
+	s.proposedValues = make(map[string]uint64)
+	s.clearedValues = make(map[string]uint64)
+
+	plan := s.ctx.Plan()
+
+	var cleared []*structs.Allocation
+	for _, nodeAllocs := range []map[string][]*structs.Allocation{plan.NodeUpdate, plan.NodePreemptions} {
+		for _, allocs := range nodeAllocs {
+			for _, alloc := range allocs {
+				if s.isTargeted(alloc) {
+					cleared = append(cleared, alloc)
+				}
+			}
+		}
+	}
+	if err := s.populateValues(cleared, s.clearedValues); err != nil {
+		s.setError(err)
+		return
+	}
+
+	var proposed []*structs.Allocation
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if s.isTargeted(alloc) {
+				proposed = append(proposed, alloc)
+			}
+		}
+	}
+	if err := s.populateValues(proposed, s.proposedValues); err != nil {
+		s.setError(err)
+	}
+}
+
+// Affine returns whether the option is affine, that is whether it runs a
+// targeted allocation or shares the value of the attribute with a node that
+// does. An error message is returned if the set couldn't be built or the
+// option is missing the attribute.
+func (s *jobAffinitySet) Affine(option *structs.Node) (bool, string) {
+	if s.errorBuilding != nil {
+		return false, s.errorBuilding.Error()
+	}
+
+	value, ok := s.scopeValue(option)
+	if !ok {
+		return false, fmt.Sprintf("missing property %q", s.affinity.Attribute)
+	}
+
+	used := s.existingValues[value] + s.proposedValues[value]
+	cleared := s.clearedValues[value]
+	return used > cleared, ""
+}
+
+// matchesMeta returns whether the job has all the meta values of the
+// affinity.
+func (s *jobAffinitySet) matchesMeta(job *structs.Job) bool {
+	for k, v := range s.affinity.Meta {
+		if job.Meta[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// isTargeted returns whether the allocation belongs to a targeted job.
+func (s *jobAffinitySet) isTargeted(alloc *structs.Allocation) bool {
+	if alloc.Namespace != s.namespace {
+		return false
+	}
+	if _, ok := s.targeted[alloc.JobID]; ok {
+		return true
+	}
+
+	// The job being scheduled may be targeted without having been
+	// registered yet, or with meta changed by the new version.
+	if alloc.JobID == s.job.ID && s.job.Namespace == s.namespace {
+		return (s.affinity.JobID == "" || s.affinity.JobID == s.job.ID) && s.matchesMeta(s.job)
+	}
+	return false
+}
+
+// populateValues counts the allocations by the value of the attribute of the
+// node they are on.
+func (s *jobAffinitySet) populateValues(allocs []*structs.Allocation, values map[string]uint64) error {
+	nodes := make(map[string]*structs.Node)
+	ws := memdb.NewWatchSet()
+	for _, alloc := range allocs {
+		node, ok := nodes[alloc.NodeID]
+		if !ok {
+			var err error
+			node, err = s.ctx.State().NodeByID(ws, alloc.NodeID)
+			if err != nil {
+				return fmt.Errorf("failed to lookup node ID %q: %v", alloc.NodeID, err)
+			}
+			nodes[alloc.NodeID] = node
+		}
+		if node == nil {
+			continue
+		}
+
+		if value, ok := s.scopeValue(node); ok {
+			values[value]++
+		}
+	}
+	return nil
+}
+
+// scopeValue returns the value of the attribute scoping the affinity for the
+// node, which is the node ID if the affinity has no attribute.
+func (s *jobAffinitySet) scopeValue(node *structs.Node) (string, bool) {
+	if s.affinity.Attribute == "" {
+		return node.ID, true
+	}
+	return getProperty(node, s.affinity.Attribute)
+}
+
+func (s *jobAffinitySet) setError(err error) {
+	s.errorBuilding = err
+	s.logger.Error("failed to build job affinity set", "affinity", s.affinity.String(), "error", err)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// MaintenanceWindowIterator is a FeasibleIterator which filters nodes that
+// are in, or about to enter, a maintenance window of their own or of their
+// node pool. Only allocations of service jobs are kept off those nodes, since
+// they are expected to be long running and would be drained once the window
+// opens.
+type MaintenanceWindowIterator struct {
+	ctx    Context
+	source FeasibleIterator
+
+	enabled bool
+	now     time.Time
+
+	// poolWindows caches the maintenance windows of the node pools seen.
+	poolWindows map[string][]*structs.MaintenanceWindow
+}
+
+// NewMaintenanceWindowIterator creates a MaintenanceWindowIterator from a
+// source.
+func NewMaintenanceWindowIterator(ctx Context, source FeasibleIterator) *MaintenanceWindowIterator {
+	return &MaintenanceWindowIterator{
+		ctx:         ctx,
+		source:      source,
+		poolWindows: make(map[string][]*structs.MaintenanceWindow),
+	}
+}
+
+func (iter *MaintenanceWindowIterator) SetJob(job *structs.Job) {
+	iter.enabled = job.Type == structs.JobTypeService
+	iter.now = time.Now()
+}
+
+func (iter *MaintenanceWindowIterator) Next() *structs.Node {
+	for {
+		// Get the next option from the source
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || !iter.enabled {
+			return option
+		}
+
+		windows := iter.windows(option)
+		if len(windows) == 0 {
+			return option
+		}
+
+		soon, err := structs.MaintenanceWindowSoon(windows, iter.now)
+		if err != nil {
+			iter.ctx.Logger().Named("maintenance_window").Error(
+				"failed to evaluate maintenance windows", "node_id", option.ID, "error", err)
+			iter.ctx.Metrics().FilterNode(option, fmt.Sprintf("maintenance window: %v", err))
+			continue
+		}
+		if soon {
+			iter.ctx.Metrics().FilterNode(option, "maintenance window")
+			continue
+		}
+
+		return option
+	}
+}
+
+// windows returns the maintenance windows of the node and of its node pool.
+func (iter *MaintenanceWindowIterator) windows(node *structs.Node) []*structs.MaintenanceWindow {
+	poolWindows, ok := iter.poolWindows[node.NodePool]
+	if !ok {
+		pool, err := iter.ctx.State().NodePoolByName(nil, node.NodePool)
+		if err != nil {
+			iter.ctx.Logger().Named("maintenance_window").Error(
+				"failed to lookup node pool", "node_pool", node.NodePool, "error", err)
+		}
+		if pool != nil {
+			poolWindows = pool.MaintenanceWindows
+		}
+		iter.poolWindows[node.NodePool] = poolWindows
+	}
+
+	if len(node.MaintenanceWindows) == 0 {
+		return poolWindows
+	}
+	if len(poolWindows) == 0 {
+		return node.MaintenanceWindows
+	}
+
+	windows := make([]*structs.MaintenanceWindow, 0, len(node.MaintenanceWindows)+len(poolWindows))
+	windows = append(windows, node.MaintenanceWindows...)
+	return append(windows, poolWindows...)
+}
+
+func (iter *MaintenanceWindowIterator) Reset() {
+	iter.source.Reset()
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestMaintenanceWindowIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	// A window that is always open and one that opens in about twelve hours
+	openWindow := &structs.MaintenanceWindow{
+		Name:     "open",
+		Cron:     "* * * * *",
+		Duration: time.Hour,
+	}
+	laterWindow := &structs.MaintenanceWindow{
+		Name:     "later",
+		Cron:     fmt.Sprintf("0 %d * * *", time.Now().UTC().Add(12*time.Hour).Hour()),
+		Duration: time.Hour,
+	}
+
+	pool := mock.NodePool()
+	pool.MaintenanceWindows = []*structs.MaintenanceWindow{openWindow}
+	must.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 100, []*structs.NodePool{pool}))
+
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[1].MaintenanceWindows = []*structs.MaintenanceWindow{openWindow}
+	nodes[2].MaintenanceWindows = []*structs.MaintenanceWindow{laterWindow}
+	nodes[3].NodePool = pool.Name
+
+	feasibleNodes := func(job *structs.Job) []*structs.Node {
+		static := NewStaticIterator(ctx, nodes)
+		iter := NewMaintenanceWindowIterator(ctx, static)
+		iter.SetJob(job)
+		return collectFeasible(iter)
+	}
+
+	// Nodes in an open window of their own or of their pool are filtered
+	must.Eq(t, []*structs.Node{nodes[0], nodes[2]}, feasibleNodes(mock.Job()))
+	must.Eq(t, 2, ctx.Metrics().ConstraintFiltered["maintenance window"])
+
+	// A longer lead filters the node of the later window too
+	nodes[2].MaintenanceWindows = []*structs.MaintenanceWindow{laterWindow.Copy()}
+	nodes[2].MaintenanceWindows[0].Lead = 13 * time.Hour
+	must.Eq(t, []*structs.Node{nodes[0]}, feasibleNodes(mock.Job()))
+
+	// Batch jobs are not affected by maintenance windows
+	must.Len(t, 4, feasibleNodes(mock.BatchJob()))
+}
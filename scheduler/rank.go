@@ -161,7 +161,25 @@ type BinPackIterator struct {
 	jobId                  structs.NamespacedID
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
-	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
+	scoreFit               scoreFitFunc
+
+	// reservations caches the reservations of node pools, which don't
+	// change within the state snapshot of the evaluation.
+	reservations map[string][]*structs.Reservation
+
+	// reservationChecker checks the constraints of reservations.
+	reservationChecker *ConstraintChecker
+}
+
+// scoreFitFunc scores the fit of the resources used on a node, including the
+// device instances used as tracked by the device accounter.
+type scoreFitFunc func(*structs.Node, *structs.ComparableResources, *structs.DeviceAccounter) float64
+
+// ignoreDevices adapts a fit scoring function that does not consider devices.
+func ignoreDevices(fn func(*structs.Node, *structs.ComparableResources) float64) scoreFitFunc {
+	return func(node *structs.Node, util *structs.ComparableResources, _ *structs.DeviceAccounter) float64 {
+		return fn(node, util)
+	}
 }
 
 // NewBinPackIterator returns a BinPackIterator which tries to fit tasks
@@ -176,7 +194,7 @@ func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority i
 		// These are default values that may be overwritten by
 		// SetSchedulerConfiguration.
 		memoryOversubscription: false,
-		scoreFit:               structs.ScoreFitBinPack,
+		scoreFit:               ignoreDevices(structs.ScoreFitBinPack),
 	}
 }
 
@@ -191,12 +209,17 @@ func (iter *BinPackIterator) SetTaskGroup(taskGroup *structs.TaskGroup) {
 
 func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	// Set scoring function.
-	algorithm := schedConfig.EffectiveSchedulerAlgorithm()
-	scoreFn := structs.ScoreFitBinPack
-	if algorithm == structs.SchedulerAlgorithmSpread {
-		scoreFn = structs.ScoreFitSpread
+	switch schedConfig.EffectiveSchedulerAlgorithm() {
+	case structs.SchedulerAlgorithmSpread:
+		iter.scoreFit = ignoreDevices(structs.ScoreFitSpread)
+	case structs.SchedulerAlgorithmWeighted:
+		weights := schedConfig.ResourceWeights.Effective()
+		iter.scoreFit = func(node *structs.Node, util *structs.ComparableResources, devices *structs.DeviceAccounter) float64 {
+			return structs.ScoreFitWeighted(node, util, devices, weights)
+		}
+	default:
+		iter.scoreFit = ignoreDevices(structs.ScoreFitBinPack)
 	}
-	iter.scoreFit = scoreFn
 
 	// Set memory oversubscription.
 	iter.memoryOversubscription = schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled
@@ -219,6 +242,19 @@ OUTER:
 			continue
 		}
 
+		// The capacity reserved on the node for other namespaces is used
+		// from the point of view of the job, so fit it onto a node with the
+		// unused reserved capacity added to its reserved resources.
+		fitNode := option.Node
+		headroom, err := iter.reservedHeadroom(option.Node, proposed)
+		if err != nil {
+			iter.ctx.Logger().Named("binpack").Error("failed retrieving reservations", "error", err)
+			continue
+		}
+		if headroom != nil {
+			fitNode = nodeWithReserved(option.Node, headroom)
+		}
+
 		// Index the existing network usage.
 		// This should never collide, since it represents the current state of
 		// the node. If it does collide though, it means we found a bug! So
@@ -271,7 +307,7 @@ OUTER:
 
 		// Initialize preemptor with node
 		preemptor := NewPreemptor(iter.priority, iter.ctx, &iter.jobId)
-		preemptor.SetNode(option.Node)
+		preemptor.SetNode(fitNode)
 
 		// Count the number of existing preemptions
 		allPreemptions := iter.ctx.Plan().NodePreemptions
@@ -530,8 +566,13 @@ OUTER:
 		proposed = append(proposed, &structs.Allocation{AllocatedResources: total})
 
 		// Check if these allocations fit, if they do not, simply skip this node
-		fit, dim, util, _ := structs.AllocsFit(option.Node, proposed, netIdx, false)
+		fit, dim, util, _ := structs.AllocsFit(fitNode, proposed, netIdx, false)
 		netIdx.Release()
+		if !fit && headroom != nil {
+			if fits, _, _, _ := structs.AllocsFit(option.Node, proposed, nil, false); fits {
+				dim = "reserved capacity"
+			}
+		}
 		if !fit {
 			// Skip the node if evictions are not enabled
 			if !iter.evict {
@@ -560,7 +601,7 @@ OUTER:
 		}
 
 		// Score the fit normally otherwise
-		fitness := iter.scoreFit(option.Node, util)
+		fitness := iter.scoreFit(fitNode, util, devAllocator.DeviceAccounter)
 		normalizedFit := fitness / binPackingMaxFitScore
 		option.Scores = append(option.Scores, normalizedFit)
 		iter.ctx.Metrics().ScoreNode(option.Node, "binpack", normalizedFit)
@@ -652,11 +693,13 @@ func (iter *JobAntiAffinityIterator) Reset() {
 
 // NodeReschedulingPenaltyIterator is used to apply a penalty to
 // a node that had a previous failed allocation for the same job.
-// This is used when attempting to reschedule a failed alloc
+// This is used when attempting to reschedule a failed alloc. Excluded
+// nodes, such as the node an allocation is migrated away from, are filtered.
 type NodeReschedulingPenaltyIterator struct {
-	ctx          Context
-	source       RankIterator
-	penaltyNodes map[string]struct{}
+	ctx           Context
+	source        RankIterator
+	penaltyNodes  map[string]struct{}
+	excludedNodes map[string]struct{}
 }
 
 // NewNodeReschedulingPenaltyIterator is used to create a NodeReschedulingPenaltyIterator that
@@ -673,8 +716,19 @@ func (iter *NodeReschedulingPenaltyIterator) SetPenaltyNodes(penaltyNodes map[st
 	iter.penaltyNodes = penaltyNodes
 }
 
+func (iter *NodeReschedulingPenaltyIterator) SetExcludedNodes(excludedNodes map[string]struct{}) {
+	iter.excludedNodes = excludedNodes
+}
+
 func (iter *NodeReschedulingPenaltyIterator) Next() *RankedNode {
 	option := iter.source.Next()
+	for option != nil {
+		if _, ok := iter.excludedNodes[option.Node.ID]; !ok {
+			break
+		}
+		iter.ctx.Metrics().FilterNode(option.Node, FilterConstraintMigratedNode)
+		option = iter.source.Next()
+	}
 	if option == nil {
 		return nil
 	}
@@ -692,6 +746,7 @@ func (iter *NodeReschedulingPenaltyIterator) Next() *RankedNode {
 
 func (iter *NodeReschedulingPenaltyIterator) Reset() {
 	iter.penaltyNodes = make(map[string]struct{})
+	iter.excludedNodes = make(map[string]struct{})
 	iter.source.Reset()
 }
 
@@ -784,6 +839,153 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// JobAffinityIterator is used to apply a weighted score to nodes according to
+// whether they are affine to the job affinities of the task group, that is
+// whether they run, or share an attribute with a node running, allocations of
+// the targeted jobs.
+type JobAffinityIterator struct {
+	ctx    Context
+	source RankIterator
+	tg     *structs.TaskGroup
+	job    *structs.Job
+
+	hasJobAffinities bool
+	groupSets        map[string][]*jobAffinitySet
+}
+
+// NewJobAffinityIterator is used to create a JobAffinityIterator that applies
+// a weighted score according to the job affinities of the task group which
+// are not required.
+func NewJobAffinityIterator(ctx Context, source RankIterator) *JobAffinityIterator {
+	return &JobAffinityIterator{
+		ctx:       ctx,
+		source:    source,
+		groupSets: make(map[string][]*jobAffinitySet),
+	}
+}
+
+func (iter *JobAffinityIterator) SetJob(job *structs.Job) {
+	iter.job = job
+	iter.groupSets = make(map[string][]*jobAffinitySet)
+}
+
+func (iter *JobAffinityIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+
+	if _, ok := iter.groupSets[tg.Name]; !ok {
+		sets := []*jobAffinitySet{}
+		for _, affinity := range tg.JobAffinities {
+			if !affinity.Required {
+				sets = append(sets, newJobAffinitySet(iter.ctx, iter.job, affinity))
+			}
+		}
+		iter.groupSets[tg.Name] = sets
+	}
+
+	iter.hasJobAffinities = len(iter.groupSets[tg.Name]) != 0
+}
+
+func (iter *JobAffinityIterator) Reset() {
+	iter.source.Reset()
+
+	for _, sets := range iter.groupSets {
+		for _, set := range sets {
+			set.PopulateProposed()
+		}
+	}
+}
+
+func (iter *JobAffinityIterator) hasAffinities() bool {
+	return iter.hasJobAffinities
+}
+
+func (iter *JobAffinityIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || !iter.hasJobAffinities {
+		return option
+	}
+
+	sets := iter.groupSets[iter.tg.Name]
+	sumWeight := 0.0
+	for _, set := range sets {
+		sumWeight += math.Abs(float64(set.affinity.Weight))
+	}
+
+	totalAffinityScore := 0.0
+	for _, set := range sets {
+		affine, errorMsg := set.Affine(option.Node)
+		if errorMsg != "" {
+			iter.ctx.Logger().Named("job_affinity").Debug("error checking job affinity", "affinity", set.affinity.String(), "error", errorMsg)
+			continue
+		}
+		if affine {
+			totalAffinityScore += float64(set.affinity.Weight)
+		}
+	}
+	normScore := totalAffinityScore / sumWeight
+	if totalAffinityScore != 0.0 {
+		option.Scores = append(option.Scores, normScore)
+		iter.ctx.Metrics().ScoreNode(option.Node, "job-affinity", normScore)
+	}
+	return option
+}
+
+// PlacementExtensionScoreIterator is used to apply the scores of the
+// placement extensions to nodes.
+type PlacementExtensionScoreIterator struct {
+	ctx        Context
+	source     RankIterator
+	extensions []PlacementExtension
+	job        *structs.Job
+	tg         *structs.TaskGroup
+}
+
+// NewPlacementExtensionScoreIterator is used to create a
+// PlacementExtensionScoreIterator that appends a score for each placement
+// extension scoring the node.
+func NewPlacementExtensionScoreIterator(ctx Context, source RankIterator) *PlacementExtensionScoreIterator {
+	return &PlacementExtensionScoreIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *PlacementExtensionScoreIterator) SetExtensions(extensions []PlacementExtension) {
+	iter.extensions = extensions
+}
+
+func (iter *PlacementExtensionScoreIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *PlacementExtensionScoreIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+}
+
+func (iter *PlacementExtensionScoreIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *PlacementExtensionScoreIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || len(iter.extensions) == 0 {
+		return option
+	}
+
+	for _, extension := range iter.extensions {
+		score, ok := extension.Score(option.Node, iter.job, iter.tg)
+		if !ok {
+			continue
+		}
+
+		// Scores out of bounds would outweigh the builtin scorers
+		score = math.Max(-1, math.Min(1, score))
+		option.Scores = append(option.Scores, score)
+		iter.ctx.Metrics().ScoreNode(option.Node, extension.Name(), score)
+	}
+	return option
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
@@ -1645,6 +1645,53 @@ func TestBinPackIterator_ExistingAlloc_PlannedEvict(t *testing.T) {
 // allocator properly. It is not intended to handle every possible device
 // request versus availability scenario. That should be covered in device
 // allocator tests.
+// TestBinPackIterator_Weighted asserts that the weighted scheduler algorithm scores
+// nodes with devices lower for task groups that do not use them, while the
+// binpack algorithm only considers CPU and memory.
+func TestBinPackIterator_Weighted(t *testing.T) {
+	plainNode := mock.Node()
+	nvidiaNode := mock.NvidiaNode()
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:      1024,
+					MemoryMB: 1024,
+				},
+			},
+		},
+	}
+
+	score := func(algorithm structs.SchedulerAlgorithm) map[string]float64 {
+		_, ctx := testContext(t)
+		nodes := []*RankedNode{{Node: plainNode}, {Node: nvidiaNode}}
+		static := NewStaticRankIterator(ctx, nodes)
+
+		binp := NewBinPackIterator(ctx, static, false, 0)
+		binp.SetTaskGroup(taskGroup)
+		binp.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+			SchedulerAlgorithm: algorithm,
+		})
+
+		scores := make(map[string]float64)
+		for _, option := range collectRanked(binp) {
+			require.Len(t, option.Scores, 1)
+			scores[option.Node.ID] = option.Scores[0]
+		}
+		require.Len(t, scores, 2)
+		return scores
+	}
+
+	binpack := score(structs.SchedulerAlgorithmBinpack)
+	require.InDelta(t, binpack[plainNode.ID], binpack[nvidiaNode.ID], 0.001)
+
+	weighted := score(structs.SchedulerAlgorithmWeighted)
+	require.Greater(t, weighted[plainNode.ID], weighted[nvidiaNode.ID])
+}
+
 func TestBinPackIterator_Devices(t *testing.T) {
 	nvidiaNode := mock.NvidiaNode()
 	devs := nvidiaNode.NodeResources.Devices[0].Instances
@@ -2220,3 +2267,94 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+func TestJobAffinityIterator(t *testing.T) {
+	state, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+	for i, n := range nodes {
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), n.Node))
+	}
+
+	// Run an app on node 0 and a database on node 1
+	app := mock.Job()
+	app.ID = "app"
+	db := mock.Job()
+	db.ID = "db"
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 200, nil, app))
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 201, nil, db))
+
+	appAlloc := mock.AllocForNode(nodes[0].Node)
+	appAlloc.Job = app
+	appAlloc.JobID = app.ID
+	dbAlloc := mock.AllocForNode(nodes[1].Node)
+	dbAlloc.Job = db
+	dbAlloc.JobID = db.ID
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 202, []*structs.Allocation{appAlloc, dbAlloc}))
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.JobAffinities = []*structs.JobAffinity{
+		{JobID: app.ID, Weight: 100},
+		{JobID: db.ID, Weight: -50},
+		{JobID: "missing", Weight: 50},
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	jobAffinity := NewJobAffinityIterator(ctx, static)
+	jobAffinity.SetJob(job)
+	jobAffinity.SetTaskGroup(tg)
+	scoreNorm := NewScoreNormalizationIterator(ctx, jobAffinity)
+
+	// Total weight = 200
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: 0.5,
+		nodes[1].Node.ID: -0.25,
+		nodes[2].Node.ID: 0,
+	}
+	for _, n := range collectRanked(scoreNorm) {
+		require.Equal(t, expectedScores[n.Node.ID], n.FinalScore)
+	}
+}
+
+func TestPlacementExtensionScoreIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	scores := map[string]float64{
+		nodes[0].Node.ID: 0.5,
+		nodes[1].Node.ID: -5,
+	}
+	extension := &testPlacementExtension{
+		name: "rack",
+		score: func(node *structs.Node, _ *structs.Job, _ *structs.TaskGroup) (float64, bool) {
+			score, ok := scores[node.ID]
+			return score, ok
+		},
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	iter := NewPlacementExtensionScoreIterator(ctx, static)
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+	iter.SetExtensions([]PlacementExtension{extension, &testPlacementExtension{name: "noop"}})
+
+	out := collectRanked(iter)
+	require.Len(t, out, 3)
+
+	// Scores are bounded, and nodes the extension didn't score are left
+	// alone.
+	require.Equal(t, []float64{0.5}, out[0].Scores)
+	require.Equal(t, []float64{-1}, out[1].Scores)
+	require.Empty(t, out[2].Scores)
+}
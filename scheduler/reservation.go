@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// reservedHeadroom returns the resources reserved on the node for namespaces
+// other than the namespace of the job which the allocations of those
+// namespaces don't use, or nil if there are none. Allocations of a namespace
+// consume its reserved resources first, so only the remainder is held back
+// from other namespaces.
+func (iter *BinPackIterator) reservedHeadroom(node *structs.Node, proposed []*structs.Allocation) (*structs.ComparableResources, error) {
+	reservations, err := iter.nodePoolReservations(node.NodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reservations in the built-in all node pool are held by every node.
+	allReservations, err := iter.nodePoolReservations(structs.NodePoolAll)
+	if err != nil {
+		return nil, err
+	}
+	reservations = append(reservations[:len(reservations):len(reservations)], allReservations...)
+	if len(reservations) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	reserved := map[string]*structs.ComparableResources{}
+	for _, reservation := range reservations {
+		if reservation.Namespace == iter.jobId.Namespace || reservation.IsExpired(now) {
+			continue
+		}
+		if !iter.reservationMatches(reservation, node) {
+			continue
+		}
+		if reserved[reservation.Namespace] == nil {
+			reserved[reservation.Namespace] = new(structs.ComparableResources)
+		}
+		reserved[reservation.Namespace].Add(reservation.Resources.Comparable())
+	}
+	if len(reserved) == 0 {
+		return nil, nil
+	}
+
+	headroom := new(structs.ComparableResources)
+	for namespace, res := range reserved {
+		used := new(structs.ComparableResources)
+		for _, alloc := range proposed {
+			if alloc.Namespace == namespace && !alloc.ClientTerminalStatus() {
+				used.Add(alloc.AllocatedResources.Comparable())
+			}
+		}
+
+		headroom.Flattened.Cpu.CpuShares += max(0, res.Flattened.Cpu.CpuShares-used.Flattened.Cpu.CpuShares)
+		headroom.Flattened.Memory.MemoryMB += max(0, res.Flattened.Memory.MemoryMB-used.Flattened.Memory.MemoryMB)
+		headroom.Shared.DiskMB += max(0, res.Shared.DiskMB-used.Shared.DiskMB)
+	}
+	return headroom, nil
+}
+
+// nodePoolReservations returns the reservations held by the nodes of the node
+// pool.
+func (iter *BinPackIterator) nodePoolReservations(pool string) ([]*structs.Reservation, error) {
+	if reservations, ok := iter.reservations[pool]; ok {
+		return reservations, nil
+	}
+
+	rawIter, err := iter.ctx.State().ReservationsByNodePool(nil, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservations []*structs.Reservation
+	for raw := rawIter.Next(); raw != nil; raw = rawIter.Next() {
+		reservations = append(reservations, raw.(*structs.Reservation))
+	}
+
+	if iter.reservations == nil {
+		iter.reservations = make(map[string][]*structs.Reservation)
+	}
+	iter.reservations[pool] = reservations
+	return reservations, nil
+}
+
+// reservationMatches returns whether the node meets the constraints of the
+// reservation.
+func (iter *BinPackIterator) reservationMatches(reservation *structs.Reservation, node *structs.Node) bool {
+	if iter.reservationChecker == nil {
+		iter.reservationChecker = NewConstraintChecker(iter.ctx, nil)
+	}
+	for _, constraint := range reservation.Constraints {
+		if !iter.reservationChecker.meetsConstraint(constraint, node) {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeWithReserved returns a copy of the node with the resources added to its
+// reserved resources.
+func nodeWithReserved(node *structs.Node, res *structs.ComparableResources) *structs.Node {
+	nc := *node
+	reserved := node.ReservedResources.Copy()
+	if reserved == nil {
+		reserved = new(structs.NodeReservedResources)
+	}
+	reserved.Cpu.CpuShares += res.Flattened.Cpu.CpuShares
+	reserved.Memory.MemoryMB += res.Flattened.Memory.MemoryMB
+	reserved.Disk.DiskMB += res.Shared.DiskMB
+	nc.ReservedResources = reserved
+	return &nc
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestBinPackIterator_Reservations(t *testing.T) {
+	ci.Parallel(t)
+
+	// The mock node has 7936 MB of memory available to allocations.
+	node := mock.Node()
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:      100,
+					MemoryMB: 1024,
+				},
+			},
+		},
+	}
+
+	reservation := func(namespace string, memoryMB int) *structs.Reservation {
+		return &structs.Reservation{
+			ID:        uuid.Generate(),
+			Namespace: namespace,
+			NodePool:  structs.NodePoolDefault,
+			Resources: &structs.ReservationResources{MemoryMB: memoryMB},
+		}
+	}
+
+	teamAlloc := func(memoryMB int64) *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Namespace = "team"
+		alloc.NodeID = node.ID
+		alloc.AllocatedResources.Tasks["web"].Memory.MemoryMB = memoryMB
+		return alloc
+	}
+
+	testCases := []struct {
+		name         string
+		namespace    string
+		reservations func() []*structs.Reservation
+		allocs       []*structs.Allocation
+		expectFit    bool
+		expectDim    string
+	}{
+		{
+			name:      "reserved for other namespace",
+			namespace: structs.DefaultNamespace,
+			reservations: func() []*structs.Reservation {
+				return []*structs.Reservation{reservation("team", 7000)}
+			},
+			expectDim: "reserved capacity",
+		},
+		{
+			name:      "reserved for own namespace",
+			namespace: "team",
+			reservations: func() []*structs.Reservation {
+				return []*structs.Reservation{reservation("team", 7000)}
+			},
+			expectFit: true,
+		},
+		{
+			name:      "reservation used by owner",
+			namespace: structs.DefaultNamespace,
+			reservations: func() []*structs.Reservation {
+				return []*structs.Reservation{reservation("team", 6000)}
+			},
+			allocs:    []*structs.Allocation{teamAlloc(2000)},
+			expectFit: true,
+		},
+		{
+			name:      "reservation in all node pool",
+			namespace: structs.DefaultNamespace,
+			reservations: func() []*structs.Reservation {
+				r := reservation("team", 7000)
+				r.NodePool = structs.NodePoolAll
+				return []*structs.Reservation{r}
+			},
+			expectDim: "reserved capacity",
+		},
+		{
+			name:      "constraint not matching node",
+			namespace: structs.DefaultNamespace,
+			reservations: func() []*structs.Reservation {
+				r := reservation("team", 7000)
+				r.Constraints = []*structs.Constraint{{
+					LTarget: "${node.class}",
+					RTarget: "gpu",
+					Operand: "=",
+				}}
+				return []*structs.Reservation{r}
+			},
+			expectFit: true,
+		},
+		{
+			name:      "expired reservation",
+			namespace: structs.DefaultNamespace,
+			reservations: func() []*structs.Reservation {
+				r := reservation("team", 7000)
+				r.ExpirationTime = pointer.Of(time.Now().Add(-time.Minute))
+				return []*structs.Reservation{r}
+			},
+			expectFit: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+			must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 900, node))
+			must.NoError(t, state.UpsertReservations(structs.MsgTypeTestSetup, 1000, tc.reservations()))
+			if len(tc.allocs) > 0 {
+				must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, tc.allocs))
+			}
+
+			job := mock.Job()
+			job.Namespace = tc.namespace
+
+			static := NewStaticRankIterator(ctx, []*RankedNode{{Node: node}})
+			binp := NewBinPackIterator(ctx, static, false, 0)
+			binp.SetJob(job)
+			binp.SetTaskGroup(taskGroup)
+			binp.SetSchedulerConfiguration(testSchedulerConfig)
+
+			out := collectRanked(binp)
+			if tc.expectFit {
+				must.Len(t, 1, out)
+				return
+			}
+			must.SliceEmpty(t, out)
+			must.Eq(t, 1, ctx.metrics.DimensionExhausted[tc.expectDim])
+		})
+	}
+}
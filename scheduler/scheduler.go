@@ -99,6 +99,10 @@ type State interface {
 	// GetJobByID is used to lookup a job by ID
 	JobByID(ws memdb.WatchSet, namespace, id string) (*structs.Job, error)
 
+	// JobsByMeta returns an iterator over the jobs of all namespaces with
+	// the meta key set to the value. The type of each result is *structs.Job
+	JobsByMeta(ws memdb.WatchSet, key, value string) (memdb.ResultIterator, error)
+
 	// DeploymentsByJobID returns the deployments associated with the job
 	DeploymentsByJobID(ws memdb.WatchSet, namespace, jobID string, all bool) ([]*structs.Deployment, error)
 
@@ -118,6 +122,10 @@ type State interface {
 	// CSIVolumeByID fetch CSI volumes, containing controller jobs
 	CSIVolumesByNodeID(memdb.WatchSet, string, string) (memdb.ResultIterator, error)
 
+	// ReservationsByNodePool returns an iterator over the reservations held
+	// by the nodes of the node pool.
+	ReservationsByNodePool(ws memdb.WatchSet, pool string) (memdb.ResultIterator, error)
+
 	// LatestIndex returns the greatest index value for all indexes.
 	LatestIndex() (uint64, error)
 }
@@ -149,3 +157,34 @@ type Planner interface {
 	// servers should be verified.
 	ServersMeetMinimumVersion(minVersion *version.Version, checkFailedServers bool) bool
 }
+
+// PlacementExtension is an external feasibility checker and scorer, such as
+// a placement plugin loaded by the servers, consulted by the stacks after
+// their builtin iterators.
+type PlacementExtension interface {
+	// Name identifies the extension in placement metrics.
+	Name() string
+
+	// Feasible returns whether the task group of the job can be placed on
+	// the node and, if not, the reason reported in placement metrics.
+	Feasible(node *structs.Node, job *structs.Job, tg *structs.TaskGroup) (bool, string)
+
+	// Score returns a score between -1 and 1 for placing the task group of
+	// the job on the node, and false if the extension didn't score the node.
+	Score(node *structs.Node, job *structs.Job, tg *structs.TaskGroup) (float64, bool)
+}
+
+// PlacementExtender is implemented by planners providing placement
+// extensions to the schedulers.
+type PlacementExtender interface {
+	PlacementExtensions() []PlacementExtension
+}
+
+// placementExtensions returns the placement extensions of the planner, if
+// any.
+func placementExtensions(planner Planner) []PlacementExtension {
+	if extender, ok := planner.(PlacementExtender); ok {
+		return extender.PlacementExtensions()
+	}
+	return nil
+}
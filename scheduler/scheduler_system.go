@@ -155,6 +155,7 @@ func (s *SystemScheduler) process() (bool, error) {
 
 	// Construct the placement stack
 	s.stack = NewSystemStack(s.sysbatch, s.ctx)
+	s.stack.SetPlacementExtensions(placementExtensions(s.planner))
 	if !s.job.Stopped() {
 		s.setJob(s.job)
 	}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Simulation runs the schedulers against a state store, such as one restored
+// from a snapshot, to preview the outcome of registering jobs or losing
+// nodes. Plans are applied to the state store without being checked by a
+// plan applier, so the store must not be shared with a live cluster.
+type Simulation struct {
+	logger  log.Logger
+	harness *Harness
+}
+
+// SimulationResult is the outcome of processing an evaluation in a
+// simulation.
+type SimulationResult struct {
+	// Eval is the evaluation processed, with its final status.
+	Eval *structs.Evaluation
+
+	// Placed, Stopped and Preempted are the allocations placed, stopped and
+	// preempted by the scheduler, keyed by node ID.
+	Placed    map[string][]*structs.Allocation
+	Stopped   map[string][]*structs.Allocation
+	Preempted map[string][]*structs.Allocation
+
+	// FailedTGAllocs are the metrics of the task groups that failed to
+	// place allocations, keyed by task group name.
+	FailedTGAllocs map[string]*structs.AllocMetric
+
+	// BlockedEval is set if the scheduler blocked an evaluation waiting for
+	// resources.
+	BlockedEval bool
+}
+
+// NewSimulation returns a simulation applying plans to the state store.
+func NewSimulation(logger log.Logger, store *state.StateStore) (*Simulation, error) {
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, err
+	}
+	return &Simulation{
+		logger: logger.Named("simulation"),
+		harness: &Harness{
+			State:                     store,
+			nextIndex:                 index + 1,
+			serversMeetMinimumVersion: true,
+		},
+	}, nil
+}
+
+// RegisterJob registers a job and evaluates it.
+func (s *Simulation) RegisterJob(job *structs.Job) (*SimulationResult, error) {
+	index := s.harness.NextIndex()
+	if err := s.harness.State.UpsertJob(structs.MsgTypeTestSetup, index, nil, job); err != nil {
+		return nil, fmt.Errorf("failed to register job: %v", err)
+	}
+
+	job, err := s.harness.State.JobByID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.process(job, &structs.Evaluation{
+		TriggeredBy: structs.EvalTriggerJobRegister,
+	})
+}
+
+// RemoveNode marks a node down and evaluates the jobs with allocations on
+// it, sorted by namespace and ID.
+func (s *Simulation) RemoveNode(nodeID string) ([]*SimulationResult, error) {
+	index := s.harness.NextIndex()
+	now := time.Now().UTC().UnixNano()
+	if err := s.harness.State.UpdateNodeStatus(structs.MsgTypeTestSetup, index, nodeID, structs.NodeStatusDown, now, nil); err != nil {
+		return nil, fmt.Errorf("failed to remove node: %v", err)
+	}
+
+	allocs, err := s.harness.State.AllocsByNode(nil, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	jobIDs := make(map[structs.NamespacedID]struct{})
+	for _, alloc := range allocs {
+		if !alloc.TerminalStatus() {
+			jobIDs[alloc.JobNamespacedID()] = struct{}{}
+		}
+	}
+	ids := make([]structs.NamespacedID, 0, len(jobIDs))
+	for id := range jobIDs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].Namespace != ids[j].Namespace {
+			return ids[i].Namespace < ids[j].Namespace
+		}
+		return ids[i].ID < ids[j].ID
+	})
+
+	results := make([]*SimulationResult, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.harness.State.JobByID(nil, id.Namespace, id.ID)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil {
+			continue
+		}
+
+		result, err := s.process(job, &structs.Evaluation{
+			TriggeredBy: structs.EvalTriggerNodeUpdate,
+			NodeID:      nodeID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// process completes an evaluation of the job and runs the scheduler of the
+// job type on it.
+func (s *Simulation) process(job *structs.Job, eval *structs.Evaluation) (*SimulationResult, error) {
+	now := time.Now().UTC().UnixNano()
+	eval.ID = uuid.Generate()
+	eval.Namespace = job.Namespace
+	eval.Priority = job.Priority
+	eval.Type = job.Type
+	eval.JobID = job.ID
+	eval.JobModifyIndex = job.JobModifyIndex
+	eval.Status = structs.EvalStatusPending
+	eval.CreateTime = now
+	eval.ModifyTime = now
+
+	if err := s.harness.State.UpsertEvals(structs.MsgTypeTestSetup, s.harness.NextIndex(), []*structs.Evaluation{eval}); err != nil {
+		return nil, err
+	}
+
+	// Discard the events of the scheduler, which are only relevant to the
+	// workers of a server.
+	eventsCh := make(chan interface{})
+	defer close(eventsCh)
+	go func() {
+		for range eventsCh {
+		}
+	}()
+
+	snap := s.harness.Snapshot()
+	sched, err := NewScheduler(eval.Type, s.logger, eventsCh, snap, s.harness)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, updates, created := len(s.harness.Plans), len(s.harness.Evals), len(s.harness.CreateEvals)
+	if err := sched.Process(eval); err != nil {
+		return nil, err
+	}
+
+	result := &SimulationResult{
+		Eval:           eval,
+		Placed:         make(map[string][]*structs.Allocation),
+		Stopped:        make(map[string][]*structs.Allocation),
+		Preempted:      make(map[string][]*structs.Allocation),
+		FailedTGAllocs: make(map[string]*structs.AllocMetric),
+	}
+	for _, plan := range s.harness.Plans[plans:] {
+		for nodeID, allocs := range plan.NodeAllocation {
+			for _, alloc := range allocs {
+				// In-place updates are not placements.
+				existing, err := snap.AllocByID(nil, alloc.ID)
+				if err != nil {
+					return nil, err
+				}
+				if existing == nil {
+					result.Placed[nodeID] = append(result.Placed[nodeID], alloc)
+				}
+			}
+		}
+		for nodeID, allocs := range plan.NodeUpdate {
+			result.Stopped[nodeID] = append(result.Stopped[nodeID], allocs...)
+		}
+		for nodeID, allocs := range plan.NodePreemptions {
+			result.Preempted[nodeID] = append(result.Preempted[nodeID], allocs...)
+		}
+	}
+	for _, update := range s.harness.Evals[updates:] {
+		if update.ID == eval.ID {
+			result.Eval = update
+			for tg, metrics := range update.FailedTGAllocs {
+				result.FailedTGAllocs[tg] = metrics
+			}
+		}
+	}
+	for _, eval := range s.harness.CreateEvals[created:] {
+		if eval.Status == structs.EvalStatusBlocked {
+			result.BlockedEval = true
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSimulation_RegisterJob(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	sim, err := NewSimulation(testlog.HCLogger(t), store)
+	must.NoError(t, err)
+
+	// The node fits 3 allocations
+	job := mock.Job()
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 2048
+
+	result, err := sim.RegisterJob(job)
+	must.NoError(t, err)
+	must.Len(t, 3, result.Placed[node.ID])
+	must.MapLen(t, 1, result.FailedTGAllocs)
+	must.Eq(t, 0, result.FailedTGAllocs["web"].CoalescedFailures)
+	must.True(t, result.BlockedEval)
+
+	// The placements are applied to the state of the simulation
+	allocs, err := store.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 3, allocs)
+}
+
+func TestSimulation_RemoveNode(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node1, node2 := mock.Node(), mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node1))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 101, node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, job))
+
+	var allocs []*structs.Allocation
+	for i, node := range []*structs.Node{node1, node2} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = structs.AllocName(job.ID, "web", uint(i))
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, allocs))
+
+	sim, err := NewSimulation(testlog.HCLogger(t), store)
+	must.NoError(t, err)
+
+	// The allocation of the removed node is replaced on the other node
+	results, err := sim.RemoveNode(node1.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, results)
+	must.Eq(t, job.ID, results[0].Eval.JobID)
+	must.Len(t, 1, results[0].Placed[node2.ID])
+	must.Len(t, 1, results[0].Stopped[node1.ID])
+	must.MapEmpty(t, results[0].FailedTGAllocs)
+
+	_, err = sim.RemoveNode(uuid.Generate())
+	must.ErrorContains(t, err, "node not found")
+}
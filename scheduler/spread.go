@@ -291,3 +291,219 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	}
 	iter.tgSpreadInfo[tg.Name] = spreadInfos
 }
+
+// MaxSkewIterator is a RankIterator which returns nodes that satisfy the
+// max_skew of the spread blocks of the task group. The spread of allocations
+// across the values of an attribute is skewed by the difference between the
+// number of allocations of the most and least used values, and a node is
+// filtered if placing on it would make the skew greater than max_skew.
+//
+// It ranks after the bin packing so that only the values with a node able to
+// fit the task group bound the skew. Nodes within max_skew of the least used
+// value across all the nodes are returned as they are ranked. The others are
+// deferred until the source is exhausted, and then only returned if they are
+// within max_skew of the least used value with a node able to fit.
+type MaxSkewIterator struct {
+	ctx    Context
+	source RankIterator
+	tg     *structs.TaskGroup
+	job    *structs.Job
+
+	// nodes are the base nodes of the stack
+	nodes []*structs.Node
+
+	// attributeValues is a memoized map from attribute to the set of its
+	// values across the base nodes
+	attributeValues map[string]map[string]struct{}
+
+	// fittingValues is a map from attribute to the set of its values across
+	// the nodes ranked since the last reset, which are able to fit the task
+	// group
+	fittingValues map[string]map[string]struct{}
+
+	// deferred are the ranked nodes exceeding the max_skew against the least
+	// used value across all the nodes, and exhausted whether the source was
+	// exhausted since the last reset
+	deferred  []*RankedNode
+	exhausted bool
+
+	hasMaxSkew        bool
+	groupPropertySets map[string][]*skewPropertySet
+}
+
+// skewPropertySet is the property set of a spread block with a max_skew.
+type skewPropertySet struct {
+	*propertySet
+	maxSkew int
+}
+
+// NewMaxSkewIterator creates a MaxSkewIterator from a source.
+func NewMaxSkewIterator(ctx Context, source RankIterator) *MaxSkewIterator {
+	return &MaxSkewIterator{
+		ctx:               ctx,
+		source:            source,
+		attributeValues:   make(map[string]map[string]struct{}),
+		fittingValues:     make(map[string]map[string]struct{}),
+		groupPropertySets: make(map[string][]*skewPropertySet),
+	}
+}
+
+// SetNodes sets the base nodes of the stack, whose values bound the skew of
+// the nodes returned without waiting for the source to be exhausted.
+func (iter *MaxSkewIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	iter.attributeValues = make(map[string]map[string]struct{})
+}
+
+func (iter *MaxSkewIterator) SetJob(job *structs.Job) {
+	iter.job = job
+
+	// reset the property sets so spreads of an older version of the job
+	// don't apply to the new one
+	iter.groupPropertySets = make(map[string][]*skewPropertySet)
+}
+
+func (iter *MaxSkewIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+
+	// Build the property set at the taskgroup level, including the spreads
+	// of the job
+	if _, ok := iter.groupPropertySets[tg.Name]; !ok {
+		var jobSpreads []*structs.Spread
+		if iter.job != nil {
+			jobSpreads = iter.job.Spreads
+		}
+
+		sets := []*skewPropertySet{}
+		for _, spreads := range [][]*structs.Spread{jobSpreads, tg.Spreads} {
+			for _, spread := range spreads {
+				if spread.MaxSkew <= 0 {
+					continue
+				}
+
+				pset := NewPropertySet(iter.ctx, iter.job)
+				pset.SetTargetValues(nil)
+				pset.SetTargetAttribute(spread.Attribute, tg.Name)
+				sets = append(sets, &skewPropertySet{propertySet: pset, maxSkew: spread.MaxSkew})
+			}
+		}
+		iter.groupPropertySets[tg.Name] = sets
+	}
+
+	// Check if there is a max skew
+	iter.hasMaxSkew = len(iter.groupPropertySets[tg.Name]) != 0
+}
+
+func (iter *MaxSkewIterator) Next() *RankedNode {
+	// Hot path if there is nothing to check
+	if !iter.hasMaxSkew {
+		return iter.source.Next()
+	}
+
+	for !iter.exhausted {
+		option := iter.source.Next()
+		if option == nil {
+			iter.exhausted = true
+			break
+		}
+
+		// The option was ranked so it is able to fit the task group
+		for _, ps := range iter.groupPropertySets[iter.tg.Name] {
+			if value, ok := getProperty(option.Node, ps.targetAttribute); ok {
+				fitting, ok := iter.fittingValues[ps.targetAttribute]
+				if !ok {
+					fitting = make(map[string]struct{})
+					iter.fittingValues[ps.targetAttribute] = fitting
+				}
+				fitting[value] = struct{}{}
+			}
+		}
+
+		if iter.satisfiesMaxSkew(option.Node, iter.values, false) {
+			return option
+		}
+		iter.deferred = append(iter.deferred, option)
+	}
+
+	// Every fitting value is known once the source is exhausted, so the
+	// deferred options are checked against the least used one
+	fitting := func(attribute string) map[string]struct{} {
+		return iter.fittingValues[attribute]
+	}
+	for len(iter.deferred) > 0 {
+		option := iter.deferred[0]
+		iter.deferred = iter.deferred[1:]
+		if iter.satisfiesMaxSkew(option.Node, fitting, true) {
+			return option
+		}
+	}
+	return nil
+}
+
+// satisfiesMaxSkew returns whether placing on the option keeps the skew of
+// every spread within its max_skew, counting the given values of the
+// attribute. If filter is set, the option is recorded as filtered when it
+// doesn't.
+func (iter *MaxSkewIterator) satisfiesMaxSkew(option *structs.Node,
+	values func(attribute string) map[string]struct{}, filter bool) bool {
+
+	for _, ps := range iter.groupPropertySets[iter.tg.Name] {
+		nValue, errorMsg, usedCount := ps.UsedCount(option, iter.tg.Name)
+		if errorMsg != "" {
+			if filter {
+				iter.ctx.Metrics().FilterNode(option, errorMsg)
+			}
+			return false
+		}
+
+		// The skew is computed against the least used value, counting
+		// values without allocations.
+		combinedUse := ps.GetCombinedUseMap()
+		minCount := usedCount
+		for value := range values(ps.targetAttribute) {
+			if count := combinedUse[value]; count < minCount {
+				minCount = count
+			}
+		}
+
+		skew := int(usedCount + 1 - minCount)
+		if skew > ps.maxSkew {
+			if filter {
+				iter.ctx.Metrics().FilterNodeSkew(option, ps.targetAttribute, nValue, skew, ps.maxSkew)
+			}
+			return false
+		}
+	}
+
+	return true
+}
+
+// values returns the set of values of the attribute across the base nodes.
+func (iter *MaxSkewIterator) values(attribute string) map[string]struct{} {
+	if values, ok := iter.attributeValues[attribute]; ok {
+		return values
+	}
+
+	values := make(map[string]struct{})
+	for _, node := range iter.nodes {
+		if value, ok := getProperty(node, attribute); ok {
+			values[value] = struct{}{}
+		}
+	}
+	iter.attributeValues[attribute] = values
+	return values
+}
+
+func (iter *MaxSkewIterator) Reset() {
+	iter.source.Reset()
+
+	iter.fittingValues = make(map[string]map[string]struct{})
+	iter.deferred = nil
+	iter.exhausted = false
+
+	for _, sets := range iter.groupPropertySets {
+		for _, ps := range sets {
+			ps.PopulateProposed()
+		}
+	}
+}
@@ -1155,3 +1155,135 @@ func TestSpread_ImplicitTargets(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxSkewIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	dcs := []string{"dc1", "dc1", "dc2", "dc2", "dc3", "dc3"}
+	var nodes []*structs.Node
+	for i, dc := range dcs {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Weight:    50,
+		Attribute: "${node.datacenter}",
+		MaxSkew:   1,
+	}}
+
+	placeAlloc := func(node *structs.Node) {
+		alloc := mock.Alloc()
+		alloc.TaskGroup = tg.Name
+		alloc.JobID = job.ID
+		alloc.Job = job
+		alloc.NodeID = node.ID
+		ctx.plan.NodeAllocation[node.ID] = append(ctx.plan.NodeAllocation[node.ID], alloc)
+	}
+	feasibleDCsOf := func(feasible []*structs.Node) []string {
+		ctx.Reset()
+		static := NewFeasibleRankIterator(ctx, NewStaticIterator(ctx, feasible))
+		binp := NewBinPackIterator(ctx, static, false, 0)
+		binp.SetJob(job)
+		binp.SetTaskGroup(tg)
+		binp.SetSchedulerConfiguration(testSchedulerConfig)
+		iter := NewMaxSkewIterator(ctx, binp)
+		iter.SetNodes(nodes)
+		iter.SetJob(job)
+		iter.SetTaskGroup(tg)
+
+		dcs := set.New[string](3)
+		for _, option := range collectRanked(iter) {
+			dcs.Insert(option.Node.Datacenter)
+		}
+		return dcs.Slice()
+	}
+	feasibleDCs := func() []string { return feasibleDCsOf(nodes) }
+
+	// Nothing placed so all nodes are feasible
+	must.SliceContainsAll(t, []string{"dc1", "dc2", "dc3"}, feasibleDCs())
+
+	// Placing in dc1 again would skew the spread by 2
+	placeAlloc(nodes[0])
+	must.SliceContainsAll(t, []string{"dc2", "dc3"}, feasibleDCs())
+	must.Eq(t, map[string]int{
+		"${node.datacenter} = dc1: skew 2 > max_skew 1": 2,
+	}, ctx.Metrics().SkewFiltered)
+
+	// Datacenters without allocations bound the skew
+	placeAlloc(nodes[2])
+	must.SliceContainsAll(t, []string{"dc3"}, feasibleDCs())
+
+	placeAlloc(nodes[5])
+	must.SliceContainsAll(t, []string{"dc1", "dc2", "dc3"}, feasibleDCs())
+
+	// Datacenters without feasible nodes don't bound the skew
+	placeAlloc(nodes[1])
+	placeAlloc(nodes[3])
+	must.SliceContainsAll(t, []string{"dc3"}, feasibleDCs())
+	must.SliceContainsAll(t, []string{"dc1", "dc2"}, feasibleDCsOf(nodes[:4]))
+
+	// Datacenters without nodes able to fit the group don't bound the skew,
+	// and their nodes are reported as exhausted rather than skewed
+	for _, node := range nodes[4:] {
+		node.NodeResources.Memory.MemoryMB = 100
+	}
+	must.SliceContainsAll(t, []string{"dc1", "dc2"}, feasibleDCs())
+	must.MapEmpty(t, ctx.Metrics().SkewFiltered)
+	must.Eq(t, 2, ctx.Metrics().DimensionExhausted["memory"])
+
+	// Nodes missing the attribute are filtered
+	tg.Spreads[0].Attribute = "${meta.rack}"
+	must.SliceEmpty(t, feasibleDCs())
+	must.Eq(t, 4, ctx.Metrics().ConstraintFiltered[`missing property "${meta.rack}"`])
+}
+
+func TestServiceSched_Spread_MaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create 4 nodes in dc1 and 1 node in dc2
+	for i, dc := range []string{"dc1", "dc1", "dc1", "dc1", "dc2"} {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+	}
+
+	// Create a job spreading across datacenters with a max skew of 1
+	job := mock.Job()
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Spreads = []*structs.Spread{{
+		Weight:    50,
+		Attribute: "${node.datacenter}",
+		MaxSkew:   1,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure the allocations never skew the spread by more than 1
+	must.Len(t, 1, h.Plans)
+	counts := map[string]int{}
+	for nodeID, allocs := range h.Plans[0].NodeAllocation {
+		node, err := h.State.NodeByID(nil, nodeID)
+		must.NoError(t, err)
+		counts[node.Datacenter] += len(allocs)
+	}
+	must.Eq(t, map[string]int{"dc1": 2, "dc2": 2}, counts)
+}
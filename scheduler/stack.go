@@ -35,10 +35,11 @@ type Stack interface {
 }
 
 type SelectOptions struct {
-	PenaltyNodeIDs map[string]struct{}
-	PreferredNodes []*structs.Node
-	Preempt        bool
-	AllocName      string
+	PenaltyNodeIDs  map[string]struct{}
+	ExcludedNodeIDs map[string]struct{}
+	PreferredNodes  []*structs.Node
+	Preempt         bool
+	AllocName       string
 }
 
 // GenericStack is the Stack used for the Generic scheduler. It is
@@ -61,13 +62,19 @@ type GenericStack struct {
 
 	distinctHostsConstraint    *DistinctHostsIterator
 	distinctPropertyConstraint *DistinctPropertyIterator
+	jobAffinityConstraint      *JobAffinityConstraintIterator
+	maintenanceWindow          *MaintenanceWindowIterator
+	extensionConstraint        *PlacementExtensionIterator
 	binPack                    *BinPackIterator
+	maxSkew                    *MaxSkewIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
+	jobAffinity                *JobAffinityIterator
 	spread                     *SpreadIterator
+	extensionScore             *PlacementExtensionScoreIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -78,6 +85,7 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 
 	// Update the set of base nodes
 	s.source.SetNodes(baseNodes)
+	s.maxSkew.SetNodes(baseNodes)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	// For batch jobs we only need to evaluate 2 options and depend on the
@@ -105,10 +113,16 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctHostsConstraint.SetJob(job)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.maxSkew.SetJob(job)
+	s.jobAffinityConstraint.SetJob(job)
+	s.maintenanceWindow.SetJob(job)
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
+	s.jobAffinity.SetJob(job)
 	s.spread.SetJob(job)
+	s.extensionConstraint.SetJob(job)
+	s.extensionScore.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -125,6 +139,13 @@ func (s *GenericStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerC
 	s.binPack.SetSchedulerConfiguration(schedConfig)
 }
 
+// SetPlacementExtensions sets the placement extensions filtering and scoring
+// nodes after the builtin iterators.
+func (s *GenericStack) SetPlacementExtensions(extensions []PlacementExtension) {
+	s.extensionConstraint.SetExtensions(extensions)
+	s.extensionScore.SetExtensions(extensions)
+}
+
 func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
 
 	// This block handles trying to select from preferred nodes if options specify them
@@ -161,6 +182,8 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	}
 	s.distinctHostsConstraint.SetTaskGroup(tg)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.maxSkew.SetTaskGroup(tg)
+	s.jobAffinityConstraint.SetTaskGroup(tg)
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.binPack.SetTaskGroup(tg)
 	if options != nil {
@@ -169,11 +192,15 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	s.jobAntiAff.SetTaskGroup(tg)
 	if options != nil {
 		s.nodeReschedulingPenalty.SetPenaltyNodes(options.PenaltyNodeIDs)
+		s.nodeReschedulingPenalty.SetExcludedNodes(options.ExcludedNodeIDs)
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
+	s.jobAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
+	s.extensionConstraint.SetTaskGroup(tg)
+	s.extensionScore.SetTaskGroup(tg)
 
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
+	if s.nodeAffinity.hasAffinities() || s.jobAffinity.hasAffinities() || s.spread.hasSpreads() {
 		// scoring spread across all nodes has quadratic behavior, so
 		// we need to consider a subset of nodes to keep evaluaton times
 		// reasonable but enough to ensure spread is correct. this
@@ -213,7 +240,9 @@ type SystemStack struct {
 	taskGroupNetwork     *NetworkChecker
 
 	distinctPropertyConstraint *DistinctPropertyIterator
+	extensionConstraint        *PlacementExtensionIterator
 	binPack                    *BinPackIterator
+	extensionScore             *PlacementExtensionScoreIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -271,12 +300,15 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.wrappedChecks)
 
+	// Filter on placement extensions last as they may be slow.
+	s.extensionConstraint = NewPlacementExtensionIterator(ctx, s.distinctPropertyConstraint)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.extensionConstraint)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -302,8 +334,11 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Create binpack iterator
 	s.binPack = NewBinPackIterator(ctx, rankSource, enablePreemption, 0)
 
+	// Apply scores from placement extensions
+	s.extensionScore = NewPlacementExtensionScoreIterator(ctx, s.binPack)
+
 	// Apply score normalization
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.binPack)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.extensionScore)
 	return s
 }
 
@@ -315,7 +350,9 @@ func (s *SystemStack) SetNodes(baseNodes []*structs.Node) {
 func (s *SystemStack) SetJob(job *structs.Job) {
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.extensionConstraint.SetJob(job)
 	s.binPack.SetJob(job)
+	s.extensionScore.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -332,6 +369,13 @@ func (s *SystemStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerCo
 	s.binPack.SetSchedulerConfiguration(schedConfig)
 }
 
+// SetPlacementExtensions sets the placement extensions filtering and scoring
+// nodes after the builtin iterators.
+func (s *SystemStack) SetPlacementExtensions(extensions []PlacementExtension) {
+	s.extensionConstraint.SetExtensions(extensions)
+	s.extensionScore.SetExtensions(extensions)
+}
+
 func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
 	// Reset the binpack selector and context
 	s.scoreNorm.Reset()
@@ -352,7 +396,9 @@ func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ran
 	}
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.extensionConstraint.SetTaskGroup(tg)
 	s.binPack.SetTaskGroup(tg)
+	s.extensionScore.SetTaskGroup(tg)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetTaskGroup(tg)
@@ -423,12 +469,21 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.distinctHostsConstraint)
 
+	// Filter on required job affinities.
+	s.jobAffinityConstraint = NewJobAffinityConstraintIterator(ctx, s.distinctPropertyConstraint)
+
+	// Filter nodes in or about to enter a maintenance window.
+	s.maintenanceWindow = NewMaintenanceWindowIterator(ctx, s.jobAffinityConstraint)
+
+	// Filter on placement extensions last as they may be slow.
+	s.extensionConstraint = NewPlacementExtensionIterator(ctx, s.maintenanceWindow)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.extensionConstraint)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -437,9 +492,13 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// by a particular task group.
 	s.binPack = NewBinPackIterator(ctx, rankSource, false, 0)
 
+	// Filter on the max skew of spread blocks. This depends on which nodes
+	// are able to fit the task group, so it follows the bin packing.
+	s.maxSkew = NewMaxSkewIterator(ctx, s.binPack)
+
 	// Apply the job anti-affinity iterator. This is to avoid placing
 	// multiple allocations on the same node for this job.
-	s.jobAntiAff = NewJobAntiAffinityIterator(ctx, s.binPack, "")
+	s.jobAntiAff = NewJobAntiAffinityIterator(ctx, s.maxSkew, "")
 
 	// Apply node rescheduling penalty. This tries to avoid placing on a
 	// node where the allocation failed previously
@@ -448,11 +507,17 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Apply scores based on affinity block
 	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
 
+	// Apply scores based on job affinity block
+	s.jobAffinity = NewJobAffinityIterator(ctx, s.nodeAffinity)
+
 	// Apply scores based on spread block
-	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
+	s.spread = NewSpreadIterator(ctx, s.jobAffinity)
+
+	// Apply scores from placement extensions
+	s.extensionScore = NewPlacementExtensionScoreIterator(ctx, s.spread)
 
 	// Add the preemption options scoring iterator
-	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
+	preemptionScorer := NewPreemptionScoringIterator(ctx, s.extensionScore)
 
 	// Normalizes scores by averaging them across various scorers
 	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
@@ -60,6 +60,9 @@ type Harness struct {
 	CreateEvals  []*structs.Evaluation
 	ReblockEvals []*structs.Evaluation
 
+	// Extensions are the placement extensions provided to the schedulers.
+	Extensions []PlacementExtension
+
 	nextIndex     uint64
 	nextIndexLock sync.Mutex
 
@@ -257,6 +260,10 @@ func (h *Harness) ServersMeetMinimumVersion(_ *version.Version, _ bool) bool {
 	return h.serversMeetMinimumVersion
 }
 
+func (h *Harness) PlacementExtensions() []PlacementExtension {
+	return h.Extensions
+}
+
 // NextIndex returns the next index
 func (h *Harness) NextIndex() uint64 {
 	h.nextIndexLock.Lock()
@@ -237,6 +237,13 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 		return c
 	}
 
+	// Check job affinities
+	if !slices.EqualFunc(a.JobAffinities, b.JobAffinities, func(a, b *structs.JobAffinity) bool {
+		return a.Equal(b)
+	}) {
+		return difference("job affinities", a.JobAffinities, b.JobAffinities)
+	}
+
 	// Check Spreads
 	if c := spreadsUpdated(jobA, jobB, taskGroup); c.modified {
 		return c
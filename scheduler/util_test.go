@@ -1209,6 +1209,24 @@ func TestTasksUpdated_NUMA(t *testing.T) {
 	must.True(t, tasksUpdated(j1, j2, name).modified)
 }
 
+func TestTasksUpdated_JobAffinities(t *testing.T) {
+	ci.Parallel(t)
+
+	j1 := mock.Job()
+	name := j1.TaskGroups[0].Name
+	j1.TaskGroups[0].JobAffinities = []*structs.JobAffinity{
+		{JobID: "cache", Weight: 50},
+	}
+
+	j2 := j1.Copy()
+
+	must.False(t, tasksUpdated(j1, j2, name).modified)
+
+	j2.TaskGroups[0].JobAffinities[0].Weight = -50
+
+	must.True(t, tasksUpdated(j1, j2, name).modified)
+}
+
 func TestTaskGroupConstraints(t *testing.T) {
 	ci.Parallel(t)
 